@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"spark-park-cricket-backend/internal/config"
+	"spark-park-cricket-backend/internal/database"
+	"spark-park-cricket-backend/internal/seed"
+	"spark-park-cricket-backend/internal/services"
+)
+
+func main() {
+	defaults := seed.DefaultConfig()
+
+	userID := flag.String("user-id", "", "ID of the user that will own the generated data (required)")
+	seedValue := flag.Int64("seed", defaults.Seed, "Random seed; the same seed produces the same matches")
+	seriesName := flag.String("series", defaults.SeriesName, "Name of the generated series")
+	teams := flag.Int("teams", defaults.Teams, "Number of teams to create")
+	matches := flag.Int("matches", defaults.Matches, "Number of matches to play")
+	format := flag.String("format", defaults.Format.Name, "Match format: t20, t10, sixes or box")
+	overs := flag.Int("overs", 0, "Override the number of overs per innings")
+	players := flag.Int("players", 0, "Override the number of players per side")
+	tendencies := flag.String("tendencies", "balanced", "Scoring tendencies: balanced, aggressive or bowling")
+	wideRate := flag.Float64("wide-rate", defaults.Extras.WideRate, "Probability of a wide")
+	noBallRate := flag.Float64("no-ball-rate", defaults.Extras.NoBallRate, "Probability of a no ball")
+	legByeRate := flag.Float64("leg-bye-rate", defaults.Extras.LegByeRate, "Probability of a leg bye")
+	byeRate := flag.Float64("bye-rate", defaults.Extras.ByeRate, "Probability of byes")
+	flag.Parse()
+
+	log.Println("=== SPARK PARK CRICKET - SYNTHETIC DATA SEED ===")
+
+	if *userID == "" {
+		log.Fatal("ERROR: -user-id is required. Generated series and matches are owned by this user.")
+	}
+
+	// Build seed configuration
+	seedConfig := defaults
+	seedConfig.Seed = *seedValue
+	seedConfig.SeriesName = *seriesName
+	seedConfig.Teams = *teams
+	seedConfig.Matches = *matches
+	seedConfig.Extras = seed.Extras{
+		WideRate:   *wideRate,
+		NoBallRate: *noBallRate,
+		LegByeRate: *legByeRate,
+		ByeRate:    *byeRate,
+	}
+
+	matchFormat, err := seed.FormatByName(strings.ToLower(*format))
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	if *overs > 0 {
+		matchFormat.Overs = *overs
+	}
+	if *players > 0 {
+		matchFormat.PlayersPerSide = *players
+	}
+	seedConfig.Format = matchFormat
+
+	seedConfig.Tendencies, err = seed.TendenciesByName(strings.ToLower(*tendencies))
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+
+	if err := seedConfig.Validate(); err != nil {
+		log.Fatalf("ERROR: Invalid configuration: %v", err)
+	}
+
+	// Load configuration and connect to database
+	cfg := config.Load()
+	dbClient, err := database.NewClient(cfg)
+	if err != nil {
+		log.Fatalf("ERROR: Failed to connect to database: %v", err)
+	}
+	defer dbClient.Close()
+
+	log.Printf("✅ Connected to Supabase database")
+	log.Printf("🗄️  Seeding schema: %s", strings.ToUpper(cfg.DatabaseSchema))
+	log.Printf("🎲 Seed: %d | Format: %s (%d overs, %d players) | Teams: %d | Matches: %d",
+		seedConfig.Seed, seedConfig.Format.Name, seedConfig.Format.Overs, seedConfig.Format.PlayersPerSide,
+		seedConfig.Teams, seedConfig.Matches)
+
+	// Build services on top of the real repositories
+	repos := dbClient.Repositories
+
+	generator := seed.NewGenerator(
+		seedConfig,
+		services.NewSeriesService(repos.Series, repos.Match),
+		services.NewMatchService(repos.Match, repos.Series, repos.Team),
+		services.NewTeamService(repos.Team, repos.Player),
+		services.NewScorecardService(repos.Scorecard, repos.Match),
+	)
+
+	ctx := context.WithValue(context.Background(), "user_id", *userID)
+
+	start := time.Now()
+	result, err := generator.Run(ctx)
+	if err != nil {
+		log.Fatalf("ERROR: Seed failed: %v", err)
+	}
+
+	log.Println("\n=== SEED COMPLETED ===")
+	log.Printf("📋 Series: %s (%s)", result.Series.Name, result.Series.ID)
+	log.Printf("👥 Teams: %d", len(result.Teams))
+	for _, m := range result.Matches {
+		scores := make([]string, 0, len(m.Scorecard.Innings))
+		for _, inn := range m.Scorecard.Innings {
+			scores = append(scores, fmt.Sprintf("%s: %d/%d (%.1f)", inn.BattingTeam, inn.TotalRuns, inn.TotalWickets, inn.TotalOvers))
+		}
+		log.Printf("🏏 Match %d: %s vs %s | %d balls | %s", m.Match.MatchNumber, m.TeamA.Name, m.TeamB.Name, m.Balls, strings.Join(scores, ", "))
+	}
+	log.Printf("⏱️  Completed in %s", time.Since(start).Round(time.Millisecond))
+}
@@ -29,6 +29,9 @@ func main() {
 	// Load configuration
 	log.Printf("Loading configuration...")
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("❌ Invalid configuration: %v", err)
+	}
 	log.Printf("✅ Configuration loaded successfully")
 
 	// Initialize Supabase client
@@ -66,7 +69,7 @@ func main() {
 	log.Printf("   - GraphQL: http://localhost:%s/api/v1/graphql", cfg.Port)
 	log.Printf("   - GraphQL Playground: http://localhost:%s/api/v1/graphql/playground", cfg.Port)
 	log.Printf("   - Health Check: http://localhost:%s/health", cfg.Port)
-	log.Printf("   - WebSocket: ws://localhost:%s/api/v1/ws/match/{match_id}", cfg.Port)
+	log.Printf("   - WebSocket: ws://localhost:%s/api/v1/ws (or /api/v1/ws/match/{match_id})", cfg.Port)
 	log.Println("===============================================")
 
 	fmt.Printf("🚀 Spark Park Cricket Backend is running on :%s\n", cfg.Port)
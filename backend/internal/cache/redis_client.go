@@ -125,6 +125,12 @@ func (r *RedisClient) Expire(key string, ttl time.Duration) error {
 	return r.client.Expire(r.ctx, key, ttl).Err()
 }
 
+// Client returns the underlying Redis client for features that need more
+// than key-value caching, such as WebSocket replay buffers
+func (r *RedisClient) Client() *redis.Client {
+	return r.client
+}
+
 // Close closes the Redis connection
 func (r *RedisClient) Close() error {
 	return r.client.Close()
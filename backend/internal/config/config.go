@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
 	"strconv"
@@ -8,6 +9,10 @@ import (
 	"github.com/joho/godotenv"
 )
 
+// defaultSessionSecret is the placeholder session secret from env.example,
+// which the server refuses to start with
+const defaultSessionSecret = "your-super-secret-session-key-change-this-in-production"
+
 type Config struct {
 	SupabaseURL            string
 	SupabaseAPIKey         string
@@ -30,6 +35,33 @@ type Config struct {
 	FrontendURL string
 	// CORS Configuration
 	AllowedOrigins string
+	// Days a soft-deleted row stays in the trash before it is purged; 0 keeps it forever
+	SoftDeleteRetentionDays int
+	// WebSocket per-connection limits; 0 uses the hub defaults
+	WebSocketMaxMessageSize       int
+	WebSocketMaxSubscriptions     int
+	WebSocketMaxMessagesPerMinute int
+	// Messages kept per WebSocket room for reconnecting clients, and how long a quiet room keeps them
+	WebSocketReplaySize       int
+	WebSocketReplayTTLMinutes int
+	// WebSocket keepalive: how often connections are pinged and how long an
+	// unresponsive one is kept
+	WebSocketPingIntervalSeconds int
+	WebSocketPongTimeoutSeconds  int
+	// WebSocket and event stream connection caps; 0 is unlimited
+	WebSocketMaxConnectionsPerIP   int
+	WebSocketMaxConnectionsPerRoom int
+	// How long a connection token from POST /ws/token can be used to connect,
+	// and the key signing it; empty derives one from the session secret
+	WebSocketTokenTTLMinutes int
+	WebSocketTokenSecret     string
+	// How often WebSocket rooms are sent viewer counts and audiences sampled; 0 turns presence off
+	WebSocketPresenceIntervalSeconds int
+	// Outbound webhooks: attempts per delivery, the delay before the first
+	// retry (doubling for each after), and whether private addresses may be targets
+	WebhookMaxAttempts         int
+	WebhookRetryBaseSeconds    int
+	WebhookAllowPrivateTargets bool
 }
 
 func Load() *Config {
@@ -54,12 +86,30 @@ func Load() *Config {
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8081/api/v1/auth/google/callback"),
-		SessionSecret:      getEnv("SESSION_SECRET", "your-super-secret-session-key-change-this-in-production"),
+		SessionSecret:      getEnv("SESSION_SECRET", defaultSessionSecret),
 		SessionMaxAge:      getEnvInt("SESSION_MAX_AGE", 86400), // 24 hours
 		// Frontend Configuration
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
 		// CORS Configuration
 		AllowedOrigins: getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:3001,http://localhost:3002,http://127.0.0.1:3000,http://127.0.0.1:3001,http://127.0.0.1:3002,https://spark-park.dojima.foundation,https://cricket-dev.dojima.foundation"),
+		// Trash retention
+		SoftDeleteRetentionDays: getEnvInt("SOFT_DELETE_RETENTION_DAYS", 30),
+		// WebSocket limits
+		WebSocketMaxMessageSize:          getEnvInt("WS_MAX_MESSAGE_SIZE", 4096),
+		WebSocketMaxSubscriptions:        getEnvInt("WS_MAX_SUBSCRIPTIONS", 50),
+		WebSocketMaxMessagesPerMinute:    getEnvInt("WS_MAX_MESSAGES_PER_MINUTE", 120),
+		WebSocketReplaySize:              getEnvInt("WS_REPLAY_BUFFER_SIZE", 100),
+		WebSocketReplayTTLMinutes:        getEnvInt("WS_REPLAY_TTL_MINUTES", 10),
+		WebSocketPingIntervalSeconds:     getEnvInt("WS_PING_INTERVAL_SECONDS", 30),
+		WebSocketPongTimeoutSeconds:      getEnvInt("WS_PONG_TIMEOUT_SECONDS", 60),
+		WebSocketMaxConnectionsPerIP:     getEnvInt("WS_MAX_CONNECTIONS_PER_IP", 50),
+		WebSocketMaxConnectionsPerRoom:   getEnvInt("WS_MAX_CONNECTIONS_PER_ROOM", 10000),
+		WebSocketTokenTTLMinutes:         getEnvInt("WS_TOKEN_TTL_MINUTES", 15),
+		WebSocketTokenSecret:             getEnv("WS_TOKEN_SECRET", ""),
+		WebSocketPresenceIntervalSeconds: getEnvInt("WS_PRESENCE_INTERVAL_SECONDS", 2),
+		WebhookMaxAttempts:               getEnvInt("WEBHOOK_MAX_ATTEMPTS", 6),
+		WebhookRetryBaseSeconds:          getEnvInt("WEBHOOK_RETRY_BASE_SECONDS", 30),
+		WebhookAllowPrivateTargets:       getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
 	}
 
 	// Log database configuration
@@ -68,6 +118,17 @@ func Load() *Config {
 	return cfg
 }
 
+// Validate reports configuration the server must not run with
+func (c *Config) Validate() error {
+	if c.SessionSecret == "" || c.SessionSecret == defaultSessionSecret {
+		return errors.New("SESSION_SECRET must be set to a random value, such as the output of openssl rand -base64 32")
+	}
+	if c.WebSocketTokenSecret != "" && c.WebSocketTokenSecret == c.SessionSecret {
+		return errors.New("WS_TOKEN_SECRET must differ from SESSION_SECRET")
+	}
+	return nil
+}
+
 // logDatabaseConfig logs the database configuration being used
 func logDatabaseConfig(cfg *Config) {
 	log.Println("=== DATABASE CONFIGURATION ===")
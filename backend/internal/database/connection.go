@@ -15,13 +15,24 @@ import (
 
 // Repositories holds all repository interfaces
 type Repositories struct {
-	Series     interfaces.SeriesRepository
-	Match      interfaces.MatchRepository
-	Scoreboard interfaces.ScoreboardRepository
-	Scorecard  interfaces.ScorecardRepository
-	Over       interfaces.OverRepository
-	Ball       interfaces.BallRepository
-	User       interfaces.UserRepository
+	Series       interfaces.SeriesRepository
+	Match        interfaces.MatchRepository
+	Scoreboard   interfaces.ScoreboardRepository
+	Scorecard    interfaces.ScorecardRepository
+	Over         interfaces.OverRepository
+	Ball         interfaces.BallRepository
+	User         interfaces.UserRepository
+	Audit        interfaces.AuditRepository
+	Organization interfaces.OrganizationRepository
+	Team         interfaces.TeamRepository
+	Player       interfaces.PlayerRepository
+	MatchSquad   interfaces.MatchSquadRepository
+	PlayerStats  interfaces.PlayerStatsRepository
+	Stage        interfaces.StageRepository
+	Venue        interfaces.VenueRepository
+	Award        interfaces.AwardRepository
+	Audience     interfaces.AudienceRepository
+	Webhook      interfaces.WebhookRepository
 }
 
 // Client wraps the Supabase client and repositories
@@ -30,6 +41,7 @@ type Client struct {
 	Repositories *Repositories
 	Schema       string
 	CacheManager *cache.CacheManager
+	Redis        *cache.RedisClient // Set when Redis is reachable; shared with the cache manager
 }
 
 // NewClient creates a new database client with all repositories
@@ -57,14 +69,16 @@ func NewClient(cfg *config.Config) (*Client, error) {
 
 	// Initialize cache manager
 	var cacheManager *cache.CacheManager
+	var redisClient *cache.RedisClient
 	if cfg.CacheEnabled {
 		log.Printf("Initializing Redis cache...")
-		redisClient, err := cache.NewRedisClient(cfg)
+		client, err := cache.NewRedisClient(cfg)
 		if err != nil {
 			// Log warning but continue without cache
 			log.Printf("⚠️  Warning: Failed to initialize Redis cache: %v", err)
 			log.Printf("Continuing without cache...")
 		} else {
+			redisClient = client
 			cacheManager = cache.NewCacheManager(redisClient, true)
 			log.Printf("✅ Redis cache initialized successfully")
 		}
@@ -75,13 +89,24 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	// Initialize base repositories
 	log.Printf("Initializing database repositories...")
 	baseRepositories := &Repositories{
-		Series:     supabase.NewSeriesRepository(client),
-		Match:      supabase.NewMatchRepository(client),
-		Scoreboard: supabase.NewScoreboardRepository(client),
-		Scorecard:  supabase.NewScorecardRepository(client, cfg.DatabaseSchema),
-		Over:       supabase.NewOverRepository(client),
-		Ball:       supabase.NewBallRepository(client),
-		User:       supabase.NewUserRepository(client),
+		Series:       supabase.NewSeriesRepository(client),
+		Match:        supabase.NewMatchRepository(client),
+		Scoreboard:   supabase.NewScoreboardRepository(client),
+		Scorecard:    supabase.NewScorecardRepository(client, cfg.DatabaseSchema),
+		Over:         supabase.NewOverRepository(client),
+		Ball:         supabase.NewBallRepository(client),
+		User:         supabase.NewUserRepository(client),
+		Audit:        supabase.NewAuditRepository(client),
+		Organization: supabase.NewOrganizationRepository(client),
+		Team:         supabase.NewTeamRepository(client),
+		Player:       supabase.NewPlayerRepository(client),
+		MatchSquad:   supabase.NewMatchSquadRepository(client),
+		PlayerStats:  supabase.NewPlayerStatsRepository(client),
+		Stage:        supabase.NewStageRepository(client),
+		Venue:        supabase.NewVenueRepository(client),
+		Award:        supabase.NewAwardRepository(client),
+		Audience:     supabase.NewAudienceRepository(client),
+		Webhook:      supabase.NewWebhookRepository(client),
 	}
 	log.Printf("✅ Base repositories initialized")
 
@@ -90,13 +115,24 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	if cacheManager != nil {
 		log.Printf("Wrapping repositories with cache layer...")
 		repositories = &Repositories{
-			Series:     cacherepo.NewCachedSeriesRepository(baseRepositories.Series, cacheManager),
-			Match:      cacherepo.NewCachedMatchRepository(baseRepositories.Match, cacheManager),
-			Scoreboard: baseRepositories.Scoreboard, // Not cached yet
-			Scorecard:  cacherepo.NewCachedScorecardRepository(baseRepositories.Scorecard, cacheManager),
-			Over:       baseRepositories.Over, // Not cached yet
-			Ball:       baseRepositories.Ball, // Not cached yet
-			User:       baseRepositories.User, // Not cached yet
+			Series:       cacherepo.NewCachedSeriesRepository(baseRepositories.Series, cacheManager),
+			Match:        cacherepo.NewCachedMatchRepository(baseRepositories.Match, cacheManager),
+			Scoreboard:   baseRepositories.Scoreboard, // Not cached yet
+			Scorecard:    cacherepo.NewCachedScorecardRepository(baseRepositories.Scorecard, cacheManager),
+			Over:         baseRepositories.Over,         // Not cached yet
+			Ball:         baseRepositories.Ball,         // Not cached yet
+			User:         baseRepositories.User,         // Not cached yet
+			Audit:        baseRepositories.Audit,        // Append-only, never cached
+			Organization: baseRepositories.Organization, // Membership checks must not be stale
+			Team:         baseRepositories.Team,         // Not cached yet
+			Player:       baseRepositories.Player,       // Not cached yet
+			MatchSquad:   baseRepositories.MatchSquad,   // Not cached yet
+			PlayerStats:  baseRepositories.PlayerStats,  // Not cached yet
+			Stage:        baseRepositories.Stage,        // Not cached yet
+			Venue:        baseRepositories.Venue,        // Read by booking checks, must not be stale
+			Award:        baseRepositories.Award,        // Not cached yet
+			Audience:     baseRepositories.Audience,     // Written once per match
+			Webhook:      baseRepositories.Webhook,      // Read on every event, must not be stale
 		}
 		log.Printf("✅ Cached repositories initialized")
 	} else {
@@ -112,7 +148,7 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	} else {
 		log.Printf("Cache Layer: Disabled")
 	}
-	log.Printf("Repositories: Series, Match, Scoreboard, Scorecard, Over, Ball, User")
+	log.Printf("Repositories: Series, Match, Scoreboard, Scorecard, Over, Ball, User, Audit, Organization")
 	log.Printf("==========================================")
 
 	return &Client{
@@ -120,6 +156,7 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		Repositories: repositories,
 		Schema:       cfg.DatabaseSchema,
 		CacheManager: cacheManager,
+		Redis:        redisClient,
 	}, nil
 }
 
@@ -0,0 +1,30 @@
+-- Create audit log table for recording mutations with before/after snapshots
+-- Version: 2.1.0
+-- Date: 2025-02-01
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id VARCHAR(255),
+    actor_email VARCHAR(255),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for the admin audit query filters
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs(request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at DESC);
+
+COMMENT ON TABLE audit_logs IS 'Append-only log of create/update/delete operations';
+COMMENT ON COLUMN audit_logs.actor_id IS 'ID of the user who made the change';
+COMMENT ON COLUMN audit_logs.request_id IS 'Request ID from RequestIDMiddleware for correlating with request logs';
+COMMENT ON COLUMN audit_logs.before IS 'JSON snapshot of the entity before the change (null for create)';
+COMMENT ON COLUMN audit_logs.after IS 'JSON snapshot of the entity after the change (null for delete)';
+
+SELECT 'Audit log table created successfully!' as status;
@@ -0,0 +1,45 @@
+-- Create organizations and memberships for tenant isolation
+-- Version: 2.2.0
+-- Date: 2025-02-08
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_is_public ON organizations(is_public);
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+
+-- Tenant column on owned content; NULL keeps existing rows visible to everyone
+ALTER TABLE series ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_series_organization_id ON series(organization_id);
+CREATE INDEX IF NOT EXISTS idx_matches_organization_id ON matches(organization_id);
+CREATE INDEX IF NOT EXISTS idx_teams_organization_id ON teams(organization_id);
+CREATE INDEX IF NOT EXISTS idx_players_organization_id ON players(organization_id);
+
+COMMENT ON TABLE organizations IS 'Clubs or park groups that own series, teams and players';
+COMMENT ON COLUMN organizations.is_public IS 'Public organizations are visible to all users, including anonymous';
+COMMENT ON TABLE organization_members IS 'User membership and role within an organization';
+COMMENT ON COLUMN matches.organization_id IS 'Copied from the parent series for scoped listing';
+
+SELECT 'Organizations created successfully!' as status;
@@ -0,0 +1,28 @@
+-- Add soft delete (trash) support to series, matches, innings, teams and players
+-- Version: 2.3.0
+-- Date: 2025-02-15
+
+ALTER TABLE series ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE innings ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Partial indexes keep trash listings and the retention purge cheap
+CREATE INDEX IF NOT EXISTS idx_series_deleted_at ON series(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_matches_deleted_at ON matches(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_innings_deleted_at ON innings(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_teams_deleted_at ON teams(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_players_deleted_at ON players(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Restores are recorded in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete', 'restore'));
+
+COMMENT ON COLUMN series.deleted_at IS 'Set when the series is moved to the trash; purged after the retention period';
+COMMENT ON COLUMN matches.deleted_at IS 'Set when the match or its series is moved to the trash';
+COMMENT ON COLUMN innings.deleted_at IS 'Set when the match is moved to the trash';
+COMMENT ON COLUMN teams.deleted_at IS 'Set when the team is moved to the trash; purged after the retention period';
+COMMENT ON COLUMN players.deleted_at IS 'Set when the player or their team is moved to the trash';
+
+SELECT 'Soft delete columns added successfully!' as status;
@@ -0,0 +1,23 @@
+-- Add ownership to teams and let players_count follow the roster
+-- Version: 2.4.0
+-- Date: 2025-02-22
+
+-- Add created_by field to teams table; players are owned through their team
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_teams_created_by ON teams(created_by);
+CREATE INDEX IF NOT EXISTS idx_players_team_id ON players(team_id);
+
+-- players_count is maintained by the API from the live roster, so a new team starts at 0
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_players_count_check;
+ALTER TABLE teams ADD CONSTRAINT teams_players_count_check CHECK (players_count >= 0 AND players_count <= 20);
+ALTER TABLE teams ALTER COLUMN players_count SET DEFAULT 0;
+
+-- Bring existing counts in line with the live roster
+UPDATE teams SET players_count = (
+    SELECT COUNT(*) FROM players WHERE players.team_id = teams.id AND players.deleted_at IS NULL
+);
+
+COMMENT ON COLUMN teams.created_by IS 'User who created this team';
+COMMENT ON COLUMN teams.players_count IS 'Number of live players on the roster (0-20), kept in sync by the API';
+
+SELECT 'Team ownership added successfully!' as status;
@@ -0,0 +1,18 @@
+-- Link matches to the teams that play them
+-- Version: 2.5.0
+-- Date: 2025-03-01
+
+-- Both columns are optional so matches created before teams existed keep working
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS team_a_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS team_b_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_matches_team_a_id ON matches(team_a_id);
+CREATE INDEX IF NOT EXISTS idx_matches_team_b_id ON matches(team_b_id);
+
+-- A team cannot play itself
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS matches_distinct_teams_check;
+ALTER TABLE matches ADD CONSTRAINT matches_distinct_teams_check CHECK (team_a_id IS NULL OR team_b_id IS NULL OR team_a_id <> team_b_id);
+
+COMMENT ON COLUMN matches.team_a_id IS 'Team playing as side A; NULL for matches not linked to a team';
+COMMENT ON COLUMN matches.team_b_id IS 'Team playing as side B; NULL for matches not linked to a team';
+
+SELECT 'Match teams linked successfully!' as status;
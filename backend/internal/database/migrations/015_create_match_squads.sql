@@ -0,0 +1,26 @@
+-- Create match squads for playing XI selection
+-- Version: 2.6.0
+-- Date: 2025-03-08
+
+CREATE TABLE IF NOT EXISTS match_squads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    team VARCHAR(1) NOT NULL CHECK (team IN ('A', 'B')),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    player_name VARCHAR(255) NOT NULL,
+    batting_order INTEGER NOT NULL DEFAULT 0 CHECK (batting_order >= 0),
+    is_captain BOOLEAN NOT NULL DEFAULT FALSE,
+    is_wicketkeeper BOOLEAN NOT NULL DEFAULT FALSE,
+    is_substitute BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (match_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_squads_match_team ON match_squads(match_id, team);
+
+COMMENT ON TABLE match_squads IS 'Declared playing XI and substitutes for each side of a match';
+COMMENT ON COLUMN match_squads.player_name IS 'Player name at selection time';
+COMMENT ON COLUMN match_squads.batting_order IS '1-based batting position; 0 for substitutes';
+
+SELECT 'Match squads table created successfully!' as status;
@@ -0,0 +1,25 @@
+-- Add cricket profiles and account claims to players
+-- Version: 2.7.0
+-- Date: 2025-03-15
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS role VARCHAR(20) CHECK (role IN ('batter', 'bowler', 'all_rounder', 'wicketkeeper'));
+ALTER TABLE players ADD COLUMN IF NOT EXISTS batting_hand VARCHAR(5) CHECK (batting_hand IN ('right', 'left'));
+ALTER TABLE players ADD COLUMN IF NOT EXISTS bowling_arm VARCHAR(5) CHECK (bowling_arm IN ('right', 'left'));
+ALTER TABLE players ADD COLUMN IF NOT EXISTS bowling_style VARCHAR(20) CHECK (bowling_style IN ('fast', 'medium', 'off_spin', 'leg_spin', 'orthodox_spin', 'wrist_spin', 'none'));
+ALTER TABLE players ADD COLUMN IF NOT EXISTS jersey_number INTEGER CHECK (jersey_number BETWEEN 1 AND 999);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS nickname VARCHAR(100);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS avatar_url TEXT;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_players_user_id ON players(user_id);
+
+-- A jersey number is worn by one live player per team, and an account claims one profile per team
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_team_jersey ON players(team_id, jersey_number)
+    WHERE jersey_number IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_team_user ON players(team_id, user_id)
+    WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN players.role IS 'Main playing role: batter, bowler, all_rounder or wicketkeeper';
+COMMENT ON COLUMN players.user_id IS 'User account that claimed this player profile';
+
+SELECT 'Player profiles added successfully!' as status;
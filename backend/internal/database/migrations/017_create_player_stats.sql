@@ -0,0 +1,82 @@
+-- Record players on each ball and aggregate career and series statistics
+-- Version: 2.8.0
+-- Date: 2025-03-22
+
+-- Players involved in each delivery; NULL for balls scored without squads
+ALTER TABLE balls ADD COLUMN IF NOT EXISTS batter_id UUID REFERENCES players(id) ON DELETE SET NULL;
+ALTER TABLE balls ADD COLUMN IF NOT EXISTS bowler_id UUID REFERENCES players(id) ON DELETE SET NULL;
+ALTER TABLE balls ADD COLUMN IF NOT EXISTS fielder_id UUID REFERENCES players(id) ON DELETE SET NULL;
+ALTER TABLE balls ADD COLUMN IF NOT EXISTS dismissed_player_id UUID REFERENCES players(id) ON DELETE SET NULL;
+
+-- One line per player per completed match, derived from the balls
+CREATE TABLE IF NOT EXISTS player_match_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    player_name VARCHAR(255) NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    batted BOOLEAN NOT NULL DEFAULT FALSE,
+    not_out BOOLEAN NOT NULL DEFAULT FALSE,
+    runs INTEGER NOT NULL DEFAULT 0 CHECK (runs >= 0),
+    balls_faced INTEGER NOT NULL DEFAULT 0 CHECK (balls_faced >= 0),
+    fours INTEGER NOT NULL DEFAULT 0 CHECK (fours >= 0),
+    sixes INTEGER NOT NULL DEFAULT 0 CHECK (sixes >= 0),
+    bowled BOOLEAN NOT NULL DEFAULT FALSE,
+    balls_bowled INTEGER NOT NULL DEFAULT 0 CHECK (balls_bowled >= 0),
+    runs_conceded INTEGER NOT NULL DEFAULT 0 CHECK (runs_conceded >= 0),
+    wickets INTEGER NOT NULL DEFAULT 0 CHECK (wickets >= 0),
+    maidens INTEGER NOT NULL DEFAULT 0 CHECK (maidens >= 0),
+    catches INTEGER NOT NULL DEFAULT 0 CHECK (catches >= 0),
+    stumpings INTEGER NOT NULL DEFAULT 0 CHECK (stumpings >= 0),
+    run_outs INTEGER NOT NULL DEFAULT 0 CHECK (run_outs >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (match_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_match_stats_player ON player_match_stats(player_id);
+
+-- Career (series_id NULL) and per-series aggregates, refreshed from player_match_stats
+CREATE TABLE IF NOT EXISTS player_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    player_name VARCHAR(255) NOT NULL,
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    matches INTEGER NOT NULL DEFAULT 0,
+    innings INTEGER NOT NULL DEFAULT 0,
+    not_outs INTEGER NOT NULL DEFAULT 0,
+    runs INTEGER NOT NULL DEFAULT 0,
+    balls_faced INTEGER NOT NULL DEFAULT 0,
+    high_score INTEGER NOT NULL DEFAULT 0,
+    high_score_not_out BOOLEAN NOT NULL DEFAULT FALSE,
+    fifties INTEGER NOT NULL DEFAULT 0,
+    hundreds INTEGER NOT NULL DEFAULT 0,
+    ducks INTEGER NOT NULL DEFAULT 0,
+    fours INTEGER NOT NULL DEFAULT 0,
+    sixes INTEGER NOT NULL DEFAULT 0,
+    bowling_innings INTEGER NOT NULL DEFAULT 0,
+    balls_bowled INTEGER NOT NULL DEFAULT 0,
+    runs_conceded INTEGER NOT NULL DEFAULT 0,
+    wickets INTEGER NOT NULL DEFAULT 0,
+    maidens INTEGER NOT NULL DEFAULT 0,
+    best_wickets INTEGER NOT NULL DEFAULT 0,
+    best_runs INTEGER NOT NULL DEFAULT 0,
+    three_wicket_hauls INTEGER NOT NULL DEFAULT 0,
+    five_wicket_hauls INTEGER NOT NULL DEFAULT 0,
+    catches INTEGER NOT NULL DEFAULT 0,
+    stumpings INTEGER NOT NULL DEFAULT 0,
+    run_outs INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- One career row and one row per series for each player
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_stats_career ON player_stats(player_id) WHERE series_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_stats_series ON player_stats(player_id, series_id) WHERE series_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_player_stats_series_id ON player_stats(series_id);
+
+COMMENT ON COLUMN balls.dismissed_player_id IS 'Player given out; differs from the batter for non-striker run outs';
+COMMENT ON TABLE player_match_stats IS 'Per-match player lines derived from the ball-by-ball scorecard of completed matches';
+COMMENT ON TABLE player_stats IS 'Career (series_id NULL) and per-series player aggregates; rebuildable from player_match_stats';
+COMMENT ON COLUMN player_stats.three_wicket_hauls IS 'Innings with three or four wickets';
+
+SELECT 'Player statistics tables created successfully!' as status;
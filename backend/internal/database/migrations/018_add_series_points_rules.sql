@@ -0,0 +1,10 @@
+-- Configurable points table scoring per series
+-- Version: 2.9.0
+-- Date: 2025-03-29
+
+-- NULL uses the defaults: 2 for a win, 1 for a tie or no result, no bonus points
+ALTER TABLE series ADD COLUMN IF NOT EXISTS points_rules JSONB;
+
+COMMENT ON COLUMN series.points_rules IS 'Points per result type: win, loss, tie, no_result, bonus_point and bonus_run_rate_ratio';
+
+SELECT 'Series points rules added successfully!' as status;
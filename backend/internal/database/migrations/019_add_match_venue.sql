@@ -0,0 +1,9 @@
+-- Record the ground each match is played at
+-- Version: 2.10.0
+-- Date: 2025-04-05
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS venue VARCHAR(255);
+
+COMMENT ON COLUMN matches.venue IS 'Ground the match is played at; set by the fixture generator or by hand';
+
+SELECT 'Match venue added successfully!' as status;
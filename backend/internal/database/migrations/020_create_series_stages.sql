@@ -0,0 +1,27 @@
+-- Series stages: group stages and knockout brackets filled automatically from results
+-- Version: 2.11.0
+-- Date: 2025-04-12
+
+CREATE TABLE IF NOT EXISTS series_stages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 1),
+    stage_type VARCHAR(20) NOT NULL CHECK (stage_type IN ('group', 'knockout')),
+    groups JSONB,
+    tie_breakers JSONB,
+    bracket JSONB,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (series_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_stages_series_id ON series_stages(series_id);
+
+COMMENT ON TABLE series_stages IS 'Ordered stages of a series; group stage matches are the series matches between two teams of the same group';
+COMMENT ON COLUMN series_stages.groups IS 'Group stage: [{name, team_ids}]';
+COMMENT ON COLUMN series_stages.tie_breakers IS 'Ordering of level teams; NULL uses the series points rules';
+COMMENT ON COLUMN series_stages.bracket IS 'Knockout stage: [{position, round, name, match_id, team_a, team_b, team_a_id, team_b_id}] with slot sources per side';
+
+SELECT 'Series stages table created successfully!' as status;
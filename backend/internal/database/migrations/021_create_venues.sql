@@ -0,0 +1,31 @@
+-- Venues with bookable pitches, referenced by matches
+-- Version: 2.12.0
+-- Date: 2025-04-19
+
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    address VARCHAR(500),
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    boundary_notes TEXT,
+    pitches INTEGER NOT NULL DEFAULT 1 CHECK (pitches BETWEEN 1 AND 20),
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_venues_organization_id ON venues(organization_id);
+CREATE INDEX IF NOT EXISTS idx_venues_name ON venues(name);
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues(id) ON DELETE SET NULL;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS pitch_number INTEGER CHECK (pitch_number >= 1);
+
+CREATE INDEX IF NOT EXISTS idx_matches_venue_id ON matches(venue_id);
+
+COMMENT ON TABLE venues IS 'Grounds matches are played at; a venue may have several pitches in use at once';
+COMMENT ON COLUMN matches.venue_id IS 'Booked venue; bookings are checked for double-booked pitches and team clashes';
+COMMENT ON COLUMN matches.pitch_number IS 'Pitch booked at the venue, 1-based';
+
+SELECT 'Venues table created successfully!' as status;
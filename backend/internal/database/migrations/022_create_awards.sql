@@ -0,0 +1,35 @@
+-- Player of the match and series awards, proposed from impact points
+-- Version: 2.13.0
+-- Date: 2025-04-26
+
+ALTER TABLE series ADD COLUMN IF NOT EXISTS impact_formula JSONB;
+
+CREATE TABLE IF NOT EXISTS awards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    award_type VARCHAR(30) NOT NULL CHECK (award_type IN ('player_of_the_match', 'best_batter', 'best_bowler', 'mvp')),
+    series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    match_id UUID REFERENCES matches(id) ON DELETE CASCADE,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    player_name VARCHAR(255) NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    points DOUBLE PRECISION NOT NULL DEFAULT 0,
+    proposed_player_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed' CHECK (status IN ('proposed', 'confirmed')),
+    overridden BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(500),
+    confirmed_by UUID,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((award_type = 'player_of_the_match') = (match_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_awards_match_type ON awards(match_id, award_type) WHERE match_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_awards_series_type ON awards(series_id, award_type) WHERE match_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_awards_player_id ON awards(player_id);
+
+COMMENT ON TABLE awards IS 'Player of the match and series awards; proposals follow results until an organiser confirms them';
+COMMENT ON COLUMN awards.proposed_player_id IS 'Computed pick; differs from player_id when the organiser overrode it';
+COMMENT ON COLUMN series.impact_formula IS 'Award impact scoring weights; NULL uses the defaults';
+
+SELECT 'Awards table created successfully!' as status;
@@ -0,0 +1,12 @@
+-- Room access policies: who may follow a match live
+-- Version: 2.14.0
+-- Date: 2025-05-03
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS access VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (access IN ('public', 'series_members', 'invite_only'));
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS invited_user_ids UUID[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN matches.access IS 'Who may follow the match over WebSockets, event streams and GraphQL subscriptions';
+COMMENT ON COLUMN matches.invited_user_ids IS 'Users who may follow an invite_only match besides its creator and organization admins';
+
+SELECT 'Match access policies added successfully!' as status;
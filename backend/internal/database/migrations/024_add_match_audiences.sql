@@ -0,0 +1,18 @@
+-- Match audiences: peak and average live viewers, saved when a match completes
+-- Version: 2.15.0
+-- Date: 2025-05-10
+
+CREATE TABLE IF NOT EXISTS match_audiences (
+    match_id UUID PRIMARY KEY REFERENCES matches(id) ON DELETE CASCADE,
+    peak_viewers INTEGER NOT NULL DEFAULT 0 CHECK (peak_viewers >= 0),
+    peak_at TIMESTAMP WITH TIME ZONE,
+    average_viewers DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (average_viewers >= 0),
+    timeline JSONB NOT NULL DEFAULT '[]',
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+COMMENT ON TABLE match_audiences IS 'Concurrent viewers of a match over WebSockets, event streams and GraphQL subscriptions, counted across instances';
+COMMENT ON COLUMN match_audiences.average_viewers IS 'Time-weighted average from the first viewer until the match completed';
+COMMENT ON COLUMN match_audiences.timeline IS 'Peak viewers per minute: [{at, viewers}]';
+
+SELECT 'Match audiences table created successfully!' as status;
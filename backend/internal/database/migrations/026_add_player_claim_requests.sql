@@ -0,0 +1,11 @@
+-- Player profile claims wait for the team owner's approval
+-- Version: 2.17.0
+-- Date: 2025-05-24
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS claim_requested_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_players_claim_requested_by ON players(claim_requested_by) WHERE claim_requested_by IS NOT NULL;
+
+COMMENT ON COLUMN players.claim_requested_by IS 'User account whose claim on this profile awaits the team owner''s approval';
+
+SELECT 'Player claim requests added successfully!' as status;
@@ -0,0 +1,9 @@
+-- Webhook deliveries are leased to the instance attempting them
+-- Version: 2.18.0
+-- Date: 2025-05-31
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN webhook_deliveries.claimed_until IS 'An instance is attempting the delivery until then; others leave it alone';
+
+SELECT 'Webhook delivery claims added successfully!' as status;
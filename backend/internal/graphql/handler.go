@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"spark-park-cricket-backend/internal/interfaces"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/pkg/events"
 	"spark-park-cricket-backend/pkg/websocket"
 
 	"github.com/graphql-go/graphql"
@@ -21,8 +23,9 @@ const (
 
 // GraphQLHandler handles GraphQL requests
 type GraphQLHandler struct {
-	schema *graphql.Schema
-	hub    *websocket.Hub
+	schema      *graphql.Schema
+	hub         *websocket.Hub
+	resolverCtx *ResolverContext
 }
 
 // GraphQLRequest represents a GraphQL request
@@ -72,11 +75,32 @@ func NewGraphQLHandler(scorecardService interfaces.ScorecardServiceInterface, hu
 	log.Printf("DEBUG: GraphQL schema created successfully")
 
 	return &GraphQLHandler{
-		schema: schema,
-		hub:    hub,
+		schema:      schema,
+		hub:         hub,
+		resolverCtx: resolverCtx,
 	}
 }
 
+// SetTeamService enables resolving real team details and rosters for matches
+func (h *GraphQLHandler) SetTeamService(teamService interfaces.TeamServiceInterface) {
+	h.resolverCtx.TeamService = teamService
+}
+
+// SetPlayerService enables the player profile query
+func (h *GraphQLHandler) SetPlayerService(playerService interfaces.PlayerServiceInterface) {
+	h.resolverCtx.PlayerService = playerService
+}
+
+// SetStatsService enables the player statistics query
+func (h *GraphQLHandler) SetStatsService(statsService interfaces.StatsServiceInterface) {
+	h.resolverCtx.StatsService = statsService
+}
+
+// SetScoringEvents enables the subscriptions, fed by the scoring service's events
+func (h *GraphQLHandler) SetScoringEvents(scoringEvents *events.ScoringEvents) {
+	h.resolverCtx.ScoringEvents = scoringEvents
+}
+
 // createSchemaWithContext creates a GraphQL schema with resolver context
 func createSchemaWithContext(resolverCtx *ResolverContext) (*graphql.Schema, error) {
 	log.Printf("DEBUG: Creating GraphQL schema with context")
@@ -292,6 +316,20 @@ func createSchemaWithContext(resolverCtx *ResolverContext) (*graphql.Schema, err
 					return resolveMatchPlayers(p)
 				},
 			},
+			"player": &graphql.Field{
+				Type: playerType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					// Add resolver context to the context
+					ctx := context.WithValue(p.Context, resolverContextKey, resolverCtx)
+					p.Context = ctx
+					return resolvePlayer(p)
+				},
+			},
 			"playerStatistics": &graphql.Field{
 				Type: graphql.NewList(playerStatisticsType),
 				Args: graphql.FieldConfigArgument{
@@ -309,23 +347,45 @@ func createSchemaWithContext(resolverCtx *ResolverContext) (*graphql.Schema, err
 		},
 	})
 
-	// Create subscription type with resolver context
+	// Create subscription type; each field streams one kind of scoring
+	// event of a match, resolved with the client's selection set
+	matchIDArgs := graphql.FieldConfigArgument{
+		"match_id": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	}
 	subscriptionType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Subscription",
 		Fields: graphql.Fields{
 			"scorecardUpdated": &graphql.Field{
-				Type: liveScorecardType,
-				Args: graphql.FieldConfigArgument{
-					"match_id": &graphql.ArgumentConfig{
-						Type: graphql.NewNonNull(graphql.String),
-					},
-				},
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					// Add resolver context to the context
-					ctx := context.WithValue(p.Context, resolverContextKey, resolverCtx)
-					p.Context = ctx
-					return resolveScorecardSubscription(p)
-				},
+				Type:      liveScorecardType,
+				Args:      matchIDArgs,
+				Subscribe: subscribeToScoringEvents(resolverCtx, models.ScoringEventScorecardUpdated),
+				Resolve:   withResolverContext(resolverCtx, resolveLiveScorecard),
+			},
+			"ballAdded": &graphql.Field{
+				Type:      ballEventType,
+				Args:      matchIDArgs,
+				Subscribe: subscribeToScoringEvents(resolverCtx, models.ScoringEventBallAdded),
+				Resolve:   resolveBallEvent,
+			},
+			"wicketFallen": &graphql.Field{
+				Type:      ballEventType,
+				Args:      matchIDArgs,
+				Subscribe: subscribeToScoringEvents(resolverCtx, models.ScoringEventWicketFallen),
+				Resolve:   resolveBallEvent,
+			},
+			"inningsCompleted": &graphql.Field{
+				Type:      inningsCompletedEventType,
+				Args:      matchIDArgs,
+				Subscribe: subscribeToScoringEvents(resolverCtx, models.ScoringEventInningsCompleted),
+				Resolve:   resolveInningsCompletedEvent,
+			},
+			"matchCompleted": &graphql.Field{
+				Type:      matchCompletedEventType,
+				Args:      matchIDArgs,
+				Subscribe: subscribeToScoringEvents(resolverCtx, models.ScoringEventMatchCompleted),
+				Resolve:   resolveMatchCompletedEvent,
 			},
 		},
 	})
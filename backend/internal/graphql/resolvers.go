@@ -3,9 +3,12 @@ package graphql
 import (
 	"fmt"
 	"log"
+	"math"
 	"spark-park-cricket-backend/internal/interfaces"
 	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/pkg/events"
 	"spark-park-cricket-backend/pkg/websocket"
+	"time"
 
 	"github.com/graphql-go/graphql"
 )
@@ -13,6 +16,10 @@ import (
 // ResolverContext holds the services needed for GraphQL resolvers
 type ResolverContext struct {
 	ScorecardService interfaces.ScorecardServiceInterface
+	TeamService      interfaces.TeamServiceInterface
+	PlayerService    interfaces.PlayerServiceInterface
+	StatsService     interfaces.StatsServiceInterface
+	ScoringEvents    *events.ScoringEvents
 	Hub              *websocket.Hub
 }
 
@@ -97,87 +104,6 @@ func resolveLiveScorecard(p graphql.ResolveParams) (interface{}, error) {
 	return liveScorecard, nil
 }
 
-// resolveScorecardSubscription resolves the scorecard subscription for real-time updates
-func resolveScorecardSubscription(p graphql.ResolveParams) (interface{}, error) {
-	matchID, ok := p.Args["match_id"].(string)
-	if !ok {
-		return nil, fmt.Errorf("match_id is required")
-	}
-
-	// Get resolver context from the context
-	resolverCtx, ok := p.Context.Value(resolverContextKey).(*ResolverContext)
-	if !ok {
-		return nil, fmt.Errorf("resolver context not found")
-	}
-
-	// For now, return the current scorecard
-	// In a real implementation, this would set up a subscription to WebSocket updates
-	scorecard, err := resolverCtx.ScorecardService.GetScorecard(p.Context, matchID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get scorecard: %w", err)
-	}
-
-	// Calculate current score for live updates
-	currentScore := calculateCurrentScore(scorecard)
-
-	// Get current over
-	scorecardOver, err := resolverCtx.ScorecardService.GetCurrentOver(p.Context, matchID, scorecard.CurrentInnings)
-	var currentOver *models.OverSummary
-	if err != nil {
-		currentOver = nil
-	} else {
-		// Get balls for the current over
-		balls, err := resolverCtx.ScorecardService.GetBallsByOver(p.Context, scorecardOver.ID)
-		if err != nil {
-			log.Printf("Error getting balls for over %s: %v", scorecardOver.ID, err)
-			balls = []*models.ScorecardBall{} // Default to empty slice on error
-		}
-
-		// Convert ScorecardBall to BallSummary
-		ballSummaries := make([]models.BallSummary, len(balls))
-		for i, ball := range balls {
-			ballSummaries[i] = models.BallSummary{
-				BallNumber: ball.BallNumber,
-				BallType:   ball.BallType,
-				RunType:    ball.RunType,
-				Runs:       ball.Runs,
-				Byes:       ball.Byes,
-				IsWicket:   ball.IsWicket,
-				WicketType: ball.WicketType,
-			}
-		}
-
-		// Convert ScorecardOver to OverSummary
-		currentOver = &models.OverSummary{
-			OverNumber:   scorecardOver.OverNumber,
-			TotalRuns:    scorecardOver.TotalRuns,
-			TotalBalls:   scorecardOver.TotalBalls,
-			TotalWickets: scorecardOver.TotalWickets,
-			Status:       scorecardOver.Status,
-			Balls:        ballSummaries,
-		}
-	}
-
-	// Build the live scorecard response
-	liveScorecard := map[string]interface{}{
-		"match_id":        scorecard.MatchID,
-		"match_number":    scorecard.MatchNumber,
-		"series_name":     scorecard.SeriesName,
-		"team_a":          scorecard.TeamA,
-		"team_b":          scorecard.TeamB,
-		"total_overs":     scorecard.TotalOvers,
-		"toss_winner":     scorecard.TossWinner,
-		"toss_type":       scorecard.TossType,
-		"current_innings": scorecard.CurrentInnings,
-		"match_status":    scorecard.MatchStatus,
-		"current_score":   currentScore,
-		"current_over":    currentOver,
-		"innings":         scorecard.Innings,
-	}
-
-	return liveScorecard, nil
-}
-
 // calculateCurrentScore calculates the current score from the scorecard
 func calculateCurrentScore(scorecard *models.ScorecardResponse) map[string]interface{} {
 	var currentInnings *models.InningsSummary
@@ -405,6 +331,8 @@ func resolveMatchDetails(p graphql.ResolveParams) (interface{}, error) {
 		"series_name":         scorecard.SeriesName,
 		"team_a":              scorecard.TeamA,
 		"team_b":              scorecard.TeamB,
+		"team_a_id":           nullableString(scorecard.TeamAID),
+		"team_b_id":           nullableString(scorecard.TeamBID),
 		"total_overs":         scorecard.TotalOvers,
 		"toss_winner":         scorecard.TossWinner,
 		"toss_type":           scorecard.TossType,
@@ -645,34 +573,47 @@ func resolveMatchTeams(p graphql.ResolveParams) (interface{}, error) {
 		return nil, fmt.Errorf("resolver context not found")
 	}
 
-	// Get the scorecard to extract team information
+	// Get the scorecard to extract the linked team IDs
 	scorecard, err := resolverCtx.ScorecardService.GetScorecard(p.Context, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get scorecard: %w", err)
 	}
 
-	// For now, return basic team information from scorecard
-	// In a real implementation, this would fetch full team details from team service
 	teams := []map[string]interface{}{
-		{
-			"id":            nil, // Would need team service to get actual team IDs
-			"name":          scorecard.TeamA,
-			"players_count": nil, // Would need team service to get actual player count
-			"created_at":    nil,
-			"updated_at":    nil,
-		},
-		{
-			"id":            nil, // Would need team service to get actual team IDs
-			"name":          scorecard.TeamB,
-			"players_count": nil, // Would need team service to get actual player count
-			"created_at":    nil,
-			"updated_at":    nil,
-		},
+		resolveTeam(p, resolverCtx, scorecard.TeamAID, scorecard.TeamA),
+		resolveTeam(p, resolverCtx, scorecard.TeamBID, scorecard.TeamB),
 	}
 
 	return teams, nil
 }
 
+// resolveTeam builds a team entry from the team service, falling back to the
+// scorecard name for matches that are not linked to a team
+func resolveTeam(p graphql.ResolveParams, resolverCtx *ResolverContext, teamID, name string) map[string]interface{} {
+	team := map[string]interface{}{
+		"id":            nullableString(teamID),
+		"name":          name,
+		"players_count": nil,
+		"created_at":    nil,
+		"updated_at":    nil,
+	}
+	if teamID == "" || resolverCtx.TeamService == nil {
+		return team
+	}
+
+	found, err := resolverCtx.TeamService.GetTeam(p.Context, teamID)
+	if err != nil {
+		log.Printf("Failed to get team %s: %v", teamID, err)
+		return team
+	}
+
+	team["name"] = found.Name
+	team["players_count"] = found.PlayersCount
+	team["created_at"] = found.CreatedAt.Format(time.RFC3339)
+	team["updated_at"] = found.UpdatedAt.Format(time.RFC3339)
+	return team
+}
+
 // resolveMatchPlayers resolves the match players query
 func resolveMatchPlayers(p graphql.ResolveParams) (interface{}, error) {
 	matchID, ok := p.Args["match_id"].(string)
@@ -686,20 +627,92 @@ func resolveMatchPlayers(p graphql.ResolveParams) (interface{}, error) {
 		return nil, fmt.Errorf("resolver context not found")
 	}
 
-	// Get the scorecard to extract team information
-	_, err := resolverCtx.ScorecardService.GetScorecard(p.Context, matchID)
+	// Get the scorecard to extract the linked team IDs
+	scorecard, err := resolverCtx.ScorecardService.GetScorecard(p.Context, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get scorecard: %w", err)
 	}
 
-	// For now, return empty players list
-	// In a real implementation, this would fetch players from player service based on teams
+	// Matches that are not linked to teams have no rosters
 	players := []map[string]interface{}{}
+	if resolverCtx.TeamService == nil {
+		return players, nil
+	}
+
+	for _, teamID := range []string{scorecard.TeamAID, scorecard.TeamBID} {
+		if teamID == "" {
+			continue
+		}
+		roster, err := resolverCtx.TeamService.GetTeamPlayers(p.Context, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get players for team %s: %w", teamID, err)
+		}
+		for _, player := range roster {
+			players = append(players, playerToMap(player))
+		}
+	}
 
 	return players, nil
 }
 
-// resolvePlayerStatistics resolves the player statistics query
+// resolvePlayer resolves the player profile query
+func resolvePlayer(p graphql.ResolveParams) (interface{}, error) {
+	playerID, ok := p.Args["id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	// Get resolver context from the context
+	resolverCtx, ok := p.Context.Value(resolverContextKey).(*ResolverContext)
+	if !ok {
+		return nil, fmt.Errorf("resolver context not found")
+	}
+	if resolverCtx.PlayerService == nil {
+		return nil, fmt.Errorf("player lookups are not available")
+	}
+
+	player, err := resolverCtx.PlayerService.GetPlayer(p.Context, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	return playerToMap(player), nil
+}
+
+// playerToMap converts a player and their profile to the GraphQL Player shape
+func playerToMap(player *models.Player) map[string]interface{} {
+	var jerseyNumber interface{}
+	if player.JerseyNumber != 0 {
+		jerseyNumber = player.JerseyNumber
+	}
+
+	return map[string]interface{}{
+		"id":            player.ID,
+		"name":          player.Name,
+		"team_id":       player.TeamID,
+		"role":          nullableString(string(player.Role)),
+		"batting_hand":  nullableString(string(player.BattingHand)),
+		"bowling_arm":   nullableString(string(player.BowlingArm)),
+		"bowling_style": nullableString(string(player.BowlingStyle)),
+		"jersey_number": jerseyNumber,
+		"nickname":      nullableString(player.Nickname),
+		"avatar_url":    nullableString(player.AvatarURL),
+		"user_id":       nullableString(player.UserID),
+		"created_at":    player.CreatedAt.Format(time.RFC3339),
+		"updated_at":    player.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// nullableString maps an empty string to a GraphQL null
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// resolvePlayerStatistics resolves the player statistics query from the
+// match's ball-by-ball data
 func resolvePlayerStatistics(p graphql.ResolveParams) (interface{}, error) {
 	matchID, ok := p.Args["match_id"].(string)
 	if !ok {
@@ -711,26 +724,49 @@ func resolvePlayerStatistics(p graphql.ResolveParams) (interface{}, error) {
 	if !ok {
 		return nil, fmt.Errorf("resolver context not found")
 	}
+	if resolverCtx.StatsService == nil {
+		return nil, fmt.Errorf("player statistics are not available")
+	}
 
-	// Get the scorecard to calculate player statistics
-	_, err := resolverCtx.ScorecardService.GetScorecard(p.Context, matchID)
+	stats, err := resolverCtx.StatsService.GetMatchPlayerStats(p.Context, matchID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get scorecard: %w", err)
+		return nil, fmt.Errorf("failed to get player statistics: %w", err)
 	}
 
-	// For now, return empty player statistics
-	// In a real implementation, this would calculate statistics from ball-by-ball data
-	playerStats := []map[string]interface{}{}
+	playerStats := make([]map[string]interface{}, len(stats))
+	for i, line := range stats {
+		playerStats[i] = playerMatchStatsToMap(line)
+	}
+	return playerStats, nil
+}
 
-	// This would require:
-	// 1. Fetching all players for both teams
-	// 2. Analyzing ball-by-ball data to calculate:
-	//    - Runs scored by each batsman
-	//    - Balls faced by each batsman
-	//    - Wickets taken by each bowler
-	//    - Overs bowled by each bowler
-	//    - Runs conceded by each bowler
-	//    - Strike rates and economy rates
+// playerMatchStatsToMap converts a player's match line to the GraphQL PlayerStatistics shape
+func playerMatchStatsToMap(line *models.PlayerMatchStats) map[string]interface{} {
+	var strikeRate, economyRate float64
+	if line.BallsFaced > 0 {
+		strikeRate = math.Round(float64(line.Runs)*10000/float64(line.BallsFaced)) / 100
+	}
+	if line.BallsBowled > 0 {
+		economyRate = math.Round(float64(line.RunsConceded)*600/float64(line.BallsBowled)) / 100
+	}
 
-	return playerStats, nil
+	return map[string]interface{}{
+		"player_id":     line.PlayerID,
+		"player_name":   line.PlayerName,
+		"team_id":       nullableString(line.TeamID),
+		"runs_scored":   line.Runs,
+		"balls_faced":   line.BallsFaced,
+		"fours":         line.Fours,
+		"sixes":         line.Sixes,
+		"not_out":       line.Batted && line.NotOut,
+		"wickets_taken": line.Wickets,
+		"overs_bowled":  float64(line.BallsBowled/6) + float64(line.BallsBowled%6)/10,
+		"maidens":       line.Maidens,
+		"runs_conceded": line.RunsConceded,
+		"strike_rate":   strikeRate,
+		"economy_rate":  economyRate,
+		"catches":       line.Catches,
+		"stumpings":     line.Stumpings,
+		"run_outs":      line.RunOuts,
+	}
 }
@@ -217,6 +217,12 @@ var (
 			"team_b": &graphql.Field{
 				Type: graphql.String,
 			},
+			"team_a_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"team_b_id": &graphql.Field{
+				Type: graphql.String,
+			},
 			"total_overs": &graphql.Field{
 				Type: graphql.Int,
 			},
@@ -372,6 +378,30 @@ var (
 			"team_id": &graphql.Field{
 				Type: graphql.String,
 			},
+			"role": &graphql.Field{
+				Type: graphql.String,
+			},
+			"batting_hand": &graphql.Field{
+				Type: graphql.String,
+			},
+			"bowling_arm": &graphql.Field{
+				Type: graphql.String,
+			},
+			"bowling_style": &graphql.Field{
+				Type: graphql.String,
+			},
+			"jersey_number": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"nickname": &graphql.Field{
+				Type: graphql.String,
+			},
+			"avatar_url": &graphql.Field{
+				Type: graphql.String,
+			},
+			"user_id": &graphql.Field{
+				Type: graphql.String,
+			},
 			"created_at": &graphql.Field{
 				Type: graphql.String,
 			},
@@ -400,15 +430,36 @@ var (
 			"balls_faced": &graphql.Field{
 				Type: graphql.Int,
 			},
+			"fours": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"sixes": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"not_out": &graphql.Field{
+				Type: graphql.Boolean,
+			},
 			"wickets_taken": &graphql.Field{
 				Type: graphql.Int,
 			},
 			"overs_bowled": &graphql.Field{
 				Type: graphql.Float,
 			},
+			"maidens": &graphql.Field{
+				Type: graphql.Int,
+			},
 			"runs_conceded": &graphql.Field{
 				Type: graphql.Int,
 			},
+			"catches": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"stumpings": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"run_outs": &graphql.Field{
+				Type: graphql.Int,
+			},
 			"strike_rate": &graphql.Field{
 				Type: graphql.Float,
 			},
@@ -418,6 +469,139 @@ var (
 		},
 	})
 
+	// EventScore type - the batting side's score after a scoring event
+	eventScoreType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "EventScore",
+		Fields: graphql.Fields{
+			"runs": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"wickets": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"overs": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"balls": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"run_rate": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	})
+
+	// BallEvent type for the ballAdded and wicketFallen subscriptions
+	ballEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "BallEvent",
+		Fields: graphql.Fields{
+			"match_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"innings_number": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"batting_team": &graphql.Field{
+				Type: teamTypeEnum,
+			},
+			"over_number": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"ball_number": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"ball_type": &graphql.Field{
+				Type: ballTypeEnum,
+			},
+			"run_type": &graphql.Field{
+				Type: runTypeEnum,
+			},
+			"runs": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"byes": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"is_wicket": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			"wicket_type": &graphql.Field{
+				Type: graphql.String,
+			},
+			"batter_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"bowler_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"fielder_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"dismissed_player_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"over_completed": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			"score": &graphql.Field{
+				Type: eventScoreType,
+			},
+			"occurred_at": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+
+	// InningsCompletedEvent type for the inningsCompleted subscription
+	inningsCompletedEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "InningsCompletedEvent",
+		Fields: graphql.Fields{
+			"match_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"innings_number": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"batting_team": &graphql.Field{
+				Type: teamTypeEnum,
+			},
+			"score": &graphql.Field{
+				Type: eventScoreType,
+			},
+			"target": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"occurred_at": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+
+	// MatchCompletedEvent type for the matchCompleted subscription
+	matchCompletedEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "MatchCompletedEvent",
+		Fields: graphql.Fields{
+			"match_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"match_status": &graphql.Field{
+				Type: graphql.String,
+			},
+			"result": &graphql.Field{
+				Type: graphql.String,
+			},
+			"winner_team_id": &graphql.Field{
+				Type: graphql.String,
+			},
+			"score": &graphql.Field{
+				Type: eventScoreType,
+			},
+			"occurred_at": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+
 	// Query type - removed as it's not used (schema is created dynamically in handler.go)
 )
 
@@ -0,0 +1,172 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// subscribeToScoringEvents returns a subscription source that streams a
+// match's scoring events of one type until the operation's context ends.
+// The match room's access policy applies, as for WebSocket rooms.
+func subscribeToScoringEvents(resolverCtx *ResolverContext, eventType models.ScoringEventType) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		matchID, ok := p.Args["match_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("match_id is required")
+		}
+		if resolverCtx.ScoringEvents == nil {
+			return nil, fmt.Errorf("subscriptions are not enabled")
+		}
+		if err := resolverCtx.Hub.CheckAccess(p.Context, matchID); err != nil {
+			return nil, err
+		}
+
+		events, unsubscribe := resolverCtx.ScoringEvents.Subscribe(matchID, eventType)
+		source := make(chan interface{})
+		go func() {
+			defer close(source)
+			defer unsubscribe()
+
+			for {
+				select {
+				case <-p.Context.Done():
+					return
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					select {
+					case source <- event:
+					case <-p.Context.Done():
+						return
+					}
+				}
+			}
+		}()
+		return source, nil
+	}
+}
+
+// withResolverContext adds the resolver context to a resolver's context
+func withResolverContext(resolverCtx *ResolverContext, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		p.Context = context.WithValue(p.Context, resolverContextKey, resolverCtx)
+		return resolve(p)
+	}
+}
+
+// scoringEventFrom returns the event a subscription payload carries
+func scoringEventFrom(p graphql.ResolveParams) (*models.ScoringEvent, error) {
+	event, ok := p.Source.(*models.ScoringEvent)
+	if !ok || event == nil {
+		return nil, fmt.Errorf("subscription payload is not a scoring event")
+	}
+	return event, nil
+}
+
+// resolveBallEvent resolves a ballAdded or wicketFallen payload
+func resolveBallEvent(p graphql.ResolveParams) (interface{}, error) {
+	event, err := scoringEventFrom(p)
+	if err != nil {
+		return nil, err
+	}
+	if event.Ball == nil {
+		return nil, fmt.Errorf("scoring event has no ball")
+	}
+
+	ball := event.Ball
+	result := map[string]interface{}{
+		"match_id":            event.MatchID,
+		"innings_number":      event.InningsNumber,
+		"ball_number":         ball.BallNumber,
+		"ball_type":           ball.BallType,
+		"run_type":            ball.RunType,
+		"runs":                ball.Runs,
+		"byes":                ball.Byes,
+		"is_wicket":           ball.IsWicket,
+		"wicket_type":         nullableString(ball.WicketType),
+		"batter_id":           nullableString(ball.BatterID),
+		"bowler_id":           nullableString(ball.BowlerID),
+		"fielder_id":          nullableString(ball.FielderID),
+		"dismissed_player_id": nullableString(ball.DismissedPlayerID),
+		"occurred_at":         event.OccurredAt.Format(time.RFC3339),
+	}
+	if event.Over != nil {
+		result["over_number"] = event.Over.OverNumber
+		result["over_completed"] = event.Over.Status == string(models.OverStatusCompleted)
+	}
+	if event.Innings != nil {
+		result["batting_team"] = event.Innings.BattingTeam
+		result["score"] = eventScore(event.Innings)
+	}
+	return result, nil
+}
+
+// resolveInningsCompletedEvent resolves an inningsCompleted payload
+func resolveInningsCompletedEvent(p graphql.ResolveParams) (interface{}, error) {
+	event, err := scoringEventFrom(p)
+	if err != nil {
+		return nil, err
+	}
+	if event.Innings == nil {
+		return nil, fmt.Errorf("scoring event has no innings")
+	}
+
+	// Only the first innings sets a target
+	var target interface{}
+	if event.InningsNumber == 1 {
+		target = event.Innings.TotalRuns + 1
+	}
+
+	return map[string]interface{}{
+		"match_id":       event.MatchID,
+		"innings_number": event.InningsNumber,
+		"batting_team":   event.Innings.BattingTeam,
+		"score":          eventScore(event.Innings),
+		"target":         target,
+		"occurred_at":    event.OccurredAt.Format(time.RFC3339),
+	}, nil
+}
+
+// resolveMatchCompletedEvent resolves a matchCompleted payload
+func resolveMatchCompletedEvent(p graphql.ResolveParams) (interface{}, error) {
+	event, err := scoringEventFrom(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"match_id":       event.MatchID,
+		"match_status":   string(models.MatchStatusCompleted),
+		"result":         event.Result,
+		"winner_team_id": nullableString(event.WinnerTeamID),
+		"occurred_at":    event.OccurredAt.Format(time.RFC3339),
+	}
+	if event.Match != nil {
+		result["match_status"] = string(event.Match.Status)
+	}
+	if event.Innings != nil {
+		result["score"] = eventScore(event.Innings)
+	}
+	return result, nil
+}
+
+// eventScore converts innings totals to the GraphQL EventScore shape
+func eventScore(innings *models.Innings) map[string]interface{} {
+	runRate := 0.0
+	if innings.TotalBalls > 0 {
+		runRate = float64(innings.TotalRuns) * 6 / float64(innings.TotalBalls)
+	}
+
+	return map[string]interface{}{
+		"runs":     innings.TotalRuns,
+		"wickets":  innings.TotalWickets,
+		"overs":    innings.TotalOvers,
+		"balls":    innings.TotalBalls,
+		"run_rate": runRate,
+	}
+}
@@ -0,0 +1,358 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// GraphQLTransportWSProtocol is the WebSocket subprotocol of the graphql-ws
+// library, https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const GraphQLTransportWSProtocol = "graphql-transport-ws"
+
+// graphql-transport-ws message types
+const (
+	transportConnectionInit = "connection_init"
+	transportConnectionAck  = "connection_ack"
+	transportPing           = "ping"
+	transportPong           = "pong"
+	transportSubscribe      = "subscribe"
+	transportNext           = "next"
+	transportError          = "error"
+	transportComplete       = "complete"
+)
+
+// graphql-transport-ws close codes
+const (
+	closeBadRequest          = 4400
+	closeUnauthorized        = 4401
+	closeSubprotocolNotOK    = 4406
+	closeInitTimeout         = 4408
+	closeSubscriberExists    = 4409
+	closeTooManyInitRequests = 4429
+)
+
+// Connection limits and timings
+const (
+	transportInitTimeout      = 10 * time.Second // connection_init must arrive within this
+	transportMaxMessageSize   = 64 * 1024
+	transportMaxOperationSize = 16 * 1024
+	transportPongWait         = 60 * time.Second
+	transportPingPeriod       = 30 * time.Second
+	transportWriteWait        = 10 * time.Second
+)
+
+// transportMessage is a graphql-transport-ws frame
+type transportMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscribePayload is the payload of a subscribe frame
+type subscribePayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// transportConn is one graphql-transport-ws connection and its running operations
+type transportConn struct {
+	conn   *websocket.Conn
+	schema *graphql.Schema
+
+	// The connecting request's values, such as its user, seen by operations
+	identity context.Context
+
+	writeMutex sync.Mutex
+
+	mutex       sync.Mutex
+	initialised bool
+	operations  map[string]context.CancelFunc
+}
+
+// ServeWS runs GraphQL operations, subscriptions in particular, over the
+// graphql-transport-ws protocol. Browsers must connect from an origin the
+// hub allows; subscriptions run as the user the request carries.
+func (h *GraphQLHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{GraphQLTransportWSProtocol},
+		CheckOrigin:  h.hub.CheckOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("GraphQL WebSocket upgrade error: %v", err)
+		return
+	}
+
+	c := &transportConn{
+		conn:       conn,
+		schema:     h.schema,
+		identity:   context.WithoutCancel(r.Context()),
+		operations: make(map[string]context.CancelFunc),
+	}
+	if conn.Subprotocol() != GraphQLTransportWSProtocol {
+		c.close(closeSubprotocolNotOK, "Subprotocol not acceptable")
+		return
+	}
+
+	c.run()
+}
+
+// run reads frames until the connection closes, then stops its operations
+func (c *transportConn) run() {
+	ctx, cancel := context.WithCancel(c.identity)
+	defer func() {
+		cancel()
+		c.conn.Close()
+	}()
+
+	initTimer := time.AfterFunc(transportInitTimeout, func() {
+		c.mutex.Lock()
+		initialised := c.initialised
+		c.mutex.Unlock()
+		if !initialised {
+			c.close(closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	go c.keepAlive(ctx)
+
+	c.conn.SetReadLimit(transportMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(transportPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(transportPongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("GraphQL WebSocket error: %v", err)
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(transportPongWait))
+
+		var msg transportMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.close(closeBadRequest, "Invalid message received")
+			return
+		}
+		if !c.handle(ctx, &msg) {
+			return
+		}
+	}
+}
+
+// handle processes one frame, returning false once the connection is closed
+func (c *transportConn) handle(ctx context.Context, msg *transportMessage) bool {
+	switch msg.Type {
+	case transportConnectionInit:
+		c.mutex.Lock()
+		already := c.initialised
+		c.initialised = true
+		c.mutex.Unlock()
+		if already {
+			c.close(closeTooManyInitRequests, "Too many initialisation requests")
+			return false
+		}
+		c.write(transportMessage{Type: transportConnectionAck})
+
+	case transportPing:
+		c.write(transportMessage{Type: transportPong, Payload: msg.Payload})
+
+	case transportPong:
+		// Answers to client pings need no reply
+
+	case transportSubscribe:
+		return c.subscribe(ctx, msg)
+
+	case transportComplete:
+		c.mutex.Lock()
+		if cancel, ok := c.operations[msg.ID]; ok {
+			cancel()
+			delete(c.operations, msg.ID)
+		}
+		c.mutex.Unlock()
+
+	default:
+		c.close(closeBadRequest, fmt.Sprintf("Unknown message type %q", msg.Type))
+		return false
+	}
+	return true
+}
+
+// subscribe validates an operation and starts running it
+func (c *transportConn) subscribe(ctx context.Context, msg *transportMessage) bool {
+	var payload subscribePayload
+	if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil || payload.Query == "" {
+		c.close(closeBadRequest, "Invalid subscribe message")
+		return false
+	}
+	if len(payload.Query) > transportMaxOperationSize {
+		c.close(closeBadRequest, "Operation is too large")
+		return false
+	}
+
+	c.mutex.Lock()
+	if !c.initialised {
+		c.mutex.Unlock()
+		c.close(closeUnauthorized, "Unauthorized")
+		return false
+	}
+	if _, exists := c.operations[msg.ID]; exists {
+		c.mutex.Unlock()
+		c.close(closeSubscriberExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+	opCtx, cancel := context.WithCancel(ctx)
+	c.operations[msg.ID] = cancel
+	c.mutex.Unlock()
+
+	document, operation, errs := c.prepare(&payload)
+	if errs != nil {
+		c.finish(msg.ID)
+		c.writePayload(msg.ID, transportError, errs)
+		return true
+	}
+
+	go c.execute(opCtx, msg.ID, &payload, document, operation)
+	return true
+}
+
+// prepare parses and validates an operation, returning the errors to send
+// in an error frame when it cannot run
+func (c *transportConn) prepare(payload *subscribePayload) (*ast.Document, string, []gqlerrors.FormattedError) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(payload.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil, "", gqlerrors.FormatErrors(err)
+	}
+
+	validation := graphql.ValidateDocument(c.schema, document, nil)
+	if !validation.IsValid {
+		return nil, "", validation.Errors
+	}
+
+	var operation string
+	for _, definition := range document.Definitions {
+		op, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if payload.OperationName == "" || (op.Name != nil && op.Name.Value == payload.OperationName) {
+			operation = op.Operation
+			break
+		}
+	}
+	if operation == "" {
+		return nil, "", gqlerrors.FormatErrors(fmt.Errorf("unknown operation %q", payload.OperationName))
+	}
+	return document, operation, nil
+}
+
+// execute runs an operation, sending each result as a next frame and a
+// complete frame at the end unless the client completed it first
+func (c *transportConn) execute(ctx context.Context, id string, payload *subscribePayload, document *ast.Document, operation string) {
+	params := graphql.ExecuteParams{
+		Schema:        *c.schema,
+		AST:           document,
+		OperationName: payload.OperationName,
+		Args:          payload.Variables,
+		Context:       ctx,
+	}
+
+	if operation == ast.OperationTypeSubscription {
+		// Drain the results so the executor is never left blocked on a send
+		for result := range graphql.ExecuteSubscription(params) {
+			if ctx.Err() == nil {
+				c.writePayload(id, transportNext, result)
+			}
+		}
+	} else {
+		c.writePayload(id, transportNext, graphql.Execute(params))
+	}
+
+	if c.finish(id) {
+		c.write(transportMessage{ID: id, Type: transportComplete})
+	}
+}
+
+// finish forgets an operation, reporting whether it was still running
+func (c *transportConn) finish(id string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cancel, ok := c.operations[id]
+	if ok {
+		cancel()
+		delete(c.operations, id)
+	}
+	return ok
+}
+
+// keepAlive pings the client so idle connections stay open and dead ones
+// are noticed
+func (c *transportConn) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(transportPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.writeMutex.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(transportWriteWait))
+			c.writeMutex.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writePayload sends a frame with a JSON payload
+func (c *transportConn) writePayload(id, messageType string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Error marshaling GraphQL %s payload: %v", messageType, err)
+		return
+	}
+	c.write(transportMessage{ID: id, Type: messageType, Payload: data})
+}
+
+// write sends a frame; gorilla connections allow one writer at a time
+func (c *transportConn) write(msg transportMessage) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(transportWriteWait))
+	if err := c.conn.WriteJSON(msg); err != nil {
+		log.Printf("Error writing GraphQL %s message: %v", msg.Type, err)
+	}
+}
+
+// close closes the connection with a graphql-transport-ws close code
+func (c *transportConn) close(code int, reason string) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(transportWriteWait))
+	c.conn.Close()
+}
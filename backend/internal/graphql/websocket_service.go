@@ -5,6 +5,7 @@ import (
 	"log"
 	"spark-park-cricket-backend/internal/interfaces"
 	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/pkg/events"
 	"spark-park-cricket-backend/pkg/websocket"
 )
 
@@ -154,6 +155,26 @@ func (s *GraphQLWebSocketService) calculateCurrentScore(scorecard *models.Scorec
 	}
 }
 
+// SetTeamService enables resolving real team details in GraphQL queries
+func (s *GraphQLWebSocketService) SetTeamService(teamService interfaces.TeamServiceInterface) {
+	s.graphqlHandler.SetTeamService(teamService)
+}
+
+// SetPlayerService enables the player profile query in GraphQL
+func (s *GraphQLWebSocketService) SetPlayerService(playerService interfaces.PlayerServiceInterface) {
+	s.graphqlHandler.SetPlayerService(playerService)
+}
+
+// SetStatsService enables the player statistics query in GraphQL
+func (s *GraphQLWebSocketService) SetStatsService(statsService interfaces.StatsServiceInterface) {
+	s.graphqlHandler.SetStatsService(statsService)
+}
+
+// SetScoringEvents enables GraphQL subscriptions driven by the scoring service's events
+func (s *GraphQLWebSocketService) SetScoringEvents(scoringEvents *events.ScoringEvents) {
+	s.graphqlHandler.SetScoringEvents(scoringEvents)
+}
+
 // GetGraphQLHandler returns the GraphQL handler
 func (s *GraphQLWebSocketService) GetGraphQLHandler() *GraphQLHandler {
 	return s.graphqlHandler
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AudienceHandler handles HTTP requests for match audiences
+type AudienceHandler struct {
+	service *services.AudienceService
+}
+
+// NewAudienceHandler creates a new audience handler
+func NewAudienceHandler(service *services.AudienceService) *AudienceHandler {
+	return &AudienceHandler{
+		service: service,
+	}
+}
+
+// GetMatchAudience handles GET /api/v1/matches/{id}/audience
+func (h *AudienceHandler) GetMatchAudience(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+
+	audience, err := h.service.GetAudience(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Match")
+		return
+	}
+
+	utils.WriteSuccess(w, audience)
+}
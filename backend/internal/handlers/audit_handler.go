@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strconv"
+	"time"
+)
+
+// AuditHandler handles HTTP requests for audit log operations
+type AuditHandler struct {
+	service *services.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(service *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// ListAuditLogs handles GET /api/v1/audit
+func (h *AuditHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Set default limit
+	limit := 50
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	// Set default offset
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	filters := &models.AuditLogFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if actorID := query.Get("actor_id"); actorID != "" {
+		filters.ActorID = &actorID
+	}
+	if action := query.Get("action"); action != "" {
+		auditAction := models.AuditAction(action)
+		switch auditAction {
+		case models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete, models.AuditActionRestore:
+			filters.Action = &auditAction
+		default:
+			utils.WriteValidationError(w, "Invalid action", "action must be one of create, update, delete")
+			return
+		}
+	}
+	if entityType := query.Get("entity_type"); entityType != "" {
+		auditEntityType := models.AuditEntityType(entityType)
+		filters.EntityType = &auditEntityType
+	}
+	if entityID := query.Get("entity_id"); entityID != "" {
+		filters.EntityID = &entityID
+	}
+	if requestID := query.Get("request_id"); requestID != "" {
+		filters.RequestID = &requestID
+	}
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid from timestamp", "from must be in RFC3339 format")
+			return
+		}
+		filters.From = &from
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid to timestamp", "to must be in RFC3339 format")
+			return
+		}
+		filters.To = &to
+	}
+	if filters.From != nil && filters.To != nil && filters.To.Before(*filters.From) {
+		utils.WriteValidationError(w, "Invalid time range", "to must be after from")
+		return
+	}
+
+	logs, err := h.service.ListAuditLogs(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, logs)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AwardHandler handles HTTP requests for match and series awards
+type AwardHandler struct {
+	service *services.AwardService
+}
+
+// NewAwardHandler creates a new award handler
+func NewAwardHandler(service *services.AwardService) *AwardHandler {
+	return &AwardHandler{
+		service: service,
+	}
+}
+
+// GetMatchAwards handles GET /api/v1/matches/{id}/awards
+func (h *AwardHandler) GetMatchAwards(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+
+	awards, err := h.service.GetMatchAwards(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Match")
+		return
+	}
+
+	utils.WriteSuccess(w, awards)
+}
+
+// ConfirmMatchAward handles POST /api/v1/matches/{id}/awards/confirm. An
+// empty body confirms the computed player of the match.
+func (h *AwardHandler) ConfirmMatchAward(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+
+	var req models.ConfirmAwardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	award, err := h.service.ConfirmMatchAward(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to confirm player of the match", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, award)
+}
+
+// GetSeriesAwards handles GET /api/v1/series/{id}/awards
+func (h *AwardHandler) GetSeriesAwards(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	awards, err := h.service.GetSeriesAwards(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, awards)
+}
+
+// ConfirmSeriesAward handles POST /api/v1/series/{id}/awards/{type}/confirm.
+// An empty body confirms the computed pick.
+func (h *AwardHandler) ConfirmSeriesAward(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+	awardType := models.AwardType(chi.URLParam(r, "type"))
+
+	var req models.ConfirmAwardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	award, err := h.service.ConfirmSeriesAward(r.Context(), id, awardType, &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to confirm series award", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, award)
+}
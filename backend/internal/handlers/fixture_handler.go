@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FixtureHandler handles HTTP requests for series fixture generation
+type FixtureHandler struct {
+	service *services.FixtureService
+}
+
+// NewFixtureHandler creates a new fixture handler
+func NewFixtureHandler(service *services.FixtureService) *FixtureHandler {
+	return &FixtureHandler{
+		service: service,
+	}
+}
+
+// PreviewFixtures handles POST /api/v1/series/{id}/fixtures/preview
+func (h *FixtureHandler) PreviewFixtures(w http.ResponseWriter, r *http.Request) {
+	seriesID, req, ok := parseFixtureRequest(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.PreviewFixtures(r.Context(), seriesID, req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to generate fixtures", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, schedule)
+}
+
+// GenerateFixtures handles POST /api/v1/series/{id}/fixtures
+func (h *FixtureHandler) GenerateFixtures(w http.ResponseWriter, r *http.Request) {
+	seriesID, req, ok := parseFixtureRequest(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.GenerateFixtures(r.Context(), seriesID, req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, schedule)
+}
+
+// parseFixtureRequest reads the series ID and fixture request, writing the error response on failure
+func parseFixtureRequest(w http.ResponseWriter, r *http.Request) (string, *models.GenerateFixturesRequest, bool) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return "", nil, false
+	}
+
+	var req models.GenerateFixturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return "", nil, false
+	}
+	if req.Format == "" {
+		utils.WriteValidationError(w, "Format is required", nil)
+		return "", nil, false
+	}
+
+	return seriesID, &req, true
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LeaderboardHandler handles HTTP requests for series leaderboards
+type LeaderboardHandler struct {
+	service *services.LeaderboardService
+}
+
+// NewLeaderboardHandler creates a new leaderboard handler
+func NewLeaderboardHandler(service *services.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		service: service,
+	}
+}
+
+// GetLeaderboards handles GET /api/v1/series/{id}/leaderboards
+func (h *LeaderboardHandler) GetLeaderboards(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	query := &models.LeaderboardQuery{
+		Category: models.LeaderboardCategory(r.URL.Query().Get("category")),
+	}
+	for param, dest := range map[string]*int{
+		"limit":            &query.Limit,
+		"min_balls_faced":  &query.MinBallsFaced,
+		"min_balls_bowled": &query.MinBallsBowled,
+	} {
+		if value := r.URL.Query().Get(param); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 {
+				utils.WriteValidationError(w, "Invalid "+param, "must be a positive number")
+				return
+			}
+			*dest = parsed
+		}
+	}
+
+	if query.Limit > models.MaxLeaderboardLimit {
+		utils.WriteValidationError(w, "Invalid limit", fmt.Sprintf("must be at most %d", models.MaxLeaderboardLimit))
+		return
+	}
+	if query.Category != "" && !knownLeaderboardCategory(query.Category) {
+		utils.WriteValidationError(w, "Invalid category", nil)
+		return
+	}
+
+	leaderboards, err := h.service.GetLeaderboards(r.Context(), seriesID, query)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, leaderboards)
+}
+
+// knownLeaderboardCategory reports whether a category names a leaderboard
+func knownLeaderboardCategory(category models.LeaderboardCategory) bool {
+	for _, known := range models.LeaderboardCategories() {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"spark-park-cricket-backend/pkg/websocket"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Server-Sent Events timings
+const (
+	sseHeartbeatInterval = 15 * time.Second // Comment lines that keep idle proxies from closing the stream
+	sseRetry             = 3 * time.Second  // How long clients wait before reconnecting
+)
+
+// MatchEventsHandler streams a match's live events as Server-Sent Events,
+// for clients that cannot use WebSockets. The stream carries the messages
+// of the match's WebSocket room, numbered alike.
+type MatchEventsHandler struct {
+	hub     *websocket.Hub
+	matches *services.MatchService
+
+	heartbeat time.Duration
+}
+
+// NewMatchEventsHandler creates a new match events handler
+func NewMatchEventsHandler(hub *websocket.Hub, matches *services.MatchService) *MatchEventsHandler {
+	return &MatchEventsHandler{
+		hub:       hub,
+		matches:   matches,
+		heartbeat: sseHeartbeatInterval,
+	}
+}
+
+// SetHeartbeatInterval changes how often idle streams are sent a heartbeat
+func (h *MatchEventsHandler) SetHeartbeatInterval(interval time.Duration) {
+	h.heartbeat = interval
+}
+
+// StreamMatchEvents handles GET /api/v1/matches/{id}/events. Each room
+// message is an event named by its type, with the room's seq as its ID, so
+// a client reconnecting with Last-Event-ID (or ?last_seq=) resumes where it
+// left off; without one the stream starts with a snapshot. The match's
+// access policy decides who may follow it.
+func (h *MatchEventsHandler) StreamMatchEvents(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "id")
+	if matchID == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+	if _, err := h.matches.GetMatch(r.Context(), matchID); err != nil {
+		utils.WriteNotFound(w, "Match not found")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteInternalError(w, "Streaming is not supported")
+		return
+	}
+
+	lastSeq := lastEventID(r)
+	clientID := uuid.New().String()
+	stream, err := h.hub.Stream(r, matchID, clientID, lastSeq)
+	switch err {
+	case nil:
+	case websocket.ErrTooManyConnections:
+		utils.WriteError(w, http.StatusTooManyRequests, "TOO_MANY_CONNECTIONS", "Too many connections", nil)
+		return
+	case websocket.ErrRoomFull:
+		utils.WriteError(w, http.StatusServiceUnavailable, "ROOM_FULL", "Match events are at capacity, try again later", nil)
+		return
+	case websocket.ErrUnauthorized:
+		utils.WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Sign in to follow this match", nil)
+		return
+	case websocket.ErrForbidden:
+		utils.WriteError(w, http.StatusForbidden, "FORBIDDEN", "You are not allowed to follow this match", nil)
+		return
+	case websocket.ErrRoomNotFound:
+		utils.WriteNotFound(w, "Match not found")
+		return
+	default:
+		utils.WriteInternalError(w, "Failed to join match events")
+		return
+	}
+	defer stream.Close()
+	log.Printf("Event stream %s opened for match %s", clientID, matchID)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Printf("Event stream %s closed for match %s", clientID, matchID)
+			return
+
+		case frame, ok := <-stream.Frames():
+			if !ok {
+				// The hub dropped a stream that fell too far behind; the
+				// client reconnects and catches up
+				return
+			}
+			err := writeEvent(w, frame)
+			stream.Sent(frame)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes a room frame as an event named by the frame's type
+func writeEvent(w http.ResponseWriter, frame []byte) error {
+	var header struct {
+		Type string `json:"type"`
+		Seq  int64  `json:"seq"`
+	}
+	if err := json.Unmarshal(frame, &header); err != nil {
+		log.Printf("Error reading event frame: %v", err)
+		return nil
+	}
+
+	event := ""
+	if header.Seq > 0 {
+		event += "id: " + strconv.FormatInt(header.Seq, 10) + "\n"
+	}
+	event += "event: " + header.Type + "\ndata: " + string(frame) + "\n\n"
+	_, err := fmt.Fprint(w, event)
+	return err
+}
+
+// lastEventID returns the seq a reconnecting client saw last, from the
+// Last-Event-ID header or the last_seq query parameter
+func lastEventID(r *http.Request) *int64 {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_seq")
+	}
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return nil
+	}
+	return &seq
+}
@@ -65,6 +65,10 @@ func (h *MatchHandler) ListMatches(w http.ResponseWriter, r *http.Request) {
 		filters.Status = &matchStatus
 	}
 
+	if organizationID := r.URL.Query().Get("organization_id"); organizationID != "" {
+		filters.OrganizationIDs = []string{organizationID}
+	}
+
 	log.Printf("DEBUG: Created filters: %+v", filters)
 
 	// Get matches from service
@@ -187,6 +191,53 @@ func (h *MatchHandler) DeleteMatch(w http.ResponseWriter, r *http.Request) {
 	utils.WriteSuccess(w, map[string]string{"message": "Match deleted successfully"})
 }
 
+// ListDeletedMatches handles GET /api/v1/matches/trash
+func (h *MatchHandler) ListDeletedMatches(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	filters := &models.MatchFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	matches, err := h.service.ListDeletedMatches(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, matches)
+}
+
+// RestoreMatch handles POST /api/v1/matches/{id}/restore
+func (h *MatchHandler) RestoreMatch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+
+	match, err := h.service.RestoreMatch(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, match)
+}
+
 // GetMatchesBySeries handles GET /api/v1/matches/series/{series_id}
 func (h *MatchHandler) GetMatchesBySeries(w http.ResponseWriter, r *http.Request) {
 	log.Printf("DEBUG: GetMatchesBySeries handler called")
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MatchSquadHandler handles HTTP requests for playing XI selection
+type MatchSquadHandler struct {
+	service *services.MatchSquadService
+}
+
+// NewMatchSquadHandler creates a new match squad handler
+func NewMatchSquadHandler(service *services.MatchSquadService) *MatchSquadHandler {
+	return &MatchSquadHandler{
+		service: service,
+	}
+}
+
+// GetSquads handles GET /api/v1/matches/{id}/squads
+func (h *MatchSquadHandler) GetSquads(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "id")
+	if matchID == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+
+	squads, err := h.service.GetSquads(r.Context(), matchID)
+	if err != nil {
+		utils.WriteNotFound(w, "Match")
+		return
+	}
+
+	utils.WriteSuccess(w, squads)
+}
+
+// SetSquad handles PUT /api/v1/matches/{id}/squads/{team}
+func (h *MatchSquadHandler) SetSquad(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "id")
+	if matchID == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+	team, ok := parseSquadTeam(r)
+	if !ok {
+		utils.WriteValidationError(w, "Invalid team", "team must be A or B")
+		return
+	}
+
+	var req models.SetMatchSquadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if len(req.Players) == 0 {
+		utils.WriteValidationError(w, "Players are required", nil)
+		return
+	}
+
+	squad, err := h.service.SetSquad(r.Context(), matchID, team, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, squad)
+}
+
+// Substitute handles POST /api/v1/matches/{id}/squads/{team}/substitutions
+func (h *MatchSquadHandler) Substitute(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "id")
+	if matchID == "" {
+		utils.WriteValidationError(w, "Match ID is required", nil)
+		return
+	}
+	team, ok := parseSquadTeam(r)
+	if !ok {
+		utils.WriteValidationError(w, "Invalid team", "team must be A or B")
+		return
+	}
+
+	var req models.SquadSubstitutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if req.PlayerOutID == "" || req.PlayerInID == "" {
+		utils.WriteValidationError(w, "player_out_id and player_in_id are required", nil)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		utils.WriteValidationError(w, "Reason is required", nil)
+		return
+	}
+
+	squad, err := h.service.Substitute(r.Context(), matchID, team, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, squad)
+}
+
+// parseSquadTeam reads the team side from the URL, accepting either case
+func parseSquadTeam(r *http.Request) (models.TeamType, bool) {
+	team := models.TeamType(strings.ToUpper(chi.URLParam(r, "team")))
+	if team != models.TeamTypeA && team != models.TeamTypeB {
+		return "", false
+	}
+	return team, true
+}
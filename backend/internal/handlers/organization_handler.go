@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// OrganizationHandler handles organization and membership HTTP requests
+type OrganizationHandler struct {
+	service *services.OrganizationService
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(service *services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+	}
+}
+
+// ListOrganizations handles GET /api/v1/organizations
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	filters := &models.OrganizationFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	orgs, err := h.service.ListOrganizations(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, orgs)
+}
+
+// CreateOrganization handles POST /api/v1/organizations
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	org, err := h.service.CreateOrganization(r.Context(), &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, org)
+}
+
+// GetOrganization handles GET /api/v1/organizations/{id}
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Organization ID is required", nil)
+		return
+	}
+
+	org, err := h.service.GetOrganization(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Organization")
+		return
+	}
+
+	utils.WriteSuccess(w, org)
+}
+
+// UpdateOrganization handles PUT /api/v1/organizations/{id}
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Organization ID is required", nil)
+		return
+	}
+
+	var req models.UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	org, err := h.service.UpdateOrganization(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, org)
+}
+
+// DeleteOrganization handles DELETE /api/v1/organizations/{id}
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Organization ID is required", nil)
+		return
+	}
+
+	if err := h.service.DeleteOrganization(r.Context(), id); err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Organization deleted successfully"})
+}
+
+// ListMembers handles GET /api/v1/organizations/{id}/members
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Organization ID is required", nil)
+		return
+	}
+
+	members, err := h.service.ListMembers(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, members)
+}
+
+// AddMember handles POST /api/v1/organizations/{id}/members
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Organization ID is required", nil)
+		return
+	}
+
+	var req models.AddOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, member)
+}
+
+// UpdateMember handles PUT /api/v1/organizations/{id}/members/{user_id}
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "user_id")
+	if id == "" || userID == "" {
+		utils.WriteValidationError(w, "Organization ID and user ID are required", nil)
+		return
+	}
+
+	var req models.UpdateOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	member, err := h.service.UpdateMemberRole(r.Context(), id, userID, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, member)
+}
+
+// RemoveMember handles DELETE /api/v1/organizations/{id}/members/{user_id}
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "user_id")
+	if id == "" || userID == "" {
+		utils.WriteValidationError(w, "Organization ID and user ID are required", nil)
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), id, userID); err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Member removed successfully"})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PlayerHandler handles HTTP requests for player operations
+type PlayerHandler struct {
+	service *services.PlayerService
+}
+
+// NewPlayerHandler creates a new player handler
+func NewPlayerHandler(service *services.PlayerService) *PlayerHandler {
+	return &PlayerHandler{
+		service: service,
+	}
+}
+
+// ListPlayers handles GET /api/v1/players
+func (h *PlayerHandler) ListPlayers(w http.ResponseWriter, r *http.Request) {
+	filters := parsePlayerFilters(r)
+
+	players, err := h.service.ListPlayers(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, players)
+}
+
+// CreatePlayer handles POST /api/v1/players
+func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if req.TeamID == "" {
+		utils.WriteValidationError(w, "Team ID is required", nil)
+		return
+	}
+
+	player, err := h.service.CreatePlayer(r.Context(), &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, player)
+}
+
+// GetPlayer handles GET /api/v1/players/{id}
+func (h *PlayerHandler) GetPlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	player, err := h.service.GetPlayer(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Player")
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// UpdatePlayer handles PUT /api/v1/players/{id}
+func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	var req models.UpdatePlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	player, err := h.service.UpdatePlayer(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// DeletePlayer handles DELETE /api/v1/players/{id}
+func (h *PlayerHandler) DeletePlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	if err := h.service.DeletePlayer(r.Context(), id); err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Player deleted successfully"})
+}
+
+// ListDeletedPlayers handles GET /api/v1/players/trash
+func (h *PlayerHandler) ListDeletedPlayers(w http.ResponseWriter, r *http.Request) {
+	filters := parsePlayerFilters(r)
+
+	players, err := h.service.ListDeletedPlayers(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, players)
+}
+
+// RestorePlayer handles POST /api/v1/players/{id}/restore
+func (h *PlayerHandler) RestorePlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	player, err := h.service.RestorePlayer(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// ClaimPlayer handles POST /api/v1/players/{id}/claim
+func (h *PlayerHandler) ClaimPlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	player, err := h.service.ClaimPlayer(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// ApproveClaim handles POST /api/v1/players/{id}/claim/approve
+func (h *PlayerHandler) ApproveClaim(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	player, err := h.service.ApproveClaim(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// ReleasePlayer handles DELETE /api/v1/players/{id}/claim
+func (h *PlayerHandler) ReleasePlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	player, err := h.service.ReleasePlayer(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, player)
+}
+
+// parsePlayerFilters reads pagination, team_id, user_id and organization_id query parameters
+func parsePlayerFilters(r *http.Request) *models.PlayerFilters {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	filters := &models.PlayerFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if teamID := r.URL.Query().Get("team_id"); teamID != "" {
+		filters.TeamID = &teamID
+	}
+	if userID := r.URL.Query().Get("user_id"); userID != "" {
+		filters.UserID = &userID
+	}
+	if organizationID := r.URL.Query().Get("organization_id"); organizationID != "" {
+		filters.OrganizationIDs = []string{organizationID}
+	}
+
+	return filters
+}
@@ -8,6 +8,7 @@ import (
 	"spark-park-cricket-backend/internal/middleware"
 	"spark-park-cricket-backend/internal/services"
 	"spark-park-cricket-backend/internal/utils"
+	"spark-park-cricket-backend/pkg/websocket"
 	"strings"
 	"time"
 
@@ -34,11 +35,35 @@ func SetupRoutes(dbClient *database.Client, cfg *config.Config) *chi.Mux {
 	// Initialize services
 	serviceContainer := services.NewContainer(dbClient.Repositories, cfg)
 
+	// Cache leaderboard inputs between balls and share scorecard versions
+	// between instances when Redis is available
+	if dbClient.CacheManager != nil {
+		serviceContainer.Leaderboard.SetCacheManager(dbClient.CacheManager)
+		serviceContainer.ScorecardVersions.SetCacheManager(dbClient.CacheManager)
+	}
+
+	// Share WebSocket sequence numbers, replay buffers, broadcasts and
+	// connection counts between instances
+	if dbClient.Redis != nil {
+		serviceContainer.Hub.SetReplayStore(websocket.NewRedisReplayStore(dbClient.Redis.Client(), cfg.WebSocketReplaySize, time.Duration(cfg.WebSocketReplayTTLMinutes)*time.Minute))
+		serviceContainer.Hub.SetBackplane(websocket.NewRedisBackplane(dbClient.Redis.Client()))
+	}
+
 	// Start WebSocket hub
 	go serviceContainer.Hub.Run()
 
+	// Start webhook deliveries
+	go serviceContainer.Webhook.Run()
+
+	// Start trash retention purge
+	if serviceContainer.Retention != nil {
+		go serviceContainer.Retention.Run(time.Hour)
+	}
+
 	// Initialize WebSocket handler
 	wsHandler := NewWebSocketHandler(serviceContainer.Hub, serviceContainer)
+	// WebSockets and event streams identify their user by session cookie or connection token
+	connectionAuth := middleware.ConnectionAuthMiddleware(serviceContainer.SessionService, serviceContainer.ConnectionTokens)
 
 	// Initialize health handler
 	healthHandler := NewHealthHandler(dbClient)
@@ -67,38 +92,167 @@ func SetupRoutes(dbClient *database.Client, cfg *config.Config) *chi.Mux {
 		// Series routes
 		r.Route("/series", func(r chi.Router) {
 			seriesHandler := NewSeriesHandler(serviceContainer.Series)
-			// Public routes (view only)
-			r.Get("/", seriesHandler.ListSeries)
-			r.Get("/{id}", seriesHandler.GetSeries)
+			// Public routes (view only, scoped to the caller's organizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", seriesHandler.ListSeries)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", seriesHandler.GetSeries)
 
 			// Protected routes (require authentication and ownership)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", seriesHandler.CreateSeries)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", seriesHandler.UpdateSeries)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", seriesHandler.DeleteSeries)
+
+			// Trash (the caller's soft-deleted series)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/trash", seriesHandler.ListDeletedSeries)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/restore", seriesHandler.RestoreSeries)
+
+			// Aggregated player statistics for the series
+			statsHandler := NewStatsHandler(serviceContainer.Stats)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/stats", statsHandler.GetSeriesStats)
+
+			// Fixture generation
+			fixtureHandler := NewFixtureHandler(serviceContainer.Fixture)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/fixtures/preview", fixtureHandler.PreviewFixtures)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/fixtures", fixtureHandler.GenerateFixtures)
+
+			// Points table
+			standingsHandler := NewStandingsHandler(serviceContainer.Standings)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/standings", standingsHandler.GetStandings)
+
+			// Leaderboards
+			leaderboardHandler := NewLeaderboardHandler(serviceContainer.Leaderboard)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/leaderboards", leaderboardHandler.GetLeaderboards)
+
+			// Stages and knockout brackets
+			stageHandler := NewStageHandler(serviceContainer.Stage)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/stages", stageHandler.ListStages)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/bracket", stageHandler.GetBracket)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/stages", stageHandler.CreateStage)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}/stages/{stage_id}", stageHandler.DeleteStage)
+
+			// Series awards
+			awardHandler := NewAwardHandler(serviceContainer.Award)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/awards", awardHandler.GetSeriesAwards)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/awards/{type}/confirm", awardHandler.ConfirmSeriesAward)
+
+			// Outbound webhooks and their delivery logs (series creator)
+			webhookHandler := NewWebhookHandler(serviceContainer.Webhook)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/{id}/webhooks", webhookHandler.ListWebhooks)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/webhooks", webhookHandler.CreateWebhook)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}/webhooks/{webhook_id}", webhookHandler.UpdateWebhook)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}/webhooks/{webhook_id}", webhookHandler.DeleteWebhook)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/{id}/webhooks/{webhook_id}/deliveries", webhookHandler.ListDeliveries)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", webhookHandler.Redeliver)
 		})
 
 		// Match routes
 		r.Route("/matches", func(r chi.Router) {
 			matchHandler := NewMatchHandler(serviceContainer.Match)
-			// Public routes (view only)
-			r.Get("/", matchHandler.ListMatches)
-			r.Get("/{id}", matchHandler.GetMatch)
-			r.Get("/series/{series_id}", matchHandler.GetMatchesBySeries)
+			// Public routes (view only, scoped to the caller's organizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", matchHandler.ListMatches)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", matchHandler.GetMatch)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/series/{series_id}", matchHandler.GetMatchesBySeries)
 
 			// Protected routes (require authentication and ownership)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", matchHandler.CreateMatch)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", matchHandler.UpdateMatch)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", matchHandler.DeleteMatch)
+
+			// Trash (the caller's soft-deleted matches)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/trash", matchHandler.ListDeletedMatches)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/restore", matchHandler.RestoreMatch)
+
+			// Playing XI selection
+			squadHandler := NewMatchSquadHandler(serviceContainer.MatchSquad)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/squads", squadHandler.GetSquads)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}/squads/{team}", squadHandler.SetSquad)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/squads/{team}/substitutions", squadHandler.Substitute)
+
+			// Player of the match
+			awardHandler := NewAwardHandler(serviceContainer.Award)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/awards", awardHandler.GetMatchAwards)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/awards/confirm", awardHandler.ConfirmMatchAward)
+
+			// Live audience, saved when the match completes
+			audienceHandler := NewAudienceHandler(serviceContainer.Audience)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/audience", audienceHandler.GetMatchAudience)
+
+			// Live events as Server-Sent Events
+			eventsHandler := NewMatchEventsHandler(serviceContainer.Hub, serviceContainer.Match)
+			r.With(connectionAuth).Get("/{id}/events", eventsHandler.StreamMatchEvents)
+		})
+
+		// Team routes
+		r.Route("/teams", func(r chi.Router) {
+			teamHandler := NewTeamHandler(serviceContainer.Team)
+			// Public routes (view only, scoped to the caller's organizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", teamHandler.ListTeams)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", teamHandler.GetTeam)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/players", teamHandler.ListTeamPlayers)
+
+			// Protected routes (require authentication and ownership)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", teamHandler.CreateTeam)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", teamHandler.UpdateTeam)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", teamHandler.DeleteTeam)
+
+			// Roster management
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/players", teamHandler.AddTeamPlayer)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/players/bulk", teamHandler.AddTeamPlayers)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}/players/{player_id}", teamHandler.RemoveTeamPlayer)
+
+			// Trash (the caller's soft-deleted teams)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/trash", teamHandler.ListDeletedTeams)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/restore", teamHandler.RestoreTeam)
+		})
+
+		// Venue routes
+		r.Route("/venues", func(r chi.Router) {
+			venueHandler := NewVenueHandler(serviceContainer.Venue)
+			// Public routes (view only, scoped to the caller's organizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", venueHandler.ListVenues)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", venueHandler.GetVenue)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/matches", venueHandler.GetVenueMatches)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/records", venueHandler.GetVenueRecords)
+
+			// Protected routes (require authentication and ownership)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", venueHandler.CreateVenue)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", venueHandler.UpdateVenue)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", venueHandler.DeleteVenue)
+		})
+
+		// Player routes
+		r.Route("/players", func(r chi.Router) {
+			playerHandler := NewPlayerHandler(serviceContainer.Player)
+			// Public routes (view only, scoped to the caller's organizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", playerHandler.ListPlayers)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", playerHandler.GetPlayer)
+
+			// Protected routes (require ownership of the player's team; claimed players may edit their own profile)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", playerHandler.CreatePlayer)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", playerHandler.UpdatePlayer)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", playerHandler.DeletePlayer)
+
+			// Profile claims link a user account to a player once the team owner approves
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/claim", playerHandler.ClaimPlayer)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/claim/approve", playerHandler.ApproveClaim)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}/claim", playerHandler.ReleasePlayer)
+
+			// Trash (soft-deleted players in the caller's organizations)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/trash", playerHandler.ListDeletedPlayers)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/restore", playerHandler.RestorePlayer)
+
+			// Career and per-series statistics
+			statsHandler := NewStatsHandler(serviceContainer.Stats)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}/stats", statsHandler.GetPlayerStats)
 		})
 
 		// Scorecard routes
 		r.Route("/scorecard", func(r chi.Router) {
 			scorecardHandler := NewScorecardHandler(serviceContainer.Scorecard)
 			// Public routes (view only)
-			r.Get("/{match_id}", scorecardHandler.GetScorecard)
-			r.Get("/{match_id}/current-over", scorecardHandler.GetCurrentOver)
-			r.Get("/{match_id}/innings/{innings_number}", scorecardHandler.GetInnings)
-			r.Get("/{match_id}/innings/{innings_number}/over/{over_number}", scorecardHandler.GetOver)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{match_id}", scorecardHandler.GetScorecard)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{match_id}/current-over", scorecardHandler.GetCurrentOver)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{match_id}/innings/{innings_number}", scorecardHandler.GetInnings)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{match_id}/innings/{innings_number}/over/{over_number}", scorecardHandler.GetOver)
 
 			// Protected routes (require authentication and ownership)
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/start", scorecardHandler.StartScoring)
@@ -106,19 +260,52 @@ func SetupRoutes(dbClient *database.Client, cfg *config.Config) *chi.Mux {
 			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{match_id}/ball", scorecardHandler.UndoBall)
 		})
 
+		// Organization routes
+		r.Route("/organizations", func(r chi.Router) {
+			organizationHandler := NewOrganizationHandler(serviceContainer.Organization)
+			// Public routes (public organizations plus the caller's memberships)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/", organizationHandler.ListOrganizations)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Get("/{id}", organizationHandler.GetOrganization)
+
+			// Protected routes (require authentication and membership role)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/", organizationHandler.CreateOrganization)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}", organizationHandler.UpdateOrganization)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}", organizationHandler.DeleteOrganization)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Get("/{id}/members", organizationHandler.ListMembers)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/{id}/members", organizationHandler.AddMember)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Put("/{id}/members/{user_id}", organizationHandler.UpdateMember)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Delete("/{id}/members/{user_id}", organizationHandler.RemoveMember)
+		})
+
+		// Audit routes (admin only)
+		r.Route("/audit", func(r chi.Router) {
+			auditHandler := NewAuditHandler(serviceContainer.Audit)
+			r.With(middleware.AdminMiddleware(serviceContainer.SessionService)).Get("/", auditHandler.ListAuditLogs)
+		})
+
+		// Statistics maintenance (admin only)
+		r.Route("/stats", func(r chi.Router) {
+			statsHandler := NewStatsHandler(serviceContainer.Stats)
+			r.With(middleware.AdminMiddleware(serviceContainer.SessionService)).Post("/rebuild", statsHandler.RebuildStats)
+		})
+
 		// WebSocket routes
 		r.Route("/ws", func(r chi.Router) {
-			r.Get("/match/{match_id}", wsHandler.ServeWS)
-			r.Get("/stats", wsHandler.GetConnectionStats)
-			r.Get("/stats/{match_id}", wsHandler.GetRoomStats)
-			r.Post("/test/{match_id}", wsHandler.TestBroadcast)
+			r.With(connectionAuth).Get("/", wsHandler.ServeMultiplexWS)
+			r.With(connectionAuth).Get("/match/{match_id}", wsHandler.ServeWS)
+			r.With(connectionAuth).Get("/series/{series_id}", wsHandler.ServeSeriesWS)
+			r.With(middleware.AuthMiddleware(serviceContainer.SessionService)).Post("/token", wsHandler.IssueConnectionToken)
+			r.With(middleware.AdminMiddleware(serviceContainer.SessionService)).Get("/stats", wsHandler.GetConnectionStats)
+			r.With(middleware.AdminMiddleware(serviceContainer.SessionService)).Get("/stats/{match_id}", wsHandler.GetRoomStats)
+			r.With(middleware.AdminMiddleware(serviceContainer.SessionService)).Post("/test/{match_id}", wsHandler.TestBroadcast)
 		})
 
 		// GraphQL routes
 		r.Route("/graphql", func(r chi.Router) {
 			// Use GraphQL handler from the service
 			graphqlHandler := serviceContainer.GraphQLWebSocket.GetGraphQLHandler()
-			r.Post("/", graphqlHandler.ServeHTTP)
+			r.With(middleware.OptionalAuthMiddleware(serviceContainer.SessionService)).Post("/", graphqlHandler.ServeHTTP)
+			r.With(connectionAuth).Get("/ws", graphqlHandler.ServeWS)
 			r.Get("/playground", graphqlHandler.GetPlaygroundHandler().ServeHTTP)
 		})
 	})
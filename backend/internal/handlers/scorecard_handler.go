@@ -7,6 +7,7 @@ import (
 	"spark-park-cricket-backend/internal/interfaces"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/utils"
+	"spark-park-cricket-backend/pkg/websocket"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -158,7 +159,7 @@ func (h *ScorecardHandler) GetScorecard(w http.ResponseWriter, r *http.Request)
 	scorecard, err := h.scorecardService.GetScorecard(r.Context(), matchID)
 	if err != nil {
 		log.Printf("Error getting scorecard: %v", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeScorecardReadError(w, err)
 		return
 	}
 
@@ -193,7 +194,7 @@ func (h *ScorecardHandler) GetCurrentOver(w http.ResponseWriter, r *http.Request
 	over, err := h.scorecardService.GetCurrentOver(r.Context(), matchID, inningsNumber)
 	if err != nil {
 		log.Printf("Error getting current over: %v", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeScorecardReadError(w, err)
 		return
 	}
 
@@ -230,7 +231,7 @@ func (h *ScorecardHandler) GetInnings(w http.ResponseWriter, r *http.Request) {
 	scorecard, err := h.scorecardService.GetScorecard(r.Context(), matchID)
 	if err != nil {
 		log.Printf("Error getting scorecard: %v", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeScorecardReadError(w, err)
 		return
 	}
 
@@ -296,7 +297,7 @@ func (h *ScorecardHandler) GetOver(w http.ResponseWriter, r *http.Request) {
 	scorecard, err := h.scorecardService.GetScorecard(r.Context(), matchID)
 	if err != nil {
 		log.Printf("Error getting scorecard: %v", err)
-		utils.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeScorecardReadError(w, err)
 		return
 	}
 
@@ -323,3 +324,18 @@ func (h *ScorecardHandler) GetOver(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Successfully retrieved over %d for innings %d, match %s", overNumber, inningsNumber, matchID)
 	utils.WriteSuccessResponse(w, over)
 }
+
+// writeScorecardReadError writes the response for a scorecard read that
+// failed, refusing callers the match's access policy keeps out
+func writeScorecardReadError(w http.ResponseWriter, err error) {
+	switch err {
+	case websocket.ErrUnauthorized:
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", "Sign in to follow this match")
+	case websocket.ErrForbidden:
+		utils.WriteErrorResponse(w, http.StatusForbidden, "FORBIDDEN", "You are not allowed to follow this match")
+	case websocket.ErrRoomNotFound:
+		utils.WriteErrorResponse(w, http.StatusNotFound, "NOT_FOUND", "Match not found")
+	default:
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
@@ -49,6 +49,10 @@ func (h *SeriesHandler) ListSeries(w http.ResponseWriter, r *http.Request) {
 		Offset: offset,
 	}
 
+	if organizationID := r.URL.Query().Get("organization_id"); organizationID != "" {
+		filters.OrganizationIDs = []string{organizationID}
+	}
+
 	series, err := h.service.ListSeries(r.Context(), filters)
 	if err != nil {
 		utils.WriteInternalError(w, err.Error())
@@ -131,3 +135,50 @@ func (h *SeriesHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteSuccess(w, map[string]string{"message": "Series deleted successfully"})
 }
+
+// ListDeletedSeries handles GET /api/v1/series/trash
+func (h *SeriesHandler) ListDeletedSeries(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	filters := &models.SeriesFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	series, err := h.service.ListDeletedSeries(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, series)
+}
+
+// RestoreSeries handles POST /api/v1/series/{id}/restore
+func (h *SeriesHandler) RestoreSeries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	restored, err := h.service.RestoreSeries(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, restored)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StageHandler handles HTTP requests for series stages and brackets
+type StageHandler struct {
+	service *services.StageService
+}
+
+// NewStageHandler creates a new stage handler
+func NewStageHandler(service *services.StageService) *StageHandler {
+	return &StageHandler{
+		service: service,
+	}
+}
+
+// CreateStage handles POST /api/v1/series/{id}/stages
+func (h *StageHandler) CreateStage(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	var req models.CreateStageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if req.Name == "" || req.Type == "" {
+		utils.WriteValidationError(w, "Name and type are required", nil)
+		return
+	}
+
+	stage, err := h.service.CreateStage(r.Context(), seriesID, &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to create stage", err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, stage)
+}
+
+// ListStages handles GET /api/v1/series/{id}/stages
+func (h *StageHandler) ListStages(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	stages, err := h.service.ListStages(r.Context(), seriesID)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, stages)
+}
+
+// DeleteStage handles DELETE /api/v1/series/{id}/stages/{stage_id}
+func (h *StageHandler) DeleteStage(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	stageID := chi.URLParam(r, "stage_id")
+	if seriesID == "" || stageID == "" {
+		utils.WriteValidationError(w, "Series ID and stage ID are required", nil)
+		return
+	}
+
+	if err := h.service.DeleteStage(r.Context(), seriesID, stageID); err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Stage deleted successfully"})
+}
+
+// GetBracket handles GET /api/v1/series/{id}/bracket
+func (h *StageHandler) GetBracket(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	bracket, err := h.service.GetBracket(r.Context(), seriesID)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, bracket)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StandingsHandler handles HTTP requests for series points tables
+type StandingsHandler struct {
+	service *services.StandingsService
+}
+
+// NewStandingsHandler creates a new standings handler
+func NewStandingsHandler(service *services.StandingsService) *StandingsHandler {
+	return &StandingsHandler{
+		service: service,
+	}
+}
+
+// GetStandings handles GET /api/v1/series/{id}/standings
+func (h *StandingsHandler) GetStandings(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	standings, err := h.service.GetStandings(r.Context(), seriesID)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, standings)
+}
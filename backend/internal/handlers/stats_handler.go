@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StatsHandler handles HTTP requests for player statistics
+type StatsHandler struct {
+	service *services.StatsService
+}
+
+// NewStatsHandler creates a new statistics handler
+func NewStatsHandler(service *services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// GetPlayerStats handles GET /api/v1/players/{id}/stats
+func (h *StatsHandler) GetPlayerStats(w http.ResponseWriter, r *http.Request) {
+	playerID := chi.URLParam(r, "id")
+	if playerID == "" {
+		utils.WriteValidationError(w, "Player ID is required", nil)
+		return
+	}
+
+	stats, err := h.service.GetPlayerStats(r.Context(), playerID, r.URL.Query().Get("series_id"))
+	if err != nil {
+		utils.WriteNotFound(w, "Player")
+		return
+	}
+
+	utils.WriteSuccess(w, stats)
+}
+
+// GetSeriesStats handles GET /api/v1/series/{id}/stats
+func (h *StatsHandler) GetSeriesStats(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	stats, err := h.service.GetSeriesStats(r.Context(), seriesID)
+	if err != nil {
+		utils.WriteNotFound(w, "Series")
+		return
+	}
+
+	utils.WriteSuccess(w, stats)
+}
+
+// RebuildStats handles POST /api/v1/stats/rebuild
+func (h *StatsHandler) RebuildStats(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.RebuildAll(r.Context())
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, result)
+}
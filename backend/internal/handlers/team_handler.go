@@ -52,6 +52,10 @@ func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
 		Offset: offset,
 	}
 
+	if organizationID := r.URL.Query().Get("organization_id"); organizationID != "" {
+		filters.OrganizationIDs = []string{organizationID}
+	}
+
 	// Get teams from service
 	teams, err := h.service.ListTeams(r.Context(), filters)
 	if err != nil {
@@ -157,6 +161,53 @@ func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
 	utils.WriteSuccess(w, map[string]string{"message": "Team deleted successfully"})
 }
 
+// ListDeletedTeams handles GET /api/v1/teams/trash
+func (h *TeamHandler) ListDeletedTeams(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	filters := &models.TeamFilters{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	teams, err := h.service.ListDeletedTeams(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, teams)
+}
+
+// RestoreTeam handles POST /api/v1/teams/{id}/restore
+func (h *TeamHandler) RestoreTeam(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Team ID is required", nil)
+		return
+	}
+
+	team, err := h.service.RestoreTeam(r.Context(), id)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, team)
+}
+
 // ListTeamPlayers handles GET /api/v1/teams/{id}/players
 func (h *TeamHandler) ListTeamPlayers(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -204,3 +255,47 @@ func (h *TeamHandler) AddTeamPlayer(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteCreated(w, player)
 }
+
+// AddTeamPlayers handles POST /api/v1/teams/{id}/players/bulk
+func (h *TeamHandler) AddTeamPlayers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Team ID is required", nil)
+		return
+	}
+
+	var req models.AddTeamPlayersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	players, err := h.service.AddPlayersToTeam(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, players)
+}
+
+// RemoveTeamPlayer handles DELETE /api/v1/teams/{id}/players/{player_id}
+func (h *TeamHandler) RemoveTeamPlayer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	playerID := chi.URLParam(r, "player_id")
+	if id == "" || playerID == "" {
+		utils.WriteValidationError(w, "Team ID and player ID are required", nil)
+		return
+	}
+
+	if err := h.service.RemovePlayerFromTeam(r.Context(), id, playerID); err != nil {
+		if err.Error() == "player not found in team" {
+			utils.WriteNotFound(w, "Player")
+			return
+		}
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Player removed from team successfully"})
+}
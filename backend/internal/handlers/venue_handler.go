@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// VenueHandler handles HTTP requests for venues
+type VenueHandler struct {
+	service *services.VenueService
+}
+
+// NewVenueHandler creates a new venue handler
+func NewVenueHandler(service *services.VenueService) *VenueHandler {
+	return &VenueHandler{
+		service: service,
+	}
+}
+
+// ListVenues handles GET /api/v1/venues
+func (h *VenueHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	filters := &models.VenueFilters{Limit: 20}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 {
+		filters.Limit = limit
+	}
+	if offset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && offset >= 0 {
+		filters.Offset = offset
+	}
+	if organizationID := r.URL.Query().Get("organization_id"); organizationID != "" {
+		filters.OrganizationIDs = []string{organizationID}
+	}
+
+	venues, err := h.service.ListVenues(r.Context(), filters)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, venues)
+}
+
+// CreateVenue handles POST /api/v1/venues
+func (h *VenueHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if req.Name == "" {
+		utils.WriteValidationError(w, "Name is required", nil)
+		return
+	}
+
+	venue, err := h.service.CreateVenue(r.Context(), &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to create venue", err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, venue)
+}
+
+// GetVenue handles GET /api/v1/venues/{id}
+func (h *VenueHandler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Venue ID is required", nil)
+		return
+	}
+
+	venue, err := h.service.GetVenue(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Venue")
+		return
+	}
+
+	utils.WriteSuccess(w, venue)
+}
+
+// UpdateVenue handles PUT /api/v1/venues/{id}
+func (h *VenueHandler) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Venue ID is required", nil)
+		return
+	}
+
+	var req models.UpdateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	venue, err := h.service.UpdateVenue(r.Context(), id, &req)
+	if err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, venue)
+}
+
+// DeleteVenue handles DELETE /api/v1/venues/{id}
+func (h *VenueHandler) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Venue ID is required", nil)
+		return
+	}
+
+	if err := h.service.DeleteVenue(r.Context(), id); err != nil {
+		utils.WriteInternalError(w, err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Venue deleted successfully"})
+}
+
+// GetVenueMatches handles GET /api/v1/venues/{id}/matches
+func (h *VenueHandler) GetVenueMatches(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Venue ID is required", nil)
+		return
+	}
+
+	matches, err := h.service.GetVenueMatches(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Venue")
+		return
+	}
+
+	utils.WriteSuccess(w, matches)
+}
+
+// GetVenueRecords handles GET /api/v1/venues/{id}/records
+func (h *VenueHandler) GetVenueRecords(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		utils.WriteValidationError(w, "Venue ID is required", nil)
+		return
+	}
+
+	records, err := h.service.GetVenueRecords(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFound(w, "Venue")
+		return
+	}
+
+	utils.WriteSuccess(w, records)
+}
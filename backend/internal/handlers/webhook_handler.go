@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler handles HTTP requests for series webhooks and their delivery logs
+type WebhookHandler struct {
+	service *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(service *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhook handles POST /api/v1/series/{id}/webhooks. The response
+// carries the signing secret, which is not shown again.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	var req models.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+	if req.URL == "" {
+		utils.WriteValidationError(w, "URL is required", nil)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), seriesID, &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to create webhook", err.Error())
+		return
+	}
+
+	utils.WriteCreated(w, webhook)
+}
+
+// ListWebhooks handles GET /api/v1/series/{id}/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	if seriesID == "" {
+		utils.WriteValidationError(w, "Series ID is required", nil)
+		return
+	}
+
+	webhooks, err := h.service.ListWebhooks(r.Context(), seriesID)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to list webhooks", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, webhooks)
+}
+
+// UpdateWebhook handles PUT /api/v1/series/{id}/webhooks/{webhook_id}
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	webhookID := chi.URLParam(r, "webhook_id")
+	if seriesID == "" || webhookID == "" {
+		utils.WriteValidationError(w, "Series ID and webhook ID are required", nil)
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body", err.Error())
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(r.Context(), seriesID, webhookID, &req)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to update webhook", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, webhook)
+}
+
+// DeleteWebhook handles DELETE /api/v1/series/{id}/webhooks/{webhook_id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	webhookID := chi.URLParam(r, "webhook_id")
+	if seriesID == "" || webhookID == "" {
+		utils.WriteValidationError(w, "Series ID and webhook ID are required", nil)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), seriesID, webhookID); err != nil {
+		utils.WriteValidationError(w, "Failed to delete webhook", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Webhook deleted successfully"})
+}
+
+// ListDeliveries handles GET /api/v1/series/{id}/webhooks/{webhook_id}/deliveries.
+// ?status=dead lists the dead letters.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	webhookID := chi.URLParam(r, "webhook_id")
+	if seriesID == "" || webhookID == "" {
+		utils.WriteValidationError(w, "Series ID and webhook ID are required", nil)
+		return
+	}
+
+	status := models.WebhookDeliveryStatus(r.URL.Query().Get("status"))
+	deliveries, err := h.service.ListDeliveries(r.Context(), seriesID, webhookID, status)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to list deliveries", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, deliveries)
+}
+
+// Redeliver handles POST /api/v1/series/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	webhookID := chi.URLParam(r, "webhook_id")
+	deliveryID := chi.URLParam(r, "delivery_id")
+	if seriesID == "" || webhookID == "" || deliveryID == "" {
+		utils.WriteValidationError(w, "Series ID, webhook ID and delivery ID are required", nil)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), seriesID, webhookID, deliveryID)
+	if err != nil {
+		utils.WriteValidationError(w, "Failed to redeliver", err.Error())
+		return
+	}
+
+	utils.WriteSuccess(w, delivery)
+}
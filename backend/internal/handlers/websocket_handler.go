@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/internal/utils"
 	"spark-park-cricket-backend/pkg/websocket"
 
 	"github.com/go-chi/chi/v5"
@@ -25,6 +27,16 @@ func NewWebSocketHandler(hub *websocket.Hub, services *services.Container) *WebS
 	}
 }
 
+// ServeMultiplexWS handles WebSocket connections that start without a room
+// and subscribe to any number of match and series rooms over the protocol
+func (h *WebSocketHandler) ServeMultiplexWS(w http.ResponseWriter, r *http.Request) {
+	clientID := uuid.New().String()
+
+	log.Printf("WebSocket connection request, client %s", clientID)
+
+	h.hub.ServeWS(w, r, "", clientID)
+}
+
 // ServeWS handles WebSocket connections for a specific match
 func (h *WebSocketHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	matchID := chi.URLParam(r, "match_id")
@@ -42,14 +54,33 @@ func (h *WebSocketHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	h.hub.ServeWS(w, r, matchID, clientID)
 }
 
-// GetConnectionStats returns WebSocket connection statistics
+// ServeSeriesWS handles WebSocket connections following a series' standings
+func (h *WebSocketHandler) ServeSeriesWS(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "series_id")
+	if seriesID == "" {
+		http.Error(w, "Series ID is required", http.StatusBadRequest)
+		return
+	}
+
+	clientID := uuid.New().String()
+
+	log.Printf("WebSocket connection request for series %s, client %s", seriesID, clientID)
+
+	h.hub.ServeWS(w, r, websocket.SeriesRoomID(seriesID), clientID)
+}
+
+// GetConnectionStats returns WebSocket connection statistics and health
+// metrics summed over every server instance
 func (h *WebSocketHandler) GetConnectionStats(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
+	cluster := h.hub.ClusterStats()
 	stats := map[string]interface{}{
-		"total_connections": h.hub.GetTotalClients(),
-		"total_rooms":       h.hub.GetTotalRooms(),
+		"total_connections": cluster.TotalClients,
+		"total_rooms":       len(cluster.Rooms),
+		"instances":         cluster.Instances,
+		"metrics":           cluster.Metrics,
 	}
 
 	response, err := json.Marshal(stats)
@@ -64,7 +95,8 @@ func (h *WebSocketHandler) GetConnectionStats(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// GetRoomStats returns statistics for a specific room/match
+// GetRoomStats returns statistics for a specific room/match summed over
+// every server instance
 func (h *WebSocketHandler) GetRoomStats(w http.ResponseWriter, r *http.Request) {
 	matchID := chi.URLParam(r, "match_id")
 	w.Header().Set("Content-Type", "application/json")
@@ -72,7 +104,7 @@ func (h *WebSocketHandler) GetRoomStats(w http.ResponseWriter, r *http.Request)
 
 	stats := map[string]interface{}{
 		"match_id":    matchID,
-		"connections": h.hub.GetRoomClients(matchID),
+		"connections": h.hub.ClusterStats().Rooms[matchID],
 	}
 
 	response, err := json.Marshal(stats)
@@ -87,6 +119,24 @@ func (h *WebSocketHandler) GetRoomStats(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// IssueConnectionToken handles POST /api/v1/ws/token, issuing the signed-in
+// user a short-lived token for opening WebSockets and event streams from
+// clients that cannot send the session cookie
+func (h *WebSocketHandler) IssueConnectionToken(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*models.User)
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required", nil)
+		return
+	}
+
+	token, err := h.services.ConnectionTokens.Issue(user)
+	if err != nil {
+		utils.WriteInternalError(w, "Failed to issue connection token")
+		return
+	}
+	utils.WriteSuccess(w, token)
+}
+
 // TestBroadcast sends a test broadcast to a specific match room
 func (h *WebSocketHandler) TestBroadcast(w http.ResponseWriter, r *http.Request) {
 	matchID := chi.URLParam(r, "match_id")
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// PlayerServiceInterface defines the player lookups needed by the GraphQL resolvers
+type PlayerServiceInterface interface {
+	GetPlayer(ctx context.Context, id string) (*models.Player, error)
+}
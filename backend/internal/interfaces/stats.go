@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// StatsServiceInterface defines the statistics lookups needed by the GraphQL resolvers
+type StatsServiceInterface interface {
+	GetMatchPlayerStats(ctx context.Context, matchID string) ([]*models.PlayerMatchStats, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// TeamServiceInterface defines the team lookups needed by the GraphQL resolvers
+type TeamServiceInterface interface {
+	GetTeam(ctx context.Context, id string) (*models.Team, error)
+	GetTeamPlayers(ctx context.Context, teamID string) ([]*models.Player, error)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"spark-park-cricket-backend/internal/services"
 	"spark-park-cricket-backend/internal/utils"
+	"strings"
 )
 
 // AuthMiddleware provides authentication middleware
@@ -63,6 +64,49 @@ func OptionalAuthMiddleware(sessionSvc services.SessionServiceInterface) func(ht
 	}
 }
 
+// ConnectionAuthMiddleware identifies the user opening a WebSocket or
+// event stream from the session cookie or, for clients that cannot send it,
+// a connection token in the token query parameter or a Bearer header.
+// Anonymous connections continue, for public rooms; an invalid token is
+// refused rather than treated as anonymous.
+func ConnectionAuthMiddleware(sessionSvc services.SessionServiceInterface, tokens *services.ConnectionTokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := sessionSvc.GetSession(r)
+			if err != nil || user == nil {
+				user = nil
+				if token := connectionToken(r); token != "" {
+					if user, err = tokens.Verify(token); err != nil {
+						utils.WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid connection token", nil)
+						return
+					}
+				}
+			}
+
+			ctx := r.Context()
+			if user != nil {
+				ctx = context.WithValue(ctx, "user", user)
+				ctx = context.WithValue(ctx, "user_id", user.ID)
+				ctx = context.WithValue(ctx, "user_email", user.Email)
+			}
+			ctx = context.WithValue(ctx, "authenticated", user != nil)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// connectionToken returns the connection token a request carries, if any
+func connectionToken(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	return ""
+}
+
 // AdminMiddleware provides admin-only access middleware
 func AdminMiddleware(sessionSvc services.SessionServiceInterface) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"spark-park-cricket-backend/internal/utils"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,9 +35,20 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// TimeoutMiddleware provides request timeout handling
+// TimeoutMiddleware provides request timeout handling. Server-Sent Events
+// requests are left alone, as their streams stay open while the client listens.
 func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
-	return middleware.Timeout(timeout)
+	withTimeout := middleware.Timeout(timeout)
+	return func(next http.Handler) http.Handler {
+		timed := withTimeout(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timed.ServeHTTP(w, r)
+		})
+	}
 }
 
 // RateLimitMiddleware provides basic rate limiting
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// MatchAudience is how many people followed a match live, over WebSockets,
+// event streams and GraphQL subscriptions alike. Averages weigh each count
+// by how long it lasted, from the first viewer on.
+type MatchAudience struct {
+	MatchID        string          `json:"match_id" db:"match_id"`
+	CurrentViewers int             `json:"current_viewers" db:"-"` // Live only
+	PeakViewers    int             `json:"peak_viewers" db:"peak_viewers"`
+	PeakAt         *time.Time      `json:"peak_at,omitempty" db:"peak_at"`
+	AverageViewers float64         `json:"average_viewers" db:"average_viewers"`
+	Timeline       []AudiencePoint `json:"timeline" db:"timeline"`
+	RecordedAt     *time.Time      `json:"recorded_at,omitempty" db:"recorded_at"` // When the match completed and the audience was saved
+}
+
+// AudiencePoint is the most viewers a match had during one minute
+type AudiencePoint struct {
+	At      time.Time `json:"at"`
+	Viewers int       `json:"viewers"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction represents the kind of mutation that was recorded
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+)
+
+// AuditEntityType represents the type of entity that was mutated
+type AuditEntityType string
+
+const (
+	AuditEntitySeries  AuditEntityType = "series"
+	AuditEntityMatch   AuditEntityType = "match"
+	AuditEntityTeam    AuditEntityType = "team"
+	AuditEntityPlayer  AuditEntityType = "player"
+	AuditEntityInnings AuditEntityType = "innings"
+	AuditEntityBall    AuditEntityType = "ball"
+
+	AuditEntityMatchSquad  AuditEntityType = "match_squad"
+	AuditEntitySeriesStage AuditEntityType = "series_stage"
+	AuditEntityVenue       AuditEntityType = "venue"
+	AuditEntityAward       AuditEntityType = "award"
+	AuditEntityWebhook     AuditEntityType = "webhook"
+
+	AuditEntityOrganization       AuditEntityType = "organization"
+	AuditEntityOrganizationMember AuditEntityType = "organization_member"
+)
+
+// AuditLog represents a single recorded mutation
+type AuditLog struct {
+	ID         string          `json:"id,omitempty" db:"id,omitempty"`
+	ActorID    string          `json:"actor_id,omitempty" db:"actor_id"`
+	ActorEmail string          `json:"actor_email,omitempty" db:"actor_email"`
+	Action     AuditAction     `json:"action" db:"action"`
+	EntityType AuditEntityType `json:"entity_type" db:"entity_type"`
+	EntityID   string          `json:"entity_id" db:"entity_id"`
+	RequestID  string          `json:"request_id,omitempty" db:"request_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"`
+	After      json.RawMessage `json:"after,omitempty" db:"after"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilters represents filters for listing audit logs
+type AuditLogFilters struct {
+	ActorID    *string          `json:"actor_id,omitempty"`
+	Action     *AuditAction     `json:"action,omitempty"`
+	EntityType *AuditEntityType `json:"entity_type,omitempty"`
+	EntityID   *string          `json:"entity_id,omitempty"`
+	RequestID  *string          `json:"request_id,omitempty"`
+	From       *time.Time       `json:"from,omitempty"`
+	To         *time.Time       `json:"to,omitempty"`
+	Limit      int              `json:"limit" validate:"min=1,max=100"`
+	Offset     int              `json:"offset" validate:"min=0"`
+}
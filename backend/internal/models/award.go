@@ -0,0 +1,132 @@
+package models
+
+import (
+	"time"
+)
+
+// ImpactFormula configures how a player's impact on a match is scored from
+// their match line. A series replaces the whole formula, so zero values
+// switch a component off.
+type ImpactFormula struct {
+	// Batting
+	RunPoints        float64 `json:"run_points"`         // Per run scored
+	BoundaryPoints   float64 `json:"boundary_points"`    // Per four; a six earns double
+	StrikeRatePar    float64 `json:"strike_rate_par"`    // Strike rate that earns no bonus or penalty
+	StrikeRatePoints float64 `json:"strike_rate_points"` // Per run above or below par for the balls faced
+
+	// Bowling
+	WicketPoints  float64 `json:"wicket_points"`
+	MaidenPoints  float64 `json:"maiden_points"`
+	EconomyPar    float64 `json:"economy_par"`    // Runs an over that earn no bonus or penalty
+	EconomyPoints float64 `json:"economy_points"` // Per run conceded below or above par for the balls bowled
+
+	// Fielding
+	CatchPoints    float64 `json:"catch_points"`
+	StumpingPoints float64 `json:"stumping_points"`
+	RunOutPoints   float64 `json:"run_out_points"`
+
+	// Match situation: the winning side's impact is scaled by this; 1 disables
+	WinnerMultiplier float64 `json:"winner_multiplier"`
+}
+
+// DefaultImpactFormula returns the formula used when a series does not set its own
+func DefaultImpactFormula() ImpactFormula {
+	return ImpactFormula{
+		RunPoints:        1,
+		BoundaryPoints:   1,
+		StrikeRatePar:    100,
+		StrikeRatePoints: 0.5,
+		WicketPoints:     20,
+		MaidenPoints:     10,
+		EconomyPar:       8,
+		EconomyPoints:    1,
+		CatchPoints:      8,
+		StumpingPoints:   10,
+		RunOutPoints:     10,
+		WinnerMultiplier: 1.2,
+	}
+}
+
+// PlayerImpact represents a player's impact points in a match, or summed over
+// a series' completed matches
+type PlayerImpact struct {
+	Rank       int     `json:"rank"`
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TeamID     string  `json:"team_id,omitempty"`
+	Matches    int     `json:"matches,omitempty"` // Series only
+	Batting    float64 `json:"batting"`
+	Bowling    float64 `json:"bowling"`
+	Fielding   float64 `json:"fielding"`
+	Total      float64 `json:"total"`
+	Winner     bool    `json:"winner,omitempty"` // Match only: played for the winning side
+}
+
+// AwardType represents a match or series award
+type AwardType string
+
+const (
+	AwardPlayerOfTheMatch AwardType = "player_of_the_match"
+	AwardBestBatter       AwardType = "best_batter" // Series: most batting impact
+	AwardBestBowler       AwardType = "best_bowler" // Series: most bowling impact
+	AwardMostValuable     AwardType = "mvp"         // Series: most total impact
+)
+
+// SeriesAwardTypes lists the series awards in display order
+func SeriesAwardTypes() []AwardType {
+	return []AwardType{AwardMostValuable, AwardBestBatter, AwardBestBowler}
+}
+
+// AwardStatus represents whether an award has been settled by the organiser
+type AwardStatus string
+
+const (
+	AwardStatusProposed  AwardStatus = "proposed"  // Computed; follows the results until confirmed
+	AwardStatusConfirmed AwardStatus = "confirmed" // Settled by the organiser; kept as is
+)
+
+// Award represents a stored award. Match awards name their match; series
+// awards leave it empty.
+type Award struct {
+	ID               string      `json:"id,omitempty" db:"id,omitempty"`
+	AwardType        AwardType   `json:"award_type" db:"award_type"`
+	SeriesID         string      `json:"series_id" db:"series_id"`
+	MatchID          string      `json:"match_id,omitempty" db:"match_id,omitempty"`
+	PlayerID         string      `json:"player_id" db:"player_id"`
+	PlayerName       string      `json:"player_name" db:"player_name"`
+	TeamID           string      `json:"team_id,omitempty" db:"team_id,omitempty"`
+	Points           float64     `json:"points" db:"points"`                         // The player's impact points
+	ProposedPlayerID string      `json:"proposed_player_id" db:"proposed_player_id"` // The computed pick
+	Status           AwardStatus `json:"status" db:"status"`
+	Overridden       bool        `json:"overridden" db:"overridden"` // Confirmed for someone other than the computed pick
+	Note             string      `json:"note,omitempty" db:"note,omitempty"`
+	ConfirmedBy      string      `json:"confirmed_by,omitempty" db:"confirmed_by,omitempty"`
+	ConfirmedAt      *time.Time  `json:"confirmed_at,omitempty" db:"confirmed_at,omitempty"`
+	CreatedAt        time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// ConfirmAwardRequest represents the organiser settling an award. An empty
+// player ID confirms the computed pick; any other player overrides it.
+type ConfirmAwardRequest struct {
+	PlayerID string `json:"player_id,omitempty"`
+	Note     string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// MatchAwards represents a match's player of the match with the impact
+// ranking behind it. Live matches have impacts but no award yet.
+type MatchAwards struct {
+	MatchID          string          `json:"match_id"`
+	PlayerOfTheMatch *Award          `json:"player_of_the_match,omitempty"`
+	Impacts          []*PlayerImpact `json:"impacts"`
+	Formula          ImpactFormula   `json:"formula"`
+}
+
+// SeriesAwards represents a series' awards with the impact ranking of its
+// completed matches
+type SeriesAwards struct {
+	SeriesID string          `json:"series_id"`
+	Awards   []*Award        `json:"awards"`
+	Impacts  []*PlayerImpact `json:"impacts"`
+	Formula  ImpactFormula   `json:"formula"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// FixtureFormat represents how the fixtures of a series are drawn up
+type FixtureFormat string
+
+const (
+	FixtureFormatRoundRobin       FixtureFormat = "round_robin"        // Every team plays every other team once
+	FixtureFormatDoubleRoundRobin FixtureFormat = "double_round_robin" // Twice, with sides swapped in the second leg
+	FixtureFormatGroups           FixtureFormat = "groups"             // Round robin within seeded groups
+	FixtureFormatKnockout         FixtureFormat = "knockout"           // Seeded single-elimination bracket
+)
+
+// Defaults used when a fixture request leaves match settings out
+const (
+	DefaultFixturePlayerCount = 11
+	DefaultFixtureTotalOvers  = 20
+	DefaultFixtureSlot        = "10:00"
+)
+
+// GenerateFixturesRequest represents the request to draw up a series' fixtures.
+// Team order is the seeding: seed 1 first.
+type GenerateFixturesRequest struct {
+	Format     FixtureFormat `json:"format" validate:"required,oneof=round_robin double_round_robin groups knockout"`
+	TeamIDs    []string      `json:"team_ids" validate:"required,min=2"`
+	GroupCount int           `json:"group_count,omitempty" validate:"omitempty,min=2"` // Required for groups
+
+	// Scheduling limits. Dates default to the series' start and end dates.
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	EndDate          *time.Time `json:"end_date,omitempty"`
+	MatchDays        []int      `json:"match_days,omitempty"`          // Weekdays to play on, 0 = Sunday; empty means every day
+	Slots            []string   `json:"slots,omitempty"`               // Start times as HH:MM; defaults to 10:00
+	Venues           []string   `json:"venues,omitempty"`              // Grounds that can host a match in every slot
+	MaxMatchesPerDay int        `json:"max_matches_per_day,omitempty"` // 0 means slots x venues
+	RestDays         int        `json:"rest_days,omitempty"`           // Days off a team gets between matches
+
+	// Match settings applied to every fixture
+	TeamPlayerCount int `json:"team_player_count,omitempty" validate:"omitempty,min=1,max=20"`
+	TotalOvers      int `json:"total_overs,omitempty" validate:"omitempty,min=1,max=20"`
+}
+
+// Fixture represents one scheduled match of a generated schedule. Knockout
+// fixtures beyond the first round have no teams yet and describe where their
+// sides come from instead.
+type Fixture struct {
+	MatchNumber int       `json:"match_number"`
+	Stage       string    `json:"stage"` // e.g. "Round 3", "Group B", "Semi-final"
+	Round       int       `json:"round"`
+	Group       string    `json:"group,omitempty"`
+	Date        time.Time `json:"date"`
+	Venue       string    `json:"venue,omitempty"`
+	TeamAID     string    `json:"team_a_id,omitempty"`
+	TeamAName   string    `json:"team_a_name,omitempty"`
+	TeamBID     string    `json:"team_b_id,omitempty"`
+	TeamBName   string    `json:"team_b_name,omitempty"`
+	TeamALabel  string    `json:"team_a_label,omitempty"` // e.g. "Winner of match 3"
+	TeamBLabel  string    `json:"team_b_label,omitempty"`
+	MatchID     string    `json:"match_id,omitempty"` // Set once the fixture is created
+}
+
+// FixtureSchedule represents a generated schedule, previewed or created
+type FixtureSchedule struct {
+	SeriesID string        `json:"series_id"`
+	Format   FixtureFormat `json:"format"`
+	Created  bool          `json:"created"` // False for previews
+	Fixtures []*Fixture    `json:"fixtures"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// LeaderboardCategory represents one series leaderboard
+type LeaderboardCategory string
+
+const (
+	LeaderboardMostRuns       LeaderboardCategory = "most_runs"
+	LeaderboardMostWickets    LeaderboardCategory = "most_wickets"
+	LeaderboardHighestScore   LeaderboardCategory = "highest_score" // Best single innings
+	LeaderboardBestBowling    LeaderboardCategory = "best_bowling"  // Best figures in a single innings
+	LeaderboardMostSixes      LeaderboardCategory = "most_sixes"
+	LeaderboardBestStrikeRate LeaderboardCategory = "best_strike_rate" // Qualifies on balls faced
+	LeaderboardBestEconomy    LeaderboardCategory = "best_economy"     // Qualifies on balls bowled
+	LeaderboardMostCatches    LeaderboardCategory = "most_catches"
+)
+
+// LeaderboardCategories lists every leaderboard in display order
+func LeaderboardCategories() []LeaderboardCategory {
+	return []LeaderboardCategory{
+		LeaderboardMostRuns,
+		LeaderboardMostWickets,
+		LeaderboardHighestScore,
+		LeaderboardBestBowling,
+		LeaderboardMostSixes,
+		LeaderboardBestStrikeRate,
+		LeaderboardBestEconomy,
+		LeaderboardMostCatches,
+	}
+}
+
+// Defaults used when a leaderboard query leaves settings out
+const (
+	DefaultLeaderboardLimit          = 10
+	MaxLeaderboardLimit              = 50
+	DefaultLeaderboardMinBallsFaced  = 30 // Strike rate qualification
+	DefaultLeaderboardMinBallsBowled = 60 // Economy qualification: 10 overs
+)
+
+// LeaderboardQuery represents the options of a leaderboard request; zero
+// values use the defaults
+type LeaderboardQuery struct {
+	Category       LeaderboardCategory `json:"category,omitempty"` // Empty returns every leaderboard
+	Limit          int                 `json:"limit,omitempty"`
+	MinBallsFaced  int                 `json:"min_balls_faced,omitempty"`
+	MinBallsBowled int                 `json:"min_balls_bowled,omitempty"`
+}
+
+// LeaderboardEntry represents one ranked player. Single-innings leaderboards
+// also name the match the performance came in.
+type LeaderboardEntry struct {
+	Rank       int     `json:"rank"` // Tied entries share a rank
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TeamID     string  `json:"team_id,omitempty"`
+	MatchID    string  `json:"match_id,omitempty"`
+	Value      float64 `json:"value"`
+	Display    string  `json:"display"` // e.g. "87*", "4/21", "152.38"
+	Matches    int     `json:"matches,omitempty"`
+	Balls      int     `json:"balls,omitempty"` // Balls faced or bowled behind the value
+}
+
+// Leaderboard represents the ranked entries of one category
+type Leaderboard struct {
+	Category LeaderboardCategory `json:"category"`
+	Title    string              `json:"title"`
+	Entries  []*LeaderboardEntry `json:"entries"`
+}
+
+// SeriesLeaderboards represents the leaderboards of a series, covering its
+// completed and live matches
+type SeriesLeaderboards struct {
+	SeriesID       string         `json:"series_id"`
+	Limit          int            `json:"limit"`
+	MinBallsFaced  int            `json:"min_balls_faced"`
+	MinBallsBowled int            `json:"min_balls_bowled"`
+	Leaderboards   []*Leaderboard `json:"leaderboards"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
@@ -13,6 +13,16 @@ const (
 	MatchStatusCancelled MatchStatus = "cancelled"
 )
 
+// MatchAccess controls who may follow a match live over WebSockets and
+// event streams
+type MatchAccess string
+
+const (
+	MatchAccessPublic        MatchAccess = "public"         // Anyone, signed in or not
+	MatchAccessSeriesMembers MatchAccess = "series_members" // Members of the organization running the series
+	MatchAccessInviteOnly    MatchAccess = "invite_only"    // Invited users, the match's creator and organization admins
+)
+
 // TossType represents the toss result
 type TossType string
 
@@ -35,45 +45,75 @@ type Match struct {
 	SeriesID         string      `json:"series_id" db:"series_id"`
 	MatchNumber      int         `json:"match_number" db:"match_number"`
 	Date             time.Time   `json:"date" db:"date"`
+	Venue            string      `json:"venue,omitempty" db:"venue,omitempty"`       // Ground the match is played at; the venue's name when VenueID is set
+	VenueID          string      `json:"venue_id,omitempty" db:"venue_id,omitempty"` // Booked venue, checked for clashes
+	PitchNumber      int         `json:"pitch_number,omitempty" db:"pitch_number,omitempty"`
 	Status           MatchStatus `json:"status" db:"status"`
+	TeamAID          string      `json:"team_a_id,omitempty" db:"team_a_id,omitempty"` // Team playing as side A
+	TeamBID          string      `json:"team_b_id,omitempty" db:"team_b_id,omitempty"` // Team playing as side B
 	TeamAPlayerCount int         `json:"team_a_player_count" db:"team_a_player_count"`
 	TeamBPlayerCount int         `json:"team_b_player_count" db:"team_b_player_count"`
 	TotalOvers       int         `json:"total_overs" db:"total_overs"`
 	TossWinner       TeamType    `json:"toss_winner" db:"toss_winner"`
 	TossType         TossType    `json:"toss_type" db:"toss_type"`
 	BattingTeam      TeamType    `json:"batting_team" db:"batting_team"`
+	OrganizationID   string      `json:"organization_id,omitempty" db:"organization_id,omitempty"`
+	Access           MatchAccess `json:"access,omitempty" db:"access,omitempty"`                     // Who may follow the match live; empty is public
+	InvitedUserIDs   []string    `json:"invited_user_ids,omitempty" db:"invited_user_ids,omitempty"` // Users who may follow an invite-only match
 	CreatedBy        string      `json:"created_by,omitempty" db:"created_by,omitempty"`
 	CreatedAt        time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time   `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time  `json:"deleted_at,omitempty" db:"deleted_at,omitempty"` // Set when soft-deleted
 }
 
 // CreateMatchRequest represents the request to create a new match
 type CreateMatchRequest struct {
-	SeriesID         string    `json:"series_id" validate:"required"`
-	MatchNumber      *int      `json:"match_number,omitempty" validate:"omitempty,min=1"`
-	Date             time.Time `json:"date" validate:"required"`
-	TeamAPlayerCount int       `json:"team_a_player_count" validate:"required,min=1,max=20"`
-	TeamBPlayerCount int       `json:"team_b_player_count" validate:"required,min=1,max=20"`
-	TotalOvers       int       `json:"total_overs" validate:"required,min=1,max=20"`
-	TossWinner       TeamType  `json:"toss_winner" validate:"required,oneof=A B"`
-	TossType         TossType  `json:"toss_type" validate:"required,oneof=H T"`
+	SeriesID         string      `json:"series_id" validate:"required"`
+	MatchNumber      *int        `json:"match_number,omitempty" validate:"omitempty,min=1"`
+	Date             time.Time   `json:"date" validate:"required"`
+	Venue            string      `json:"venue,omitempty" validate:"omitempty,max=255"`
+	VenueID          string      `json:"venue_id,omitempty"`
+	PitchNumber      int         `json:"pitch_number,omitempty" validate:"omitempty,min=1,max=20"` // Defaults to 1 when a venue is booked
+	TeamAID          string      `json:"team_a_id,omitempty"`
+	TeamBID          string      `json:"team_b_id,omitempty"`
+	TeamAPlayerCount int         `json:"team_a_player_count" validate:"required,min=1,max=20"`
+	TeamBPlayerCount int         `json:"team_b_player_count" validate:"required,min=1,max=20"`
+	TotalOvers       int         `json:"total_overs" validate:"required,min=1,max=20"`
+	TossWinner       TeamType    `json:"toss_winner" validate:"required,oneof=A B"`
+	TossType         TossType    `json:"toss_type" validate:"required,oneof=H T"`
+	Access           MatchAccess `json:"access,omitempty" validate:"omitempty,oneof=public series_members invite_only"`
+	InvitedUserIDs   []string    `json:"invited_user_ids,omitempty"`
 }
 
 // UpdateMatchRequest represents the request to update a match
 type UpdateMatchRequest struct {
 	MatchNumber      *int         `json:"match_number,omitempty" validate:"omitempty,min=1"`
 	Date             *time.Time   `json:"date,omitempty"`
+	Venue            *string      `json:"venue,omitempty" validate:"omitempty,max=255"`
+	VenueID          *string      `json:"venue_id,omitempty"` // Empty releases the booking
+	PitchNumber      *int         `json:"pitch_number,omitempty" validate:"omitempty,min=1,max=20"`
 	Status           *MatchStatus `json:"status,omitempty" validate:"omitempty,oneof=live completed cancelled"`
+	TeamAID          *string      `json:"team_a_id,omitempty"`
+	TeamBID          *string      `json:"team_b_id,omitempty"`
 	TeamAPlayerCount *int         `json:"team_a_player_count,omitempty" validate:"omitempty,min=1,max=20"`
 	TeamBPlayerCount *int         `json:"team_b_player_count,omitempty" validate:"omitempty,min=1,max=20"`
 	TotalOvers       *int         `json:"total_overs,omitempty" validate:"omitempty,min=1,max=20"`
 	BattingTeam      *TeamType    `json:"batting_team,omitempty" validate:"omitempty,oneof=A B"`
+	Access           *MatchAccess `json:"access,omitempty" validate:"omitempty,oneof=public series_members invite_only"`
+	InvitedUserIDs   *[]string    `json:"invited_user_ids,omitempty"` // Replaces the invitees
 }
 
 // MatchFilters represents filters for listing matches
 type MatchFilters struct {
 	SeriesID *string      `json:"series_id,omitempty"`
 	Status   *MatchStatus `json:"status,omitempty"`
-	Limit    int          `json:"limit" validate:"min=1,max=100"`
-	Offset   int          `json:"offset" validate:"min=0"`
+	// OrganizationIDs scopes results to these organizations plus unowned rows; nil means unscoped
+	OrganizationIDs []string `json:"organization_ids,omitempty"`
+	// OnlyOrganizations leaves out the unowned rows, for callers who asked for specific organizations
+	OnlyOrganizations bool    `json:"-"`
+	CreatedBy         *string `json:"created_by,omitempty"`
+	// Deleted lists soft-deleted rows (the trash) instead of live ones
+	Deleted bool `json:"deleted,omitempty"`
+	Limit   int  `json:"limit" validate:"min=1,max=100"`
+	Offset  int  `json:"offset" validate:"min=0"`
 }
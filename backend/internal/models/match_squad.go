@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// MinPlayingXI is the smallest side that can take the field; n players allow n-1 wickets
+const MinPlayingXI = 2
+
+// MatchSquadPlayer represents a player selected for one side of a match
+type MatchSquadPlayer struct {
+	ID             string    `json:"id,omitempty" db:"id,omitempty"`
+	MatchID        string    `json:"match_id" db:"match_id"`
+	Team           TeamType  `json:"team" db:"team"`
+	PlayerID       string    `json:"player_id" db:"player_id"`
+	PlayerName     string    `json:"player_name" db:"player_name"`     // Name at selection time
+	BattingOrder   int       `json:"batting_order" db:"batting_order"` // 1-based; 0 for substitutes
+	IsCaptain      bool      `json:"is_captain" db:"is_captain"`
+	IsWicketkeeper bool      `json:"is_wicketkeeper" db:"is_wicketkeeper"`
+	IsSubstitute   bool      `json:"is_substitute" db:"is_substitute"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MatchSquad represents the declared squad of one side of a match
+type MatchSquad struct {
+	MatchID      string              `json:"match_id"`
+	Team         TeamType            `json:"team"`
+	TeamID       string              `json:"team_id"`
+	PlayingCount int                 `json:"playing_count"`
+	Players      []*MatchSquadPlayer `json:"players"`
+}
+
+// MatchSquadsResponse represents both declared squads of a match
+type MatchSquadsResponse struct {
+	MatchID string      `json:"match_id"`
+	TeamA   *MatchSquad `json:"team_a,omitempty"`
+	TeamB   *MatchSquad `json:"team_b,omitempty"`
+}
+
+// SquadPlayerRequest represents one player in a squad declaration
+type SquadPlayerRequest struct {
+	PlayerID       string `json:"player_id" validate:"required"`
+	BattingOrder   int    `json:"batting_order,omitempty" validate:"omitempty,min=1"`
+	IsCaptain      bool   `json:"is_captain,omitempty"`
+	IsWicketkeeper bool   `json:"is_wicketkeeper,omitempty"`
+	IsSubstitute   bool   `json:"is_substitute,omitempty"`
+}
+
+// SetMatchSquadRequest represents the request to declare one side's squad.
+// Batting order defaults to the order of the playing players when omitted.
+type SetMatchSquadRequest struct {
+	Players []SquadPlayerRequest `json:"players" validate:"required,min=2"`
+}
+
+// SquadSubstitutionRequest represents swapping a playing player for a substitute
+// once the match is under way
+type SquadSubstitutionRequest struct {
+	PlayerOutID string `json:"player_out_id" validate:"required"`
+	PlayerInID  string `json:"player_in_id" validate:"required"`
+	Reason      string `json:"reason" validate:"required"`
+}
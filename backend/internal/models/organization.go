@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+)
+
+// OrganizationRole represents a member's role within an organization
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"  // Full control including deleting the organization
+	OrganizationRoleAdmin  OrganizationRole = "admin"  // Manage members and all content
+	OrganizationRoleMember OrganizationRole = "member" // Create and score own series, matches and teams
+	OrganizationRoleViewer OrganizationRole = "viewer" // Read-only access to private content
+)
+
+// IsValid checks if the role is one of the known roles
+func (r OrganizationRole) IsValid() bool {
+	switch r {
+	case OrganizationRoleOwner, OrganizationRoleAdmin, OrganizationRoleMember, OrganizationRoleViewer:
+		return true
+	}
+	return false
+}
+
+// CanManage returns true if the role can manage members and organization settings
+func (r OrganizationRole) CanManage() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleAdmin
+}
+
+// CanContribute returns true if the role can create content in the organization
+func (r OrganizationRole) CanContribute() bool {
+	return r.CanManage() || r == OrganizationRoleMember
+}
+
+// Organization represents a club or park group that owns series, teams and players
+type Organization struct {
+	ID          string    `json:"id,omitempty" db:"id,omitempty"`
+	Name        string    `json:"name" db:"name"`
+	Slug        string    `json:"slug" db:"slug"`
+	Description string    `json:"description,omitempty" db:"description"`
+	IsPublic    bool      `json:"is_public" db:"is_public"` // Public organizations are visible to everyone
+	CreatedBy   string    `json:"created_by,omitempty" db:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" db:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty"`
+}
+
+// OrganizationMember represents a user's membership in an organization
+type OrganizationMember struct {
+	ID             string           `json:"id,omitempty" db:"id,omitempty"`
+	OrganizationID string           `json:"organization_id" db:"organization_id"`
+	UserID         string           `json:"user_id" db:"user_id"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	CreatedAt      time.Time        `json:"created_at,omitempty" db:"created_at,omitempty"`
+	UpdatedAt      time.Time        `json:"updated_at,omitempty" db:"updated_at,omitempty"`
+}
+
+// CreateOrganizationRequest represents the request to create a new organization
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" validate:"required,min=3,max=255"`
+	Slug        string `json:"slug,omitempty" validate:"omitempty,min=3,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1000"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// UpdateOrganizationRequest represents the request to update an organization
+type UpdateOrganizationRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+	IsPublic    *bool   `json:"is_public,omitempty"`
+}
+
+// AddOrganizationMemberRequest represents the request to add a member to an organization
+type AddOrganizationMemberRequest struct {
+	UserID string           `json:"user_id" validate:"required"`
+	Role   OrganizationRole `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+// UpdateOrganizationMemberRequest represents the request to change a member's role
+type UpdateOrganizationMemberRequest struct {
+	Role OrganizationRole `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+// OrganizationFilters represents filters for listing organizations
+type OrganizationFilters struct {
+	IDs    []string `json:"ids,omitempty"`
+	Limit  int      `json:"limit" validate:"min=1,max=100"`
+	Offset int      `json:"offset" validate:"min=0"`
+}
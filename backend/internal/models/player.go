@@ -4,30 +4,121 @@ import (
 	"time"
 )
 
+// MaxJerseyNumber is the largest shirt number a player can wear
+const MaxJerseyNumber = 999
+
+// PlayingRole represents a player's main role in the side
+type PlayingRole string
+
+const (
+	PlayingRoleBatter       PlayingRole = "batter"
+	PlayingRoleBowler       PlayingRole = "bowler"
+	PlayingRoleAllRounder   PlayingRole = "all_rounder"
+	PlayingRoleWicketkeeper PlayingRole = "wicketkeeper"
+)
+
+// IsValid checks if the role is one of the known roles
+func (r PlayingRole) IsValid() bool {
+	switch r {
+	case PlayingRoleBatter, PlayingRoleBowler, PlayingRoleAllRounder, PlayingRoleWicketkeeper:
+		return true
+	}
+	return false
+}
+
+// Hand represents a batting hand or bowling arm
+type Hand string
+
+const (
+	HandRight Hand = "right"
+	HandLeft  Hand = "left"
+)
+
+// IsValid checks if the hand is left or right
+func (h Hand) IsValid() bool {
+	return h == HandRight || h == HandLeft
+}
+
+// BowlingStyle represents the kind of bowling a player delivers
+type BowlingStyle string
+
+const (
+	BowlingStyleFast        BowlingStyle = "fast"
+	BowlingStyleMedium      BowlingStyle = "medium"
+	BowlingStyleOffSpin     BowlingStyle = "off_spin"
+	BowlingStyleLegSpin     BowlingStyle = "leg_spin"
+	BowlingStyleOrthodox    BowlingStyle = "orthodox_spin" // Left-arm finger spin
+	BowlingStyleWristSpin   BowlingStyle = "wrist_spin"    // Left-arm wrist spin
+	BowlingStyleDoesNotBowl BowlingStyle = "none"
+)
+
+// IsValid checks if the style is one of the known bowling styles
+func (s BowlingStyle) IsValid() bool {
+	switch s {
+	case BowlingStyleFast, BowlingStyleMedium, BowlingStyleOffSpin, BowlingStyleLegSpin,
+		BowlingStyleOrthodox, BowlingStyleWristSpin, BowlingStyleDoesNotBowl:
+		return true
+	}
+	return false
+}
+
+// PlayerProfile holds the optional cricket profile of a player. Empty values
+// mean the detail has not been set.
+type PlayerProfile struct {
+	Role         PlayingRole  `json:"role,omitempty" db:"role"`
+	BattingHand  Hand         `json:"batting_hand,omitempty" db:"batting_hand"`
+	BowlingArm   Hand         `json:"bowling_arm,omitempty" db:"bowling_arm"`
+	BowlingStyle BowlingStyle `json:"bowling_style,omitempty" db:"bowling_style"`
+	JerseyNumber int          `json:"jersey_number,omitempty" db:"jersey_number"` // 0 when not set
+	Nickname     string       `json:"nickname,omitempty" db:"nickname"`
+	AvatarURL    string       `json:"avatar_url,omitempty" db:"avatar_url"`
+}
+
 // Player represents a cricket player
 type Player struct {
-	ID        string    `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	TeamID    string    `json:"team_id" db:"team_id"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID             string `json:"id" db:"id"`
+	Name           string `json:"name" db:"name"`
+	TeamID         string `json:"team_id" db:"team_id"`
+	OrganizationID string `json:"organization_id,omitempty" db:"organization_id,omitempty"`
+	PlayerProfile
+	UserID           string     `json:"user_id,omitempty" db:"user_id"`                       // Account that claimed this profile
+	ClaimRequestedBy string     `json:"claim_requested_by,omitempty" db:"claim_requested_by"` // Account whose claim awaits the team owner's approval
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" db:"deleted_at,omitempty"` // Set when soft-deleted
 }
 
 // CreatePlayerRequest represents the request to create a new player
 type CreatePlayerRequest struct {
 	Name   string `json:"name" validate:"required,min=2,max=255"`
 	TeamID string `json:"team_id" validate:"required"`
+	PlayerProfile
 }
 
-// UpdatePlayerRequest represents the request to update a player
+// UpdatePlayerRequest represents the request to update a player. Profile
+// fields set to an empty string (or jersey number 0) are cleared.
 type UpdatePlayerRequest struct {
-	Name   *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	TeamID *string `json:"team_id,omitempty"`
+	Name         *string       `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	TeamID       *string       `json:"team_id,omitempty"`
+	Role         *PlayingRole  `json:"role,omitempty"`
+	BattingHand  *Hand         `json:"batting_hand,omitempty"`
+	BowlingArm   *Hand         `json:"bowling_arm,omitempty"`
+	BowlingStyle *BowlingStyle `json:"bowling_style,omitempty"`
+	JerseyNumber *int          `json:"jersey_number,omitempty" validate:"omitempty,min=0,max=999"`
+	Nickname     *string       `json:"nickname,omitempty" validate:"omitempty,max=100"`
+	AvatarURL    *string       `json:"avatar_url,omitempty" validate:"omitempty,max=500"`
 }
 
 // PlayerFilters represents filters for listing players
 type PlayerFilters struct {
 	TeamID *string `json:"team_id,omitempty"`
-	Limit  int     `json:"limit" validate:"min=1,max=100"`
-	Offset int     `json:"offset" validate:"min=0"`
+	UserID *string `json:"user_id,omitempty"`
+	// OrganizationIDs scopes results to these organizations plus unowned rows; nil means unscoped
+	OrganizationIDs []string `json:"organization_ids,omitempty"`
+	// OnlyOrganizations leaves out the unowned rows, for callers who asked for specific organizations
+	OnlyOrganizations bool `json:"-"`
+	// Deleted lists soft-deleted rows (the trash) instead of live ones
+	Deleted bool `json:"deleted,omitempty"`
+	Limit   int  `json:"limit" validate:"min=1,max=100"`
+	Offset  int  `json:"offset" validate:"min=0"`
 }
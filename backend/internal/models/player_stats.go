@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+)
+
+// PlayerMatchStats represents one player's contribution to a single completed
+// match, derived from the ball-by-ball scorecard
+type PlayerMatchStats struct {
+	ID         string `json:"id,omitempty" db:"id,omitempty"`
+	MatchID    string `json:"match_id" db:"match_id"`
+	SeriesID   string `json:"series_id" db:"series_id"`
+	PlayerID   string `json:"player_id" db:"player_id"`
+	PlayerName string `json:"player_name" db:"player_name"`
+	TeamID     string `json:"team_id,omitempty" db:"team_id"`
+
+	// Batting
+	Batted     bool `json:"batted" db:"batted"`
+	NotOut     bool `json:"not_out" db:"not_out"`
+	Runs       int  `json:"runs" db:"runs"`
+	BallsFaced int  `json:"balls_faced" db:"balls_faced"`
+	Fours      int  `json:"fours" db:"fours"`
+	Sixes      int  `json:"sixes" db:"sixes"`
+
+	// Bowling
+	Bowled       bool `json:"bowled" db:"bowled"`
+	BallsBowled  int  `json:"balls_bowled" db:"balls_bowled"` // Legal deliveries only
+	RunsConceded int  `json:"runs_conceded" db:"runs_conceded"`
+	Wickets      int  `json:"wickets" db:"wickets"`
+	Maidens      int  `json:"maidens" db:"maidens"`
+
+	// Fielding
+	Catches   int `json:"catches" db:"catches"`
+	Stumpings int `json:"stumpings" db:"stumpings"`
+	RunOuts   int `json:"run_outs" db:"run_outs"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PlayerStats represents a player's aggregated record, either over their
+// whole career (empty SeriesID) or within one series. Rates are derived from
+// the stored counts when the record is served.
+type PlayerStats struct {
+	ID         string `json:"id,omitempty" db:"id,omitempty"`
+	PlayerID   string `json:"player_id" db:"player_id"`
+	PlayerName string `json:"player_name" db:"player_name"`
+	SeriesID   string `json:"series_id,omitempty" db:"series_id"`
+	Matches    int    `json:"matches" db:"matches"`
+
+	// Batting
+	Innings         int     `json:"innings" db:"innings"`
+	NotOuts         int     `json:"not_outs" db:"not_outs"`
+	Runs            int     `json:"runs" db:"runs"`
+	BallsFaced      int     `json:"balls_faced" db:"balls_faced"`
+	HighScore       int     `json:"high_score" db:"high_score"`
+	HighScoreNotOut bool    `json:"high_score_not_out" db:"high_score_not_out"`
+	BattingAverage  float64 `json:"batting_average" db:"-"` // 0 when never dismissed
+	StrikeRate      float64 `json:"strike_rate" db:"-"`
+	Fifties         int     `json:"fifties" db:"fifties"`
+	Hundreds        int     `json:"hundreds" db:"hundreds"`
+	Ducks           int     `json:"ducks" db:"ducks"`
+	Fours           int     `json:"fours" db:"fours"`
+	Sixes           int     `json:"sixes" db:"sixes"`
+
+	// Bowling
+	BowlingInnings   int     `json:"bowling_innings" db:"bowling_innings"`
+	BallsBowled      int     `json:"balls_bowled" db:"balls_bowled"`
+	Overs            float64 `json:"overs" db:"-"` // Cricket notation, e.g. 3.4
+	RunsConceded     int     `json:"runs_conceded" db:"runs_conceded"`
+	Wickets          int     `json:"wickets" db:"wickets"`
+	Maidens          int     `json:"maidens" db:"maidens"`
+	BestWickets      int     `json:"best_wickets" db:"best_wickets"`
+	BestRuns         int     `json:"best_runs" db:"best_runs"`
+	BestFigures      string  `json:"best_figures,omitempty" db:"-"` // e.g. "4/21"
+	BowlingAverage   float64 `json:"bowling_average" db:"-"`        // 0 when wicketless
+	Economy          float64 `json:"economy" db:"-"`
+	ThreeWicketHauls int     `json:"three_wicket_hauls" db:"three_wicket_hauls"`
+	FiveWicketHauls  int     `json:"five_wicket_hauls" db:"five_wicket_hauls"`
+
+	// Fielding
+	Catches   int `json:"catches" db:"catches"`
+	Stumpings int `json:"stumpings" db:"stumpings"`
+	RunOuts   int `json:"run_outs" db:"run_outs"`
+
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PlayerStatsResponse represents a player's career record with a breakdown
+// per series and the awards they have won
+type PlayerStatsResponse struct {
+	PlayerID string         `json:"player_id"`
+	Career   *PlayerStats   `json:"career"`
+	Series   []*PlayerStats `json:"series"`
+	Awards   []*Award       `json:"awards"`
+}
+
+// StatsRebuildResponse reports the outcome of a full statistics rebuild
+type StatsRebuildResponse struct {
+	Matches int `json:"matches"`
+	Players int `json:"players"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PurgeResult reports how many soft-deleted rows a retention purge removed
+type PurgeResult struct {
+	Cutoff  time.Time `json:"cutoff"`
+	Series  int       `json:"series"`
+	Matches int       `json:"matches"`
+	Teams   int       `json:"teams"`
+	Players int       `json:"players"`
+}
@@ -6,17 +6,18 @@ import (
 
 // Innings represents a cricket innings
 type Innings struct {
-	ID            string    `json:"id" db:"id"`
-	MatchID       string    `json:"match_id" db:"match_id"`
-	InningsNumber int       `json:"innings_number" db:"innings_number"`
-	BattingTeam   TeamType  `json:"batting_team" db:"batting_team"`
-	TotalRuns     int       `json:"total_runs" db:"total_runs"`
-	TotalWickets  int       `json:"total_wickets" db:"total_wickets"`
-	TotalOvers    float64   `json:"total_overs" db:"total_overs"`
-	TotalBalls    int       `json:"total_balls" db:"total_balls"`
-	Status        string    `json:"status" db:"status"` // "in_progress", "completed"
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	ID            string     `json:"id" db:"id"`
+	MatchID       string     `json:"match_id" db:"match_id"`
+	InningsNumber int        `json:"innings_number" db:"innings_number"`
+	BattingTeam   TeamType   `json:"batting_team" db:"batting_team"`
+	TotalRuns     int        `json:"total_runs" db:"total_runs"`
+	TotalWickets  int        `json:"total_wickets" db:"total_wickets"`
+	TotalOvers    float64    `json:"total_overs" db:"total_overs"`
+	TotalBalls    int        `json:"total_balls" db:"total_balls"`
+	Status        string     `json:"status" db:"status"` // "in_progress", "completed"
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty" db:"deleted_at,omitempty"` // Set when the match is soft-deleted
 }
 
 // ScorecardOver represents a cricket over in scorecard
@@ -34,16 +35,23 @@ type ScorecardOver struct {
 
 // ScorecardBall represents a cricket ball in scorecard
 type ScorecardBall struct {
-	ID         string    `json:"id" db:"id"`
-	OverID     string    `json:"over_id" db:"over_id"`
-	BallNumber int       `json:"ball_number" db:"ball_number"`
-	BallType   BallType  `json:"ball_type" db:"ball_type"`
-	RunType    RunType   `json:"run_type" db:"run_type"`
-	Runs       int       `json:"runs" db:"runs"`
-	Byes       int       `json:"byes" db:"byes"` // Additional runs from byes
-	IsWicket   bool      `json:"is_wicket" db:"is_wicket"`
-	WicketType string    `json:"wicket_type,omitempty" db:"wicket_type"` // "bowled", "caught", "lbw", "run_out", "stumped", "hit_wicket"
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID         string   `json:"id" db:"id"`
+	OverID     string   `json:"over_id" db:"over_id"`
+	BallNumber int      `json:"ball_number" db:"ball_number"`
+	BallType   BallType `json:"ball_type" db:"ball_type"`
+	RunType    RunType  `json:"run_type" db:"run_type"`
+	Runs       int      `json:"runs" db:"runs"`
+	Byes       int      `json:"byes" db:"byes"` // Additional runs from byes
+	IsWicket   bool     `json:"is_wicket" db:"is_wicket"`
+	WicketType string   `json:"wicket_type,omitempty" db:"wicket_type"` // "bowled", "caught", "lbw", "run_out", "stumped", "hit_wicket"
+
+	// Players involved in the delivery; empty for matches scored without squads
+	BatterID          string `json:"batter_id,omitempty" db:"batter_id"`
+	BowlerID          string `json:"bowler_id,omitempty" db:"bowler_id"`
+	FielderID         string `json:"fielder_id,omitempty" db:"fielder_id"`                   // Catcher, stumper or run-out fielder
+	DismissedPlayerID string `json:"dismissed_player_id,omitempty" db:"dismissed_player_id"` // Defaults to the batter on a wicket
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 // ScorecardRequest represents the request to start scoring
@@ -60,21 +68,31 @@ type BallEventRequest struct {
 	IsWicket      bool     `json:"is_wicket"`
 	WicketType    string   `json:"wicket_type,omitempty"`
 	Byes          int      `json:"byes,omitempty"` // Additional runs from byes (0-6)
+
+	// Players involved in the delivery. Batter and bowler are required once
+	// both squads are declared; fielder and dismissed player only on wickets.
+	BatterID          string `json:"batter_id,omitempty"`
+	BowlerID          string `json:"bowler_id,omitempty"`
+	FielderID         string `json:"fielder_id,omitempty"`
+	DismissedPlayerID string `json:"dismissed_player_id,omitempty"` // Non-striker run outs; defaults to the batter
 }
 
 // ScorecardResponse represents the complete scorecard
 type ScorecardResponse struct {
-	MatchID        string           `json:"match_id"`
-	MatchNumber    int              `json:"match_number"`
-	SeriesName     string           `json:"series_name"`
-	TeamA          string           `json:"team_a"`
-	TeamB          string           `json:"team_b"`
-	TotalOvers     int              `json:"total_overs"`
-	TossWinner     TeamType         `json:"toss_winner"`
-	TossType       TossType         `json:"toss_type"`
-	CurrentInnings int              `json:"current_innings"`
-	Innings        []InningsSummary `json:"innings"`
-	MatchStatus    string           `json:"match_status"`
+	MatchID          string           `json:"match_id"`
+	MatchNumber      int              `json:"match_number"`
+	SeriesName       string           `json:"series_name"`
+	TeamAID          string           `json:"team_a_id,omitempty"`
+	TeamBID          string           `json:"team_b_id,omitempty"`
+	TeamA            string           `json:"team_a"`
+	TeamB            string           `json:"team_b"`
+	TotalOvers       int              `json:"total_overs"`
+	TossWinner       TeamType         `json:"toss_winner"`
+	TossType         TossType         `json:"toss_type"`
+	CurrentInnings   int              `json:"current_innings"`
+	Innings          []InningsSummary `json:"innings"`
+	MatchStatus      string           `json:"match_status"`
+	PlayerOfTheMatch *Award           `json:"player_of_the_match,omitempty"` // Proposed or confirmed once the match completes
 }
 
 // ExtrasSummary represents extras in an innings
@@ -118,6 +136,11 @@ type BallSummary struct {
 	Byes       int      `json:"byes"`
 	IsWicket   bool     `json:"is_wicket"`
 	WicketType string   `json:"wicket_type,omitempty"`
+
+	BatterID          string `json:"batter_id,omitempty"`
+	BowlerID          string `json:"bowler_id,omitempty"`
+	FielderID         string `json:"fielder_id,omitempty"`
+	DismissedPlayerID string `json:"dismissed_player_id,omitempty"`
 }
 
 // WicketType represents different types of wickets
@@ -0,0 +1,74 @@
+package models
+
+// ScorecardDelta represents what one scoring change did to a match's
+// scorecard, broadcast to match rooms instead of the whole scorecard.
+// Totals are absolute and balls are keyed by innings, over and ball number,
+// so applying a delta twice is harmless. A client holding version N applies
+// the delta whose BaseVersion is N, ignores older ones and reloads a
+// snapshot when it finds a gap.
+type ScorecardDelta struct {
+	MatchID        string           `json:"match_id"`
+	Version        int64            `json:"version"`
+	BaseVersion    int64            `json:"base_version"`
+	Cause          ScoringEventType `json:"cause,omitempty"` // ball_added or ball_undone; empty when scoring starts
+	MatchStatus    string           `json:"match_status"`
+	CurrentInnings int              `json:"current_innings"`
+	Ball           *DeltaBall       `json:"ball,omitempty"` // The ball added, or the ball an undo removed
+	Over           *OverTotals      `json:"over,omitempty"`
+	Innings        []InningsTotals  `json:"innings"` // Innings that changed, including one the change started
+	CurrentScore   *CurrentScore    `json:"current_score"`
+}
+
+// DeltaBall represents a ball in a scorecard delta with the position it
+// takes, or took, in the scorecard
+type DeltaBall struct {
+	InningsNumber int `json:"innings_number"`
+	OverNumber    int `json:"over_number"`
+	BallSummary
+}
+
+// OverTotals represents an over's totals without its balls
+type OverTotals struct {
+	InningsNumber int    `json:"innings_number"`
+	OverNumber    int    `json:"over_number"`
+	TotalRuns     int    `json:"total_runs"`
+	TotalBalls    int    `json:"total_balls"`
+	TotalWickets  int    `json:"total_wickets"`
+	Status        string `json:"status"`
+}
+
+// InningsTotals represents an innings' totals without its overs. Extras
+// are left for clients to add up from the balls.
+type InningsTotals struct {
+	InningsNumber int      `json:"innings_number"`
+	BattingTeam   TeamType `json:"batting_team"`
+	TotalRuns     int      `json:"total_runs"`
+	TotalWickets  int      `json:"total_wickets"`
+	TotalOvers    float64  `json:"total_overs"`
+	TotalBalls    int      `json:"total_balls"`
+	Status        string   `json:"status"`
+}
+
+// CurrentScore represents the batting side's score in the current innings
+type CurrentScore struct {
+	Runs    int     `json:"runs"`
+	Wickets int     `json:"wickets"`
+	Overs   float64 `json:"overs"`
+	Balls   int     `json:"balls"`
+	RunRate float64 `json:"run_rate"`
+}
+
+// ScorecardMilestone represents a wicket, a completed over or innings, or a
+// match result, broadcast to match rooms after the delta that brought it
+// about. Target is set when the first innings completes.
+type ScorecardMilestone struct {
+	MatchID      string         `json:"match_id"`
+	Version      int64          `json:"version"` // The version of the delta it follows
+	Ball         *DeltaBall     `json:"ball,omitempty"`
+	Over         *OverTotals    `json:"over,omitempty"`
+	Innings      *InningsTotals `json:"innings,omitempty"`
+	Target       int            `json:"target,omitempty"`
+	Result       string         `json:"result,omitempty"`
+	WinnerTeamID string         `json:"winner_team_id,omitempty"` // Empty for a tie
+	CurrentScore *CurrentScore  `json:"current_score"`
+}
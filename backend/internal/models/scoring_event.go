@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ScoringEventType represents what happened in a scored match
+type ScoringEventType string
+
+const (
+	ScoringEventScorecardUpdated ScoringEventType = "scorecard_updated" // Scoring started, or a ball was added or undone
+	ScoringEventBallAdded        ScoringEventType = "ball_added"
+	ScoringEventBallUndone       ScoringEventType = "ball_undone"
+	ScoringEventWicketFallen     ScoringEventType = "wicket_fallen"
+	ScoringEventInningsCompleted ScoringEventType = "innings_completed"
+	ScoringEventMatchCompleted   ScoringEventType = "match_completed"
+)
+
+// ScoringEvent represents a change published by the scoring service. Ball
+// and Over are set for ball and wicket events; Innings holds the innings
+// totals after the change; Match, Result and WinnerTeamID (empty for a tie)
+// are set when a match completes. A scorecard update also carries the match,
+// the ball event that led to it as Cause, and that event's ball, over and
+// result.
+type ScoringEvent struct {
+	Type          ScoringEventType `json:"type"`
+	Cause         ScoringEventType `json:"cause,omitempty"` // Scorecard updates only: ball_added or ball_undone, empty when scoring starts
+	MatchID       string           `json:"match_id"`
+	InningsNumber int              `json:"innings_number"`
+	Ball          *ScorecardBall   `json:"ball,omitempty"`
+	Over          *ScorecardOver   `json:"over,omitempty"`
+	Innings       *Innings         `json:"innings,omitempty"`
+	Match         *Match           `json:"match,omitempty"`
+	Result        string           `json:"result,omitempty"` // Why the match completed, e.g. "target reached: 151/151"
+	WinnerTeamID  string           `json:"winner_team_id,omitempty"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+}
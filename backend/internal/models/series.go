@@ -6,31 +6,47 @@ import (
 
 // Series represents a cricket tournament or competition
 type Series struct {
-	ID        string    `json:"id,omitempty" db:"id,omitempty"`
-	Name      string    `json:"name" db:"name"`
-	StartDate time.Time `json:"start_date" db:"start_date"`
-	EndDate   time.Time `json:"end_date" db:"end_date"`
-	CreatedBy string    `json:"created_by,omitempty" db:"created_by,omitempty"`
-	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty"`
+	ID             string         `json:"id,omitempty" db:"id,omitempty"`
+	Name           string         `json:"name" db:"name"`
+	StartDate      time.Time      `json:"start_date" db:"start_date"`
+	EndDate        time.Time      `json:"end_date" db:"end_date"`
+	OrganizationID string         `json:"organization_id,omitempty" db:"organization_id,omitempty"`
+	PointsRules    *PointsRules   `json:"points_rules,omitempty" db:"points_rules,omitempty"`     // Points table scoring; nil uses the defaults
+	ImpactFormula  *ImpactFormula `json:"impact_formula,omitempty" db:"impact_formula,omitempty"` // Award impact scoring; nil uses the defaults
+	CreatedBy      string         `json:"created_by,omitempty" db:"created_by,omitempty"`
+	CreatedAt      time.Time      `json:"created_at,omitempty" db:"created_at,omitempty"`
+	UpdatedAt      time.Time      `json:"updated_at,omitempty" db:"updated_at,omitempty"`
+	DeletedAt      *time.Time     `json:"deleted_at,omitempty" db:"deleted_at,omitempty"` // Set when soft-deleted
 }
 
 // CreateSeriesRequest represents the request to create a new series
 type CreateSeriesRequest struct {
-	Name      string    `json:"name" validate:"required,min=3,max=255"`
-	StartDate time.Time `json:"start_date" validate:"required"`
-	EndDate   time.Time `json:"end_date" validate:"required,gtfield=StartDate"`
+	Name           string         `json:"name" validate:"required,min=3,max=255"`
+	StartDate      time.Time      `json:"start_date" validate:"required"`
+	EndDate        time.Time      `json:"end_date" validate:"required,gtfield=StartDate"`
+	OrganizationID string         `json:"organization_id,omitempty"`
+	PointsRules    *PointsRules   `json:"points_rules,omitempty"`
+	ImpactFormula  *ImpactFormula `json:"impact_formula,omitempty"`
 }
 
 // UpdateSeriesRequest represents the request to update a series
 type UpdateSeriesRequest struct {
-	Name      *string    `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
-	StartDate *time.Time `json:"start_date,omitempty"`
-	EndDate   *time.Time `json:"end_date,omitempty"`
+	Name          *string        `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
+	StartDate     *time.Time     `json:"start_date,omitempty"`
+	EndDate       *time.Time     `json:"end_date,omitempty"`
+	PointsRules   *PointsRules   `json:"points_rules,omitempty"`   // Replaces the series' points rules
+	ImpactFormula *ImpactFormula `json:"impact_formula,omitempty"` // Replaces the series' award impact formula
 }
 
 // SeriesFilters represents filters for listing series
 type SeriesFilters struct {
-	Limit  int `json:"limit" validate:"min=1,max=100"`
-	Offset int `json:"offset" validate:"min=0"`
+	// OrganizationIDs scopes results to these organizations plus unowned rows; nil means unscoped
+	OrganizationIDs []string `json:"organization_ids,omitempty"`
+	// OnlyOrganizations leaves out the unowned rows, for callers who asked for specific organizations
+	OnlyOrganizations bool    `json:"-"`
+	CreatedBy         *string `json:"created_by,omitempty"`
+	// Deleted lists soft-deleted rows (the trash) instead of live ones
+	Deleted bool `json:"deleted,omitempty"`
+	Limit   int  `json:"limit" validate:"min=1,max=100"`
+	Offset  int  `json:"offset" validate:"min=0"`
 }
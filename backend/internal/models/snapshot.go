@@ -0,0 +1,18 @@
+package models
+
+// MatchSnapshot represents the current state of a match room, sent to a
+// WebSocket client when it subscribes, asks to resync or can no longer have
+// its missed messages replayed. Version is the scorecard version that
+// scorecard_delta messages build on.
+type MatchSnapshot struct {
+	Version   int64              `json:"version"`
+	Scorecard *ScorecardResponse `json:"scorecard"`
+}
+
+// SeriesSnapshot represents the current state of a series room. The bracket
+// is left out for series without stages.
+type SeriesSnapshot struct {
+	Standings    *SeriesStandings    `json:"standings"`
+	Bracket      *SeriesBracket      `json:"bracket,omitempty"`
+	Leaderboards *SeriesLeaderboards `json:"leaderboards"`
+}
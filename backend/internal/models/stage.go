@@ -0,0 +1,167 @@
+package models
+
+import (
+	"time"
+)
+
+// StageType represents the kind of a series stage
+type StageType string
+
+const (
+	StageTypeGroup    StageType = "group"    // Teams play within groups and are ranked
+	StageTypeKnockout StageType = "knockout" // Bracket whose sides are filled as results come in
+)
+
+// TieBreaker represents one criterion for ordering teams level on the criteria before it
+type TieBreaker string
+
+const (
+	TieBreakerPoints     TieBreaker = "points"
+	TieBreakerWins       TieBreaker = "wins"
+	TieBreakerNetRunRate TieBreaker = "net_run_rate"
+	TieBreakerHeadToHead TieBreaker = "head_to_head" // Points earned in matches between the two teams
+)
+
+// DefaultTieBreakers returns the ordering used when none is configured
+func DefaultTieBreakers() []TieBreaker {
+	return []TieBreaker{TieBreakerPoints, TieBreakerWins, TieBreakerNetRunRate}
+}
+
+// SlotSourceType represents where a bracket side takes its team from
+type SlotSourceType string
+
+const (
+	SlotSourceGroupPosition SlotSourceType = "group_position" // Finishing position in a group
+	SlotSourceWinner        SlotSourceType = "winner"         // Winner of an earlier bracket match
+	SlotSourceLoser         SlotSourceType = "loser"          // Loser of an earlier bracket match, e.g. for a third-place playoff
+	SlotSourceTeam          SlotSourceType = "team"           // A fixed team
+)
+
+// SlotSource describes how one side of a bracket match is filled
+type SlotSource struct {
+	Type SlotSourceType `json:"type"`
+	// Group position: the group stage (defaults to the latest group stage before
+	// this one), the group name and the 1-based finishing position
+	StageID  string `json:"stage_id,omitempty"`
+	Group    string `json:"group,omitempty"`
+	Position int    `json:"position,omitempty"`
+	// Winner or loser: 1-based position of an earlier match in the same bracket
+	Match int `json:"match,omitempty"`
+	// Fixed team
+	TeamID string `json:"team_id,omitempty"`
+}
+
+// StageGroup represents one group of a group stage
+type StageGroup struct {
+	Name    string   `json:"name"`
+	TeamIDs []string `json:"team_ids"`
+}
+
+// BracketMatch represents one match of a knockout stage and where its sides come from
+type BracketMatch struct {
+	Position int        `json:"position"` // 1-based order within the bracket
+	Round    int        `json:"round"`
+	Name     string     `json:"name,omitempty"` // Defaults to the round name, e.g. "Semi-final 1"
+	MatchID  string     `json:"match_id"`
+	TeamA    SlotSource `json:"team_a"`
+	TeamB    SlotSource `json:"team_b"`
+	// Teams last filled in automatically; manual changes to the match are kept
+	// while a source is unresolved
+	TeamAID string `json:"team_a_id,omitempty"`
+	TeamBID string `json:"team_b_id,omitempty"`
+}
+
+// SeriesStage represents a stage of a series: a group stage or a knockout bracket.
+// Group stage matches are the series' non-bracket matches between two teams of
+// the same group.
+type SeriesStage struct {
+	ID          string          `json:"id,omitempty" db:"id,omitempty"`
+	SeriesID    string          `json:"series_id" db:"series_id"`
+	Name        string          `json:"name" db:"name"`
+	Position    int             `json:"position" db:"position"` // 1-based order within the series
+	Type        StageType       `json:"type" db:"stage_type"`
+	Groups      []StageGroup    `json:"groups,omitempty" db:"groups"`
+	TieBreakers []TieBreaker    `json:"tie_breakers,omitempty" db:"tie_breakers"` // Empty uses the series' rules
+	Bracket     []*BracketMatch `json:"bracket,omitempty" db:"bracket"`
+	CreatedBy   string          `json:"created_by,omitempty" db:"created_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// BracketMatchRequest represents one match of a new knockout stage. A match is
+// created for it unless an existing match of the series is given.
+type BracketMatchRequest struct {
+	Round   int        `json:"round" validate:"required,min=1"`
+	Name    string     `json:"name,omitempty"`
+	MatchID string     `json:"match_id,omitempty"`
+	Date    time.Time  `json:"date,omitempty"`
+	Venue   string     `json:"venue,omitempty"`
+	TeamA   SlotSource `json:"team_a"`
+	TeamB   SlotSource `json:"team_b"`
+}
+
+// CreateStageRequest represents the request to add a stage to a series
+type CreateStageRequest struct {
+	Name        string                `json:"name" validate:"required,min=1,max=100"`
+	Type        StageType             `json:"type" validate:"required,oneof=group knockout"`
+	Groups      []StageGroup          `json:"groups,omitempty"`
+	TieBreakers []TieBreaker          `json:"tie_breakers,omitempty"`
+	Bracket     []BracketMatchRequest `json:"bracket,omitempty"`
+
+	// Settings for created bracket matches
+	TeamPlayerCount int `json:"team_player_count,omitempty" validate:"omitempty,min=1,max=20"`
+	TotalOvers      int `json:"total_overs,omitempty" validate:"omitempty,min=1,max=20"`
+}
+
+// BracketSide represents one side of a bracket match as shown to viewers
+type BracketSide struct {
+	TeamID   string `json:"team_id,omitempty"`
+	TeamName string `json:"team_name,omitempty"`
+	Label    string `json:"label"` // e.g. "1st in Group A", "Winner of Semi-final 1"
+}
+
+// BracketMatchView represents a bracket match ready to render
+type BracketMatchView struct {
+	Position     int          `json:"position"`
+	Name         string       `json:"name"` // e.g. "Semi-final 2"
+	MatchID      string       `json:"match_id"`
+	MatchNumber  int          `json:"match_number,omitempty"`
+	Date         *time.Time   `json:"date,omitempty"`
+	Venue        string       `json:"venue,omitempty"`
+	Status       MatchStatus  `json:"status,omitempty"`
+	TeamA        *BracketSide `json:"team_a"`
+	TeamB        *BracketSide `json:"team_b"`
+	WinnerTeamID string       `json:"winner_team_id,omitempty"`
+}
+
+// BracketRound represents one round of a knockout stage
+type BracketRound struct {
+	Round   int                 `json:"round"`
+	Name    string              `json:"name"` // e.g. "Semi-final", "Final"
+	Matches []*BracketMatchView `json:"matches"`
+}
+
+// GroupStandings represents the ranked table of one group
+type GroupStandings struct {
+	Name      string          `json:"name"`
+	Complete  bool            `json:"complete"` // Every pair has played and no match is still live
+	Standings []*StandingsRow `json:"standings"`
+}
+
+// StageView represents a stage ready to render: group tables or bracket rounds
+type StageView struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Position    int               `json:"position"`
+	Type        StageType         `json:"type"`
+	TieBreakers []TieBreaker      `json:"tie_breakers,omitempty"`
+	Groups      []*GroupStandings `json:"groups,omitempty"`
+	Rounds      []*BracketRound   `json:"rounds,omitempty"`
+}
+
+// SeriesBracket represents every stage of a series ready to render
+type SeriesBracket struct {
+	SeriesID  string       `json:"series_id"`
+	Stages    []*StageView `json:"stages"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+// PointsRules configures the points a series awards for each result
+type PointsRules struct {
+	Win      int `json:"win"`
+	Loss     int `json:"loss"`
+	Tie      int `json:"tie"`
+	NoResult int `json:"no_result"`
+	// BonusPoint is added for a win whose run rate is at least
+	// BonusRunRateRatio times the loser's; a ratio of 0 disables bonus points
+	BonusPoint        int     `json:"bonus_point"`
+	BonusRunRateRatio float64 `json:"bonus_run_rate_ratio"`
+	// TieBreakers orders teams level on points; empty uses DefaultTieBreakers
+	TieBreakers []TieBreaker `json:"tie_breakers,omitempty"`
+}
+
+// DefaultPointsRules returns the points used when a series does not set its own
+func DefaultPointsRules() PointsRules {
+	return PointsRules{Win: 2, Loss: 0, Tie: 1, NoResult: 1}
+}
+
+// MatchOutcome represents how a match ended for the standings
+type MatchOutcome string
+
+const (
+	MatchOutcomeWin      MatchOutcome = "win"
+	MatchOutcomeTie      MatchOutcome = "tie"
+	MatchOutcomeNoResult MatchOutcome = "no_result" // Cancelled, or completed without both innings
+)
+
+// MatchResult represents the result of a completed or cancelled match
+type MatchResult struct {
+	MatchID      string       `json:"match_id"`
+	MatchNumber  int          `json:"match_number"`
+	Outcome      MatchOutcome `json:"outcome"`
+	TeamAID      string       `json:"team_a_id"`
+	TeamBID      string       `json:"team_b_id"`
+	WinnerTeamID string       `json:"winner_team_id,omitempty"`
+	LoserTeamID  string       `json:"loser_team_id,omitempty"`
+	BonusPoint   bool         `json:"bonus_point,omitempty"` // Winner earned the bonus point
+}
+
+// StandingsRow represents one team's line in a series points table
+type StandingsRow struct {
+	Position     int     `json:"position"`
+	TeamID       string  `json:"team_id"`
+	TeamName     string  `json:"team_name"`
+	Played       int     `json:"played"`
+	Won          int     `json:"won"`
+	Lost         int     `json:"lost"`
+	Tied         int     `json:"tied"`
+	NoResult     int     `json:"no_result"`
+	BonusPoints  int     `json:"bonus_points"`
+	Points       int     `json:"points"`
+	RunsFor      int     `json:"runs_for"`
+	OversFor     float64 `json:"overs_for"` // Cricket notation, e.g. 19.4
+	RunsAgainst  int     `json:"runs_against"`
+	OversAgainst float64 `json:"overs_against"`
+	NetRunRate   float64 `json:"net_run_rate"`
+
+	// Legal balls behind the overs columns; a side bowled out is charged its full quota
+	BallsFor     int `json:"-"`
+	BallsAgainst int `json:"-"`
+}
+
+// SeriesStandings represents the points table of a series
+type SeriesStandings struct {
+	SeriesID    string          `json:"series_id"`
+	PointsRules PointsRules     `json:"points_rules"`
+	Standings   []*StandingsRow `json:"standings"`
+	Results     []*MatchResult  `json:"results"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
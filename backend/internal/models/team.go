@@ -4,19 +4,26 @@ import (
 	"time"
 )
 
+// MaxTeamPlayers is the largest roster a team may have
+const MaxTeamPlayers = 20
+
 // Team represents a cricket team
 type Team struct {
-	ID           string    `json:"id,omitempty" db:"id,omitempty"`
-	Name         string    `json:"name" db:"name"`
-	PlayersCount int       `json:"players_count" db:"players_count"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID             string     `json:"id,omitempty" db:"id,omitempty"`
+	Name           string     `json:"name" db:"name"`
+	PlayersCount   int        `json:"players_count" db:"players_count"` // Kept in sync with the live roster
+	OrganizationID string     `json:"organization_id,omitempty" db:"organization_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty" db:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at,omitempty"` // Set when soft-deleted
 }
 
-// CreateTeamRequest represents the request to create a new team
+// CreateTeamRequest represents the request to create a new team. The roster
+// starts empty; players_count follows the players added to it.
 type CreateTeamRequest struct {
-	Name         string `json:"name" validate:"required,min=3,max=255"`
-	PlayersCount int    `json:"players_count" validate:"required,min=1,max=20"`
+	Name           string `json:"name" validate:"required,min=3,max=255"`
+	OrganizationID string `json:"organization_id,omitempty"`
 }
 
 // CreateTeamData represents the data structure for team creation (without ID)
@@ -29,12 +36,29 @@ type CreateTeamData struct {
 
 // UpdateTeamRequest represents the request to update a team
 type UpdateTeamRequest struct {
-	Name         *string `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
-	PlayersCount *int    `json:"players_count,omitempty" validate:"omitempty,min=1,max=20"`
+	Name *string `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
+}
+
+// TeamPlayerRequest describes a player added through a team's roster endpoints
+type TeamPlayerRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=255"`
+	PlayerProfile
+}
+
+// AddTeamPlayersRequest represents the request to add several players to a team at once
+type AddTeamPlayersRequest struct {
+	Players []TeamPlayerRequest `json:"players" validate:"required,min=1,max=20,dive"`
 }
 
 // TeamFilters represents filters for listing teams
 type TeamFilters struct {
-	Limit  int `json:"limit" validate:"min=1,max=100"`
-	Offset int `json:"offset" validate:"min=0"`
+	CreatedBy *string `json:"created_by,omitempty"`
+	// OrganizationIDs scopes results to these organizations plus unowned rows; nil means unscoped
+	OrganizationIDs []string `json:"organization_ids,omitempty"`
+	// OnlyOrganizations leaves out the unowned rows, for callers who asked for specific organizations
+	OnlyOrganizations bool `json:"-"`
+	// Deleted lists soft-deleted rows (the trash) instead of live ones
+	Deleted bool `json:"deleted,omitempty"`
+	Limit   int  `json:"limit" validate:"min=1,max=100"`
+	Offset  int  `json:"offset" validate:"min=0"`
 }
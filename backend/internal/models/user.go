@@ -65,3 +65,10 @@ type AuthResponse struct {
 	Token   string `json:"token,omitempty"`
 	Message string `json:"message"`
 }
+
+// ConnectionToken authenticates a WebSocket or event stream connection from
+// a client that cannot send the session cookie
+type ConnectionToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+)
+
+// Venue represents a ground matches are played at. A venue may have several
+// pitches that can be booked at the same time.
+type Venue struct {
+	ID             string    `json:"id,omitempty" db:"id,omitempty"`
+	Name           string    `json:"name" db:"name"`
+	Address        string    `json:"address,omitempty" db:"address,omitempty"`
+	Latitude       *float64  `json:"latitude,omitempty" db:"latitude,omitempty"`
+	Longitude      *float64  `json:"longitude,omitempty" db:"longitude,omitempty"`
+	BoundaryNotes  string    `json:"boundary_notes,omitempty" db:"boundary_notes,omitempty"` // e.g. "short leg-side boundary, trees count as six"
+	Pitches        int       `json:"pitches" db:"pitches"`
+	OrganizationID string    `json:"organization_id,omitempty" db:"organization_id,omitempty"`
+	CreatedBy      string    `json:"created_by,omitempty" db:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MaxVenuePitches is the most pitches a venue may have
+const MaxVenuePitches = 20
+
+// CreateVenueRequest represents the request to create a new venue. Pitches
+// defaults to one.
+type CreateVenueRequest struct {
+	Name           string   `json:"name" validate:"required,min=2,max=255"`
+	Address        string   `json:"address,omitempty" validate:"omitempty,max=500"`
+	Latitude       *float64 `json:"latitude,omitempty"`
+	Longitude      *float64 `json:"longitude,omitempty"`
+	BoundaryNotes  string   `json:"boundary_notes,omitempty" validate:"omitempty,max=1000"`
+	Pitches        int      `json:"pitches,omitempty" validate:"omitempty,min=1,max=20"`
+	OrganizationID string   `json:"organization_id,omitempty"`
+}
+
+// UpdateVenueRequest represents the request to update a venue
+type UpdateVenueRequest struct {
+	Name          *string  `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Address       *string  `json:"address,omitempty" validate:"omitempty,max=500"`
+	Latitude      *float64 `json:"latitude,omitempty"`
+	Longitude     *float64 `json:"longitude,omitempty"`
+	BoundaryNotes *string  `json:"boundary_notes,omitempty" validate:"omitempty,max=1000"`
+	Pitches       *int     `json:"pitches,omitempty" validate:"omitempty,min=1,max=20"`
+}
+
+// VenueFilters represents filters for listing venues
+type VenueFilters struct {
+	// OrganizationIDs scopes results to these organizations plus unowned rows; nil means unscoped
+	OrganizationIDs []string `json:"organization_ids,omitempty"`
+	// OnlyOrganizations leaves out the unowned rows, for callers who asked for specific organizations
+	OnlyOrganizations bool `json:"-"`
+	Limit             int  `json:"limit" validate:"min=1,max=100"`
+	Offset            int  `json:"offset" validate:"min=0"`
+}
+
+// MaxMatchOvers is the longest match allowed, per innings
+const MaxMatchOvers = 20
+
+// Match bookings block their pitch for an estimate of the match length: both
+// innings at a few minutes an over plus the innings break
+const (
+	MinutesPerOver      = 4
+	InningsBreakMinutes = 20
+)
+
+// EstimatedMatchDuration returns how long a match of the given overs holds
+// its pitch and its teams
+func EstimatedMatchDuration(totalOvers int) time.Duration {
+	return time.Duration(2*totalOvers*MinutesPerOver+InningsBreakMinutes) * time.Minute
+}
+
+// VenueChase represents the highest successful chase at a venue
+type VenueChase struct {
+	MatchID string `json:"match_id"`
+	Target  int    `json:"target"`
+	Runs    int    `json:"runs"`
+	Wickets int    `json:"wickets"`
+}
+
+// VenueTotal represents the highest innings total at a venue
+type VenueTotal struct {
+	MatchID string `json:"match_id"`
+	Runs    int    `json:"runs"`
+	Wickets int    `json:"wickets"`
+}
+
+// VenueRecords represents the records of a venue, taken from its completed
+// matches that have both innings
+type VenueRecords struct {
+	VenueID             string      `json:"venue_id"`
+	VenueName           string      `json:"venue_name"`
+	MatchesPlayed       int         `json:"matches_played"`
+	AverageFirstInnings float64     `json:"average_first_innings"` // Rounded to one decimal
+	HighestTotal        *VenueTotal `json:"highest_total,omitempty"`
+	HighestChase        *VenueChase `json:"highest_chase,omitempty"`
+	WinsBattingFirst    int         `json:"wins_batting_first"`
+	WinsChasing         int         `json:"wins_chasing"`
+	Ties                int         `json:"ties"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types: the messages broadcast to a series' match rooms and
+// to the series room, under the same names
+var WebhookEventTypes = []string{
+	"scorecard_delta",   // Every ball added or undone
+	"wicket_fallen",     // A ball took a wicket
+	"over_completed",    // A ball completed an over
+	"innings_completed", // With the target after the first innings
+	"match_completed",   // With the result and the winning team
+	"standings_update",  // The series points table changed
+	"bracket_update",    // The series stages and bracket changed
+	"leaderboard_update",
+}
+
+// IsWebhookEventType reports whether webhooks can subscribe to an event type
+func IsWebhookEventType(eventType string) bool {
+	for _, known := range WebhookEventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is an endpoint a series' events are posted to. Each payload is
+// signed with the secret, which is only shown when the webhook is created.
+type Webhook struct {
+	ID         string    `json:"id" db:"id"`
+	SeriesID   string    `json:"series_id" db:"series_id"`
+	URL        string    `json:"url" db:"url"`
+	Secret     string    `json:"secret,omitempty" db:"secret"`
+	EventTypes []string  `json:"event_types" db:"event_types"` // Empty receives every type
+	Active     bool      `json:"active" db:"active"`
+	CreatedBy  string    `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Receives reports whether the webhook is sent events of a type
+func (w *Webhook) Receives(eventType string) bool {
+	if !w.Active {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range w.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhookRequest represents the request to register a webhook
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// UpdateWebhookRequest represents the request to update a webhook
+type UpdateWebhookRequest struct {
+	URL        *string   `json:"url,omitempty" validate:"omitempty,url"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+// WebhookDeliveryStatus represents where a delivery is in its attempts
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Not attempted yet
+	WebhookDeliveryRetrying  WebhookDeliveryStatus = "retrying"  // Failed, and attempted again at NextAttemptAt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // The endpoint answered 2xx
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // Every attempt failed; only redelivered on request
+)
+
+// WebhookDelivery is one event sent to one webhook, logged with the outcome
+// of its last attempt
+type WebhookDelivery struct {
+	ID             string                `json:"id" db:"id"`
+	WebhookID      string                `json:"webhook_id" db:"webhook_id"`
+	SeriesID       string                `json:"series_id" db:"series_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	ResponseStatus int                   `json:"response_status,omitempty" db:"response_status"`
+	LastError      string                `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	ClaimedUntil   *time.Time            `json:"claimed_until,omitempty" db:"claimed_until"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookPayload is the JSON body posted for an event. ID is shared by the
+// deliveries of one event to several webhooks, so receivers can drop
+// duplicates from retries and redeliveries.
+type WebhookPayload struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	SeriesID   string      `json:"series_id"`
+	MatchID    string      `json:"match_id,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
@@ -6,6 +6,7 @@ import (
 	"spark-park-cricket-backend/internal/cache"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 )
 
 // CachedMatchRepository wraps a match repository with caching
@@ -30,28 +31,7 @@ func (r *CachedMatchRepository) Create(ctx context.Context, match *models.Match)
 	}
 
 	// Invalidate caches
-	_ = r.cache.Invalidate("match:list")
-	_ = r.cache.Invalidate("match:count")
-
-	// Invalidate common pagination cache keys
-	_ = r.cache.Invalidate("match:list:limit:20")
-	_ = r.cache.Invalidate("match:list:limit:10")
-	_ = r.cache.Invalidate("match:list:limit:5")
-	_ = r.cache.Invalidate("match:list:limit:3")
-	_ = r.cache.Invalidate("match:list:limit:2")
-
-	// Invalidate all possible match list cache keys with different pagination parameters
-	// This is necessary because the cache keys include limit and offset parameters
-	_ = r.cache.InvalidatePattern("match:list:*")
-
-	if match.SeriesID != "" {
-		seriesKey := r.cache.GetMatchesBySeriesKey(match.SeriesID)
-		_ = r.cache.Invalidate(seriesKey)
-
-		// Invalidate next match number cache for this series
-		nextNumberKey := fmt.Sprintf("match:next_number:series:%s", match.SeriesID)
-		_ = r.cache.Invalidate(nextNumberKey)
-	}
+	r.invalidateLists(match.SeriesID)
 
 	// Cache the new match
 	if match.ID != "" {
@@ -80,8 +60,14 @@ func (r *CachedMatchRepository) GetByID(ctx context.Context, id string) (*models
 
 // GetAll retrieves all matches with caching
 func (r *CachedMatchRepository) GetAll(ctx context.Context, filters *models.MatchFilters) ([]*models.Match, error) {
+	// Tenant-scoped lists and per-user trash listings vary per caller, so
+	// they are always read from the database
+	if filters != nil && (filters.OrganizationIDs != nil || filters.CreatedBy != nil || filters.Deleted) {
+		return r.repo.GetAll(ctx, filters)
+	}
+
 	// Create cache key based on filters
-	cacheKey := "match:list"
+	cacheKey := fmt.Sprintf("match:list:v%d", listVersion(r.cache, matchListVersionKey))
 	if filters != nil {
 		if filters.SeriesID != nil && *filters.SeriesID != "" {
 			cacheKey += fmt.Sprintf(":series:%s", *filters.SeriesID)
@@ -125,6 +111,17 @@ func (r *CachedMatchRepository) GetBySeriesID(ctx context.Context, seriesID stri
 	return matches, nil
 }
 
+// GetByVenueID reads through to the database; venue lists are not cached
+func (r *CachedMatchRepository) GetByVenueID(ctx context.Context, venueID string) ([]*models.Match, error) {
+	return r.repo.GetByVenueID(ctx, venueID)
+}
+
+// GetByDateRange reads through to the database so booking checks see every
+// match as it is now
+func (r *CachedMatchRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*models.Match, error) {
+	return r.repo.GetByDateRange(ctx, from, to)
+}
+
 // Update updates a match and invalidates cache
 func (r *CachedMatchRepository) Update(ctx context.Context, id string, match *models.Match) error {
 	err := r.repo.Update(ctx, id, match)
@@ -135,26 +132,7 @@ func (r *CachedMatchRepository) Update(ctx context.Context, id string, match *mo
 	// Invalidate caches
 	key := r.cache.GetMatchKey(id)
 	_ = r.cache.Invalidate(key)
-	_ = r.cache.Invalidate("match:list")
-	_ = r.cache.Invalidate("match:count")
-
-	// Invalidate common pagination cache keys
-	_ = r.cache.Invalidate("match:list:limit:20")
-	_ = r.cache.Invalidate("match:list:limit:10")
-	_ = r.cache.Invalidate("match:list:limit:5")
-	_ = r.cache.Invalidate("match:list:limit:3")
-	_ = r.cache.Invalidate("match:list:limit:2")
-
-	_ = r.cache.InvalidatePattern("match:list:*")
-
-	if match.SeriesID != "" {
-		seriesKey := r.cache.GetMatchesBySeriesKey(match.SeriesID)
-		_ = r.cache.Invalidate(seriesKey)
-
-		// Invalidate next match number cache for this series
-		nextNumberKey := fmt.Sprintf("match:next_number:series:%s", match.SeriesID)
-		_ = r.cache.Invalidate(nextNumberKey)
-	}
+	r.invalidateLists(match.SeriesID)
 
 	// Update cache with new data
 	_ = r.cache.Set(key, match, cache.StaticDataTTL)
@@ -178,26 +156,7 @@ func (r *CachedMatchRepository) Delete(ctx context.Context, id string) error {
 	// Invalidate caches
 	key := r.cache.GetMatchKey(id)
 	_ = r.cache.Invalidate(key)
-	_ = r.cache.Invalidate("match:list")
-	_ = r.cache.Invalidate("match:count")
-
-	// Invalidate common pagination cache keys
-	_ = r.cache.Invalidate("match:list:limit:20")
-	_ = r.cache.Invalidate("match:list:limit:10")
-	_ = r.cache.Invalidate("match:list:limit:5")
-	_ = r.cache.Invalidate("match:list:limit:3")
-	_ = r.cache.Invalidate("match:list:limit:2")
-
-	_ = r.cache.InvalidatePattern("match:list:*")
-
-	if match.SeriesID != "" {
-		seriesKey := r.cache.GetMatchesBySeriesKey(match.SeriesID)
-		_ = r.cache.Invalidate(seriesKey)
-
-		// Invalidate next match number cache for this series
-		nextNumberKey := fmt.Sprintf("match:next_number:series:%s", match.SeriesID)
-		_ = r.cache.Invalidate(nextNumberKey)
-	}
+	r.invalidateLists(match.SeriesID)
 
 	return nil
 }
@@ -249,3 +208,91 @@ func (r *CachedMatchRepository) ExistsBySeriesAndMatchNumber(ctx context.Context
 
 	return exists, nil
 }
+
+// SoftDelete moves a match and its innings to the trash and invalidates cache
+func (r *CachedMatchRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	// Get match first to know which series to invalidate
+	match, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = r.repo.SoftDelete(ctx, id, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	r.invalidateMatchData(id)
+	r.invalidateLists(match.SeriesID)
+
+	return nil
+}
+
+// Restore brings a match and its innings back from the trash and invalidates cache
+func (r *CachedMatchRepository) Restore(ctx context.Context, id string) error {
+	match, err := r.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = r.repo.Restore(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	r.invalidateMatchData(id)
+	r.invalidateLists(match.SeriesID)
+
+	return nil
+}
+
+// GetDeletedByID retrieves a match from the trash (not cached)
+func (r *CachedMatchRepository) GetDeletedByID(ctx context.Context, id string) (*models.Match, error) {
+	return r.repo.GetDeletedByID(ctx, id)
+}
+
+// GetDeletedBySeriesID retrieves a series' matches from the trash (not cached)
+func (r *CachedMatchRepository) GetDeletedBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
+	return r.repo.GetDeletedBySeriesID(ctx, seriesID)
+}
+
+// PurgeDeleted permanently removes expired trash. Purged rows were already
+// excluded from cached reads, so only the lists and counts need invalidating.
+func (r *CachedMatchRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	purged, err := r.repo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		r.invalidateLists("")
+	}
+
+	return purged, nil
+}
+
+// invalidateMatchData drops the cached match along with its scorecard and
+// innings, which are soft deleted and restored together with the match
+func (r *CachedMatchRepository) invalidateMatchData(matchID string) {
+	_ = r.cache.Invalidate(r.cache.GetMatchKey(matchID))
+	_ = r.cache.Invalidate(r.cache.GetScorecardKey(matchID))
+	_ = r.cache.Invalidate(fmt.Sprintf("innings:match:%s", matchID))
+	for inningsNumber := 1; inningsNumber <= 2; inningsNumber++ {
+		_ = r.cache.Invalidate(fmt.Sprintf("innings:match:%s:number:%d", matchID, inningsNumber))
+	}
+}
+
+// invalidateLists drops every cached match list and count, plus the
+// per-series list and next match number when a series is given
+func (r *CachedMatchRepository) invalidateLists(seriesID string) {
+	bumpListVersion(r.cache, matchListVersionKey)
+	_ = r.cache.Invalidate("match:count")
+
+	if seriesID != "" {
+		_ = r.cache.Invalidate(r.cache.GetMatchesBySeriesKey(seriesID))
+
+		// Invalidate next match number cache for this series
+		nextNumberKey := fmt.Sprintf("match:next_number:series:%s", seriesID)
+		_ = r.cache.Invalidate(nextNumberKey)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"spark-park-cricket-backend/internal/cache"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 )
 
 // CachedSeriesRepository wraps a series repository with caching
@@ -29,12 +30,9 @@ func (r *CachedSeriesRepository) Create(ctx context.Context, series *models.Seri
 		return err
 	}
 
-	// Invalidate series list cache - invalidate all possible cache keys
+	// Invalidate series list cache
 	fmt.Printf("DEBUG: CachedSeriesRepository.Create - Invalidating cache keys\n")
-	_ = r.cache.Invalidate("series:list")
-	_ = r.cache.Invalidate("series:list:limit:20")
-	_ = r.cache.Invalidate("series:list:limit:50")
-	_ = r.cache.Invalidate("series:list:limit:100")
+	r.invalidateLists()
 	fmt.Printf("DEBUG: CachedSeriesRepository.Create - Cache invalidation completed\n")
 
 	// Cache the new series
@@ -64,8 +62,14 @@ func (r *CachedSeriesRepository) GetByID(ctx context.Context, id string) (*model
 
 // GetAll retrieves all series with caching
 func (r *CachedSeriesRepository) GetAll(ctx context.Context, filters *models.SeriesFilters) ([]*models.Series, error) {
+	// Tenant-scoped lists and per-user trash listings vary per caller, so
+	// they are always read from the database
+	if filters != nil && (filters.OrganizationIDs != nil || filters.CreatedBy != nil || filters.Deleted) {
+		return r.repo.GetAll(ctx, filters)
+	}
+
 	// Create cache key based on filters
-	cacheKey := "series:list"
+	cacheKey := fmt.Sprintf("series:list:v%d", listVersion(r.cache, seriesListVersionKey))
 	if filters != nil {
 		// Add filter parameters to cache key
 		if filters.Limit > 0 {
@@ -105,7 +109,7 @@ func (r *CachedSeriesRepository) Update(ctx context.Context, id string, series *
 	// Invalidate caches
 	key := r.cache.GetSeriesKey(id)
 	_ = r.cache.Invalidate(key)
-	_ = r.cache.Invalidate("series:list")
+	r.invalidateLists()
 
 	// Update cache with new data
 	_ = r.cache.Set(key, series, cache.StaticDataTTL)
@@ -123,7 +127,7 @@ func (r *CachedSeriesRepository) Delete(ctx context.Context, id string) error {
 	// Invalidate caches
 	key := r.cache.GetSeriesKey(id)
 	_ = r.cache.Invalidate(key)
-	_ = r.cache.Invalidate("series:list")
+	r.invalidateLists()
 
 	return nil
 }
@@ -143,3 +147,55 @@ func (r *CachedSeriesRepository) Count(ctx context.Context) (int64, error) {
 
 	return count, nil
 }
+
+// SoftDelete moves a series to the trash and invalidates cache
+func (r *CachedSeriesRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	err := r.repo.SoftDelete(ctx, id, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	_ = r.cache.Invalidate(r.cache.GetSeriesKey(id))
+	r.invalidateLists()
+
+	return nil
+}
+
+// Restore brings a series back from the trash and invalidates cache
+func (r *CachedSeriesRepository) Restore(ctx context.Context, id string) error {
+	err := r.repo.Restore(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_ = r.cache.Invalidate(r.cache.GetSeriesKey(id))
+	r.invalidateLists()
+
+	return nil
+}
+
+// GetDeletedByID retrieves a series from the trash (not cached)
+func (r *CachedSeriesRepository) GetDeletedByID(ctx context.Context, id string) (*models.Series, error) {
+	return r.repo.GetDeletedByID(ctx, id)
+}
+
+// PurgeDeleted permanently removes expired trash. Purged rows were already
+// excluded from cached reads, so only the counts need invalidating.
+func (r *CachedSeriesRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	purged, err := r.repo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		_ = r.cache.Invalidate("series:count")
+	}
+
+	return purged, nil
+}
+
+// invalidateLists drops every cached series list and the series count
+func (r *CachedSeriesRepository) invalidateLists() {
+	bumpListVersion(r.cache, seriesListVersionKey)
+	_ = r.cache.Invalidate("series:count")
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"spark-park-cricket-backend/internal/cache"
+)
+
+// List cache keys embed a version number so that bumping the version
+// invalidates every page and filter combination at once. This stands in for
+// CacheManager.InvalidatePattern, which is not implemented for Redis.
+const (
+	seriesListVersionKey = "series:list:version"
+	matchListVersionKey  = "match:list:version"
+)
+
+// listVersion returns the current version of a cached list namespace
+func listVersion(cm *cache.CacheManager, versionKey string) int64 {
+	var version int64
+	_ = cm.Get(versionKey, &version)
+	return version
+}
+
+// bumpListVersion invalidates all cached lists in a namespace
+func bumpListVersion(cm *cache.CacheManager, versionKey string) {
+	_, _ = cm.IncrementVersion(versionKey)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// AudienceRepository defines the interface for match audience data operations
+type AudienceRepository interface {
+	// Save stores a match's audience, replacing any saved before
+	Save(ctx context.Context, audience *models.MatchAudience) error
+	GetByMatchID(ctx context.Context, matchID string) (*models.MatchAudience, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// AuditRepository defines the interface for audit log data operations
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	GetAll(ctx context.Context, filters *models.AuditLogFilters) ([]*models.AuditLog, error)
+	Count(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// AwardRepository defines the interface for match and series award data operations
+type AwardRepository interface {
+	Create(ctx context.Context, award *models.Award) error
+	Update(ctx context.Context, id string, award *models.Award) error
+	Delete(ctx context.Context, id string) error
+	GetByMatchID(ctx context.Context, matchID string) ([]*models.Award, error)
+	// GetBySeriesID returns a series' own awards, not those of its matches
+	GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Award, error)
+	// GetByPlayerID returns every award a player holds, newest first
+	GetByPlayerID(ctx context.Context, playerID string) ([]*models.Award, error)
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"spark-park-cricket-backend/internal/models"
+	"time"
 )
 
 // MatchRepository defines the interface for match data operations
@@ -11,9 +12,21 @@ type MatchRepository interface {
 	GetByID(ctx context.Context, id string) (*models.Match, error)
 	GetAll(ctx context.Context, filters *models.MatchFilters) ([]*models.Match, error)
 	GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error)
+	GetByVenueID(ctx context.Context, venueID string) ([]*models.Match, error)
+	// GetByDateRange returns the matches starting between from and to, inclusive
+	GetByDateRange(ctx context.Context, from, to time.Time) ([]*models.Match, error)
 	Update(ctx context.Context, id string, match *models.Match) error
 	Delete(ctx context.Context, id string) error
 	Count(ctx context.Context) (int64, error)
 	GetNextMatchNumber(ctx context.Context, seriesID string) (int, error)
 	ExistsBySeriesAndMatchNumber(ctx context.Context, seriesID string, matchNumber int) (bool, error)
+
+	// Soft delete operations. GetByID and GetAll exclude soft-deleted rows
+	// unless filters ask for the trash. Soft deleting a match also soft deletes
+	// its innings.
+	SoftDelete(ctx context.Context, id string, deletedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	GetDeletedByID(ctx context.Context, id string) (*models.Match, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+	GetDeletedBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error)
 }
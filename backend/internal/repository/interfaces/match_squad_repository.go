@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// MatchSquadRepository defines the interface for match squad data operations
+type MatchSquadRepository interface {
+	GetByMatchID(ctx context.Context, matchID string) ([]*models.MatchSquadPlayer, error)
+	GetByMatchAndTeam(ctx context.Context, matchID string, team models.TeamType) ([]*models.MatchSquadPlayer, error)
+	// ReplaceSquad removes one side's squad and inserts the given players in its place
+	ReplaceSquad(ctx context.Context, matchID string, team models.TeamType, players []*models.MatchSquadPlayer) error
+	Update(ctx context.Context, id string, player *models.MatchSquadPlayer) error
+}
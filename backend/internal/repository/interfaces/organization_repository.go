@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// OrganizationRepository defines the interface for organization and membership data operations
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) error
+	GetByID(ctx context.Context, id string) (*models.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Organization, error)
+	GetAll(ctx context.Context, filters *models.OrganizationFilters) ([]*models.Organization, error)
+	GetPublic(ctx context.Context) ([]*models.Organization, error)
+	Update(ctx context.Context, id string, org *models.Organization) error
+	Delete(ctx context.Context, id string) error
+	Count(ctx context.Context) (int64, error)
+
+	// Membership operations
+	AddMember(ctx context.Context, member *models.OrganizationMember) error
+	GetMember(ctx context.Context, organizationID, userID string) (*models.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationID string) ([]*models.OrganizationMember, error)
+	GetMembershipsByUser(ctx context.Context, userID string) ([]*models.OrganizationMember, error)
+	UpdateMember(ctx context.Context, member *models.OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationID, userID string) error
+}
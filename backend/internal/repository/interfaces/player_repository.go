@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"spark-park-cricket-backend/internal/models"
+	"time"
 )
 
 // PlayerRepository defines the interface for player data operations
@@ -14,4 +15,12 @@ type PlayerRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetByTeamID(ctx context.Context, teamID string) ([]*models.Player, error)
 	Count(ctx context.Context) (int64, error)
+
+	// Soft delete operations. GetByID and GetAll exclude soft-deleted rows
+	// unless filters ask for the trash.
+	SoftDelete(ctx context.Context, id string, deletedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	GetDeletedByID(ctx context.Context, id string) (*models.Player, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+	GetDeletedByTeamID(ctx context.Context, teamID string) ([]*models.Player, error)
 }
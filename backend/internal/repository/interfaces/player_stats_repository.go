@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// PlayerStatsRepository defines the interface for player statistics data operations
+type PlayerStatsRepository interface {
+	// ReplaceMatchStats removes a match's per-player rows and inserts the given ones
+	ReplaceMatchStats(ctx context.Context, matchID string, stats []*models.PlayerMatchStats) error
+	GetMatchStatsByMatchID(ctx context.Context, matchID string) ([]*models.PlayerMatchStats, error)
+	GetMatchStatsByPlayer(ctx context.Context, playerID string) ([]*models.PlayerMatchStats, error)
+	DeleteMatchStats(ctx context.Context, matchID string) error
+
+	// ReplacePlayerStats removes a player's career and series aggregates and inserts the given ones
+	ReplacePlayerStats(ctx context.Context, playerID string, stats []*models.PlayerStats) error
+	GetPlayerStats(ctx context.Context, playerID string) ([]*models.PlayerStats, error)
+	GetSeriesStats(ctx context.Context, seriesID string) ([]*models.PlayerStats, error)
+
+	// DeleteAll clears every match row and aggregate ahead of a full rebuild
+	DeleteAll(ctx context.Context) error
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"spark-park-cricket-backend/internal/models"
+	"time"
 )
 
 // SeriesRepository defines the interface for series data operations
@@ -13,4 +14,11 @@ type SeriesRepository interface {
 	Update(ctx context.Context, id string, series *models.Series) error
 	Delete(ctx context.Context, id string) error
 	Count(ctx context.Context) (int64, error)
+
+	// Soft delete operations. GetByID and GetAll exclude soft-deleted rows
+	// unless filters ask for the trash.
+	SoftDelete(ctx context.Context, id string, deletedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	GetDeletedByID(ctx context.Context, id string) (*models.Series, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// StageRepository defines the interface for series stage data operations
+type StageRepository interface {
+	Create(ctx context.Context, stage *models.SeriesStage) error
+	GetByID(ctx context.Context, id string) (*models.SeriesStage, error)
+	// GetBySeriesID returns a series' stages in position order
+	GetBySeriesID(ctx context.Context, seriesID string) ([]*models.SeriesStage, error)
+	Update(ctx context.Context, id string, stage *models.SeriesStage) error
+	Delete(ctx context.Context, id string) error
+}
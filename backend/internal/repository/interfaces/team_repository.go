@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"spark-park-cricket-backend/internal/models"
+	"time"
 )
 
 // TeamRepository defines the interface for team data operations
@@ -11,6 +12,15 @@ type TeamRepository interface {
 	GetByID(ctx context.Context, id string) (*models.Team, error)
 	GetAll(ctx context.Context, filters *models.TeamFilters) ([]*models.Team, error)
 	Update(ctx context.Context, id string, team *models.Team) error
+	// UpdatePlayersCount stores the size of the team's live roster
+	UpdatePlayersCount(ctx context.Context, id string, count int) error
 	Delete(ctx context.Context, id string) error
 	Count(ctx context.Context) (int64, error)
+
+	// Soft delete operations. GetByID and GetAll exclude soft-deleted rows
+	// unless filters ask for the trash.
+	SoftDelete(ctx context.Context, id string, deletedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	GetDeletedByID(ctx context.Context, id string) (*models.Team, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 }
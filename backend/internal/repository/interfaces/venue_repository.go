@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// VenueRepository defines the interface for venue data operations
+type VenueRepository interface {
+	Create(ctx context.Context, venue *models.Venue) error
+	GetByID(ctx context.Context, id string) (*models.Venue, error)
+	GetAll(ctx context.Context, filters *models.VenueFilters) ([]*models.Venue, error)
+	Update(ctx context.Context, id string, venue *models.Venue) error
+	Delete(ctx context.Context, id string) error
+}
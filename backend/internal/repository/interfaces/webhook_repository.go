@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"time"
+)
+
+// WebhookRepository defines the interface for webhook and delivery log data operations
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id string) (*models.Webhook, error)
+	GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Webhook, error)
+	Update(ctx context.Context, id string, webhook *models.Webhook) error
+	Delete(ctx context.Context, id string) error
+
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, id string, delivery *models.WebhookDelivery) error
+	// GetDeliveries returns a webhook's deliveries, newest first, of one
+	// status or all when status is empty
+	GetDeliveries(ctx context.Context, webhookID string, status models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error)
+	// GetUnfinishedDeliveries returns pending and retrying deliveries, oldest first
+	GetUnfinishedDeliveries(ctx context.Context, limit int) ([]*models.WebhookDelivery, error)
+	// ClaimDelivery leases a delivery until the given time, provided it still
+	// has the status and next attempt time it was read with and no other
+	// lease is running. It reports whether the claim was won, and then
+	// refreshes the delivery.
+	ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error)
+}
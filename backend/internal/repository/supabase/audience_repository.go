@@ -0,0 +1,48 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/supabase-go"
+)
+
+type audienceRepository struct {
+	client *supabase.Client
+}
+
+// NewAudienceRepository creates a new match audience repository
+func NewAudienceRepository(client *supabase.Client) interfaces.AudienceRepository {
+	return &audienceRepository{
+		client: client,
+	}
+}
+
+func (r *audienceRepository) Save(ctx context.Context, audience *models.MatchAudience) error {
+	audienceData := map[string]interface{}{
+		"match_id":        audience.MatchID,
+		"peak_viewers":    audience.PeakViewers,
+		"peak_at":         audience.PeakAt,
+		"average_viewers": audience.AverageViewers,
+		"timeline":        audience.Timeline,
+		"recorded_at":     audience.RecordedAt,
+	}
+
+	var result []models.MatchAudience
+	_, err := r.client.From("match_audiences").Insert([]map[string]interface{}{audienceData}, true, "match_id", "", "").ExecuteTo(&result)
+	return err
+}
+
+func (r *audienceRepository) GetByMatchID(ctx context.Context, matchID string) (*models.MatchAudience, error) {
+	var result []models.MatchAudience
+	_, err := r.client.From("match_audiences").Select("*", "", false).Eq("match_id", matchID).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("audience not found")
+	}
+	return &result[0], nil
+}
@@ -0,0 +1,98 @@
+package supabase
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type auditRepository struct {
+	client *supabase.Client
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(client *supabase.Client) interfaces.AuditRepository {
+	return &auditRepository{
+		client: client,
+	}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	data := map[string]interface{}{
+		"actor_id":    entry.ActorID,
+		"actor_email": entry.ActorEmail,
+		"action":      entry.Action,
+		"entity_type": entry.EntityType,
+		"entity_id":   entry.EntityID,
+		"request_id":  entry.RequestID,
+		"before":      entry.Before,
+		"after":       entry.After,
+		"created_at":  entry.CreatedAt,
+	}
+
+	var result []models.AuditLog
+	_, err := r.client.From("audit_logs").Insert(data, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*entry = result[0]
+	}
+
+	return nil
+}
+
+func (r *auditRepository) GetAll(ctx context.Context, filters *models.AuditLogFilters) ([]*models.AuditLog, error) {
+	var result []models.AuditLog
+	query := r.client.From("audit_logs").Select("*", "", false)
+
+	if filters.ActorID != nil {
+		query = query.Eq("actor_id", *filters.ActorID)
+	}
+	if filters.Action != nil {
+		query = query.Eq("action", string(*filters.Action))
+	}
+	if filters.EntityType != nil {
+		query = query.Eq("entity_type", string(*filters.EntityType))
+	}
+	if filters.EntityID != nil {
+		query = query.Eq("entity_id", *filters.EntityID)
+	}
+	if filters.RequestID != nil {
+		query = query.Eq("request_id", *filters.RequestID)
+	}
+	if filters.From != nil {
+		query = query.Gte("created_at", filters.From.Format(time.RFC3339))
+	}
+	if filters.To != nil {
+		query = query.Lte("created_at", filters.To.Format(time.RFC3339))
+	}
+
+	query = query.Order("created_at", &postgrest.OrderOpts{Ascending: false})
+	query = query.Range(filters.Offset, filters.Offset+filters.Limit-1, "")
+
+	_, err := query.ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	entries := make([]*models.AuditLog, len(result))
+	for i := range result {
+		entries[i] = &result[i]
+	}
+	return entries, nil
+}
+
+func (r *auditRepository) Count(ctx context.Context) (int64, error) {
+	_, count, err := r.client.From("audit_logs").Select("id", "exact", true).Execute()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,116 @@
+package supabase
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type awardRepository struct {
+	client *supabase.Client
+}
+
+// NewAwardRepository creates a new award repository
+func NewAwardRepository(client *supabase.Client) interfaces.AwardRepository {
+	return &awardRepository{
+		client: client,
+	}
+}
+
+func (r *awardRepository) Create(ctx context.Context, award *models.Award) error {
+	awardData := awardRow(award)
+	awardData["created_at"] = award.CreatedAt
+
+	var result []models.Award
+	_, err := r.client.From("awards").Insert([]map[string]interface{}{awardData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*award = result[0]
+	}
+
+	return nil
+}
+
+func (r *awardRepository) Update(ctx context.Context, id string, award *models.Award) error {
+	var result []models.Award
+	_, err := r.client.From("awards").Update(awardRow(award), "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*award = result[0]
+	}
+
+	return nil
+}
+
+func (r *awardRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.From("awards").Delete("", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
+
+func (r *awardRepository) GetByMatchID(ctx context.Context, matchID string) ([]*models.Award, error) {
+	var result []models.Award
+	_, err := r.client.From("awards").Select("*", "", false).Eq("match_id", matchID).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	return awardPointers(result), nil
+}
+
+func (r *awardRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Award, error) {
+	var result []models.Award
+	_, err := r.client.From("awards").Select("*", "", false).Eq("series_id", seriesID).Is("match_id", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	return awardPointers(result), nil
+}
+
+func (r *awardRepository) GetByPlayerID(ctx context.Context, playerID string) ([]*models.Award, error) {
+	var result []models.Award
+	_, err := r.client.From("awards").
+		Select("*", "", false).
+		Eq("player_id", playerID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	return awardPointers(result), nil
+}
+
+// awardRow maps the columns an award write sets
+func awardRow(award *models.Award) map[string]interface{} {
+	return map[string]interface{}{
+		"award_type":         award.AwardType,
+		"series_id":          award.SeriesID,
+		"match_id":           nullIfEmpty(award.MatchID),
+		"player_id":          award.PlayerID,
+		"player_name":        award.PlayerName,
+		"team_id":            nullIfEmpty(award.TeamID),
+		"points":             award.Points,
+		"proposed_player_id": award.ProposedPlayerID,
+		"status":             award.Status,
+		"overridden":         award.Overridden,
+		"note":               nullIfEmpty(award.Note),
+		"confirmed_by":       nullIfEmpty(award.ConfirmedBy),
+		"confirmed_at":       award.ConfirmedAt,
+		"updated_at":         award.UpdatedAt,
+	}
+}
+
+func awardPointers(result []models.Award) []*models.Award {
+	awards := make([]*models.Award, len(result))
+	for i := range result {
+		awards[i] = &result[i]
+	}
+	return awards
+}
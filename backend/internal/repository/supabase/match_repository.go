@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 
 	"github.com/supabase-community/supabase-go"
 )
@@ -35,6 +36,28 @@ func (r *matchRepository) Create(ctx context.Context, match *models.Match) error
 		"created_at":          match.CreatedAt,
 		"updated_at":          match.UpdatedAt,
 	}
+	if match.OrganizationID != "" {
+		matchData["organization_id"] = match.OrganizationID
+	}
+	if match.Venue != "" {
+		matchData["venue"] = match.Venue
+	}
+	if match.VenueID != "" {
+		matchData["venue_id"] = match.VenueID
+		matchData["pitch_number"] = match.PitchNumber
+	}
+	if match.TeamAID != "" {
+		matchData["team_a_id"] = match.TeamAID
+	}
+	if match.TeamBID != "" {
+		matchData["team_b_id"] = match.TeamBID
+	}
+	if match.Access != "" {
+		matchData["access"] = match.Access
+	}
+	if len(match.InvitedUserIDs) > 0 {
+		matchData["invited_user_ids"] = match.InvitedUserIDs
+	}
 
 	matchDataSlice := []map[string]interface{}{matchData}
 	var result []models.Match
@@ -53,7 +76,7 @@ func (r *matchRepository) Create(ctx context.Context, match *models.Match) error
 
 func (r *matchRepository) GetByID(ctx context.Context, id string) (*models.Match, error) {
 	var result []models.Match
-	_, err := r.client.From("matches").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	_, err := r.client.From("matches").Select("*", "", false).Eq("id", id).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +89,7 @@ func (r *matchRepository) GetByID(ctx context.Context, id string) (*models.Match
 func (r *matchRepository) GetAll(ctx context.Context, filters *models.MatchFilters) ([]*models.Match, error) {
 	var result []models.Match
 	query := r.client.From("matches").Select("*", "", false)
+	query = deletedFilter(query, filters.Deleted)
 
 	if filters.SeriesID != nil {
 		query = query.Eq("series_id", *filters.SeriesID)
@@ -73,6 +97,12 @@ func (r *matchRepository) GetAll(ctx context.Context, filters *models.MatchFilte
 	if filters.Status != nil {
 		query = query.Eq("status", string(*filters.Status))
 	}
+	if filters.CreatedBy != nil {
+		query = query.Eq("created_by", *filters.CreatedBy)
+	}
+	if filters.OrganizationIDs != nil {
+		query = query.Or(organizationScope(filters.OrganizationIDs, !filters.OnlyOrganizations), "")
+	}
 
 	query = query.Range(filters.Offset, filters.Offset+filters.Limit-1, "")
 
@@ -91,7 +121,7 @@ func (r *matchRepository) GetAll(ctx context.Context, filters *models.MatchFilte
 
 func (r *matchRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
 	var result []models.Match
-	_, err := r.client.From("matches").Select("*", "", false).Eq("series_id", seriesID).ExecuteTo(&result)
+	_, err := r.client.From("matches").Select("*", "", false).Eq("series_id", seriesID).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +134,39 @@ func (r *matchRepository) GetBySeriesID(ctx context.Context, seriesID string) ([
 	return matches, nil
 }
 
+func (r *matchRepository) GetByVenueID(ctx context.Context, venueID string) ([]*models.Match, error) {
+	var result []models.Match
+	_, err := r.client.From("matches").Select("*", "", false).Eq("venue_id", venueID).Is("deleted_at", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*models.Match, len(result))
+	for i := range result {
+		matches[i] = &result[i]
+	}
+	return matches, nil
+}
+
+func (r *matchRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*models.Match, error) {
+	var result []models.Match
+	_, err := r.client.From("matches").
+		Select("*", "", false).
+		Gte("date", from.UTC().Format(time.RFC3339)).
+		Lte("date", to.UTC().Format(time.RFC3339)).
+		Is("deleted_at", "null").
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*models.Match, len(result))
+	for i := range result {
+		matches[i] = &result[i]
+	}
+	return matches, nil
+}
+
 func (r *matchRepository) Update(ctx context.Context, id string, match *models.Match) error {
 	// Create a map to avoid UUID issues
 	matchData := map[string]interface{}{
@@ -117,9 +180,27 @@ func (r *matchRepository) Update(ctx context.Context, id string, match *models.M
 		"toss_winner":         match.TossWinner,
 		"toss_type":           match.TossType,
 		"batting_team":        match.BattingTeam,
+		"venue":               nullIfEmpty(match.Venue),
+		"venue_id":            nullIfEmpty(match.VenueID),
+		"pitch_number":        nullIfZero(match.PitchNumber),
 		"created_by":          match.CreatedBy,
 		"updated_at":          match.UpdatedAt,
 	}
+	// Empty team IDs unlink the match from its teams
+	matchData["team_a_id"] = nil
+	if match.TeamAID != "" {
+		matchData["team_a_id"] = match.TeamAID
+	}
+	matchData["team_b_id"] = nil
+	if match.TeamBID != "" {
+		matchData["team_b_id"] = match.TeamBID
+	}
+	if match.Access != "" {
+		matchData["access"] = match.Access
+	}
+	if match.InvitedUserIDs != nil {
+		matchData["invited_user_ids"] = match.InvitedUserIDs
+	}
 
 	var result []models.Match
 	_, err := r.client.From("matches").Update(matchData, "", "").Eq("id", id).ExecuteTo(&result)
@@ -141,7 +222,7 @@ func (r *matchRepository) Delete(ctx context.Context, id string) error {
 
 func (r *matchRepository) Count(ctx context.Context) (int64, error) {
 	var result []models.Match
-	_, err := r.client.From("matches").Select("*", "", false).ExecuteTo(&result)
+	_, err := r.client.From("matches").Select("*", "", false).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return 0, err
 	}
@@ -149,7 +230,8 @@ func (r *matchRepository) Count(ctx context.Context) (int64, error) {
 }
 
 func (r *matchRepository) GetNextMatchNumber(ctx context.Context, seriesID string) (int, error) {
-	// Get all matches for the series to find the highest match number
+	// Get all matches for the series to find the highest match number.
+	// Soft-deleted matches are included so a restored match keeps its number.
 	var result []models.Match
 	_, err := r.client.From("matches").
 		Select("match_number", "", false).
@@ -178,6 +260,7 @@ func (r *matchRepository) GetNextMatchNumber(ctx context.Context, seriesID strin
 }
 
 func (r *matchRepository) ExistsBySeriesAndMatchNumber(ctx context.Context, seriesID string, matchNumber int) (bool, error) {
+	// Soft-deleted matches still hold their number until purged
 	var result []models.Match
 	_, err := r.client.From("matches").
 		Select("id", "", false).
@@ -192,3 +275,53 @@ func (r *matchRepository) ExistsBySeriesAndMatchNumber(ctx context.Context, seri
 	// Return true if any match exists with the given series ID and match number
 	return len(result) > 0, nil
 }
+
+func (r *matchRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	// Innings go to the trash with the match, sharing its timestamp
+	if err := softDeleteWhere(r.client, "innings", "match_id", id, deletedAt); err != nil {
+		return fmt.Errorf("failed to soft delete innings: %w", err)
+	}
+	return softDeleteWhere(r.client, "matches", "id", id, deletedAt)
+}
+
+func (r *matchRepository) Restore(ctx context.Context, id string) error {
+	match, err := r.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := restoreWhere(r.client, "innings", "match_id", id, *match.DeletedAt); err != nil {
+		return fmt.Errorf("failed to restore innings: %w", err)
+	}
+	return restoreWhere(r.client, "matches", "id", id, *match.DeletedAt)
+}
+
+func (r *matchRepository) GetDeletedByID(ctx context.Context, id string) (*models.Match, error) {
+	var result []models.Match
+	_, err := r.client.From("matches").Select("*", "", false).Eq("id", id).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("deleted match not found")
+	}
+	return &result[0], nil
+}
+
+func (r *matchRepository) GetDeletedBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
+	var result []models.Match
+	_, err := r.client.From("matches").Select("*", "", false).Eq("series_id", seriesID).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	matches := make([]*models.Match, len(result))
+	for i := range result {
+		matches[i] = &result[i]
+	}
+	return matches, nil
+}
+
+func (r *matchRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return purgeDeletedBefore(r.client, "matches", before)
+}
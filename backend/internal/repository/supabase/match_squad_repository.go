@@ -0,0 +1,117 @@
+package supabase
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type matchSquadRepository struct {
+	client *supabase.Client
+}
+
+// NewMatchSquadRepository creates a new match squad repository
+func NewMatchSquadRepository(client *supabase.Client) interfaces.MatchSquadRepository {
+	return &matchSquadRepository{
+		client: client,
+	}
+}
+
+func (r *matchSquadRepository) GetByMatchID(ctx context.Context, matchID string) ([]*models.MatchSquadPlayer, error) {
+	var result []models.MatchSquadPlayer
+	_, err := r.client.From("match_squads").
+		Select("*", "", false).
+		Eq("match_id", matchID).
+		Order("batting_order", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSquadPointers(result), nil
+}
+
+func (r *matchSquadRepository) GetByMatchAndTeam(ctx context.Context, matchID string, team models.TeamType) ([]*models.MatchSquadPlayer, error) {
+	var result []models.MatchSquadPlayer
+	_, err := r.client.From("match_squads").
+		Select("*", "", false).
+		Eq("match_id", matchID).
+		Eq("team", string(team)).
+		Order("batting_order", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSquadPointers(result), nil
+}
+
+func (r *matchSquadRepository) ReplaceSquad(ctx context.Context, matchID string, team models.TeamType, players []*models.MatchSquadPlayer) error {
+	_, err := r.client.From("match_squads").
+		Delete("", "").
+		Eq("match_id", matchID).
+		Eq("team", string(team)).
+		ExecuteTo(nil)
+	if err != nil {
+		return err
+	}
+	if len(players) == 0 {
+		return nil
+	}
+
+	// Insert without IDs so the database generates them
+	rows := make([]map[string]interface{}, len(players))
+	for i, player := range players {
+		rows[i] = map[string]interface{}{
+			"match_id":        matchID,
+			"team":            team,
+			"player_id":       player.PlayerID,
+			"player_name":     player.PlayerName,
+			"batting_order":   player.BattingOrder,
+			"is_captain":      player.IsCaptain,
+			"is_wicketkeeper": player.IsWicketkeeper,
+			"is_substitute":   player.IsSubstitute,
+			"created_at":      player.CreatedAt,
+			"updated_at":      player.UpdatedAt,
+		}
+	}
+
+	var result []models.MatchSquadPlayer
+	_, err = r.client.From("match_squads").Insert(rows, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	// Copy generated IDs back; rows come back in insertion order
+	for i := range result {
+		if i < len(players) {
+			*players[i] = result[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *matchSquadRepository) Update(ctx context.Context, id string, player *models.MatchSquadPlayer) error {
+	updateData := map[string]interface{}{
+		"batting_order":   player.BattingOrder,
+		"is_captain":      player.IsCaptain,
+		"is_wicketkeeper": player.IsWicketkeeper,
+		"is_substitute":   player.IsSubstitute,
+		"updated_at":      player.UpdatedAt,
+	}
+
+	_, err := r.client.From("match_squads").Update(updateData, "", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
+
+func toSquadPointers(result []models.MatchSquadPlayer) []*models.MatchSquadPlayer {
+	players := make([]*models.MatchSquadPlayer, len(result))
+	for i := range result {
+		players[i] = &result[i]
+	}
+	return players
+}
@@ -0,0 +1,236 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/supabase-go"
+)
+
+type organizationRepository struct {
+	client *supabase.Client
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(client *supabase.Client) interfaces.OrganizationRepository {
+	return &organizationRepository{
+		client: client,
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	// Create a map without ID for insertion
+	orgData := map[string]interface{}{
+		"name":        org.Name,
+		"slug":        org.Slug,
+		"description": org.Description,
+		"is_public":   org.IsPublic,
+		"created_by":  org.CreatedBy,
+		"created_at":  org.CreatedAt,
+		"updated_at":  org.UpdatedAt,
+	}
+
+	var result []models.Organization
+	_, err := r.client.From("organizations").Insert([]map[string]interface{}{orgData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*org = result[0]
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	var result []models.Organization
+	_, err := r.client.From("organizations").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("organization not found")
+	}
+	return &result[0], nil
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	var result []models.Organization
+	_, err := r.client.From("organizations").Select("*", "", false).Eq("slug", slug).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("organization not found")
+	}
+	return &result[0], nil
+}
+
+func (r *organizationRepository) GetAll(ctx context.Context, filters *models.OrganizationFilters) ([]*models.Organization, error) {
+	var result []models.Organization
+	query := r.client.From("organizations").Select("*", "", false)
+
+	if filters.IDs != nil {
+		query = query.In("id", filters.IDs)
+	}
+	query = query.Range(filters.Offset, filters.Offset+filters.Limit-1, "")
+
+	_, err := query.ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	orgs := make([]*models.Organization, len(result))
+	for i := range result {
+		orgs[i] = &result[i]
+	}
+	return orgs, nil
+}
+
+func (r *organizationRepository) GetPublic(ctx context.Context) ([]*models.Organization, error) {
+	var result []models.Organization
+	_, err := r.client.From("organizations").Select("*", "", false).Eq("is_public", "true").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	orgs := make([]*models.Organization, len(result))
+	for i := range result {
+		orgs[i] = &result[i]
+	}
+	return orgs, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, id string, org *models.Organization) error {
+	// Supabase returns an array even for single updates, so we need to handle that
+	var result []models.Organization
+	_, err := r.client.From("organizations").Update(org, "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*org = result[0]
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.From("organizations").Delete("", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
+
+func (r *organizationRepository) Count(ctx context.Context) (int64, error) {
+	var result []models.Organization
+	_, err := r.client.From("organizations").Select("id", "", false).ExecuteTo(&result)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(result)), nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) error {
+	memberData := map[string]interface{}{
+		"organization_id": member.OrganizationID,
+		"user_id":         member.UserID,
+		"role":            member.Role,
+		"created_at":      member.CreatedAt,
+		"updated_at":      member.UpdatedAt,
+	}
+
+	var result []models.OrganizationMember
+	_, err := r.client.From("organization_members").Insert([]map[string]interface{}{memberData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*member = result[0]
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, organizationID, userID string) (*models.OrganizationMember, error) {
+	var result []models.OrganizationMember
+	_, err := r.client.From("organization_members").
+		Select("*", "", false).
+		Eq("organization_id", organizationID).
+		Eq("user_id", userID).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("membership not found")
+	}
+	return &result[0], nil
+}
+
+func (r *organizationRepository) GetMembers(ctx context.Context, organizationID string) ([]*models.OrganizationMember, error) {
+	var result []models.OrganizationMember
+	_, err := r.client.From("organization_members").Select("*", "", false).Eq("organization_id", organizationID).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	members := make([]*models.OrganizationMember, len(result))
+	for i := range result {
+		members[i] = &result[i]
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) GetMembershipsByUser(ctx context.Context, userID string) ([]*models.OrganizationMember, error) {
+	var result []models.OrganizationMember
+	_, err := r.client.From("organization_members").Select("*", "", false).Eq("user_id", userID).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	members := make([]*models.OrganizationMember, len(result))
+	for i := range result {
+		members[i] = &result[i]
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *models.OrganizationMember) error {
+	updateData := map[string]interface{}{
+		"role":       member.Role,
+		"updated_at": member.UpdatedAt,
+	}
+
+	var result []models.OrganizationMember
+	_, err := r.client.From("organization_members").
+		Update(updateData, "", "").
+		Eq("organization_id", member.OrganizationID).
+		Eq("user_id", member.UserID).
+		ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*member = result[0]
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID string) error {
+	_, err := r.client.From("organization_members").
+		Delete("", "").
+		Eq("organization_id", organizationID).
+		Eq("user_id", userID).
+		ExecuteTo(nil)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 
 	"github.com/supabase-community/supabase-go"
 )
@@ -21,13 +22,31 @@ func NewPlayerRepository(client *supabase.Client) interfaces.PlayerRepository {
 }
 
 func (r *playerRepository) Create(ctx context.Context, player *models.Player) error {
-	_, err := r.client.From("players").Insert(player, false, "", "", "").ExecuteTo(&player)
-	return err
+	// Insert without ID so the database generates one
+	playerData := playerRow(player)
+	playerData["created_at"] = player.CreatedAt
+	if player.OrganizationID == "" {
+		delete(playerData, "organization_id")
+	}
+
+	// Supabase returns an array even for single inserts, so we need to handle that
+	var result []models.Player
+	_, err := r.client.From("players").Insert([]map[string]interface{}{playerData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		// Copy the result back to the original player
+		*player = result[0]
+	}
+
+	return nil
 }
 
 func (r *playerRepository) GetByID(ctx context.Context, id string) (*models.Player, error) {
 	var result []models.Player
-	_, err := r.client.From("players").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	_, err := r.client.From("players").Select("*", "", false).Eq("id", id).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +59,7 @@ func (r *playerRepository) GetByID(ctx context.Context, id string) (*models.Play
 func (r *playerRepository) GetAll(ctx context.Context, filters *models.PlayerFilters) ([]*models.Player, error) {
 	var result []models.Player
 	query := r.client.From("players").Select("*", "", false)
+	query = deletedFilter(query, filters != nil && filters.Deleted)
 
 	if filters != nil {
 		if filters.Limit > 0 {
@@ -50,6 +70,12 @@ func (r *playerRepository) GetAll(ctx context.Context, filters *models.PlayerFil
 		if filters.TeamID != nil && *filters.TeamID != "" {
 			query = query.Eq("team_id", *filters.TeamID)
 		}
+		if filters.UserID != nil && *filters.UserID != "" {
+			query = query.Eq("user_id", *filters.UserID)
+		}
+		if filters.OrganizationIDs != nil {
+			query = query.Or(organizationScope(filters.OrganizationIDs, !filters.OnlyOrganizations), "")
+		}
 	}
 
 	_, err := query.ExecuteTo(&result)
@@ -66,8 +92,20 @@ func (r *playerRepository) GetAll(ctx context.Context, filters *models.PlayerFil
 }
 
 func (r *playerRepository) Update(ctx context.Context, id string, player *models.Player) error {
-	_, err := r.client.From("players").Update(player, "", "").Eq("id", id).ExecuteTo(&player)
-	return err
+	// Send every mutable column so cleared profile fields are written as NULL
+	// Supabase returns an array even for single updates, so we need to handle that
+	var result []models.Player
+	_, err := r.client.From("players").Update(playerRow(player), "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		// Copy the result back to the original player
+		*player = result[0]
+	}
+
+	return nil
 }
 
 func (r *playerRepository) Delete(ctx context.Context, id string) error {
@@ -77,7 +115,7 @@ func (r *playerRepository) Delete(ctx context.Context, id string) error {
 
 func (r *playerRepository) GetByTeamID(ctx context.Context, teamID string) ([]*models.Player, error) {
 	var result []models.Player
-	_, err := r.client.From("players").Select("*", "", false).Eq("team_id", teamID).ExecuteTo(&result)
+	_, err := r.client.From("players").Select("*", "", false).Eq("team_id", teamID).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -92,9 +130,88 @@ func (r *playerRepository) GetByTeamID(ctx context.Context, teamID string) ([]*m
 
 func (r *playerRepository) Count(ctx context.Context) (int64, error) {
 	var result []models.Player
-	_, err := r.client.From("players").Select("*", "", false).ExecuteTo(&result)
+	_, err := r.client.From("players").Select("*", "", false).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return 0, err
 	}
 	return int64(len(result)), nil
 }
+
+func (r *playerRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	return softDeleteWhere(r.client, "players", "id", id, deletedAt)
+}
+
+func (r *playerRepository) Restore(ctx context.Context, id string) error {
+	player, err := r.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return restoreWhere(r.client, "players", "id", id, *player.DeletedAt)
+}
+
+func (r *playerRepository) GetDeletedByID(ctx context.Context, id string) (*models.Player, error) {
+	var result []models.Player
+	_, err := r.client.From("players").Select("*", "", false).Eq("id", id).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("deleted player not found")
+	}
+	return &result[0], nil
+}
+
+func (r *playerRepository) GetDeletedByTeamID(ctx context.Context, teamID string) ([]*models.Player, error) {
+	var result []models.Player
+	_, err := r.client.From("players").Select("*", "", false).Eq("team_id", teamID).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to slice of pointers
+	players := make([]*models.Player, len(result))
+	for i := range result {
+		players[i] = &result[i]
+	}
+	return players, nil
+}
+
+func (r *playerRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return purgeDeletedBefore(r.client, "players", before)
+}
+
+// playerRow maps a player to its mutable columns. Unset profile fields and an
+// unclaimed profile are stored as NULL, as is a claim nobody has requested.
+func playerRow(player *models.Player) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               player.Name,
+		"team_id":            player.TeamID,
+		"organization_id":    nullIfEmpty(player.OrganizationID),
+		"role":               nullIfEmpty(string(player.Role)),
+		"batting_hand":       nullIfEmpty(string(player.BattingHand)),
+		"bowling_arm":        nullIfEmpty(string(player.BowlingArm)),
+		"bowling_style":      nullIfEmpty(string(player.BowlingStyle)),
+		"jersey_number":      nullIfZero(player.JerseyNumber),
+		"nickname":           nullIfEmpty(player.Nickname),
+		"avatar_url":         nullIfEmpty(player.AvatarURL),
+		"user_id":            nullIfEmpty(player.UserID),
+		"claim_requested_by": nullIfEmpty(player.ClaimRequestedBy),
+		"updated_at":         player.UpdatedAt,
+	}
+}
+
+// nullIfEmpty maps an empty string to a NULL column value
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullIfZero maps a zero number to a NULL column value
+func nullIfZero(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,193 @@
+package supabase
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type playerStatsRepository struct {
+	client *supabase.Client
+}
+
+// NewPlayerStatsRepository creates a new player statistics repository
+func NewPlayerStatsRepository(client *supabase.Client) interfaces.PlayerStatsRepository {
+	return &playerStatsRepository{
+		client: client,
+	}
+}
+
+func (r *playerStatsRepository) ReplaceMatchStats(ctx context.Context, matchID string, stats []*models.PlayerMatchStats) error {
+	if err := r.DeleteMatchStats(ctx, matchID); err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, len(stats))
+	for i, row := range stats {
+		rows[i] = map[string]interface{}{
+			"match_id":      matchID,
+			"series_id":     row.SeriesID,
+			"player_id":     row.PlayerID,
+			"player_name":   row.PlayerName,
+			"team_id":       nullIfEmpty(row.TeamID),
+			"batted":        row.Batted,
+			"not_out":       row.NotOut,
+			"runs":          row.Runs,
+			"balls_faced":   row.BallsFaced,
+			"fours":         row.Fours,
+			"sixes":         row.Sixes,
+			"bowled":        row.Bowled,
+			"balls_bowled":  row.BallsBowled,
+			"runs_conceded": row.RunsConceded,
+			"wickets":       row.Wickets,
+			"maidens":       row.Maidens,
+			"catches":       row.Catches,
+			"stumpings":     row.Stumpings,
+			"run_outs":      row.RunOuts,
+			"created_at":    row.CreatedAt,
+		}
+	}
+
+	_, err := r.client.From("player_match_stats").Insert(rows, false, "", "", "").ExecuteTo(nil)
+	return err
+}
+
+func (r *playerStatsRepository) GetMatchStatsByMatchID(ctx context.Context, matchID string) ([]*models.PlayerMatchStats, error) {
+	var result []models.PlayerMatchStats
+	_, err := r.client.From("player_match_stats").
+		Select("*", "", false).
+		Eq("match_id", matchID).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMatchStatsPointers(result), nil
+}
+
+func (r *playerStatsRepository) GetMatchStatsByPlayer(ctx context.Context, playerID string) ([]*models.PlayerMatchStats, error) {
+	var result []models.PlayerMatchStats
+	_, err := r.client.From("player_match_stats").
+		Select("*", "", false).
+		Eq("player_id", playerID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMatchStatsPointers(result), nil
+}
+
+func (r *playerStatsRepository) DeleteMatchStats(ctx context.Context, matchID string) error {
+	_, err := r.client.From("player_match_stats").Delete("", "").Eq("match_id", matchID).ExecuteTo(nil)
+	return err
+}
+
+func (r *playerStatsRepository) ReplacePlayerStats(ctx context.Context, playerID string, stats []*models.PlayerStats) error {
+	_, err := r.client.From("player_stats").Delete("", "").Eq("player_id", playerID).ExecuteTo(nil)
+	if err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	// Derived rates are computed on read, so only the counts are stored
+	rows := make([]map[string]interface{}, len(stats))
+	for i, row := range stats {
+		rows[i] = map[string]interface{}{
+			"player_id":          playerID,
+			"player_name":        row.PlayerName,
+			"series_id":          nullIfEmpty(row.SeriesID), // NULL for the career row
+			"matches":            row.Matches,
+			"innings":            row.Innings,
+			"not_outs":           row.NotOuts,
+			"runs":               row.Runs,
+			"balls_faced":        row.BallsFaced,
+			"high_score":         row.HighScore,
+			"high_score_not_out": row.HighScoreNotOut,
+			"fifties":            row.Fifties,
+			"hundreds":           row.Hundreds,
+			"ducks":              row.Ducks,
+			"fours":              row.Fours,
+			"sixes":              row.Sixes,
+			"bowling_innings":    row.BowlingInnings,
+			"balls_bowled":       row.BallsBowled,
+			"runs_conceded":      row.RunsConceded,
+			"wickets":            row.Wickets,
+			"maidens":            row.Maidens,
+			"best_wickets":       row.BestWickets,
+			"best_runs":          row.BestRuns,
+			"three_wicket_hauls": row.ThreeWicketHauls,
+			"five_wicket_hauls":  row.FiveWicketHauls,
+			"catches":            row.Catches,
+			"stumpings":          row.Stumpings,
+			"run_outs":           row.RunOuts,
+			"updated_at":         row.UpdatedAt,
+		}
+	}
+
+	_, err = r.client.From("player_stats").Insert(rows, false, "", "", "").ExecuteTo(nil)
+	return err
+}
+
+func (r *playerStatsRepository) GetPlayerStats(ctx context.Context, playerID string) ([]*models.PlayerStats, error) {
+	var result []models.PlayerStats
+	_, err := r.client.From("player_stats").
+		Select("*", "", false).
+		Eq("player_id", playerID).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPlayerStatsPointers(result), nil
+}
+
+func (r *playerStatsRepository) GetSeriesStats(ctx context.Context, seriesID string) ([]*models.PlayerStats, error) {
+	var result []models.PlayerStats
+	_, err := r.client.From("player_stats").
+		Select("*", "", false).
+		Eq("series_id", seriesID).
+		Order("runs", &postgrest.OrderOpts{Ascending: false}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPlayerStatsPointers(result), nil
+}
+
+func (r *playerStatsRepository) DeleteAll(ctx context.Context) error {
+	// PostgREST refuses unfiltered deletes, so match every row explicitly
+	_, err := r.client.From("player_match_stats").Delete("", "").Not("id", "is", "null").ExecuteTo(nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.From("player_stats").Delete("", "").Not("id", "is", "null").ExecuteTo(nil)
+	return err
+}
+
+func toMatchStatsPointers(result []models.PlayerMatchStats) []*models.PlayerMatchStats {
+	stats := make([]*models.PlayerMatchStats, len(result))
+	for i := range result {
+		stats[i] = &result[i]
+	}
+	return stats
+}
+
+func toPlayerStatsPointers(result []models.PlayerStats) []*models.PlayerStats {
+	stats := make([]*models.PlayerStats, len(result))
+	for i := range result {
+		stats[i] = &result[i]
+	}
+	return stats
+}
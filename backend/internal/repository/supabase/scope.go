@@ -0,0 +1,20 @@
+package supabase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// organizationScope builds a PostgREST "or" filter that matches rows owned by
+// one of the given organizations. With includeUnowned it also matches rows
+// with no organization, which predate tenancy and stay visible to everyone.
+func organizationScope(organizationIDs []string, includeUnowned bool) string {
+	filters := []string{}
+	if len(organizationIDs) > 0 {
+		filters = append(filters, fmt.Sprintf("organization_id.in.(%s)", strings.Join(organizationIDs, ",")))
+	}
+	if includeUnowned || len(organizationIDs) == 0 {
+		filters = append(filters, "organization_id.is.null")
+	}
+	return strings.Join(filters, ",")
+}
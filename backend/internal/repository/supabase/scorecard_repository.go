@@ -69,6 +69,7 @@ func (r *scorecardRepository) GetInningsByMatchID(ctx context.Context, matchID s
 	_, err := r.client.From("innings").
 		Select("*", "", false).
 		Eq("match_id", matchID).
+		Is("deleted_at", "null").
 		ExecuteTo(&innings)
 
 	if err != nil {
@@ -88,6 +89,7 @@ func (r *scorecardRepository) GetInningsByMatchAndNumber(ctx context.Context, ma
 	_, err := r.client.From("innings").
 		Select("*", "", false).
 		Eq("match_id", matchID).
+		Is("deleted_at", "null").
 		Eq("innings_number", fmt.Sprintf("%d", inningsNumber)).
 		ExecuteTo(&innings)
 
@@ -325,6 +327,18 @@ func (r *scorecardRepository) CreateBall(ctx context.Context, ball *models.Score
 		data["wicket_type"] = ball.WicketType
 	}
 
+	// Players are only recorded when the scorer provides them
+	for column, playerID := range map[string]string{
+		"batter_id":           ball.BatterID,
+		"bowler_id":           ball.BowlerID,
+		"fielder_id":          ball.FielderID,
+		"dismissed_player_id": ball.DismissedPlayerID,
+	} {
+		if playerID != "" {
+			data[column] = playerID
+		}
+	}
+
 	var result []models.ScorecardBall
 	_, err := r.client.From(r.getTableName("balls")).Insert(data, false, "", "", "").ExecuteTo(&result)
 	if err != nil {
@@ -436,6 +450,7 @@ func (r *scorecardRepository) GetScorecard(ctx context.Context, matchID string)
 	_, err := r.client.From("matches").
 		Select("*, series(name)", "", false).
 		Eq("id", matchID).
+		Is("deleted_at", "null").
 		ExecuteTo(&matches)
 
 	if err != nil {
@@ -494,6 +509,11 @@ func (r *scorecardRepository) GetScorecard(ctx context.Context, matchID string)
 					Byes:       ball.Byes,
 					IsWicket:   ball.IsWicket,
 					WicketType: ball.WicketType,
+
+					BatterID:          ball.BatterID,
+					BowlerID:          ball.BowlerID,
+					FielderID:         ball.FielderID,
+					DismissedPlayerID: ball.DismissedPlayerID,
 				})
 
 				// Calculate extras
@@ -571,12 +591,36 @@ func (r *scorecardRepository) GetScorecard(ctx context.Context, matchID string)
 		}
 	}
 
+	// Get team names, falling back to the side letters for matches created
+	// before teams were linked
+	teamA, teamB := "Team A", "Team B"
+	if match.TeamAID != "" || match.TeamBID != "" {
+		var teams []*models.Team
+		_, err := r.client.From("teams").
+			Select("id,name", "", false).
+			In("id", []string{match.TeamAID, match.TeamBID}).
+			ExecuteTo(&teams)
+
+		if err == nil {
+			for _, team := range teams {
+				switch team.ID {
+				case match.TeamAID:
+					teamA = team.Name
+				case match.TeamBID:
+					teamB = team.Name
+				}
+			}
+		}
+	}
+
 	scorecard := &models.ScorecardResponse{
 		MatchID:        matchID,
 		MatchNumber:    match.MatchNumber,
 		SeriesName:     seriesName,
-		TeamA:          "Team A",
-		TeamB:          "Team B",
+		TeamAID:        match.TeamAID,
+		TeamBID:        match.TeamBID,
+		TeamA:          teamA,
+		TeamB:          teamB,
 		TotalOvers:     match.TotalOvers,
 		TossWinner:     match.TossWinner,
 		TossType:       match.TossType,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 
 	"github.com/supabase-community/supabase-go"
 )
@@ -38,7 +39,7 @@ func (r *seriesRepository) Create(ctx context.Context, series *models.Series) er
 
 func (r *seriesRepository) GetByID(ctx context.Context, id string) (*models.Series, error) {
 	var result []models.Series
-	_, err := r.client.From("series").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	_, err := r.client.From("series").Select("*", "", false).Eq("id", id).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +54,15 @@ func (r *seriesRepository) GetAll(ctx context.Context, filters *models.SeriesFil
 
 	var result []models.Series
 	query := r.client.From("series").Select("*", "", false)
+	query = deletedFilter(query, filters != nil && filters.Deleted)
 
 	if filters != nil {
+		if filters.CreatedBy != nil {
+			query = query.Eq("created_by", *filters.CreatedBy)
+		}
+		if filters.OrganizationIDs != nil {
+			query = query.Or(organizationScope(filters.OrganizationIDs, !filters.OnlyOrganizations), "")
+		}
 		if filters.Limit > 0 {
 			query = query.Limit(filters.Limit, "")
 		}
@@ -108,9 +116,37 @@ func (r *seriesRepository) Delete(ctx context.Context, id string) error {
 
 func (r *seriesRepository) Count(ctx context.Context) (int64, error) {
 	var result []models.Series
-	_, err := r.client.From("series").Select("*", "", false).ExecuteTo(&result)
+	_, err := r.client.From("series").Select("*", "", false).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return 0, err
 	}
 	return int64(len(result)), nil
 }
+
+func (r *seriesRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	return softDeleteWhere(r.client, "series", "id", id, deletedAt)
+}
+
+func (r *seriesRepository) Restore(ctx context.Context, id string) error {
+	series, err := r.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return restoreWhere(r.client, "series", "id", id, *series.DeletedAt)
+}
+
+func (r *seriesRepository) GetDeletedByID(ctx context.Context, id string) (*models.Series, error) {
+	var result []models.Series
+	_, err := r.client.From("series").Select("*", "", false).Eq("id", id).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("deleted series not found")
+	}
+	return &result[0], nil
+}
+
+func (r *seriesRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return purgeDeletedBefore(r.client, "series", before)
+}
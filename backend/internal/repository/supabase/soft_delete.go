@@ -0,0 +1,50 @@
+package supabase
+
+import (
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+// deletedAtValue formats a soft-delete timestamp for PostgREST filters and updates
+func deletedAtValue(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// deletedFilter restricts a query to live rows, or to soft-deleted rows when deleted is set
+func deletedFilter(query *postgrest.FilterBuilder, deleted bool) *postgrest.FilterBuilder {
+	if deleted {
+		return query.Not("deleted_at", "is", "null")
+	}
+	return query.Is("deleted_at", "null")
+}
+
+// softDeleteWhere marks live rows where column equals value as deleted at deletedAt
+func softDeleteWhere(client *supabase.Client, table, column, value string, deletedAt time.Time) error {
+	data := map[string]interface{}{"deleted_at": deletedAtValue(deletedAt)}
+	_, _, err := client.From(table).Update(data, "minimal", "").Eq(column, value).Is("deleted_at", "null").Execute()
+	return err
+}
+
+// restoreWhere clears deleted_at on rows where column equals value that were
+// deleted at deletedAt. Matching on the timestamp restores only rows removed by
+// the same cascade, leaving rows that were deleted separately in the trash.
+func restoreWhere(client *supabase.Client, table, column, value string, deletedAt time.Time) error {
+	data := map[string]interface{}{"deleted_at": nil}
+	_, _, err := client.From(table).Update(data, "minimal", "").Eq(column, value).Eq("deleted_at", deletedAtValue(deletedAt)).Execute()
+	return err
+}
+
+// purgeDeletedBefore permanently removes rows soft-deleted before the cutoff and
+// returns how many were removed. Child rows go with them via ON DELETE CASCADE.
+func purgeDeletedBefore(client *supabase.Client, table string, before time.Time) (int, error) {
+	var result []struct {
+		ID string `json:"id"`
+	}
+	_, err := client.From(table).Delete("representation", "").Lt("deleted_at", deletedAtValue(before)).ExecuteTo(&result)
+	if err != nil {
+		return 0, err
+	}
+	return len(result), nil
+}
@@ -0,0 +1,107 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type stageRepository struct {
+	client *supabase.Client
+}
+
+// NewStageRepository creates a new series stage repository
+func NewStageRepository(client *supabase.Client) interfaces.StageRepository {
+	return &stageRepository{
+		client: client,
+	}
+}
+
+func (r *stageRepository) Create(ctx context.Context, stage *models.SeriesStage) error {
+	stageData := map[string]interface{}{
+		"series_id":    stage.SeriesID,
+		"name":         stage.Name,
+		"position":     stage.Position,
+		"stage_type":   stage.Type,
+		"groups":       stage.Groups,
+		"tie_breakers": stage.TieBreakers,
+		"bracket":      stage.Bracket,
+		"created_by":   stage.CreatedBy,
+		"created_at":   stage.CreatedAt,
+		"updated_at":   stage.UpdatedAt,
+	}
+
+	var result []models.SeriesStage
+	_, err := r.client.From("series_stages").Insert([]map[string]interface{}{stageData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*stage = result[0]
+	}
+
+	return nil
+}
+
+func (r *stageRepository) GetByID(ctx context.Context, id string) (*models.SeriesStage, error) {
+	var result []models.SeriesStage
+	_, err := r.client.From("series_stages").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("stage not found")
+	}
+	return &result[0], nil
+}
+
+func (r *stageRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.SeriesStage, error) {
+	var result []models.SeriesStage
+	_, err := r.client.From("series_stages").
+		Select("*", "", false).
+		Eq("series_id", seriesID).
+		Order("position", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]*models.SeriesStage, len(result))
+	for i := range result {
+		stages[i] = &result[i]
+	}
+	return stages, nil
+}
+
+func (r *stageRepository) Update(ctx context.Context, id string, stage *models.SeriesStage) error {
+	stageData := map[string]interface{}{
+		"name":         stage.Name,
+		"position":     stage.Position,
+		"groups":       stage.Groups,
+		"tie_breakers": stage.TieBreakers,
+		"bracket":      stage.Bracket,
+		"updated_at":   stage.UpdatedAt,
+	}
+
+	var result []models.SeriesStage
+	_, err := r.client.From("series_stages").Update(stageData, "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*stage = result[0]
+	}
+
+	return nil
+}
+
+func (r *stageRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.From("series_stages").Delete("", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
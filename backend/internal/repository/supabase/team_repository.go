@@ -6,6 +6,7 @@ import (
 	"log"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
 
 	"github.com/supabase-community/supabase-go"
 )
@@ -31,6 +32,12 @@ func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
 		"created_at":    team.CreatedAt,
 		"updated_at":    team.UpdatedAt,
 	}
+	if team.OrganizationID != "" {
+		teamData["organization_id"] = team.OrganizationID
+	}
+	if team.CreatedBy != "" {
+		teamData["created_by"] = team.CreatedBy
+	}
 
 	log.Printf("DEBUG: Created teamData map: %+v", teamData)
 
@@ -61,7 +68,7 @@ func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
 
 func (r *teamRepository) GetByID(ctx context.Context, id string) (*models.Team, error) {
 	var result []models.Team
-	_, err := r.client.From("teams").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	_, err := r.client.From("teams").Select("*", "", false).Eq("id", id).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +81,15 @@ func (r *teamRepository) GetByID(ctx context.Context, id string) (*models.Team,
 func (r *teamRepository) GetAll(ctx context.Context, filters *models.TeamFilters) ([]*models.Team, error) {
 	var result []models.Team
 	query := r.client.From("teams").Select("*", "", false)
+	query = deletedFilter(query, filters != nil && filters.Deleted)
 
 	if filters != nil {
+		if filters.OrganizationIDs != nil {
+			query = query.Or(organizationScope(filters.OrganizationIDs, !filters.OnlyOrganizations), "")
+		}
+		if filters.CreatedBy != nil && *filters.CreatedBy != "" {
+			query = query.Eq("created_by", *filters.CreatedBy)
+		}
 		if filters.Limit > 0 {
 			query = query.Limit(filters.Limit, "")
 		}
@@ -112,6 +126,15 @@ func (r *teamRepository) Update(ctx context.Context, id string, team *models.Tea
 	return nil
 }
 
+func (r *teamRepository) UpdatePlayersCount(ctx context.Context, id string, count int) error {
+	data := map[string]interface{}{
+		"players_count": count,
+		"updated_at":    time.Now(),
+	}
+	_, _, err := r.client.From("teams").Update(data, "minimal", "").Eq("id", id).Execute()
+	return err
+}
+
 func (r *teamRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.client.From("teams").Delete("", "").Eq("id", id).ExecuteTo(nil)
 	return err
@@ -119,9 +142,37 @@ func (r *teamRepository) Delete(ctx context.Context, id string) error {
 
 func (r *teamRepository) Count(ctx context.Context) (int64, error) {
 	var result []models.Team
-	_, err := r.client.From("teams").Select("*", "", false).ExecuteTo(&result)
+	_, err := r.client.From("teams").Select("*", "", false).Is("deleted_at", "null").ExecuteTo(&result)
 	if err != nil {
 		return 0, err
 	}
 	return int64(len(result)), nil
 }
+
+func (r *teamRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	return softDeleteWhere(r.client, "teams", "id", id, deletedAt)
+}
+
+func (r *teamRepository) Restore(ctx context.Context, id string) error {
+	team, err := r.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return restoreWhere(r.client, "teams", "id", id, *team.DeletedAt)
+}
+
+func (r *teamRepository) GetDeletedByID(ctx context.Context, id string) (*models.Team, error) {
+	var result []models.Team
+	_, err := r.client.From("teams").Select("*", "", false).Eq("id", id).Not("deleted_at", "is", "null").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("deleted team not found")
+	}
+	return &result[0], nil
+}
+
+func (r *teamRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	return purgeDeletedBefore(r.client, "teams", before)
+}
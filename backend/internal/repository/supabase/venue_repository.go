@@ -0,0 +1,115 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type venueRepository struct {
+	client *supabase.Client
+}
+
+// NewVenueRepository creates a new venue repository
+func NewVenueRepository(client *supabase.Client) interfaces.VenueRepository {
+	return &venueRepository{
+		client: client,
+	}
+}
+
+func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	venueData := map[string]interface{}{
+		"name":           venue.Name,
+		"address":        nullIfEmpty(venue.Address),
+		"latitude":       venue.Latitude,
+		"longitude":      venue.Longitude,
+		"boundary_notes": nullIfEmpty(venue.BoundaryNotes),
+		"pitches":        venue.Pitches,
+		"created_by":     venue.CreatedBy,
+		"created_at":     venue.CreatedAt,
+		"updated_at":     venue.UpdatedAt,
+	}
+	if venue.OrganizationID != "" {
+		venueData["organization_id"] = venue.OrganizationID
+	}
+
+	var result []models.Venue
+	_, err := r.client.From("venues").Insert([]map[string]interface{}{venueData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*venue = result[0]
+	}
+
+	return nil
+}
+
+func (r *venueRepository) GetByID(ctx context.Context, id string) (*models.Venue, error) {
+	var result []models.Venue
+	_, err := r.client.From("venues").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("venue not found")
+	}
+	return &result[0], nil
+}
+
+func (r *venueRepository) GetAll(ctx context.Context, filters *models.VenueFilters) ([]*models.Venue, error) {
+	var result []models.Venue
+	query := r.client.From("venues").Select("*", "", false)
+
+	if filters.OrganizationIDs != nil {
+		query = query.Or(organizationScope(filters.OrganizationIDs, !filters.OnlyOrganizations), "")
+	}
+	query = query.Order("name", &postgrest.OrderOpts{Ascending: true})
+	query = query.Range(filters.Offset, filters.Offset+filters.Limit-1, "")
+
+	_, err := query.ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	venues := make([]*models.Venue, len(result))
+	for i := range result {
+		venues[i] = &result[i]
+	}
+	return venues, nil
+}
+
+func (r *venueRepository) Update(ctx context.Context, id string, venue *models.Venue) error {
+	venueData := map[string]interface{}{
+		"name":           venue.Name,
+		"address":        nullIfEmpty(venue.Address),
+		"latitude":       venue.Latitude,
+		"longitude":      venue.Longitude,
+		"boundary_notes": nullIfEmpty(venue.BoundaryNotes),
+		"pitches":        venue.Pitches,
+		"updated_at":     venue.UpdatedAt,
+	}
+
+	var result []models.Venue
+	_, err := r.client.From("venues").Update(venueData, "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*venue = result[0]
+	}
+
+	return nil
+}
+
+// Delete removes a venue; its matches keep their venue name but lose the booking
+func (r *venueRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.From("venues").Delete("", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
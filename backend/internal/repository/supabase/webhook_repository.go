@@ -0,0 +1,220 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+type webhookRepository struct {
+	client *supabase.Client
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(client *supabase.Client) interfaces.WebhookRepository {
+	return &webhookRepository{
+		client: client,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	webhookData := webhookRow(webhook)
+	webhookData["series_id"] = webhook.SeriesID
+	webhookData["secret"] = webhook.Secret
+	webhookData["created_by"] = webhook.CreatedBy
+	webhookData["created_at"] = webhook.CreatedAt
+
+	var result []models.Webhook
+	_, err := r.client.From("webhooks").Insert([]map[string]interface{}{webhookData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*webhook = result[0]
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	var result []models.Webhook
+	_, err := r.client.From("webhooks").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("webhook not found")
+	}
+	return &result[0], nil
+}
+
+func (r *webhookRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Webhook, error) {
+	var result []models.Webhook
+	_, err := r.client.From("webhooks").
+		Select("*", "", false).
+		Eq("series_id", seriesID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*models.Webhook, len(result))
+	for i := range result {
+		webhooks[i] = &result[i]
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, id string, webhook *models.Webhook) error {
+	var result []models.Webhook
+	_, err := r.client.From("webhooks").Update(webhookRow(webhook), "", "").Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*webhook = result[0]
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.From("webhooks").Delete("", "").Eq("id", id).ExecuteTo(nil)
+	return err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	deliveryData := deliveryRow(delivery)
+	deliveryData["webhook_id"] = delivery.WebhookID
+	deliveryData["series_id"] = delivery.SeriesID
+	deliveryData["event_type"] = delivery.EventType
+	deliveryData["payload"] = delivery.Payload
+	deliveryData["created_at"] = delivery.CreatedAt
+
+	var result []models.WebhookDelivery
+	_, err := r.client.From("webhook_deliveries").Insert([]map[string]interface{}{deliveryData}, false, "", "", "").ExecuteTo(&result)
+	if err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*delivery = result[0]
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var result []models.WebhookDelivery
+	_, err := r.client.From("webhook_deliveries").Select("*", "", false).Eq("id", id).ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("delivery not found")
+	}
+	return &result[0], nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, id string, delivery *models.WebhookDelivery) error {
+	var result []models.WebhookDelivery
+	_, err := r.client.From("webhook_deliveries").Update(deliveryRow(delivery), "", "").Eq("id", id).ExecuteTo(&result)
+	return err
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, webhookID string, status models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	query := r.client.From("webhook_deliveries").Select("*", "", false).Eq("webhook_id", webhookID)
+	if status != "" {
+		query = query.Eq("status", string(status))
+	}
+
+	var result []models.WebhookDelivery
+	_, err := query.Order("created_at", &postgrest.OrderOpts{Ascending: false}).Limit(limit, "").ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	return deliveryPointers(result), nil
+}
+
+func (r *webhookRepository) GetUnfinishedDeliveries(ctx context.Context, limit int) ([]*models.WebhookDelivery, error) {
+	var result []models.WebhookDelivery
+	_, err := r.client.From("webhook_deliveries").
+		Select("*", "", false).
+		In("status", []string{string(models.WebhookDeliveryPending), string(models.WebhookDeliveryRetrying)}).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, "").
+		ExecuteTo(&result)
+	if err != nil {
+		return nil, err
+	}
+	return deliveryPointers(result), nil
+}
+
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error) {
+	// The conditions and the lease are one UPDATE, so of several instances
+	// claiming the same delivery only one gets the row back
+	query := r.client.From("webhook_deliveries").
+		Update(map[string]interface{}{"claimed_until": until}, "", "").
+		Eq("id", delivery.ID).
+		Eq("status", string(delivery.Status)).
+		Or(fmt.Sprintf(`claimed_until.is.null,claimed_until.lt."%s"`, time.Now().UTC().Format(time.RFC3339Nano)), "")
+	if delivery.NextAttemptAt != nil {
+		query = query.Eq("next_attempt_at", delivery.NextAttemptAt.UTC().Format(time.RFC3339Nano))
+	} else {
+		query = query.Is("next_attempt_at", "null")
+	}
+
+	var result []models.WebhookDelivery
+	if _, err := query.ExecuteTo(&result); err != nil {
+		return false, err
+	}
+	if len(result) == 0 {
+		return false, nil
+	}
+	*delivery = result[0]
+	return true, nil
+}
+
+// webhookRow maps the columns a webhook write sets
+func webhookRow(webhook *models.Webhook) map[string]interface{} {
+	eventTypes := webhook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return map[string]interface{}{
+		"url":         webhook.URL,
+		"event_types": eventTypes,
+		"active":      webhook.Active,
+		"updated_at":  webhook.UpdatedAt,
+	}
+}
+
+// deliveryRow maps the columns an attempt updates
+func deliveryRow(delivery *models.WebhookDelivery) map[string]interface{} {
+	return map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"response_status": nullIfZero(delivery.ResponseStatus),
+		"last_error":      nullIfEmpty(delivery.LastError),
+		"next_attempt_at": delivery.NextAttemptAt,
+		"delivered_at":    delivery.DeliveredAt,
+		"claimed_until":   delivery.ClaimedUntil,
+		"updated_at":      delivery.UpdatedAt,
+	}
+}
+
+func deliveryPointers(result []models.WebhookDelivery) []*models.WebhookDelivery {
+	deliveries := make([]*models.WebhookDelivery, len(result))
+	for i := range result {
+		deliveries[i] = &result[i]
+	}
+	return deliveries
+}
@@ -0,0 +1,89 @@
+package seed
+
+import (
+	"math/rand"
+	"spark-park-cricket-backend/internal/models"
+)
+
+// wicketTypes are the dismissals a generated wicket can be recorded as
+var wicketTypes = []string{"bowled", "caught", "caught", "caught", "lbw", "run_out", "stumped"}
+
+// BallGenerator produces random but reproducible ball events
+type BallGenerator struct {
+	rng        *rand.Rand
+	tendencies Tendencies
+	extras     Extras
+}
+
+// NewBallGenerator creates a ball generator seeded with the given value
+func NewBallGenerator(seed int64, tendencies Tendencies, extras Extras) *BallGenerator {
+	return &BallGenerator{
+		rng:        rand.New(rand.NewSource(seed)),
+		tendencies: tendencies,
+		extras:     extras,
+	}
+}
+
+// Next returns the next ball event for the given match and innings
+func (g *BallGenerator) Next(matchID string, inningsNumber int) *models.BallEventRequest {
+	req := &models.BallEventRequest{
+		MatchID:       matchID,
+		InningsNumber: inningsNumber,
+		BallType:      models.BallTypeGood,
+	}
+
+	// Decide whether this delivery is an extra
+	roll := g.rng.Float64()
+	switch {
+	case roll < g.extras.WideRate:
+		req.BallType = models.BallTypeWide
+		req.RunType = models.RunTypeWD
+		// Occasionally the wide runs away to the boundary
+		if g.rng.Intn(10) == 0 {
+			req.Byes = 4
+		}
+		return req
+	case roll < g.extras.WideRate+g.extras.NoBallRate:
+		req.BallType = models.BallTypeNoBall
+		req.RunType = models.RunTypeNB
+		return req
+	case roll < g.extras.WideRate+g.extras.NoBallRate+g.extras.LegByeRate:
+		req.RunType = models.RunTypeLB
+		return req
+	case roll < g.extras.WideRate+g.extras.NoBallRate+g.extras.LegByeRate+g.extras.ByeRate:
+		req.RunType = models.RunTypeZero
+		req.Byes = 1 + g.rng.Intn(4)
+		return req
+	}
+
+	// Legal delivery - pick an outcome from the weighted tendencies
+	t := g.tendencies
+	pick := g.rng.Intn(t.total())
+	outcomes := []struct {
+		weight  int
+		runType models.RunType
+		wicket  bool
+	}{
+		{t.Dot, models.RunTypeZero, false},
+		{t.Single, models.RunTypeOne, false},
+		{t.Double, models.RunTypeTwo, false},
+		{t.Triple, models.RunTypeThree, false},
+		{t.Four, models.RunTypeFour, false},
+		{t.Six, models.RunTypeSix, false},
+		{t.Wicket, models.RunTypeWC, true},
+	}
+	for _, outcome := range outcomes {
+		if pick < outcome.weight {
+			req.RunType = outcome.runType
+			if outcome.wicket {
+				req.IsWicket = true
+				req.WicketType = wicketTypes[g.rng.Intn(len(wicketTypes))]
+			}
+			return req
+		}
+		pick -= outcome.weight
+	}
+
+	req.RunType = models.RunTypeZero
+	return req
+}
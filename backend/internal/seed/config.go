@@ -0,0 +1,136 @@
+package seed
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format describes the shape of a generated match
+type Format struct {
+	Name           string `json:"name"`
+	Overs          int    `json:"overs"`
+	PlayersPerSide int    `json:"players_per_side"`
+}
+
+// Predefined match formats
+var (
+	FormatT20     = Format{Name: "t20", Overs: 20, PlayersPerSide: 11}
+	FormatT10     = Format{Name: "t10", Overs: 10, PlayersPerSide: 11}
+	FormatSixes   = Format{Name: "sixes", Overs: 5, PlayersPerSide: 6}
+	FormatBoxGame = Format{Name: "box", Overs: 3, PlayersPerSide: 4}
+)
+
+// FormatByName returns a predefined format by its name
+func FormatByName(name string) (Format, error) {
+	switch name {
+	case FormatT20.Name:
+		return FormatT20, nil
+	case FormatT10.Name:
+		return FormatT10, nil
+	case FormatSixes.Name:
+		return FormatSixes, nil
+	case FormatBoxGame.Name:
+		return FormatBoxGame, nil
+	default:
+		return Format{}, fmt.Errorf("unknown format: %s", name)
+	}
+}
+
+// Tendencies are relative weights for the outcome of a legal delivery.
+// They do not need to sum to any particular value.
+type Tendencies struct {
+	Dot    int `json:"dot"`
+	Single int `json:"single"`
+	Double int `json:"double"`
+	Triple int `json:"triple"`
+	Four   int `json:"four"`
+	Six    int `json:"six"`
+	Wicket int `json:"wicket"`
+}
+
+// Predefined scoring tendencies
+var (
+	TendenciesBalanced   = Tendencies{Dot: 35, Single: 35, Double: 10, Triple: 2, Four: 10, Six: 4, Wicket: 4}
+	TendenciesAggressive = Tendencies{Dot: 25, Single: 30, Double: 10, Triple: 2, Four: 16, Six: 10, Wicket: 7}
+	TendenciesBowling    = Tendencies{Dot: 50, Single: 30, Double: 6, Triple: 1, Four: 6, Six: 2, Wicket: 5}
+)
+
+// TendenciesByName returns predefined scoring tendencies by name
+func TendenciesByName(name string) (Tendencies, error) {
+	switch name {
+	case "balanced":
+		return TendenciesBalanced, nil
+	case "aggressive":
+		return TendenciesAggressive, nil
+	case "bowling":
+		return TendenciesBowling, nil
+	default:
+		return Tendencies{}, fmt.Errorf("unknown tendencies: %s", name)
+	}
+}
+
+// total returns the sum of all weights
+func (t Tendencies) total() int {
+	return t.Dot + t.Single + t.Double + t.Triple + t.Four + t.Six + t.Wicket
+}
+
+// Extras controls how often extras are bowled, as probabilities in [0, 1]
+type Extras struct {
+	WideRate   float64 `json:"wide_rate"`
+	NoBallRate float64 `json:"no_ball_rate"`
+	LegByeRate float64 `json:"leg_bye_rate"`
+	ByeRate    float64 `json:"bye_rate"`
+}
+
+// DefaultExtras is a typical frequency of extras in club cricket
+var DefaultExtras = Extras{WideRate: 0.04, NoBallRate: 0.01, LegByeRate: 0.02, ByeRate: 0.01}
+
+// Config holds settings for a seed run
+type Config struct {
+	Seed       int64      `json:"seed"`
+	SeriesName string     `json:"series_name"`
+	StartDate  time.Time  `json:"start_date"`
+	Teams      int        `json:"teams"`
+	Matches    int        `json:"matches"`
+	Format     Format     `json:"format"`
+	Tendencies Tendencies `json:"tendencies"`
+	Extras     Extras     `json:"extras"`
+}
+
+// DefaultConfig returns a config for a small T10 series
+func DefaultConfig() *Config {
+	return &Config{
+		Seed:       1,
+		SeriesName: "Synthetic Series",
+		StartDate:  time.Now().Truncate(24 * time.Hour),
+		Teams:      4,
+		Matches:    3,
+		Format:     FormatT10,
+		Tendencies: TendenciesBalanced,
+		Extras:     DefaultExtras,
+	}
+}
+
+// Validate checks that the config can produce complete matches
+func (c *Config) Validate() error {
+	if c.Teams < 2 {
+		return fmt.Errorf("at least 2 teams are required")
+	}
+	if c.Matches < 1 {
+		return fmt.Errorf("at least 1 match is required")
+	}
+	if c.Format.Overs < 1 || c.Format.Overs > 20 {
+		return fmt.Errorf("overs must be between 1 and 20")
+	}
+	if c.Format.PlayersPerSide < 2 || c.Format.PlayersPerSide > 20 {
+		return fmt.Errorf("players per side must be between 2 and 20")
+	}
+	if c.Tendencies.total() <= 0 {
+		return fmt.Errorf("scoring tendencies must have at least one positive weight")
+	}
+	extras := c.Extras.WideRate + c.Extras.NoBallRate + c.Extras.LegByeRate + c.Extras.ByeRate
+	if extras < 0 || extras >= 1 {
+		return fmt.Errorf("combined extras rate must be between 0 and 1")
+	}
+	return nil
+}
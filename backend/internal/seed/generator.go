@@ -0,0 +1,226 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"spark-park-cricket-backend/internal/interfaces"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"time"
+)
+
+var teamNames = []string{
+	"Park Strikers", "Riverside Royals", "Hilltop Hawks", "Lakeside Lions",
+	"Northfield Knights", "Southgate Spartans", "Eastwood Eagles", "Westend Warriors",
+}
+
+var firstNames = []string{
+	"Arjun", "Rohan", "Vikram", "Sanjay", "Kiran", "Aditya", "Rahul", "Dev",
+	"Nikhil", "Amit", "Farhan", "Imran", "Tom", "Sam", "Joe", "Ben",
+}
+
+var lastNames = []string{
+	"Sharma", "Patel", "Reddy", "Iyer", "Khan", "Singh", "Nair", "Rao",
+	"Das", "Mehta", "Smith", "Brown", "Taylor", "Clarke", "Hughes", "Ali",
+}
+
+// MatchResult describes one generated match
+type MatchResult struct {
+	Match     *models.Match             `json:"match"`
+	TeamA     *models.Team              `json:"team_a"`
+	TeamB     *models.Team              `json:"team_b"`
+	Balls     int                       `json:"balls"`
+	Scorecard *models.ScorecardResponse `json:"scorecard"`
+}
+
+// Result describes everything created by a seed run
+type Result struct {
+	Series  *models.Series              `json:"series"`
+	Teams   []*models.Team              `json:"teams"`
+	Players map[string][]*models.Player `json:"players"`
+	Matches []*MatchResult              `json:"matches"`
+}
+
+// Generator creates synthetic series, teams, players and matches through the real services
+type Generator struct {
+	cfg              *Config
+	rng              *rand.Rand
+	balls            *BallGenerator
+	seriesService    *services.SeriesService
+	matchService     *services.MatchService
+	teamService      *services.TeamService
+	scorecardService interfaces.ScorecardServiceInterface
+}
+
+// NewGenerator creates a new synthetic data generator
+func NewGenerator(
+	cfg *Config,
+	seriesService *services.SeriesService,
+	matchService *services.MatchService,
+	teamService *services.TeamService,
+	scorecardService interfaces.ScorecardServiceInterface,
+) *Generator {
+	return &Generator{
+		cfg:              cfg,
+		rng:              rand.New(rand.NewSource(cfg.Seed)),
+		balls:            NewBallGenerator(cfg.Seed, cfg.Tendencies, cfg.Extras),
+		seriesService:    seriesService,
+		matchService:     matchService,
+		teamService:      teamService,
+		scorecardService: scorecardService,
+	}
+}
+
+// Run generates the full data set. The context must carry the user_id that will own the data.
+func (g *Generator) Run(ctx context.Context) (*Result, error) {
+	if err := g.cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid seed config: %w", err)
+	}
+
+	result := &Result{
+		Players: make(map[string][]*models.Player),
+	}
+
+	// Create series spanning one day per match
+	series, err := g.seriesService.CreateSeries(ctx, &models.CreateSeriesRequest{
+		Name:      g.cfg.SeriesName,
+		StartDate: g.cfg.StartDate,
+		EndDate:   g.cfg.StartDate.AddDate(0, 0, g.cfg.Matches),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+	result.Series = series
+	log.Printf("Seed: created series %s (%s)", series.Name, series.ID)
+
+	// Create teams with full rosters
+	for i := 0; i < g.cfg.Teams; i++ {
+		team, players, err := g.createTeam(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		result.Teams = append(result.Teams, team)
+		result.Players[team.ID] = players
+	}
+
+	// Create and play matches, rotating through team pairings
+	for i := 0; i < g.cfg.Matches; i++ {
+		teamA, teamB := g.pickTeams(result.Teams, i)
+		matchResult, err := g.playMatch(ctx, series, i, teamA, teamB)
+		if err != nil {
+			return nil, err
+		}
+		result.Matches = append(result.Matches, matchResult)
+	}
+
+	return result, nil
+}
+
+// createTeam creates a team and its players
+func (g *Generator) createTeam(ctx context.Context, index int) (*models.Team, []*models.Player, error) {
+	name := teamNames[index%len(teamNames)]
+	if index >= len(teamNames) {
+		name = fmt.Sprintf("%s %d", name, index/len(teamNames)+1)
+	}
+
+	team, err := g.teamService.CreateTeam(ctx, &models.CreateTeamRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create team %s: %w", name, err)
+	}
+
+	roster := &models.AddTeamPlayersRequest{
+		Players: make([]models.TeamPlayerRequest, g.cfg.Format.PlayersPerSide),
+	}
+	for i := range roster.Players {
+		roster.Players[i].Name = g.playerName()
+	}
+	players, err := g.teamService.AddPlayersToTeam(ctx, team.ID, roster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add players to team %s: %w", name, err)
+	}
+
+	log.Printf("Seed: created team %s (%s) with %d players", team.Name, team.ID, len(players))
+	return team, players, nil
+}
+
+// playerName returns a random player name
+func (g *Generator) playerName() string {
+	return fmt.Sprintf("%s %s", firstNames[g.rng.Intn(len(firstNames))], lastNames[g.rng.Intn(len(lastNames))])
+}
+
+// pickTeams returns the pairing for the given match index
+func (g *Generator) pickTeams(teams []*models.Team, matchIndex int) (*models.Team, *models.Team) {
+	n := len(teams)
+	first := matchIndex % n
+	offset := 1 + (matchIndex/n)%(n-1)
+	return teams[first], teams[(first+offset)%n]
+}
+
+// playMatch creates a match and scores it ball by ball until it completes
+func (g *Generator) playMatch(ctx context.Context, series *models.Series, index int, teamA, teamB *models.Team) (*MatchResult, error) {
+	tossWinner := models.TeamTypeA
+	if g.rng.Intn(2) == 1 {
+		tossWinner = models.TeamTypeB
+	}
+	tossType := models.TossTypeHeads
+	if g.rng.Intn(2) == 1 {
+		tossType = models.TossTypeTails
+	}
+
+	match, err := g.matchService.CreateMatch(ctx, &models.CreateMatchRequest{
+		SeriesID:         series.ID,
+		TeamAID:          teamA.ID,
+		TeamBID:          teamB.ID,
+		Date:             g.cfg.StartDate.AddDate(0, 0, index).Add(10 * time.Hour),
+		TeamAPlayerCount: g.cfg.Format.PlayersPerSide,
+		TeamBPlayerCount: g.cfg.Format.PlayersPerSide,
+		TotalOvers:       g.cfg.Format.Overs,
+		TossWinner:       tossWinner,
+		TossType:         tossType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create match: %w", err)
+	}
+	log.Printf("Seed: created match %d (%s): %s vs %s", match.MatchNumber, match.ID, teamA.Name, teamB.Name)
+
+	if err := g.scorecardService.StartScoring(ctx, match.ID); err != nil {
+		return nil, fmt.Errorf("failed to start scoring for match %s: %w", match.ID, err)
+	}
+
+	// Guard against a runaway loop; extras are rare so this is a generous upper bound
+	maxBalls := 2 * g.cfg.Format.Overs * 6 * 4
+
+	balls := 0
+	inningsNumber := 1
+	for {
+		if balls >= maxBalls {
+			return nil, fmt.Errorf("match %s did not complete after %d balls", match.ID, balls)
+		}
+
+		if err := g.scorecardService.AddBall(ctx, g.balls.Next(match.ID, inningsNumber)); err != nil {
+			return nil, fmt.Errorf("failed to add ball %d for match %s: %w", balls+1, match.ID, err)
+		}
+		balls++
+
+		scorecard, err := g.scorecardService.GetScorecard(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scorecard for match %s: %w", match.ID, err)
+		}
+		if scorecard.MatchStatus == string(models.MatchStatusCompleted) {
+			log.Printf("Seed: match %s completed after %d balls", match.ID, balls)
+			match.Status = models.MatchStatusCompleted
+			return &MatchResult{
+				Match:     match,
+				TeamA:     teamA,
+				TeamB:     teamB,
+				Balls:     balls,
+				Scorecard: scorecard,
+			}, nil
+		}
+		inningsNumber = scorecard.CurrentInnings
+	}
+}
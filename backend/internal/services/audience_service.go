@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"spark-park-cricket-backend/pkg/websocket"
+	"sync"
+	"time"
+)
+
+// Audience tracking bounds
+const (
+	audienceIdleTTL     = 6 * time.Hour // Matches without viewers this long stop being tracked
+	maxAudienceTimeline = 24 * 60       // Minutes of timeline kept per match
+)
+
+// AudienceService keeps the live audience of each match from the hub's
+// presence samples, and saves it when the match completes so organisers can
+// see how many followed it.
+type AudienceService struct {
+	audienceRepo interfaces.AudienceRepository
+	matchRepo    interfaces.MatchRepository
+	orgs         *OrganizationService
+
+	mutex   sync.Mutex
+	matches map[string]*audienceTracker
+}
+
+// audienceTracker accumulates the viewer counts sampled for one match
+type audienceTracker struct {
+	viewers    int       // At the last sample
+	first      time.Time // First sample with viewers
+	last       time.Time
+	idleSince  time.Time // When viewers last dropped to none
+	peak       int
+	peakAt     time.Time
+	viewerTime float64 // Viewer-seconds from first to last
+	timeline   []models.AudiencePoint
+}
+
+// NewAudienceService creates a new audience service
+func NewAudienceService(audienceRepo interfaces.AudienceRepository, matchRepo interfaces.MatchRepository) *AudienceService {
+	return &AudienceService{
+		audienceRepo: audienceRepo,
+		matchRepo:    matchRepo,
+		matches:      make(map[string]*audienceTracker),
+	}
+}
+
+// SetOrganizationService enables hiding the audiences of matches in
+// organizations the caller cannot see
+func (s *AudienceService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// RecordViewers takes a presence sample from the hub: the viewers of every
+// room with any. Match rooms not in it have lost their viewers; series rooms
+// are ignored.
+func (s *AudienceService) RecordViewers(at time.Time, viewers map[string]int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for roomID, count := range viewers {
+		if _, isSeries := websocket.SeriesIDFromRoom(roomID); isSeries || count <= 0 {
+			continue
+		}
+		if s.matches[roomID] == nil {
+			s.matches[roomID] = &audienceTracker{first: at, last: at}
+		}
+	}
+
+	for matchID, tracker := range s.matches {
+		tracker.observe(at, viewers[matchID])
+		if tracker.viewers == 0 && at.Sub(tracker.idleSince) > audienceIdleTTL {
+			delete(s.matches, matchID)
+		}
+	}
+}
+
+// MatchCompleted saves a match's audience up to its completion; a match
+// nobody followed is saved with no viewers. Viewers after completion keep
+// being counted, and are saved should the match be reopened and completed
+// again.
+func (s *AudienceService) MatchCompleted(ctx context.Context, matchID string) error {
+	if s == nil {
+		return nil
+	}
+
+	audience := s.liveAudience(matchID)
+	if audience == nil {
+		audience = &models.MatchAudience{MatchID: matchID, Timeline: []models.AudiencePoint{}}
+	}
+	recordedAt := time.Now()
+	audience.RecordedAt = &recordedAt
+
+	if err := s.audienceRepo.Save(ctx, audience); err != nil {
+		return fmt.Errorf("failed to save audience: %w", err)
+	}
+	return nil
+}
+
+// GetAudience returns a match's audience: as saved once it has completed,
+// with who is watching now, or as counted so far while it is live
+func (s *AudienceService) GetAudience(ctx context.Context, matchID string) (*models.MatchAudience, error) {
+	if matchID == "" {
+		return nil, fmt.Errorf("match ID is required")
+	}
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("match not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, match.OrganizationID); err != nil {
+		return nil, fmt.Errorf("match not found")
+	}
+
+	live := s.liveAudience(matchID)
+	if match.Status == models.MatchStatusCompleted || live == nil {
+		if saved, err := s.audienceRepo.GetByMatchID(ctx, matchID); err == nil {
+			if live != nil {
+				saved.CurrentViewers = live.CurrentViewers
+			}
+			return saved, nil
+		}
+	}
+	if live != nil {
+		return live, nil
+	}
+	return &models.MatchAudience{MatchID: matchID, Timeline: []models.AudiencePoint{}}, nil
+}
+
+// liveAudience returns the audience counted for a match so far, or nil if
+// it has not been followed since the instance started
+func (s *AudienceService) liveAudience(matchID string) *models.MatchAudience {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tracker := s.matches[matchID]
+	if tracker == nil {
+		return nil
+	}
+
+	audience := &models.MatchAudience{
+		MatchID:        matchID,
+		CurrentViewers: tracker.viewers,
+		PeakViewers:    tracker.peak,
+		AverageViewers: float64(tracker.viewers),
+		Timeline:       append([]models.AudiencePoint{}, tracker.timeline...),
+	}
+	if tracker.peak > 0 {
+		peakAt := tracker.peakAt
+		audience.PeakAt = &peakAt
+	}
+	if elapsed := tracker.last.Sub(tracker.first).Seconds(); elapsed > 0 {
+		audience.AverageViewers = math.Round(tracker.viewerTime/elapsed*100) / 100
+	}
+	return audience
+}
+
+// observe records a sample, counting the previous one as lasting until it
+func (t *audienceTracker) observe(at time.Time, viewers int) {
+	if at.After(t.last) {
+		t.viewerTime += float64(t.viewers) * at.Sub(t.last).Seconds()
+		t.last = at
+	}
+	if viewers == 0 && t.viewers > 0 {
+		t.idleSince = at
+	}
+	t.viewers = viewers
+
+	if viewers > t.peak {
+		t.peak = viewers
+		t.peakAt = at
+	}
+
+	minute := at.Truncate(time.Minute)
+	if n := len(t.timeline); n > 0 && t.timeline[n-1].At.Equal(minute) {
+		if viewers > t.timeline[n-1].Viewers {
+			t.timeline[n-1].Viewers = viewers
+		}
+		return
+	}
+	t.timeline = append(t.timeline, models.AudiencePoint{At: minute, Viewers: viewers})
+	if len(t.timeline) > maxAudienceTimeline {
+		t.timeline = t.timeline[len(t.timeline)-maxAudienceTimeline:]
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// AuditService records mutations made through the domain services
+type AuditService struct {
+	auditRepo interfaces.AuditRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo interfaces.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Snapshot captures the current state of an entity as JSON. Take the snapshot
+// before mutating the entity in place, otherwise before and after will match.
+func (s *AuditService) Snapshot(entity interface{}) json.RawMessage {
+	if s == nil || entity == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		log.Printf("Error creating audit snapshot: %v", err)
+		return nil
+	}
+	return data
+}
+
+// Record stores an audit entry for a mutation. Actor and request ID are taken
+// from the context. Failures are logged and never fail the calling operation.
+// A nil service is a no-op so services can be used without auditing.
+func (s *AuditService) Record(ctx context.Context, action models.AuditAction, entityType models.AuditEntityType, entityID string, before, after interface{}) {
+	if s == nil {
+		return
+	}
+
+	entry := &models.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		RequestID:  chimiddleware.GetReqID(ctx),
+		Before:     s.toRaw(before),
+		After:      s.toRaw(after),
+		CreatedAt:  time.Now(),
+	}
+	if userID, ok := ctx.Value("user_id").(string); ok {
+		entry.ActorID = userID
+	}
+	if email, ok := ctx.Value("user_email").(string); ok {
+		entry.ActorEmail = email
+	}
+
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("Error recording audit log for %s %s %s: %v", action, entityType, entityID, err)
+	}
+}
+
+// toRaw converts a snapshot or entity to raw JSON
+func (s *AuditService) toRaw(v interface{}) json.RawMessage {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		return value
+	default:
+		return s.Snapshot(value)
+	}
+}
+
+// ListAuditLogs retrieves audit logs with optional filtering
+func (s *AuditService) ListAuditLogs(ctx context.Context, filters *models.AuditLogFilters) ([]*models.AuditLog, error) {
+	// Set default values
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	if filters.From != nil && filters.To != nil && filters.To.Before(*filters.From) {
+		return nil, fmt.Errorf("'to' must be after 'from'")
+	}
+
+	logs, err := s.auditRepo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return logs, nil
+}
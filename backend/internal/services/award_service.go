@@ -0,0 +1,566 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+)
+
+// AwardService scores every player's impact from ball data, proposes the
+// player of the match when a match completes and keeps the series awards in
+// step with results. Organisers confirm or override proposals; confirmed
+// awards are never recomputed.
+type AwardService struct {
+	awardRepo     interfaces.AwardRepository
+	seriesRepo    interfaces.SeriesRepository
+	matchRepo     interfaces.MatchRepository
+	scorecardRepo interfaces.ScorecardRepository
+	stats         *StatsService
+	audit         *AuditService
+	orgs          *OrganizationService
+}
+
+// NewAwardService creates a new award service. Player lines come from the
+// statistics service, so live and completed matches are scored the same way.
+func NewAwardService(awardRepo interfaces.AwardRepository, seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, scorecardRepo interfaces.ScorecardRepository, stats *StatsService) *AwardService {
+	return &AwardService{
+		awardRepo:     awardRepo,
+		seriesRepo:    seriesRepo,
+		matchRepo:     matchRepo,
+		scorecardRepo: scorecardRepo,
+		stats:         stats,
+	}
+}
+
+// SetAuditService enables audit logging of award confirmations
+func (s *AwardService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of awards by organization
+func (s *AwardService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// MatchResultChanged proposes the player of the match when a match starts
+// counting (it completes or is restored) and withdraws an unconfirmed
+// proposal when it stops (it is reopened or deleted). The series awards are
+// refreshed either way.
+func (s *AwardService) MatchResultChanged(ctx context.Context, match *models.Match, counts bool) error {
+	series, err := s.seriesRepo.GetByID(ctx, match.SeriesID)
+	if err != nil {
+		return fmt.Errorf("series not found: %w", err)
+	}
+
+	existing, err := s.matchAward(ctx, match.ID)
+	if err != nil {
+		return err
+	}
+
+	if counts {
+		impacts, err := s.matchImpacts(ctx, match, seriesImpactFormula(series), map[string]string{})
+		if err != nil {
+			return err
+		}
+		if err := s.propose(ctx, existing, &models.Award{AwardType: models.AwardPlayerOfTheMatch, SeriesID: match.SeriesID, MatchID: match.ID}, impacts, impactTotal); err != nil {
+			return err
+		}
+	} else if existing != nil && existing.Status == models.AwardStatusProposed {
+		if err := s.awardRepo.Delete(ctx, existing.ID); err != nil {
+			return fmt.Errorf("failed to withdraw player of the match: %w", err)
+		}
+	}
+
+	return s.refreshSeriesAwards(ctx, series)
+}
+
+// GetMatchAwards retrieves a match's player of the match and the impact
+// ranking of its players, live or completed
+func (s *AwardService) GetMatchAwards(ctx context.Context, matchID string) (*models.MatchAwards, error) {
+	match, series, err := s.visibleMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	formula := seriesImpactFormula(series)
+	impacts, err := s.matchImpacts(ctx, match, formula, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	award, err := s.matchAward(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MatchAwards{MatchID: matchID, PlayerOfTheMatch: award, Impacts: impacts, Formula: formula}, nil
+}
+
+// GetPlayerOfTheMatch retrieves the stored player of the match, or nil
+func (s *AwardService) GetPlayerOfTheMatch(ctx context.Context, matchID string) (*models.Award, error) {
+	return s.matchAward(ctx, matchID)
+}
+
+// ConfirmMatchAward settles a match's player of the match. Only the match's
+// creator can confirm, and only once the match is completed.
+func (s *AwardService) ConfirmMatchAward(ctx context.Context, matchID string, req *models.ConfirmAwardRequest) (*models.Award, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	match, series, err := s.visibleMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only confirm awards for matches you created")
+	}
+	if err := s.orgs.CheckCanContribute(ctx, match.OrganizationID); err != nil {
+		return nil, err
+	}
+	if match.Status != models.MatchStatusCompleted {
+		return nil, fmt.Errorf("match is not completed, cannot confirm player of the match")
+	}
+
+	impacts, err := s.matchImpacts(ctx, match, seriesImpactFormula(series), map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.matchAward(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.confirm(ctx, existing, &models.Award{AwardType: models.AwardPlayerOfTheMatch, SeriesID: match.SeriesID, MatchID: matchID}, impacts, impactTotal, req)
+}
+
+// GetSeriesAwards retrieves a series' awards and the impact ranking of its
+// completed matches
+func (s *AwardService) GetSeriesAwards(ctx context.Context, seriesID string) (*models.SeriesAwards, error) {
+	series, err := s.visibleSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	formula := seriesImpactFormula(series)
+	impacts, err := s.seriesImpacts(ctx, series, formula)
+	if err != nil {
+		return nil, err
+	}
+	awards, err := s.awardRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series awards: %w", err)
+	}
+
+	return &models.SeriesAwards{SeriesID: seriesID, Awards: sortSeriesAwards(awards), Impacts: rankImpacts(impacts, impactTotal), Formula: formula}, nil
+}
+
+// ConfirmSeriesAward settles one of a series' awards. Only the series'
+// creator can confirm.
+func (s *AwardService) ConfirmSeriesAward(ctx context.Context, seriesID string, awardType models.AwardType, req *models.ConfirmAwardRequest) (*models.Award, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	score, ok := seriesAwardScores[awardType]
+	if !ok {
+		return nil, fmt.Errorf("unknown series award: %s", awardType)
+	}
+
+	series, err := s.visibleSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only confirm awards for series you created")
+	}
+	if err := s.orgs.CheckCanContribute(ctx, series.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	impacts, err := s.seriesImpacts(ctx, series, seriesImpactFormula(series))
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.seriesAward(ctx, seriesID, awardType)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.confirm(ctx, existing, &models.Award{AwardType: awardType, SeriesID: seriesID}, impacts, score, req)
+}
+
+// seriesAwardScores picks the impact component each series award is decided on
+var seriesAwardScores = map[models.AwardType]func(*models.PlayerImpact) float64{
+	models.AwardMostValuable: impactTotal,
+	models.AwardBestBatter:   func(impact *models.PlayerImpact) float64 { return impact.Batting },
+	models.AwardBestBowler:   func(impact *models.PlayerImpact) float64 { return impact.Bowling },
+}
+
+func impactTotal(impact *models.PlayerImpact) float64 {
+	return impact.Total
+}
+
+// refreshSeriesAwards recomputes a series' unconfirmed awards from its
+// completed matches
+func (s *AwardService) refreshSeriesAwards(ctx context.Context, series *models.Series) error {
+	impacts, err := s.seriesImpacts(ctx, series, seriesImpactFormula(series))
+	if err != nil {
+		return err
+	}
+
+	for _, awardType := range models.SeriesAwardTypes() {
+		existing, err := s.seriesAward(ctx, series.ID, awardType)
+		if err != nil {
+			return err
+		}
+		if err := s.propose(ctx, existing, &models.Award{AwardType: awardType, SeriesID: series.ID}, impacts, seriesAwardScores[awardType]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propose stores the top-scoring player as the award's proposal, replacing
+// an earlier proposal. Confirmed awards are left alone, and a proposal is
+// withdrawn when nobody scores above zero.
+func (s *AwardService) propose(ctx context.Context, existing, award *models.Award, impacts []*models.PlayerImpact, score func(*models.PlayerImpact) float64) error {
+	if existing != nil && existing.Status == models.AwardStatusConfirmed {
+		return nil
+	}
+
+	ranked := rankImpacts(impacts, score)
+	if len(ranked) == 0 || score(ranked[0]) <= 0 {
+		if existing == nil {
+			return nil
+		}
+		if err := s.awardRepo.Delete(ctx, existing.ID); err != nil {
+			return fmt.Errorf("failed to withdraw %s award: %w", award.AwardType, err)
+		}
+		return nil
+	}
+
+	top := ranked[0]
+	award.PlayerID = top.PlayerID
+	award.PlayerName = top.PlayerName
+	award.TeamID = top.TeamID
+	award.Points = score(top)
+	award.ProposedPlayerID = top.PlayerID
+	award.Status = models.AwardStatusProposed
+	award.UpdatedAt = time.Now()
+
+	if existing == nil {
+		award.CreatedAt = award.UpdatedAt
+		if err := s.awardRepo.Create(ctx, award); err != nil {
+			return fmt.Errorf("failed to propose %s award: %w", award.AwardType, err)
+		}
+		return nil
+	}
+	if existing.PlayerID == award.PlayerID && existing.Points == award.Points {
+		return nil
+	}
+	award.CreatedAt = existing.CreatedAt
+	if err := s.awardRepo.Update(ctx, existing.ID, award); err != nil {
+		return fmt.Errorf("failed to propose %s award: %w", award.AwardType, err)
+	}
+	return nil
+}
+
+// confirm settles an award for the requested player, or the computed pick.
+// The player must have an impact line in the match or series.
+func (s *AwardService) confirm(ctx context.Context, existing, award *models.Award, impacts []*models.PlayerImpact, score func(*models.PlayerImpact) float64, req *models.ConfirmAwardRequest) (*models.Award, error) {
+	ranked := rankImpacts(impacts, score)
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("no player has taken part yet, cannot confirm %s award", award.AwardType)
+	}
+	if len(req.Note) > 500 {
+		return nil, fmt.Errorf("note must be at most 500 characters")
+	}
+
+	playerID := req.PlayerID
+	if playerID == "" {
+		playerID = ranked[0].PlayerID
+		if existing != nil {
+			playerID = existing.PlayerID
+		}
+	}
+	var winner *models.PlayerImpact
+	for _, impact := range ranked {
+		if impact.PlayerID == playerID {
+			winner = impact
+			break
+		}
+	}
+	if winner == nil {
+		return nil, fmt.Errorf("player %s did not take part, cannot receive the %s award", playerID, award.AwardType)
+	}
+
+	userID, _ := ctx.Value("user_id").(string)
+	now := time.Now()
+	var before interface{}
+	award.ProposedPlayerID = ranked[0].PlayerID
+	award.CreatedAt = now
+	if existing != nil {
+		before = s.audit.Snapshot(existing)
+		award.ProposedPlayerID = existing.ProposedPlayerID
+		award.CreatedAt = existing.CreatedAt
+	}
+	award.PlayerID = winner.PlayerID
+	award.PlayerName = winner.PlayerName
+	award.TeamID = winner.TeamID
+	award.Points = score(winner)
+	award.Status = models.AwardStatusConfirmed
+	award.Overridden = award.PlayerID != award.ProposedPlayerID
+	award.Note = req.Note
+	award.ConfirmedBy = userID
+	award.ConfirmedAt = &now
+	award.UpdatedAt = now
+
+	if existing == nil {
+		if err := s.awardRepo.Create(ctx, award); err != nil {
+			return nil, fmt.Errorf("failed to confirm %s award: %w", award.AwardType, err)
+		}
+		s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityAward, award.ID, nil, award)
+		return award, nil
+	}
+	if err := s.awardRepo.Update(ctx, existing.ID, award); err != nil {
+		return nil, fmt.Errorf("failed to confirm %s award: %w", award.AwardType, err)
+	}
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityAward, existing.ID, before, award)
+	return award, nil
+}
+
+// matchImpacts scores every player of a match, ranked by total impact
+func (s *AwardService) matchImpacts(ctx context.Context, match *models.Match, formula models.ImpactFormula, names map[string]string) ([]*models.PlayerImpact, error) {
+	lines, err := s.stats.computeMatch(ctx, match, names)
+	if err != nil {
+		return nil, err
+	}
+
+	winnerTeamID := ""
+	if match.Status == models.MatchStatusCompleted {
+		innings, err := s.scorecardRepo.GetInningsByMatchID(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get innings for match %s: %w", match.ID, err)
+		}
+		winnerTeamID = matchWinnerTeamID(match, innings)
+	}
+
+	impacts := make([]*models.PlayerImpact, 0, len(lines))
+	for _, line := range lines {
+		impacts = append(impacts, scoreImpact(line, formula, winnerTeamID != "" && line.TeamID == winnerTeamID))
+	}
+	return rankImpacts(impacts, impactTotal), nil
+}
+
+// seriesImpacts sums the impact of each player over a series' completed matches
+func (s *AwardService) seriesImpacts(ctx context.Context, series *models.Series, formula models.ImpactFormula) ([]*models.PlayerImpact, error) {
+	matches, err := s.matchRepo.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series matches: %w", err)
+	}
+
+	names := map[string]string{}
+	totals := map[string]*models.PlayerImpact{}
+	var order []string
+	for _, match := range matches {
+		if match.Status != models.MatchStatusCompleted {
+			continue
+		}
+		impacts, err := s.matchImpacts(ctx, match, formula, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, impact := range impacts {
+			total, ok := totals[impact.PlayerID]
+			if !ok {
+				total = &models.PlayerImpact{PlayerID: impact.PlayerID, PlayerName: impact.PlayerName, TeamID: impact.TeamID}
+				totals[impact.PlayerID] = total
+				order = append(order, impact.PlayerID)
+			}
+			total.Matches++
+			total.Batting = roundTo2(total.Batting + impact.Batting)
+			total.Bowling = roundTo2(total.Bowling + impact.Bowling)
+			total.Fielding = roundTo2(total.Fielding + impact.Fielding)
+			total.Total = roundTo2(total.Total + impact.Total)
+		}
+	}
+
+	impacts := make([]*models.PlayerImpact, 0, len(order))
+	for _, playerID := range order {
+		impacts = append(impacts, totals[playerID])
+	}
+	return impacts, nil
+}
+
+// scoreImpact applies the formula to one player's match line
+func scoreImpact(line *models.PlayerMatchStats, formula models.ImpactFormula, winner bool) *models.PlayerImpact {
+	batting := float64(line.Runs)*formula.RunPoints +
+		float64(line.Fours+2*line.Sixes)*formula.BoundaryPoints
+	if line.BallsFaced > 0 {
+		par := float64(line.BallsFaced) * formula.StrikeRatePar / 100
+		batting += (float64(line.Runs) - par) * formula.StrikeRatePoints
+	}
+
+	bowling := float64(line.Wickets)*formula.WicketPoints + float64(line.Maidens)*formula.MaidenPoints
+	if line.BallsBowled > 0 {
+		par := float64(line.BallsBowled) * formula.EconomyPar / 6
+		bowling += (par - float64(line.RunsConceded)) * formula.EconomyPoints
+	}
+
+	fielding := float64(line.Catches)*formula.CatchPoints +
+		float64(line.Stumpings)*formula.StumpingPoints +
+		float64(line.RunOuts)*formula.RunOutPoints
+
+	if winner && formula.WinnerMultiplier > 0 {
+		batting *= formula.WinnerMultiplier
+		bowling *= formula.WinnerMultiplier
+		fielding *= formula.WinnerMultiplier
+	}
+
+	return &models.PlayerImpact{
+		PlayerID:   line.PlayerID,
+		PlayerName: line.PlayerName,
+		TeamID:     line.TeamID,
+		Batting:    roundTo2(batting),
+		Bowling:    roundTo2(bowling),
+		Fielding:   roundTo2(fielding),
+		Total:      roundTo2(batting + bowling + fielding),
+		Winner:     winner,
+	}
+}
+
+// rankImpacts returns the impacts ordered by the given score, highest first,
+// then by name, with ranks shared by level players
+func rankImpacts(impacts []*models.PlayerImpact, score func(*models.PlayerImpact) float64) []*models.PlayerImpact {
+	ranked := make([]*models.PlayerImpact, 0, len(impacts))
+	for _, impact := range impacts {
+		copied := *impact
+		ranked = append(ranked, &copied)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if score(ranked[i]) != score(ranked[j]) {
+			return score(ranked[i]) > score(ranked[j])
+		}
+		return ranked[i].PlayerName < ranked[j].PlayerName
+	})
+	for i, impact := range ranked {
+		impact.Rank = i + 1
+		if i > 0 && score(impact) == score(ranked[i-1]) {
+			impact.Rank = ranked[i-1].Rank
+		}
+	}
+	return ranked
+}
+
+// matchWinnerTeamID returns the team that won a completed match, or empty
+// for ties, no results and matches not linked to teams
+func matchWinnerTeamID(match *models.Match, innings []*models.Innings) string {
+	var first, second *models.Innings
+	for _, inn := range innings {
+		switch inn.InningsNumber {
+		case 1:
+			first = inn
+		case 2:
+			second = inn
+		}
+	}
+	if first == nil || second == nil || first.TotalRuns == second.TotalRuns {
+		return ""
+	}
+
+	winner := first.BattingTeam
+	if second.TotalRuns > first.TotalRuns {
+		winner = second.BattingTeam
+	}
+	if winner == models.TeamTypeB {
+		return match.TeamBID
+	}
+	return match.TeamAID
+}
+
+// matchAward returns a match's stored player of the match, or nil
+func (s *AwardService) matchAward(ctx context.Context, matchID string) (*models.Award, error) {
+	awards, err := s.awardRepo.GetByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match awards: %w", err)
+	}
+	for _, award := range awards {
+		if award.AwardType == models.AwardPlayerOfTheMatch {
+			return award, nil
+		}
+	}
+	return nil, nil
+}
+
+// seriesAward returns one of a series' stored awards, or nil
+func (s *AwardService) seriesAward(ctx context.Context, seriesID string, awardType models.AwardType) (*models.Award, error) {
+	awards, err := s.awardRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series awards: %w", err)
+	}
+	for _, award := range awards {
+		if award.AwardType == awardType {
+			return award, nil
+		}
+	}
+	return nil, nil
+}
+
+// visibleMatch loads a match and its series if the caller may see them
+func (s *AwardService) visibleMatch(ctx context.Context, matchID string) (*models.Match, *models.Series, error) {
+	if matchID == "" {
+		return nil, nil, fmt.Errorf("match ID is required")
+	}
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("match not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, match.OrganizationID); err != nil {
+		return nil, nil, fmt.Errorf("match not found")
+	}
+	series, err := s.seriesRepo.GetByID(ctx, match.SeriesID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("series not found: %w", err)
+	}
+	return match, series, nil
+}
+
+// visibleSeries loads a series if the caller may see it
+func (s *AwardService) visibleSeries(ctx context.Context, seriesID string) (*models.Series, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("series not found")
+	}
+	return series, nil
+}
+
+// sortSeriesAwards orders series awards as SeriesAwardTypes lists them
+func sortSeriesAwards(awards []*models.Award) []*models.Award {
+	position := map[models.AwardType]int{}
+	for i, awardType := range models.SeriesAwardTypes() {
+		position[awardType] = i
+	}
+	sort.SliceStable(awards, func(i, j int) bool {
+		return position[awards[i].AwardType] < position[awards[j].AwardType]
+	})
+	return awards
+}
+
+// seriesImpactFormula returns a series' impact formula, or the defaults
+func seriesImpactFormula(series *models.Series) models.ImpactFormula {
+	if series.ImpactFormula != nil {
+		return *series.ImpactFormula
+	}
+	return models.DefaultImpactFormula()
+}
@@ -7,53 +7,204 @@ import (
 	"spark-park-cricket-backend/internal/interfaces"
 	"spark-park-cricket-backend/pkg/events"
 	"spark-park-cricket-backend/pkg/websocket"
+	"strings"
+	"time"
 )
 
 // Container holds all service instances
 type Container struct {
-	Series           *SeriesService
-	Match            *MatchService
-	Scorecard        interfaces.ScorecardServiceInterface
-	Hub              *websocket.Hub
-	Broadcaster      *events.EventBroadcaster
-	GraphQLWebSocket *graphql.GraphQLWebSocketService
+	Series            *SeriesService
+	Match             *MatchService
+	Team              *TeamService
+	Player            *PlayerService
+	MatchSquad        *MatchSquadService
+	Stats             *StatsService
+	Standings         *StandingsService
+	Fixture           *FixtureService
+	Stage             *StageService
+	Leaderboard       *LeaderboardService
+	Venue             *VenueService
+	Award             *AwardService
+	Audience          *AudienceService
+	Webhook           *WebhookService
+	Audit             *AuditService
+	Organization      *OrganizationService
+	Retention         *RetentionService
+	Scorecard         interfaces.ScorecardServiceInterface
+	ScorecardVersions *ScorecardVersions
+	Hub               *websocket.Hub
+	Broadcaster       *events.EventBroadcaster
+	GraphQLWebSocket  *graphql.GraphQLWebSocketService
 	// Authentication services
-	AuthService    *AuthService
-	SessionService *SessionService
+	AuthService      *AuthService
+	SessionService   *SessionService
+	ConnectionTokens *ConnectionTokenService
 }
 
 // NewContainer creates a new service container with all services
 func NewContainer(repos *database.Repositories, cfg *config.Config) *Container {
 	// Create WebSocket hub
 	hub := websocket.NewHub()
+	hub.SetLimits(websocket.Limits{
+		MaxMessageSize:       int64(cfg.WebSocketMaxMessageSize),
+		MaxSubscriptions:     cfg.WebSocketMaxSubscriptions,
+		MaxMessagesPerMinute: cfg.WebSocketMaxMessagesPerMinute,
+	})
+	hub.SetKeepalive(websocket.Keepalive{
+		PingInterval: time.Duration(cfg.WebSocketPingIntervalSeconds) * time.Second,
+		PongTimeout:  time.Duration(cfg.WebSocketPongTimeoutSeconds) * time.Second,
+	})
+	hub.SetCapacity(websocket.Capacity{
+		MaxConnectionsPerIP:   cfg.WebSocketMaxConnectionsPerIP,
+		MaxConnectionsPerRoom: cfg.WebSocketMaxConnectionsPerRoom,
+	})
+	hub.SetAllowedOrigins(strings.Split(cfg.AllowedOrigins, ","))
+	hub.SetReplayStore(websocket.NewMemoryReplayStore(cfg.WebSocketReplaySize, time.Duration(cfg.WebSocketReplayTTLMinutes)*time.Minute))
 
 	// Create event broadcaster
 	broadcaster := events.NewEventBroadcaster(hub)
 
+	// Create the bus carrying scoring events to GraphQL subscriptions
+	scoringEvents := events.NewScoringEvents()
+
 	// Create base scorecard service
 	baseScorecardService := NewScorecardService(repos.Scorecard, repos.Match)
 
 	// Create GraphQL WebSocket service
 	graphqlWebSocketService := graphql.NewGraphQLWebSocketService(baseScorecardService, hub)
+	graphqlWebSocketService.SetScoringEvents(scoringEvents)
+
+	// Create audit service shared by all mutating services
+	auditService := NewAuditService(repos.Audit)
+
+	// Create organization service used for tenant scoping
+	organizationService := NewOrganizationService(repos.Organization)
+	organizationService.SetAuditService(auditService)
+
+	// Create domain services with audit logging and tenant scoping
+	seriesService := NewSeriesService(repos.Series, repos.Match)
+	seriesService.SetAuditService(auditService)
+	seriesService.SetOrganizationService(organizationService)
+	matchService := NewMatchService(repos.Match, repos.Series, repos.Team)
+	matchService.SetAuditService(auditService)
+	matchService.SetOrganizationService(organizationService)
+	matchService.SetVenueRepository(repos.Venue)
+	teamService := NewTeamService(repos.Team, repos.Player)
+	teamService.SetAuditService(auditService)
+	teamService.SetOrganizationService(organizationService)
+	graphqlWebSocketService.SetTeamService(teamService)
+	playerService := NewPlayerService(repos.Player, repos.Team)
+	playerService.SetAuditService(auditService)
+	playerService.SetOrganizationService(organizationService)
+	graphqlWebSocketService.SetPlayerService(playerService)
+	matchSquadService := NewMatchSquadService(repos.Match, repos.MatchSquad, repos.Player, repos.Scorecard)
+	matchSquadService.SetAuditService(auditService)
+	matchSquadService.SetOrganizationService(organizationService)
+	statsService := NewStatsService(repos.PlayerStats, repos.Match, repos.Series, repos.Player, repos.Scorecard)
+	statsService.SetMatchSquadRepository(repos.MatchSquad)
+	statsService.SetOrganizationService(organizationService)
+	matchService.SetStatsService(statsService)
+	graphqlWebSocketService.SetStatsService(statsService)
+	standingsService := NewStandingsService(repos.Series, repos.Match, repos.Team, repos.Scorecard)
+	standingsService.SetOrganizationService(organizationService)
+	standingsService.SetBroadcaster(broadcaster)
+	matchService.SetStandingsService(standingsService)
+	fixtureService := NewFixtureService(repos.Series, repos.Match, repos.Team, matchService)
+	fixtureService.SetOrganizationService(organizationService)
+	stageService := NewStageService(repos.Stage, repos.Series, repos.Match, repos.Team, repos.Scorecard, standingsService, matchService)
+	stageService.SetAuditService(auditService)
+	stageService.SetOrganizationService(organizationService)
+	stageService.SetBroadcaster(broadcaster)
+	matchService.SetStageService(stageService)
+	leaderboardService := NewLeaderboardService(repos.Series, repos.Match, statsService)
+	leaderboardService.SetOrganizationService(organizationService)
+	leaderboardService.SetBroadcaster(broadcaster)
+	matchService.SetLeaderboardService(leaderboardService)
+	venueService := NewVenueService(repos.Venue, repos.Match, repos.Scorecard)
+	venueService.SetAuditService(auditService)
+	venueService.SetOrganizationService(organizationService)
+	awardService := NewAwardService(repos.Award, repos.Series, repos.Match, repos.Scorecard, statsService)
+	awardService.SetAuditService(auditService)
+	awardService.SetOrganizationService(organizationService)
+	matchService.SetAwardService(awardService)
+	statsService.SetAwardRepository(repos.Award)
+	audienceService := NewAudienceService(repos.Audience, repos.Match)
+	audienceService.SetOrganizationService(organizationService)
+	matchService.SetAudienceService(audienceService)
+	if cfg.WebSocketPresenceIntervalSeconds > 0 {
+		hub.SetPresence(time.Duration(cfg.WebSocketPresenceIntervalSeconds)*time.Second, audienceService.RecordViewers)
+	}
+
+	webhookService := NewWebhookService(repos.Webhook, repos.Series, repos.Match)
+	webhookService.SetAuditService(auditService)
+	webhookService.SetRetryPolicy(WebhookRetryPolicy{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BaseDelay:   time.Duration(cfg.WebhookRetryBaseSeconds) * time.Second,
+	})
+	webhookService.SetAllowPrivateTargets(cfg.WebhookAllowPrivateTargets)
+	broadcaster.SetObserver(webhookService.Dispatch)
+
+	// Create trash retention job unless retention is disabled
+	var retentionService *RetentionService
+	if cfg.SoftDeleteRetentionDays > 0 {
+		retention := time.Duration(cfg.SoftDeleteRetentionDays) * 24 * time.Hour
+		retentionService = NewRetentionService(repos.Series, repos.Match, repos.Team, repos.Player, retention)
+	}
 
 	// Create GraphQL-integrated scorecard service
 	scorecardServiceWithGraphQL := NewScorecardServiceWithGraphQL(repos.Scorecard, repos.Match, hub)
+	scorecardServiceWithGraphQL.SetAuditService(auditService)
+	scorecardServiceWithGraphQL.SetMatchSquadRepository(repos.MatchSquad)
+	scorecardServiceWithGraphQL.SetStatsService(statsService)
+	scorecardServiceWithGraphQL.SetStandingsService(standingsService)
+	scorecardServiceWithGraphQL.SetStageService(stageService)
+	scorecardServiceWithGraphQL.SetLeaderboardService(leaderboardService)
+	scorecardServiceWithGraphQL.SetAwardService(awardService)
+	scorecardServiceWithGraphQL.SetAudienceService(audienceService)
+	scorecardServiceWithGraphQL.SetWebhookService(webhookService)
+	scorecardServiceWithGraphQL.SetScoringEvents(scoringEvents)
+	scorecardVersions := NewScorecardVersions()
+	scorecardServiceWithGraphQL.SetScorecardVersions(scorecardVersions)
+	roomSnapshotService := NewRoomSnapshotService(scorecardServiceWithGraphQL.ScorecardService, standingsService, stageService, leaderboardService)
+	roomSnapshotService.SetScorecardVersions(scorecardVersions)
+	hub.SetSnapshotProvider(roomSnapshotService.Snapshot)
+	roomAccessService := NewRoomAccessService(repos.Match, repos.Series, organizationService)
+	hub.SetAccessPolicy(roomAccessService.CheckRoomAccess)
+	scorecardServiceWithGraphQL.SetAccessPolicy(roomAccessService.CheckRoomAccess)
 
 	// Create authentication services
 	sessionService := NewSessionService(repos.User, cfg)
 	authService := NewAuthService(cfg, repos.User, sessionService)
+	connectionTokens := NewConnectionTokenService(connectionTokenSecret(cfg), time.Duration(cfg.WebSocketTokenTTLMinutes)*time.Minute)
 
 	// Create container
 	container := &Container{
-		Series:           NewSeriesService(repos.Series),
-		Match:            NewMatchService(repos.Match, repos.Series),
-		Scorecard:        scorecardServiceWithGraphQL,
-		Hub:              hub,
-		Broadcaster:      broadcaster,
-		GraphQLWebSocket: graphqlWebSocketService,
+		Series:            seriesService,
+		Match:             matchService,
+		Team:              teamService,
+		Player:            playerService,
+		MatchSquad:        matchSquadService,
+		Stats:             statsService,
+		Standings:         standingsService,
+		Fixture:           fixtureService,
+		Stage:             stageService,
+		Leaderboard:       leaderboardService,
+		Venue:             venueService,
+		Award:             awardService,
+		Audience:          audienceService,
+		Webhook:           webhookService,
+		Audit:             auditService,
+		Organization:      organizationService,
+		Retention:         retentionService,
+		Scorecard:         scorecardServiceWithGraphQL,
+		ScorecardVersions: scorecardVersions,
+		Hub:               hub,
+		Broadcaster:       broadcaster,
+		GraphQLWebSocket:  graphqlWebSocketService,
 		// Authentication services
-		AuthService:    authService,
-		SessionService: sessionService,
+		AuthService:      authService,
+		SessionService:   sessionService,
+		ConnectionTokens: connectionTokens,
 	}
 
 	return container
@@ -0,0 +1,594 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+)
+
+// maxScheduleDays bounds open-ended schedules so impossible limits fail
+// instead of searching forever
+const maxScheduleDays = 3 * 365
+
+// FixtureService draws up and schedules the fixtures of a series. Matches are
+// created through the match service so they get the same checks, numbering and
+// audit trail as matches created one at a time.
+type FixtureService struct {
+	seriesRepo interfaces.SeriesRepository
+	matchRepo  interfaces.MatchRepository
+	teamRepo   interfaces.TeamRepository
+	matches    *MatchService
+	orgs       *OrganizationService
+}
+
+// NewFixtureService creates a new fixture service
+func NewFixtureService(seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, teamRepo interfaces.TeamRepository, matches *MatchService) *FixtureService {
+	return &FixtureService{
+		seriesRepo: seriesRepo,
+		matchRepo:  matchRepo,
+		teamRepo:   teamRepo,
+		matches:    matches,
+	}
+}
+
+// SetOrganizationService enables tenant scoping of fixtures by organization
+func (s *FixtureService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// fixtureDraft is a fixture being drawn up. Knockout fixtures beyond the first
+// round take their sides from the winners of earlier fixtures.
+type fixtureDraft struct {
+	fixture  *models.Fixture
+	knockout bool
+	feederA  *fixtureDraft
+	feederB  *fixtureDraft
+	day      int // Schedule day index once scheduled, -1 before
+}
+
+// scheduleWindow holds the parsed scheduling limits of a request
+type scheduleWindow struct {
+	start     time.Time
+	end       time.Time // Zero for no end date
+	matchDays map[time.Weekday]bool
+	slots     []time.Duration
+	venues    []string
+	perDay    int
+	restDays  int
+}
+
+// PreviewFixtures draws up and schedules a series' fixtures without creating them
+func (s *FixtureService) PreviewFixtures(ctx context.Context, seriesID string, req *models.GenerateFixturesRequest) (*models.FixtureSchedule, error) {
+	drafts, err := s.plan(ctx, seriesID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFixtureSchedule(seriesID, req.Format, drafts, false), nil
+}
+
+// GenerateFixtures draws up and schedules a series' fixtures and creates a
+// match for each. Fixtures default to side A batting first; the toss can be
+// recorded before the match starts.
+func (s *FixtureService) GenerateFixtures(ctx context.Context, seriesID string, req *models.GenerateFixturesRequest) (*models.FixtureSchedule, error) {
+	drafts, err := s.plan(ctx, seriesID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	playerCount := req.TeamPlayerCount
+	if playerCount == 0 {
+		playerCount = models.DefaultFixturePlayerCount
+	}
+	totalOvers := req.TotalOvers
+	if totalOvers == 0 {
+		totalOvers = models.DefaultFixtureTotalOvers
+	}
+
+	for i, draft := range drafts {
+		fixture := draft.fixture
+		matchNumber := fixture.MatchNumber
+		match, err := s.matches.CreateMatch(ctx, &models.CreateMatchRequest{
+			SeriesID:         seriesID,
+			MatchNumber:      &matchNumber,
+			Date:             fixture.Date,
+			Venue:            fixture.Venue,
+			TeamAID:          fixture.TeamAID,
+			TeamBID:          fixture.TeamBID,
+			TeamAPlayerCount: playerCount,
+			TeamBPlayerCount: playerCount,
+			TotalOvers:       totalOvers,
+			TossWinner:       models.TeamTypeA,
+			TossType:         models.TossTypeHeads,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create match %d (%d of %d fixtures created): %w", matchNumber, i, len(drafts), err)
+		}
+		fixture.MatchID = match.ID
+	}
+
+	return newFixtureSchedule(seriesID, req.Format, drafts, true), nil
+}
+
+// plan validates a request, draws up its fixtures, schedules them and numbers
+// them after the series' existing matches
+func (s *FixtureService) plan(ctx context.Context, seriesID string, req *models.GenerateFixturesRequest) ([]*fixtureDraft, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckCanContribute(ctx, series.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := validateFixtureRequest(req); err != nil {
+		return nil, err
+	}
+	window, err := newScheduleWindow(series, req)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.fixtureTeamNames(ctx, series, req.TeamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts []*fixtureDraft
+	switch req.Format {
+	case models.FixtureFormatRoundRobin:
+		drafts = drawRoundRobin(req.TeamIDs, false)
+	case models.FixtureFormatDoubleRoundRobin:
+		drafts = drawRoundRobin(req.TeamIDs, true)
+	case models.FixtureFormatGroups:
+		drafts = drawGroups(req.TeamIDs, req.GroupCount)
+	case models.FixtureFormatKnockout:
+		drafts = drawKnockout(req.TeamIDs)
+	default:
+		return nil, fmt.Errorf("unknown fixture format: %s", req.Format)
+	}
+
+	scheduled, err := scheduleFixtures(drafts, window)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := s.matchRepo.GetNextMatchNumber(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next match number: %w", err)
+	}
+	for i, draft := range scheduled {
+		draft.fixture.MatchNumber = next + i
+	}
+	for _, draft := range scheduled {
+		fixture := draft.fixture
+		fixture.TeamAName = names[fixture.TeamAID]
+		fixture.TeamBName = names[fixture.TeamBID]
+		if draft.feederA != nil {
+			fixture.TeamALabel = fmt.Sprintf("Winner of match %d", draft.feederA.fixture.MatchNumber)
+		}
+		if draft.feederB != nil {
+			fixture.TeamBLabel = fmt.Sprintf("Winner of match %d", draft.feederB.fixture.MatchNumber)
+		}
+	}
+
+	return scheduled, nil
+}
+
+// fixtureTeamNames checks that every team can play in the series and returns their names
+func (s *FixtureService) fixtureTeamNames(ctx context.Context, series *models.Series, teamIDs []string) (map[string]string, error) {
+	names := make(map[string]string, len(teamIDs))
+	for _, teamID := range teamIDs {
+		team, err := s.teamRepo.GetByID(ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("team %s not found: %w", teamID, err)
+		}
+		if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+			return nil, fmt.Errorf("team %s not found", teamID)
+		}
+		if series.OrganizationID != "" && team.OrganizationID != "" && team.OrganizationID != series.OrganizationID {
+			return nil, fmt.Errorf("team %s belongs to a different organization than the series", teamID)
+		}
+		names[teamID] = team.Name
+	}
+	return names, nil
+}
+
+// validateFixtureRequest checks the teams, grouping and match settings of a fixture request
+func validateFixtureRequest(req *models.GenerateFixturesRequest) error {
+	if len(req.TeamIDs) < 2 {
+		return fmt.Errorf("at least 2 teams are required")
+	}
+	seen := make(map[string]bool, len(req.TeamIDs))
+	for _, teamID := range req.TeamIDs {
+		if teamID == "" {
+			return fmt.Errorf("team IDs cannot be empty")
+		}
+		if seen[teamID] {
+			return fmt.Errorf("team %s is listed more than once", teamID)
+		}
+		seen[teamID] = true
+	}
+
+	if req.Format == models.FixtureFormatGroups {
+		if req.GroupCount < 2 {
+			return fmt.Errorf("group_count must be at least 2 for a group stage")
+		}
+		if len(req.TeamIDs) < req.GroupCount*2 {
+			return fmt.Errorf("%d teams cannot fill %d groups of at least 2", len(req.TeamIDs), req.GroupCount)
+		}
+	}
+
+	if req.TeamPlayerCount < 0 || req.TeamPlayerCount > 20 {
+		return fmt.Errorf("team_player_count must be between 1 and 20")
+	}
+	if req.TotalOvers < 0 || req.TotalOvers > 20 {
+		return fmt.Errorf("total_overs must be between 1 and 20")
+	}
+	return nil
+}
+
+// newScheduleWindow parses a request's scheduling limits, defaulting dates to the series'
+func newScheduleWindow(series *models.Series, req *models.GenerateFixturesRequest) (*scheduleWindow, error) {
+	window := &scheduleWindow{
+		start:    series.StartDate,
+		end:      series.EndDate,
+		venues:   req.Venues,
+		perDay:   req.MaxMatchesPerDay,
+		restDays: req.RestDays,
+	}
+	if req.StartDate != nil {
+		window.start = *req.StartDate
+	}
+	if req.EndDate != nil {
+		window.end = *req.EndDate
+	}
+	if window.start.IsZero() {
+		return nil, fmt.Errorf("start_date is required when the series has no start date")
+	}
+	if !window.end.IsZero() && window.end.Before(window.start) {
+		return nil, fmt.Errorf("end date must be after start date")
+	}
+	if window.restDays < 0 || window.perDay < 0 {
+		return nil, fmt.Errorf("rest_days and max_matches_per_day cannot be negative")
+	}
+
+	if len(req.MatchDays) > 0 {
+		window.matchDays = make(map[time.Weekday]bool, len(req.MatchDays))
+		for _, day := range req.MatchDays {
+			if day < 0 || day > 6 {
+				return nil, fmt.Errorf("match days must be weekdays 0 (Sunday) to 6 (Saturday)")
+			}
+			window.matchDays[time.Weekday(day)] = true
+		}
+	}
+
+	slots := req.Slots
+	if len(slots) == 0 {
+		slots = []string{models.DefaultFixtureSlot}
+	}
+	for _, slot := range slots {
+		start, err := time.Parse("15:04", slot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot %q: use HH:MM", slot)
+		}
+		window.slots = append(window.slots, time.Duration(start.Hour())*time.Hour+time.Duration(start.Minute())*time.Minute)
+	}
+
+	if len(window.venues) == 0 {
+		window.venues = []string{""}
+	}
+	if window.perDay == 0 || window.perDay > len(window.slots)*len(window.venues) {
+		window.perDay = len(window.slots) * len(window.venues)
+	}
+	return window, nil
+}
+
+// roundRobinPairings returns the rounds of a single round robin using the
+// circle method; with an odd number of teams one team sits out each round
+func roundRobinPairings(teamIDs []string) [][][2]string {
+	teams := append([]string{}, teamIDs...)
+	if len(teams)%2 == 1 {
+		teams = append(teams, "")
+	}
+	n := len(teams)
+
+	rounds := make([][][2]string, 0, n-1)
+	for round := 0; round < n-1; round++ {
+		var pairings [][2]string
+		for i := 0; i < n/2; i++ {
+			a, b := teams[i], teams[n-1-i]
+			if a == "" || b == "" {
+				continue
+			}
+			// Alternate the fixed team's side so it is not always side A
+			if i == 0 && round%2 == 1 {
+				a, b = b, a
+			}
+			pairings = append(pairings, [2]string{a, b})
+		}
+		rounds = append(rounds, pairings)
+
+		// Keep the first team fixed and rotate the rest
+		last := teams[n-1]
+		copy(teams[2:], teams[1:n-1])
+		teams[1] = last
+	}
+	return rounds
+}
+
+// drawRoundRobin draws up a single or double round robin
+func drawRoundRobin(teamIDs []string, double bool) []*fixtureDraft {
+	rounds := roundRobinPairings(teamIDs)
+	var drafts []*fixtureDraft
+	for i, pairings := range rounds {
+		drafts = append(drafts, roundDrafts(pairings, i+1, "", false)...)
+	}
+	if double {
+		for i, pairings := range rounds {
+			swapped := make([][2]string, len(pairings))
+			for j, pairing := range pairings {
+				swapped[j] = [2]string{pairing[1], pairing[0]}
+			}
+			drafts = append(drafts, roundDrafts(swapped, len(rounds)+i+1, "", false)...)
+		}
+	}
+	return drafts
+}
+
+// drawGroups seeds teams into groups in snake order (1-2-2-1 for two groups)
+// and draws a round robin within each group, interleaving the groups' rounds
+func drawGroups(teamIDs []string, groupCount int) []*fixtureDraft {
+	groups := make([][]string, groupCount)
+	for i, teamID := range teamIDs {
+		pos := i % groupCount
+		if (i/groupCount)%2 == 1 {
+			pos = groupCount - 1 - pos
+		}
+		groups[pos] = append(groups[pos], teamID)
+	}
+
+	groupRounds := make([][][][2]string, groupCount)
+	maxRounds := 0
+	for g, teams := range groups {
+		groupRounds[g] = roundRobinPairings(teams)
+		if len(groupRounds[g]) > maxRounds {
+			maxRounds = len(groupRounds[g])
+		}
+	}
+
+	var drafts []*fixtureDraft
+	for round := 0; round < maxRounds; round++ {
+		for g := range groups {
+			if round < len(groupRounds[g]) {
+				drafts = append(drafts, roundDrafts(groupRounds[g][round], round+1, groupName(g), false)...)
+			}
+		}
+	}
+	return drafts
+}
+
+// roundDrafts turns one round's pairings into fixture drafts
+func roundDrafts(pairings [][2]string, round int, group string, knockout bool) []*fixtureDraft {
+	drafts := make([]*fixtureDraft, 0, len(pairings))
+	for _, pairing := range pairings {
+		stage := fmt.Sprintf("Round %d", round)
+		if group != "" {
+			stage = "Group " + group
+		}
+		drafts = append(drafts, &fixtureDraft{
+			fixture: &models.Fixture{
+				Stage:   stage,
+				Round:   round,
+				Group:   group,
+				TeamAID: pairing[0],
+				TeamBID: pairing[1],
+			},
+			knockout: knockout,
+			day:      -1,
+		})
+	}
+	return drafts
+}
+
+// groupName returns the letter of the i-th group
+func groupName(i int) string {
+	return string(rune('A' + i))
+}
+
+// bracketSeeds returns the seed at each position of a bracket of the given
+// power-of-two size, so that seeds 1 and 2 can only meet in the final
+func bracketSeeds(size int) []int {
+	seeds := []int{1}
+	for len(seeds) < size {
+		n := len(seeds) * 2
+		next := make([]int, 0, n)
+		for _, seed := range seeds {
+			next = append(next, seed, n+1-seed)
+		}
+		seeds = next
+	}
+	return seeds
+}
+
+// knockoutStage names a knockout round by the number of teams left in it
+func knockoutStage(teamsLeft int) string {
+	switch teamsLeft {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semi-final"
+	case 8:
+		return "Quarter-final"
+	default:
+		return fmt.Sprintf("Round of %d", teamsLeft)
+	}
+}
+
+// drawKnockout draws up a seeded single-elimination bracket. When the number
+// of teams is not a power of two the top seeds get byes into the second round.
+func drawKnockout(teamIDs []string) []*fixtureDraft {
+	size := 2
+	for size < len(teamIDs) {
+		size *= 2
+	}
+
+	// Each entry is a known team or the winner of an earlier fixture
+	type entry struct {
+		teamID string
+		from   *fixtureDraft
+	}
+	entries := make([]entry, 0, size)
+	for _, seed := range bracketSeeds(size) {
+		if seed <= len(teamIDs) {
+			entries = append(entries, entry{teamID: teamIDs[seed-1]})
+		} else {
+			entries = append(entries, entry{})
+		}
+	}
+
+	var drafts []*fixtureDraft
+	for round, teamsLeft := 1, size; len(entries) > 1; round, teamsLeft = round+1, teamsLeft/2 {
+		next := make([]entry, 0, len(entries)/2)
+		for i := 0; i < len(entries); i += 2 {
+			a, b := entries[i], entries[i+1]
+			// A bye only occurs in the first round, where the other side is a team
+			if a.teamID == "" && a.from == nil {
+				next = append(next, b)
+				continue
+			}
+			if b.teamID == "" && b.from == nil {
+				next = append(next, a)
+				continue
+			}
+			draft := &fixtureDraft{
+				fixture: &models.Fixture{
+					Stage:   knockoutStage(teamsLeft),
+					Round:   round,
+					TeamAID: a.teamID,
+					TeamBID: b.teamID,
+				},
+				knockout: true,
+				feederA:  a.from,
+				feederB:  b.from,
+				day:      -1,
+			}
+			drafts = append(drafts, draft)
+			next = append(next, entry{from: draft})
+		}
+		entries = next
+	}
+	return drafts
+}
+
+// scheduleFixtures gives each fixture a date, slot and venue, day by day.
+// Fixtures keep their drawn order where possible; a team plays at most once a
+// day and gets its rest days, including the winners of earlier knockout
+// fixtures, and a knockout round only starts once the previous round has been
+// played. Returns the fixtures in schedule order.
+func scheduleFixtures(drafts []*fixtureDraft, window *scheduleWindow) ([]*fixtureDraft, error) {
+	scheduled := make([]*fixtureDraft, 0, len(drafts))
+	lastDay := map[string]int{}
+	dayStart := time.Date(window.start.Year(), window.start.Month(), window.start.Day(), 0, 0, 0, 0, window.start.Location())
+
+	for day := 0; len(scheduled) < len(drafts); day++ {
+		date := dayStart.AddDate(0, 0, day)
+		if !window.end.IsZero() && date.After(window.end) {
+			return nil, fmt.Errorf("not enough match dates: only %d of %d fixtures fit by %s", len(scheduled), len(drafts), window.end.Format("2006-01-02"))
+		}
+		if day > maxScheduleDays {
+			return nil, fmt.Errorf("could not schedule %d fixtures within %d days", len(drafts), maxScheduleDays)
+		}
+		if window.matchDays != nil && !window.matchDays[date.Weekday()] {
+			continue
+		}
+
+		// Only the earliest unfinished knockout round can be played today
+		openRound := 0
+		for _, draft := range drafts {
+			if draft.knockout && draft.day < 0 && (openRound == 0 || draft.fixture.Round < openRound) {
+				openRound = draft.fixture.Round
+			}
+		}
+
+		playedToday := 0
+		for _, slot := range window.slots {
+			for _, venue := range window.venues {
+				if playedToday >= window.perDay {
+					break
+				}
+				draft := nextSchedulable(drafts, day, openRound, lastDay, window.restDays)
+				if draft == nil {
+					break
+				}
+				draft.day = day
+				draft.fixture.Date = date.Add(slot)
+				draft.fixture.Venue = venue
+				for _, teamID := range []string{draft.fixture.TeamAID, draft.fixture.TeamBID} {
+					if teamID != "" {
+						lastDay[teamID] = day
+					}
+				}
+				scheduled = append(scheduled, draft)
+				playedToday++
+			}
+		}
+	}
+	return scheduled, nil
+}
+
+// nextSchedulable returns the first unscheduled fixture whose teams are free on the given day
+func nextSchedulable(drafts []*fixtureDraft, day, openRound int, lastDay map[string]int, restDays int) *fixtureDraft {
+	for _, draft := range drafts {
+		if draft.day >= 0 {
+			continue
+		}
+		if draft.knockout && draft.fixture.Round != openRound {
+			continue
+		}
+		if teamResting(draft.fixture.TeamAID, day, lastDay, restDays) || teamResting(draft.fixture.TeamBID, day, lastDay, restDays) {
+			continue
+		}
+		if winnerResting(draft.feederA, day, restDays) || winnerResting(draft.feederB, day, restDays) {
+			continue
+		}
+		return draft
+	}
+	return nil
+}
+
+// teamResting reports whether a team has played too recently to play on the given day
+func teamResting(teamID string, day int, lastDay map[string]int, restDays int) bool {
+	if teamID == "" {
+		return false
+	}
+	last, played := lastDay[teamID]
+	return played && day-last <= restDays
+}
+
+// winnerResting reports whether the winner of a feeder fixture, whose team is
+// not known yet, has played too recently to play on the given day. Feeders are
+// in an earlier round, so they are always scheduled by then.
+func winnerResting(feeder *fixtureDraft, day int, restDays int) bool {
+	return feeder != nil && day-feeder.day <= restDays
+}
+
+// newFixtureSchedule builds the response for a planned schedule
+func newFixtureSchedule(seriesID string, format models.FixtureFormat, drafts []*fixtureDraft, created bool) *models.FixtureSchedule {
+	fixtures := make([]*models.Fixture, len(drafts))
+	for i, draft := range drafts {
+		fixtures[i] = draft.fixture
+	}
+	return &models.FixtureSchedule{
+		SeriesID: seriesID,
+		Format:   format,
+		Created:  created,
+		Fixtures: fixtures,
+	}
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"spark-park-cricket-backend/internal/cache"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"spark-park-cricket-backend/pkg/events"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leaderboardTitles are the display names of the leaderboards
+var leaderboardTitles = map[models.LeaderboardCategory]string{
+	models.LeaderboardMostRuns:       "Most runs",
+	models.LeaderboardMostWickets:    "Most wickets",
+	models.LeaderboardHighestScore:   "Highest score",
+	models.LeaderboardBestBowling:    "Best bowling",
+	models.LeaderboardMostSixes:      "Most sixes",
+	models.LeaderboardBestStrikeRate: "Best strike rate",
+	models.LeaderboardBestEconomy:    "Best economy",
+	models.LeaderboardMostCatches:    "Most catches",
+}
+
+// LeaderboardService ranks the players of a series from the ball-by-ball
+// scorecards of its completed and live matches. Each match's player lines are
+// cached and invalidated on every ball, and ranking changes are pushed to
+// series WebSocket rooms.
+type LeaderboardService struct {
+	seriesRepo  interfaces.SeriesRepository
+	matchRepo   interfaces.MatchRepository
+	stats       *StatsService
+	cache       *cache.CacheManager
+	orgs        *OrganizationService
+	broadcaster *events.EventBroadcaster
+
+	// Last published ranking of each series, to push only changes
+	mu       sync.Mutex
+	rankings map[string]string
+}
+
+// NewLeaderboardService creates a new leaderboard service. Player lines are
+// derived with the statistics service.
+func NewLeaderboardService(seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, stats *StatsService) *LeaderboardService {
+	return &LeaderboardService{
+		seriesRepo: seriesRepo,
+		matchRepo:  matchRepo,
+		stats:      stats,
+		rankings:   map[string]string{},
+	}
+}
+
+// SetCacheManager enables caching each match's player lines between balls
+func (s *LeaderboardService) SetCacheManager(cacheManager *cache.CacheManager) {
+	s.cache = cacheManager
+}
+
+// SetOrganizationService enables tenant scoping of leaderboards by organization
+func (s *LeaderboardService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// SetBroadcaster enables pushing ranking changes to series WebSocket rooms
+func (s *LeaderboardService) SetBroadcaster(broadcaster *events.EventBroadcaster) {
+	s.broadcaster = broadcaster
+}
+
+// GetLeaderboards retrieves the leaderboards of a series
+func (s *LeaderboardService) GetLeaderboards(ctx context.Context, seriesID string, query *models.LeaderboardQuery) (*models.SeriesLeaderboards, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("series not found")
+	}
+
+	if query == nil {
+		query = &models.LeaderboardQuery{}
+	}
+	if err := validateLeaderboardQuery(query); err != nil {
+		return nil, err
+	}
+
+	return s.computeLeaderboards(ctx, seriesID, *query)
+}
+
+// BallRecorded drops a match's cached player lines after a ball is added or
+// undone and publishes the series' leaderboards if a ranking changed
+func (s *LeaderboardService) BallRecorded(ctx context.Context, match *models.Match) error {
+	if s.cache != nil {
+		_ = s.cache.Invalidate(leaderboardLinesKey(match.ID))
+	}
+	return s.PublishLeaderboards(ctx, match.SeriesID)
+}
+
+// PublishLeaderboards recomputes a series' leaderboards with the default
+// settings and pushes them to the series WebSocket room when the order of
+// players on any leaderboard has changed since the last push
+func (s *LeaderboardService) PublishLeaderboards(ctx context.Context, seriesID string) error {
+	if s.broadcaster == nil {
+		return nil
+	}
+
+	leaderboards, err := s.computeLeaderboards(ctx, seriesID, models.LeaderboardQuery{})
+	if err != nil {
+		return err
+	}
+
+	ranking := rankingSignature(leaderboards)
+	s.mu.Lock()
+	changed := s.rankings[seriesID] != ranking
+	s.rankings[seriesID] = ranking
+	s.mu.Unlock()
+	if !changed {
+		return nil
+	}
+
+	s.broadcaster.BroadcastLeaderboardUpdate(ctx, seriesID, leaderboards)
+	return nil
+}
+
+// validateLeaderboardQuery checks a query's category, limit and thresholds
+func validateLeaderboardQuery(query *models.LeaderboardQuery) error {
+	if query.Category != "" {
+		if _, ok := leaderboardTitles[query.Category]; !ok {
+			return fmt.Errorf("unknown leaderboard category: %s", query.Category)
+		}
+	}
+	if query.Limit < 0 || query.Limit > models.MaxLeaderboardLimit {
+		return fmt.Errorf("limit must be between 1 and %d", models.MaxLeaderboardLimit)
+	}
+	if query.MinBallsFaced < 0 || query.MinBallsBowled < 0 {
+		return fmt.Errorf("qualifying thresholds cannot be negative")
+	}
+	return nil
+}
+
+// computeLeaderboards ranks the players of every completed and live match of a series
+func (s *LeaderboardService) computeLeaderboards(ctx context.Context, seriesID string, query models.LeaderboardQuery) (*models.SeriesLeaderboards, error) {
+	if query.Limit == 0 {
+		query.Limit = models.DefaultLeaderboardLimit
+	}
+	if query.MinBallsFaced == 0 {
+		query.MinBallsFaced = models.DefaultLeaderboardMinBallsFaced
+	}
+	if query.MinBallsBowled == 0 {
+		query.MinBallsBowled = models.DefaultLeaderboardMinBallsBowled
+	}
+
+	matches, err := s.matchRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series matches: %w", err)
+	}
+	matches = append([]*models.Match{}, matches...)
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].MatchNumber < matches[j].MatchNumber
+	})
+
+	names := map[string]string{}
+	var lines []*models.PlayerMatchStats
+	for _, match := range matches {
+		if match.Status == models.MatchStatusCancelled {
+			continue
+		}
+		matchLines, err := s.matchLines(ctx, match, names)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, matchLines...)
+	}
+
+	categories := models.LeaderboardCategories()
+	if query.Category != "" {
+		categories = []models.LeaderboardCategory{query.Category}
+	}
+
+	totals, teams := seriesTotals(lines)
+	result := &models.SeriesLeaderboards{
+		SeriesID:       seriesID,
+		Limit:          query.Limit,
+		MinBallsFaced:  query.MinBallsFaced,
+		MinBallsBowled: query.MinBallsBowled,
+		Leaderboards:   make([]*models.Leaderboard, 0, len(categories)),
+		UpdatedAt:      time.Now(),
+	}
+	for _, category := range categories {
+		result.Leaderboards = append(result.Leaderboards, &models.Leaderboard{
+			Category: category,
+			Title:    leaderboardTitles[category],
+			Entries:  rankLeaderboard(category, lines, totals, teams, query),
+		})
+	}
+	return result, nil
+}
+
+// matchLines returns a match's player lines from its scorecard, through the
+// cache when one is configured
+func (s *LeaderboardService) matchLines(ctx context.Context, match *models.Match, names map[string]string) ([]*models.PlayerMatchStats, error) {
+	var lines []*models.PlayerMatchStats
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(leaderboardLinesKey(match.ID), &lines, cache.ScorecardTTL, func() (interface{}, error) {
+			return s.stats.computeMatch(ctx, match, names)
+		})
+	} else {
+		lines, err = s.stats.computeMatch(ctx, match, names)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute player lines for match %s: %w", match.ID, err)
+	}
+	return lines, nil
+}
+
+// leaderboardLinesKey returns the cache key of a match's player lines
+func leaderboardLinesKey(matchID string) string {
+	return fmt.Sprintf("leaderboard:lines:match:%s", matchID)
+}
+
+// seriesTotals rolls match lines up into one record per player, with the team
+// each player last played for
+func seriesTotals(lines []*models.PlayerMatchStats) (map[string]*models.PlayerStats, map[string]string) {
+	totals := map[string]*models.PlayerStats{}
+	teams := map[string]string{}
+	for _, line := range lines {
+		total, ok := totals[line.PlayerID]
+		if !ok {
+			total = &models.PlayerStats{PlayerID: line.PlayerID, SeriesID: line.SeriesID}
+			totals[line.PlayerID] = total
+		}
+		addMatchStats(total, line)
+		total.PlayerName = line.PlayerName
+		if line.TeamID != "" {
+			teams[line.PlayerID] = line.TeamID
+		}
+	}
+	for _, total := range totals {
+		fillRates(total)
+	}
+	return totals, teams
+}
+
+// leaderboardCandidate is an entry before ranking. Keys are compared in
+// order, higher first; ascending measures are negated.
+type leaderboardCandidate struct {
+	entry *models.LeaderboardEntry
+	keys  []float64
+}
+
+// rankLeaderboard builds and ranks the entries of one category
+func rankLeaderboard(category models.LeaderboardCategory, lines []*models.PlayerMatchStats, totals map[string]*models.PlayerStats, teams map[string]string, query models.LeaderboardQuery) []*models.LeaderboardEntry {
+	var candidates []leaderboardCandidate
+	add := func(total *models.PlayerStats, value float64, display string, balls int, keys ...float64) {
+		candidates = append(candidates, leaderboardCandidate{
+			entry: &models.LeaderboardEntry{
+				PlayerID:   total.PlayerID,
+				PlayerName: total.PlayerName,
+				TeamID:     teams[total.PlayerID],
+				Value:      value,
+				Display:    display,
+				Matches:    total.Matches,
+				Balls:      balls,
+			},
+			keys: keys,
+		})
+	}
+
+	switch category {
+	case models.LeaderboardHighestScore, models.LeaderboardBestBowling:
+		for _, line := range lines {
+			var candidate leaderboardCandidate
+			switch {
+			case category == models.LeaderboardHighestScore && line.Batted:
+				display := fmt.Sprintf("%d", line.Runs)
+				notOut := 0.0
+				if line.NotOut {
+					display += "*"
+					notOut = 1
+				}
+				candidate = leaderboardCandidate{
+					entry: &models.LeaderboardEntry{Value: float64(line.Runs), Display: display, Balls: line.BallsFaced},
+					keys:  []float64{float64(line.Runs), notOut, -float64(line.BallsFaced)},
+				}
+			case category == models.LeaderboardBestBowling && line.Bowled && line.Wickets > 0:
+				candidate = leaderboardCandidate{
+					entry: &models.LeaderboardEntry{Value: float64(line.Wickets), Display: fmt.Sprintf("%d/%d", line.Wickets, line.RunsConceded), Balls: line.BallsBowled},
+					keys:  []float64{float64(line.Wickets), -float64(line.RunsConceded)},
+				}
+			default:
+				continue
+			}
+			candidate.entry.PlayerID = line.PlayerID
+			candidate.entry.PlayerName = totals[line.PlayerID].PlayerName
+			candidate.entry.TeamID = line.TeamID
+			candidate.entry.MatchID = line.MatchID
+			candidates = append(candidates, candidate)
+		}
+	default:
+		for _, total := range totals {
+			switch category {
+			case models.LeaderboardMostRuns:
+				if total.Runs > 0 {
+					add(total, float64(total.Runs), fmt.Sprintf("%d", total.Runs), total.BallsFaced, float64(total.Runs), -float64(total.BallsFaced))
+				}
+			case models.LeaderboardMostWickets:
+				if total.Wickets > 0 {
+					add(total, float64(total.Wickets), fmt.Sprintf("%d", total.Wickets), total.BallsBowled, float64(total.Wickets), -float64(total.RunsConceded))
+				}
+			case models.LeaderboardMostSixes:
+				if total.Sixes > 0 {
+					add(total, float64(total.Sixes), fmt.Sprintf("%d", total.Sixes), total.BallsFaced, float64(total.Sixes))
+				}
+			case models.LeaderboardBestStrikeRate:
+				if total.BallsFaced >= query.MinBallsFaced {
+					add(total, total.StrikeRate, fmt.Sprintf("%.2f", total.StrikeRate), total.BallsFaced, total.StrikeRate, float64(total.Runs))
+				}
+			case models.LeaderboardBestEconomy:
+				if total.BallsBowled >= query.MinBallsBowled {
+					add(total, total.Economy, fmt.Sprintf("%.2f", total.Economy), total.BallsBowled, -total.Economy, float64(total.BallsBowled))
+				}
+			case models.LeaderboardMostCatches:
+				if total.Catches > 0 {
+					add(total, float64(total.Catches), fmt.Sprintf("%d", total.Catches), 0, float64(total.Catches))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if c := compareKeys(candidates[i].keys, candidates[j].keys); c != 0 {
+			return c > 0
+		}
+		if candidates[i].entry.PlayerName != candidates[j].entry.PlayerName {
+			return candidates[i].entry.PlayerName < candidates[j].entry.PlayerName
+		}
+		return candidates[i].entry.MatchID < candidates[j].entry.MatchID
+	})
+
+	entries := make([]*models.LeaderboardEntry, 0, query.Limit)
+	for i, candidate := range candidates {
+		// Tied entries share the rank of the first of them
+		candidate.entry.Rank = i + 1
+		if i > 0 && compareKeys(candidate.keys, candidates[i-1].keys) == 0 {
+			candidate.entry.Rank = entries[i-1].Rank
+		}
+		if candidate.entry.Rank > query.Limit {
+			break
+		}
+		entries = append(entries, candidate.entry)
+	}
+	return entries
+}
+
+// compareKeys compares two key lists in order: 1 if a ranks higher, -1 if lower, 0 if level
+func compareKeys(a, b []float64) int {
+	for k := range a {
+		switch {
+		case a[k] > b[k]:
+			return 1
+		case a[k] < b[k]:
+			return -1
+		}
+	}
+	return 0
+}
+
+// rankingSignature summarises the order of players on every leaderboard
+func rankingSignature(leaderboards *models.SeriesLeaderboards) string {
+	var b strings.Builder
+	for _, leaderboard := range leaderboards.Leaderboards {
+		b.WriteString(string(leaderboard.Category))
+		for _, entry := range leaderboard.Entries {
+			fmt.Fprintf(&b, "|%d:%s:%s", entry.Rank, entry.PlayerID, entry.MatchID)
+		}
+		b.WriteString(";")
+	}
+	return b.String()
+}
@@ -11,18 +11,79 @@ import (
 
 // MatchService handles business logic for match operations
 type MatchService struct {
-	matchRepo  interfaces.MatchRepository
-	seriesRepo interfaces.SeriesRepository
+	matchRepo    interfaces.MatchRepository
+	seriesRepo   interfaces.SeriesRepository
+	teamRepo     interfaces.TeamRepository
+	venueRepo    interfaces.VenueRepository
+	audit        *AuditService
+	orgs         *OrganizationService
+	stats        *StatsService
+	standings    *StandingsService
+	stages       *StageService
+	leaderboards *LeaderboardService
+	awards       *AwardService
+	audience     *AudienceService
 }
 
-// NewMatchService creates a new match service
-func NewMatchService(matchRepo interfaces.MatchRepository, seriesRepo interfaces.SeriesRepository) *MatchService {
+// NewMatchService creates a new match service. The team repository is used to
+// check the teams a match is played between.
+func NewMatchService(matchRepo interfaces.MatchRepository, seriesRepo interfaces.SeriesRepository, teamRepo interfaces.TeamRepository) *MatchService {
 	return &MatchService{
 		matchRepo:  matchRepo,
 		seriesRepo: seriesRepo,
+		teamRepo:   teamRepo,
 	}
 }
 
+// SetVenueRepository enables booking matches at venues, checked for double-booked pitches
+func (s *MatchService) SetVenueRepository(venueRepo interfaces.VenueRepository) {
+	s.venueRepo = venueRepo
+}
+
+// SetAuditService enables audit logging of match mutations
+func (s *MatchService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of matches by organization
+func (s *MatchService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// SetStatsService enables keeping player statistics in step with match
+// status changes, deletes and restores
+func (s *MatchService) SetStatsService(stats *StatsService) {
+	s.stats = stats
+}
+
+// SetStandingsService enables publishing series standings when a match's
+// result changes
+func (s *MatchService) SetStandingsService(standings *StandingsService) {
+	s.standings = standings
+}
+
+// SetStageService enables advancing series brackets when a match's result changes
+func (s *MatchService) SetStageService(stages *StageService) {
+	s.stages = stages
+}
+
+// SetLeaderboardService enables publishing series leaderboards when a match
+// stops or starts counting towards them
+func (s *MatchService) SetLeaderboardService(leaderboards *LeaderboardService) {
+	s.leaderboards = leaderboards
+}
+
+// SetAwardService enables proposing awards when a match starts or stops
+// counting towards its series
+func (s *MatchService) SetAwardService(awards *AwardService) {
+	s.awards = awards
+}
+
+// SetAudienceService enables saving a match's live audience when it completes
+func (s *MatchService) SetAudienceService(audience *AudienceService) {
+	s.audience = audience
+}
+
 // CreateMatch creates a new match
 func (s *MatchService) CreateMatch(ctx context.Context, req *models.CreateMatchRequest) (*models.Match, error) {
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Starting creation with request: %+v\n", req)
@@ -38,13 +99,23 @@ func (s *MatchService) CreateMatch(ctx context.Context, req *models.CreateMatchR
 
 	// Validate series exists
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Validating series exists with ID: %s\n", req.SeriesID)
-	_, err := s.seriesRepo.GetByID(ctx, req.SeriesID)
+	series, err := s.seriesRepo.GetByID(ctx, req.SeriesID)
 	if err != nil {
 		fmt.Printf("DEBUG: MatchService.CreateMatch - Series validation failed: %v\n", err)
 		return nil, fmt.Errorf("series not found: %w", err)
 	}
+
+	// Matches belong to their series' organization
+	if err := s.orgs.CheckCanContribute(ctx, series.OrganizationID); err != nil {
+		return nil, err
+	}
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Series validation successful\n")
 
+	// Validate the teams playing the match
+	if err := s.validateMatchTeams(ctx, series, req.TeamAID, req.TeamBID); err != nil {
+		return nil, err
+	}
+
 	// Determine match number - use provided number or auto-increment
 	var matchNumber int
 	if req.MatchNumber != nil {
@@ -76,22 +147,38 @@ func (s *MatchService) CreateMatch(ctx context.Context, req *models.CreateMatchR
 
 	// Create match model with toss winner as batting team by default
 	match := &models.Match{
+		OrganizationID:   series.OrganizationID,
 		SeriesID:         req.SeriesID,
 		MatchNumber:      matchNumber,
 		Date:             req.Date,
+		Venue:            req.Venue,
+		VenueID:          req.VenueID,
+		PitchNumber:      req.PitchNumber,
 		Status:           models.MatchStatusLive, // Always live by default
+		TeamAID:          req.TeamAID,
+		TeamBID:          req.TeamBID,
 		TeamAPlayerCount: req.TeamAPlayerCount,
 		TeamBPlayerCount: req.TeamBPlayerCount,
 		TotalOvers:       req.TotalOvers,
 		TossWinner:       req.TossWinner,
 		TossType:         req.TossType,
 		BattingTeam:      req.TossWinner, // Winner of toss bats first
+		Access:           req.Access,
+		InvitedUserIDs:   req.InvitedUserIDs,
 		CreatedBy:        userID,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Created match model: %+v\n", match)
 
+	// The venue's pitch and both teams must be free for the match
+	if err := s.resolveVenue(ctx, series, match); err != nil {
+		return nil, err
+	}
+	if err := s.checkBookings(ctx, match); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Calling repository.Create\n")
 	err = s.matchRepo.Create(ctx, match)
@@ -101,6 +188,7 @@ func (s *MatchService) CreateMatch(ctx context.Context, req *models.CreateMatchR
 	}
 
 	fmt.Printf("DEBUG: MatchService.CreateMatch - Successfully created match with ID: %s\n", match.ID)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityMatch, match.ID, nil, match)
 	return match, nil
 }
 
@@ -120,6 +208,10 @@ func (s *MatchService) GetMatch(ctx context.Context, id string) (*models.Match,
 		return nil, fmt.Errorf("failed to get match: %w", err)
 	}
 
+	if err := s.orgs.CheckVisible(ctx, match.OrganizationID); err != nil {
+		return nil, fmt.Errorf("failed to get match: match not found")
+	}
+
 	fmt.Printf("DEBUG: MatchService.GetMatch - Successfully retrieved match: %+v\n", match)
 	return match, nil
 }
@@ -135,6 +227,14 @@ func (s *MatchService) ListMatches(ctx context.Context, filters *models.MatchFil
 	if filters.Limit > 100 {
 		filters.Limit = 100
 	}
+
+	// Scope to the caller's organizations
+	filters.OnlyOrganizations = filters.OrganizationIDs != nil
+	organizationIDs, err := s.orgs.ScopeFilter(ctx, filters.OrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	filters.OrganizationIDs = organizationIDs
 	fmt.Printf("DEBUG: MatchService.ListMatches - Using filters: %+v\n", filters)
 
 	fmt.Printf("DEBUG: MatchService.ListMatches - Calling repository.GetAll\n")
@@ -183,6 +283,9 @@ func (s *MatchService) UpdateMatch(ctx context.Context, id string, req *models.U
 		fmt.Printf("DEBUG: MatchService.UpdateMatch - Access denied: user %s cannot update match created by %s\n", userID, match.CreatedBy)
 		return nil, fmt.Errorf("access denied: you can only update matches you created")
 	}
+	before := s.audit.Snapshot(match)
+	wasCompleted := match.Status == models.MatchStatusCompleted
+	wasStatus := match.Status
 
 	// Update fields if provided
 	if req.MatchNumber != nil {
@@ -191,6 +294,9 @@ func (s *MatchService) UpdateMatch(ctx context.Context, id string, req *models.U
 	if req.Date != nil {
 		match.Date = *req.Date
 	}
+	if req.Venue != nil {
+		match.Venue = *req.Venue
+	}
 	if req.Status != nil {
 		match.Status = *req.Status
 	}
@@ -206,6 +312,53 @@ func (s *MatchService) UpdateMatch(ctx context.Context, id string, req *models.U
 	if req.BattingTeam != nil {
 		match.BattingTeam = *req.BattingTeam
 	}
+	if req.Access != nil {
+		match.Access = *req.Access
+	}
+	if req.InvitedUserIDs != nil {
+		match.InvitedUserIDs = *req.InvitedUserIDs
+	}
+	if req.TeamAID != nil || req.TeamBID != nil {
+		teamAID, teamBID := match.TeamAID, match.TeamBID
+		if req.TeamAID != nil {
+			teamAID = *req.TeamAID
+		}
+		if req.TeamBID != nil {
+			teamBID = *req.TeamBID
+		}
+		series, err := s.seriesRepo.GetByID(ctx, match.SeriesID)
+		if err != nil {
+			return nil, fmt.Errorf("series not found: %w", err)
+		}
+		if err := s.validateMatchTeams(ctx, series, teamAID, teamBID); err != nil {
+			return nil, err
+		}
+		match.TeamAID = teamAID
+		match.TeamBID = teamBID
+	}
+	if req.VenueID != nil {
+		match.VenueID = *req.VenueID
+		if match.VenueID == "" {
+			match.PitchNumber = 0
+		}
+	}
+	if req.PitchNumber != nil {
+		match.PitchNumber = *req.PitchNumber
+	}
+	if bookingChanged(req) {
+		if req.VenueID != nil || req.PitchNumber != nil {
+			series, err := s.seriesRepo.GetByID(ctx, match.SeriesID)
+			if err != nil {
+				return nil, fmt.Errorf("series not found: %w", err)
+			}
+			if err := s.resolveVenue(ctx, series, match); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkBookings(ctx, match); err != nil {
+			return nil, err
+		}
+	}
 
 	match.UpdatedAt = time.Now()
 
@@ -215,10 +368,21 @@ func (s *MatchService) UpdateMatch(ctx context.Context, id string, req *models.U
 		return nil, fmt.Errorf("failed to update match: %w", err)
 	}
 
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityMatch, id, before, match)
+	if isCompleted := match.Status == models.MatchStatusCompleted; isCompleted != wasCompleted {
+		s.syncStats(ctx, id, isCompleted)
+		s.syncAwards(ctx, match, isCompleted)
+		if isCompleted {
+			s.saveAudience(ctx, id)
+		}
+	}
+	if match.Status != wasStatus {
+		s.seriesResultsChanged(ctx, match.SeriesID)
+	}
 	return match, nil
 }
 
-// DeleteMatch deletes a match
+// DeleteMatch moves a match and its innings to the trash
 func (s *MatchService) DeleteMatch(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("match ID is required")
@@ -246,15 +410,146 @@ func (s *MatchService) DeleteMatch(ctx context.Context, id string) error {
 		return fmt.Errorf("cannot delete a live match")
 	}
 
-	// Delete match
-	err = s.matchRepo.Delete(ctx, id)
+	// Soft delete match
+	err = s.matchRepo.SoftDelete(ctx, id, time.Now().UTC().Truncate(time.Microsecond))
 	if err != nil {
 		return fmt.Errorf("failed to delete match: %w", err)
 	}
 
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityMatch, id, match, nil)
+	if match.Status == models.MatchStatusCompleted {
+		s.syncStats(ctx, id, false)
+		s.syncAwards(ctx, match, false)
+	}
+	s.seriesResultsChanged(ctx, match.SeriesID)
 	return nil
 }
 
+// ListDeletedMatches retrieves the caller's matches that are in the trash
+func (s *MatchService) ListDeletedMatches(ctx context.Context, filters *models.MatchFilters) ([]*models.Match, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	// Set default values
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+	filters.Deleted = true
+	filters.CreatedBy = &userID
+
+	matches, err := s.matchRepo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+// RestoreMatch brings a match and its innings back from the trash
+func (s *MatchService) RestoreMatch(ctx context.Context, id string) (*models.Match, error) {
+	if id == "" {
+		return nil, fmt.Errorf("match ID is required")
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	match, err := s.matchRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("match not found in trash: %w", err)
+	}
+
+	// Check ownership
+	if match.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only restore matches you created")
+	}
+
+	// A match cannot outlive its series
+	if _, err := s.seriesRepo.GetByID(ctx, match.SeriesID); err != nil {
+		return nil, fmt.Errorf("cannot restore match: its series is deleted, restore the series first")
+	}
+	before := s.audit.Snapshot(match)
+
+	if err := s.matchRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore match: %w", err)
+	}
+
+	match.DeletedAt = nil
+	s.audit.Record(ctx, models.AuditActionRestore, models.AuditEntityMatch, id, before, match)
+	if match.Status == models.MatchStatusCompleted {
+		s.syncStats(ctx, id, true)
+		s.syncAwards(ctx, match, true)
+	}
+	s.seriesResultsChanged(ctx, match.SeriesID)
+	return match, nil
+}
+
+// syncStats records or removes a match's player statistics. Failures are only
+// logged because statistics can always be rebuilt.
+func (s *MatchService) syncStats(ctx context.Context, matchID string, record bool) {
+	if s.stats == nil {
+		return
+	}
+
+	var err error
+	if record {
+		err = s.stats.RecordMatch(ctx, matchID)
+	} else {
+		err = s.stats.RemoveMatch(ctx, matchID)
+	}
+	if err != nil {
+		log.Printf("Error syncing player statistics for match %s: %v", matchID, err)
+	}
+}
+
+// syncAwards proposes or withdraws a match's player of the match and
+// refreshes its series awards. Failures are only logged because proposals
+// are recomputed on the next result.
+func (s *MatchService) syncAwards(ctx context.Context, match *models.Match, counts bool) {
+	if s.awards == nil {
+		return
+	}
+	if err := s.awards.MatchResultChanged(ctx, match, counts); err != nil {
+		log.Printf("Error syncing awards for match %s: %v", match.ID, err)
+	}
+}
+
+// saveAudience saves a completed match's audience. Failures are only logged
+// because the audience stays available while the instance runs.
+func (s *MatchService) saveAudience(ctx context.Context, matchID string) {
+	if err := s.audience.MatchCompleted(ctx, matchID); err != nil {
+		log.Printf("Error saving audience for match %s: %v", matchID, err)
+	}
+}
+
+// seriesResultsChanged advances a series' brackets and pushes its points
+// table and leaderboards to its WebSocket room. All three are derived from
+// the results, so failures are only logged.
+func (s *MatchService) seriesResultsChanged(ctx context.Context, seriesID string) {
+	if s.stages != nil {
+		if err := s.stages.Advance(ctx, seriesID); err != nil {
+			log.Printf("Error advancing stages for series %s: %v", seriesID, err)
+		}
+	}
+	if s.standings != nil {
+		if err := s.standings.PublishStandings(ctx, seriesID); err != nil {
+			log.Printf("Error publishing standings for series %s: %v", seriesID, err)
+		}
+	}
+	if s.leaderboards != nil {
+		if err := s.leaderboards.PublishLeaderboards(ctx, seriesID); err != nil {
+			log.Printf("Error publishing leaderboards for series %s: %v", seriesID, err)
+		}
+	}
+}
+
 // GetMatchesBySeries retrieves all matches for a specific series
 func (s *MatchService) GetMatchesBySeries(ctx context.Context, seriesID string) ([]*models.Match, error) {
 	if seriesID == "" {
@@ -266,5 +561,137 @@ func (s *MatchService) GetMatchesBySeries(ctx context.Context, seriesID string)
 		return nil, fmt.Errorf("failed to get matches by series: %w", err)
 	}
 
+	// Matches share their series' organization
+	if len(matches) > 0 {
+		if err := s.orgs.CheckVisible(ctx, matches[0].OrganizationID); err != nil {
+			return nil, fmt.Errorf("series not found")
+		}
+	}
+
 	return matches, nil
 }
+
+// validateMatchTeams checks that a match's teams exist, are distinct and are
+// visible to the caller. Both IDs may be empty for matches that are not linked
+// to teams, but one cannot be set without the other.
+func (s *MatchService) validateMatchTeams(ctx context.Context, series *models.Series, teamAID, teamBID string) error {
+	if teamAID == "" && teamBID == "" {
+		return nil
+	}
+	if teamAID == "" || teamBID == "" {
+		return fmt.Errorf("team_a_id and team_b_id must be provided together")
+	}
+	if teamAID == teamBID {
+		return fmt.Errorf("team A and team B must be different teams")
+	}
+
+	sides := []struct {
+		side   models.TeamType
+		teamID string
+	}{{models.TeamTypeA, teamAID}, {models.TeamTypeB, teamBID}}
+	for _, side := range sides {
+		team, err := s.teamRepo.GetByID(ctx, side.teamID)
+		if err != nil {
+			return fmt.Errorf("team %s not found: %w", side.side, err)
+		}
+		if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+			return fmt.Errorf("team %s not found", side.side)
+		}
+		// Teams from another organization cannot play in its series
+		if series.OrganizationID != "" && team.OrganizationID != "" && team.OrganizationID != series.OrganizationID {
+			return fmt.Errorf("team %s belongs to a different organization than the series", side.side)
+		}
+	}
+
+	return nil
+}
+
+// resolveVenue checks a match's venue and pitch and names the match's ground
+// after the venue. Matches without a venue ID keep their free-text venue.
+func (s *MatchService) resolveVenue(ctx context.Context, series *models.Series, match *models.Match) error {
+	if match.VenueID == "" {
+		if match.PitchNumber != 0 {
+			return fmt.Errorf("pitch_number requires a venue_id")
+		}
+		return nil
+	}
+	if s.venueRepo == nil {
+		return fmt.Errorf("venue bookings are not available")
+	}
+
+	venue, err := s.venueRepo.GetByID(ctx, match.VenueID)
+	if err != nil {
+		return fmt.Errorf("venue not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, venue.OrganizationID); err != nil {
+		return fmt.Errorf("venue not found")
+	}
+	// Venues from another organization cannot host its series
+	if series.OrganizationID != "" && venue.OrganizationID != "" && venue.OrganizationID != series.OrganizationID {
+		return fmt.Errorf("venue belongs to a different organization than the series")
+	}
+
+	if match.PitchNumber == 0 {
+		match.PitchNumber = 1
+	}
+	if match.PitchNumber < 1 || match.PitchNumber > venue.Pitches {
+		return fmt.Errorf("pitch %d does not exist at %s, which has %d pitches", match.PitchNumber, venue.Name, venue.Pitches)
+	}
+	match.Venue = venue.Name
+	return nil
+}
+
+// checkBookings ensures a match's pitch and teams are free for its estimated
+// length. Cancelled matches do not hold bookings.
+func (s *MatchService) checkBookings(ctx context.Context, match *models.Match) error {
+	if match.Status == models.MatchStatusCancelled {
+		return nil
+	}
+	if match.VenueID == "" && match.TeamAID == "" && match.TeamBID == "" {
+		return nil
+	}
+
+	start := match.Date
+	end := start.Add(models.EstimatedMatchDuration(match.TotalOvers))
+	// The longest match starting this far back may still be running at the start
+	from := start.Add(-models.EstimatedMatchDuration(models.MaxMatchOvers))
+	others, err := s.matchRepo.GetByDateRange(ctx, from, end)
+	if err != nil {
+		return fmt.Errorf("failed to check bookings: %w", err)
+	}
+
+	for _, other := range others {
+		if other.ID == match.ID || other.Status == models.MatchStatusCancelled {
+			continue
+		}
+		otherEnd := other.Date.Add(models.EstimatedMatchDuration(other.TotalOvers))
+		if !other.Date.Before(end) || !start.Before(otherEnd) {
+			continue
+		}
+
+		if match.VenueID != "" && other.VenueID == match.VenueID && other.PitchNumber == match.PitchNumber {
+			return fmt.Errorf("pitch %d at %s is already booked from %s to %s", match.PitchNumber, match.Venue,
+				other.Date.Format(time.RFC3339), otherEnd.Format(time.RFC3339))
+		}
+		for _, side := range []struct {
+			side   models.TeamType
+			teamID string
+		}{{models.TeamTypeA, match.TeamAID}, {models.TeamTypeB, match.TeamBID}} {
+			if side.teamID != "" && (side.teamID == other.TeamAID || side.teamID == other.TeamBID) {
+				return fmt.Errorf("team %s is already playing another match from %s to %s", side.side,
+					other.Date.Format(time.RFC3339), otherEnd.Format(time.RFC3339))
+			}
+		}
+	}
+
+	return nil
+}
+
+// bookingChanged reports whether an update moves a match's pitch, teams or
+// time slot, or brings it back from cancellation
+func bookingChanged(req *models.UpdateMatchRequest) bool {
+	return req.Date != nil || req.TotalOvers != nil ||
+		(req.Status != nil && *req.Status == models.MatchStatusLive) ||
+		req.VenueID != nil || req.PitchNumber != nil ||
+		req.TeamAID != nil || req.TeamBID != nil
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+)
+
+// MatchSquadService handles playing XI selection for matches. Squads can be
+// redeclared freely until the first ball is bowled; after that only audited
+// substitutions are allowed.
+type MatchSquadService struct {
+	matchRepo     interfaces.MatchRepository
+	squadRepo     interfaces.MatchSquadRepository
+	playerRepo    interfaces.PlayerRepository
+	scorecardRepo interfaces.ScorecardRepository
+	audit         *AuditService
+	orgs          *OrganizationService
+}
+
+// NewMatchSquadService creates a new match squad service
+func NewMatchSquadService(matchRepo interfaces.MatchRepository, squadRepo interfaces.MatchSquadRepository, playerRepo interfaces.PlayerRepository, scorecardRepo interfaces.ScorecardRepository) *MatchSquadService {
+	return &MatchSquadService{
+		matchRepo:     matchRepo,
+		squadRepo:     squadRepo,
+		playerRepo:    playerRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// SetAuditService enables audit logging of squad changes
+func (s *MatchSquadService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of squads by organization
+func (s *MatchSquadService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// GetSquads retrieves both declared squads of a match
+func (s *MatchSquadService) GetSquads(ctx context.Context, matchID string) (*models.MatchSquadsResponse, error) {
+	if matchID == "" {
+		return nil, fmt.Errorf("match ID is required")
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("match not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, match.OrganizationID); err != nil {
+		return nil, fmt.Errorf("match not found")
+	}
+
+	players, err := s.squadRepo.GetByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get squads: %w", err)
+	}
+	sortSquad(players)
+
+	var teamA, teamB []*models.MatchSquadPlayer
+	for _, player := range players {
+		if player.Team == models.TeamTypeA {
+			teamA = append(teamA, player)
+		} else {
+			teamB = append(teamB, player)
+		}
+	}
+
+	response := &models.MatchSquadsResponse{MatchID: matchID}
+	if len(teamA) > 0 {
+		response.TeamA = buildSquad(match, models.TeamTypeA, teamA)
+	}
+	if len(teamB) > 0 {
+		response.TeamB = buildSquad(match, models.TeamTypeB, teamB)
+	}
+	return response, nil
+}
+
+// SetSquad declares the squad for one side of a match, replacing any earlier
+// declaration. The side's player count on the match follows the playing XI.
+func (s *MatchSquadService) SetSquad(ctx context.Context, matchID string, team models.TeamType, req *models.SetMatchSquadRequest) (*models.MatchSquad, error) {
+	match, err := s.getOwnedMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	started, err := s.hasFirstBall(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if started {
+		return nil, fmt.Errorf("squads are locked once the first ball is bowled, use a substitution instead")
+	}
+
+	teamID, err := squadTeamID(match, team)
+	if err != nil {
+		return nil, err
+	}
+
+	roster, err := s.rosterByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := buildSquadPlayers(matchID, team, req.Players, roster)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.squadRepo.GetByMatchAndTeam(ctx, matchID, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get squad: %w", err)
+	}
+	before := s.audit.Snapshot(previous)
+
+	if err := s.squadRepo.ReplaceSquad(ctx, matchID, team, players); err != nil {
+		return nil, fmt.Errorf("failed to save squad: %w", err)
+	}
+
+	// Wicket limits follow the declared XI
+	squad := buildSquad(match, team, players)
+	if team == models.TeamTypeA {
+		match.TeamAPlayerCount = squad.PlayingCount
+	} else {
+		match.TeamBPlayerCount = squad.PlayingCount
+	}
+	match.UpdatedAt = time.Now()
+	if err := s.matchRepo.Update(ctx, matchID, match); err != nil {
+		return nil, fmt.Errorf("failed to update match player count: %w", err)
+	}
+
+	action := models.AuditActionUpdate
+	if len(previous) == 0 {
+		action = models.AuditActionCreate
+	}
+	s.audit.Record(ctx, action, models.AuditEntityMatchSquad, matchID, before, squad)
+	return squad, nil
+}
+
+// Substitute swaps a playing player for a named substitute. The incoming
+// player takes over the batting position and keeping duties; the playing
+// count does not change. Substitutions are allowed while the match is live and
+// are always audited with their reason.
+func (s *MatchSquadService) Substitute(ctx context.Context, matchID string, team models.TeamType, req *models.SquadSubstitutionRequest) (*models.MatchSquad, error) {
+	if req.Reason == "" {
+		return nil, fmt.Errorf("a reason is required for substitutions")
+	}
+
+	match, err := s.getOwnedMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := squadTeamID(match, team); err != nil {
+		return nil, err
+	}
+
+	players, err := s.squadRepo.GetByMatchAndTeam(ctx, matchID, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get squad: %w", err)
+	}
+	if len(players) == 0 {
+		return nil, fmt.Errorf("team %s has not declared a squad", team)
+	}
+	before := s.audit.Snapshot(players)
+
+	var out, in *models.MatchSquadPlayer
+	for _, player := range players {
+		switch player.PlayerID {
+		case req.PlayerOutID:
+			out = player
+		case req.PlayerInID:
+			in = player
+		}
+	}
+	if out == nil || out.IsSubstitute {
+		return nil, fmt.Errorf("player %s is not in the playing XI", req.PlayerOutID)
+	}
+	if in == nil || !in.IsSubstitute {
+		return nil, fmt.Errorf("player %s is not a named substitute", req.PlayerInID)
+	}
+	if out.IsCaptain {
+		return nil, fmt.Errorf("the captain cannot be substituted")
+	}
+
+	now := time.Now()
+	in.IsSubstitute = false
+	in.BattingOrder = out.BattingOrder
+	in.IsWicketkeeper = out.IsWicketkeeper
+	in.UpdatedAt = now
+	out.IsSubstitute = true
+	out.BattingOrder = 0
+	out.IsWicketkeeper = false
+	out.UpdatedAt = now
+
+	if err := s.squadRepo.Update(ctx, out.ID, out); err != nil {
+		return nil, fmt.Errorf("failed to update squad: %w", err)
+	}
+	if err := s.squadRepo.Update(ctx, in.ID, in); err != nil {
+		return nil, fmt.Errorf("failed to update squad: %w", err)
+	}
+
+	sortSquad(players)
+	squad := buildSquad(match, team, players)
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityMatchSquad, matchID, before, map[string]interface{}{
+		"squad":        squad,
+		"substitution": req,
+	})
+	return squad, nil
+}
+
+// getOwnedMatch loads a live match that the caller created
+func (s *MatchSquadService) getOwnedMatch(ctx context.Context, matchID string) (*models.Match, error) {
+	if matchID == "" {
+		return nil, fmt.Errorf("match ID is required")
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("match not found: %w", err)
+	}
+	if match.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only select squads for matches you created")
+	}
+	if match.Status != models.MatchStatusLive {
+		return nil, fmt.Errorf("match is not live, squads can no longer change")
+	}
+
+	return match, nil
+}
+
+// hasFirstBall reports whether any ball has been recorded for the match
+func (s *MatchSquadService) hasFirstBall(ctx context.Context, matchID string) (bool, error) {
+	innings, err := s.scorecardRepo.GetInningsByMatchID(ctx, matchID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check scoring state: %w", err)
+	}
+	for _, inn := range innings {
+		if inn.TotalBalls > 0 || inn.TotalRuns > 0 || inn.TotalWickets > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rosterByID loads a team's live roster keyed by player ID
+func (s *MatchSquadService) rosterByID(ctx context.Context, teamID string) (map[string]*models.Player, error) {
+	players, err := s.playerRepo.GetByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team roster: %w", err)
+	}
+
+	roster := make(map[string]*models.Player, len(players))
+	for _, player := range players {
+		roster[player.ID] = player
+	}
+	return roster, nil
+}
+
+// squadTeamID returns the team linked to one side of the match
+func squadTeamID(match *models.Match, team models.TeamType) (string, error) {
+	var teamID string
+	switch team {
+	case models.TeamTypeA:
+		teamID = match.TeamAID
+	case models.TeamTypeB:
+		teamID = match.TeamBID
+	default:
+		return "", fmt.Errorf("team must be A or B")
+	}
+	if teamID == "" {
+		return "", fmt.Errorf("match is not linked to teams, set team_a_id and team_b_id first")
+	}
+	return teamID, nil
+}
+
+// buildSquadPlayers validates a squad declaration against the team roster.
+// The playing XI needs exactly one captain and one wicketkeeper and a batting
+// order of 1..n; when no batting order is given the request order is used.
+func buildSquadPlayers(matchID string, team models.TeamType, requested []models.SquadPlayerRequest, roster map[string]*models.Player) ([]*models.MatchSquadPlayer, error) {
+	now := time.Now()
+	seen := make(map[string]bool, len(requested))
+	playing := 0
+	captains := 0
+	keepers := 0
+	ordered := 0
+
+	players := make([]*models.MatchSquadPlayer, 0, len(requested))
+	for _, req := range requested {
+		player, ok := roster[req.PlayerID]
+		if !ok {
+			return nil, fmt.Errorf("player %s is not on the team roster", req.PlayerID)
+		}
+		if seen[req.PlayerID] {
+			return nil, fmt.Errorf("player %s is selected more than once", req.PlayerID)
+		}
+		seen[req.PlayerID] = true
+
+		if req.IsSubstitute {
+			if req.IsCaptain || req.IsWicketkeeper {
+				return nil, fmt.Errorf("substitute %s cannot be captain or wicketkeeper", player.Name)
+			}
+			if req.BattingOrder != 0 {
+				return nil, fmt.Errorf("substitute %s cannot have a batting order", player.Name)
+			}
+		} else {
+			playing++
+			if req.BattingOrder > 0 {
+				ordered++
+			}
+		}
+		if req.IsCaptain {
+			captains++
+		}
+		if req.IsWicketkeeper {
+			keepers++
+		}
+
+		players = append(players, &models.MatchSquadPlayer{
+			MatchID:        matchID,
+			Team:           team,
+			PlayerID:       player.ID,
+			PlayerName:     player.Name,
+			BattingOrder:   req.BattingOrder,
+			IsCaptain:      req.IsCaptain,
+			IsWicketkeeper: req.IsWicketkeeper,
+			IsSubstitute:   req.IsSubstitute,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	if playing < models.MinPlayingXI || playing > models.MaxTeamPlayers {
+		return nil, fmt.Errorf("playing XI must have between %d and %d players, got %d", models.MinPlayingXI, models.MaxTeamPlayers, playing)
+	}
+	if captains != 1 {
+		return nil, fmt.Errorf("squad must have exactly one captain, got %d", captains)
+	}
+	if keepers != 1 {
+		return nil, fmt.Errorf("squad must have exactly one wicketkeeper, got %d", keepers)
+	}
+
+	switch ordered {
+	case 0:
+		position := 1
+		for _, player := range players {
+			if !player.IsSubstitute {
+				player.BattingOrder = position
+				position++
+			}
+		}
+	case playing:
+		positions := make(map[int]bool, playing)
+		for _, player := range players {
+			if player.IsSubstitute {
+				continue
+			}
+			if player.BattingOrder > playing || positions[player.BattingOrder] {
+				return nil, fmt.Errorf("batting order must use each position from 1 to %d once", playing)
+			}
+			positions[player.BattingOrder] = true
+		}
+	default:
+		return nil, fmt.Errorf("batting order must be given for all playing players or none")
+	}
+
+	sortSquad(players)
+	return players, nil
+}
+
+// sortSquad orders the playing XI by batting order followed by substitutes
+func sortSquad(players []*models.MatchSquadPlayer) {
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].IsSubstitute != players[j].IsSubstitute {
+			return !players[i].IsSubstitute
+		}
+		return players[i].BattingOrder < players[j].BattingOrder
+	})
+}
+
+// buildSquad assembles one side's squad response
+func buildSquad(match *models.Match, team models.TeamType, players []*models.MatchSquadPlayer) *models.MatchSquad {
+	teamID := match.TeamAID
+	if team == models.TeamTypeB {
+		teamID = match.TeamBID
+	}
+
+	playing := 0
+	for _, player := range players {
+		if !player.IsSubstitute {
+			playing++
+		}
+	}
+
+	return &models.MatchSquad{
+		MatchID:      match.ID,
+		Team:         team,
+		TeamID:       teamID,
+		PlayingCount: playing,
+		Players:      players,
+	}
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"strings"
+	"time"
+)
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// OrganizationService handles business logic for organizations, memberships and tenant scoping
+type OrganizationService struct {
+	orgRepo interfaces.OrganizationRepository
+	audit   *AuditService
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(orgRepo interfaces.OrganizationRepository) *OrganizationService {
+	return &OrganizationService{
+		orgRepo: orgRepo,
+	}
+}
+
+// SetAuditService enables audit logging of organization mutations
+func (s *OrganizationService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// CreateOrganization creates a new organization with the caller as owner
+func (s *OrganizationService) CreateOrganization(ctx context.Context, req *models.CreateOrganizationRequest) (*models.Organization, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("organization name is required")
+	}
+
+	slug := req.Slug
+	if slug == "" {
+		slug = req.Name
+	}
+	slug = strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(slug), "-"), "-")
+	if slug == "" {
+		return nil, fmt.Errorf("organization slug must contain letters or numbers")
+	}
+
+	// Slugs are unique across the deployment
+	if existing, err := s.orgRepo.GetBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, fmt.Errorf("organization slug '%s' is already taken", slug)
+	}
+
+	org := &models.Organization{
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	// Creator becomes the first owner
+	owner := &models.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Role:           models.OrganizationRoleOwner,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := s.orgRepo.AddMember(ctx, owner); err != nil {
+		return nil, fmt.Errorf("failed to add owner to organization: %w", err)
+	}
+
+	log.Printf("Created organization %s (%s) owned by %s", org.Name, org.ID, userID)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityOrganization, org.ID, nil, org)
+	return org, nil
+}
+
+// GetOrganization retrieves an organization visible to the caller
+func (s *OrganizationService) GetOrganization(ctx context.Context, id string) (*models.Organization, error) {
+	if id == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	if err := s.CheckVisible(ctx, id); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// ListOrganizations retrieves organizations the caller belongs to plus public organizations
+func (s *OrganizationService) ListOrganizations(ctx context.Context, filters *models.OrganizationFilters) ([]*models.Organization, error) {
+	// Set default values
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+
+	visibleIDs, err := s.VisibleOrganizationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if visibleIDs != nil {
+		if len(visibleIDs) == 0 {
+			return []*models.Organization{}, nil
+		}
+		filters.IDs = visibleIDs
+	}
+
+	orgs, err := s.orgRepo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// UpdateOrganization updates an organization's settings (owners and admins only)
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, id string, req *models.UpdateOrganizationRequest) (*models.Organization, error) {
+	if id == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	if _, err := s.requireRole(ctx, id, models.OrganizationRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	before := s.audit.Snapshot(org)
+
+	if req.Name != nil {
+		org.Name = *req.Name
+	}
+	if req.Description != nil {
+		org.Description = *req.Description
+	}
+	if req.IsPublic != nil {
+		org.IsPublic = *req.IsPublic
+	}
+	org.UpdatedAt = time.Now()
+
+	if err := s.orgRepo.Update(ctx, id, org); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityOrganization, id, before, org)
+	return org, nil
+}
+
+// DeleteOrganization deletes an organization (owners only)
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("organization ID is required")
+	}
+
+	if _, err := s.requireRole(ctx, id, func(r models.OrganizationRole) bool { return r == models.OrganizationRoleOwner }); err != nil {
+		return err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("organization not found: %w", err)
+	}
+
+	if err := s.orgRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityOrganization, id, org, nil)
+	return nil
+}
+
+// ListMembers retrieves the members of an organization (members only)
+func (s *OrganizationService) ListMembers(ctx context.Context, organizationID string) ([]*models.OrganizationMember, error) {
+	if _, err := s.requireRole(ctx, organizationID, models.OrganizationRole.IsValid); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMembers(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// AddMember adds a user to an organization (owners and admins only)
+func (s *OrganizationService) AddMember(ctx context.Context, organizationID string, req *models.AddOrganizationMemberRequest) (*models.OrganizationMember, error) {
+	caller, err := s.requireRole(ctx, organizationID, models.OrganizationRole.CanManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", req.Role)
+	}
+	if req.Role == models.OrganizationRoleOwner && caller.Role != models.OrganizationRoleOwner {
+		return nil, fmt.Errorf("access denied: only owners can add owners")
+	}
+
+	if existing, err := s.orgRepo.GetMember(ctx, organizationID, req.UserID); err == nil && existing != nil {
+		return nil, fmt.Errorf("user is already a member of this organization")
+	}
+
+	member := &models.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         req.UserID,
+		Role:           req.Role,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := s.orgRepo.AddMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityOrganizationMember, member.ID, nil, member)
+	return member, nil
+}
+
+// UpdateMemberRole changes a member's role (owners and admins only)
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, organizationID, userID string, req *models.UpdateOrganizationMemberRequest) (*models.OrganizationMember, error) {
+	caller, err := s.requireRole(ctx, organizationID, models.OrganizationRole.CanManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", req.Role)
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("member not found: %w", err)
+	}
+	before := s.audit.Snapshot(member)
+
+	// Only owners can grant or revoke ownership
+	if (req.Role == models.OrganizationRoleOwner || member.Role == models.OrganizationRoleOwner) && caller.Role != models.OrganizationRoleOwner {
+		return nil, fmt.Errorf("access denied: only owners can change ownership")
+	}
+	if member.Role == models.OrganizationRoleOwner && req.Role != models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, organizationID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = req.Role
+	member.UpdatedAt = time.Now()
+	if err := s.orgRepo.UpdateMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityOrganizationMember, member.ID, before, member)
+	return member, nil
+}
+
+// RemoveMember removes a user from an organization. Owners and admins can remove
+// others; any member can remove themselves.
+func (s *OrganizationService) RemoveMember(ctx context.Context, organizationID, userID string) error {
+	callerID, ok := ctx.Value("user_id").(string)
+	if !ok || callerID == "" {
+		return fmt.Errorf("user authentication required")
+	}
+
+	var caller *models.OrganizationMember
+	var err error
+	if callerID == userID {
+		caller, err = s.requireRole(ctx, organizationID, models.OrganizationRole.IsValid)
+	} else {
+		caller, err = s.requireRole(ctx, organizationID, models.OrganizationRole.CanManage)
+	}
+	if err != nil {
+		return err
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("member not found: %w", err)
+	}
+
+	if member.Role == models.OrganizationRoleOwner {
+		if caller.Role != models.OrganizationRoleOwner {
+			return fmt.Errorf("access denied: only owners can remove owners")
+		}
+		if err := s.ensureAnotherOwner(ctx, organizationID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, organizationID, userID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityOrganizationMember, member.ID, member, nil)
+	return nil
+}
+
+// VisibleOrganizationIDs returns the organizations whose content the caller can
+// read: their memberships plus public organizations. A nil result means the
+// caller should not be scoped: an admin, or a nil service when tenancy is not
+// configured.
+func (s *OrganizationService) VisibleOrganizationIDs(ctx context.Context) ([]string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if isAdmin, ok := ctx.Value("is_admin").(bool); ok && isAdmin {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool)
+	ids := []string{}
+
+	if userID, ok := ctx.Value("user_id").(string); ok && userID != "" {
+		memberships, err := s.orgRepo.GetMembershipsByUser(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get memberships: %w", err)
+		}
+		for _, m := range memberships {
+			if !seen[m.OrganizationID] {
+				seen[m.OrganizationID] = true
+				ids = append(ids, m.OrganizationID)
+			}
+		}
+	}
+
+	public, err := s.orgRepo.GetPublic(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public organizations: %w", err)
+	}
+	for _, org := range public {
+		if !seen[org.ID] {
+			seen[org.ID] = true
+			ids = append(ids, org.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// CheckVisible returns an error if content owned by the organization is not
+// visible to the caller. Content without an organization is always visible.
+func (s *OrganizationService) CheckVisible(ctx context.Context, organizationID string) error {
+	if s == nil || organizationID == "" {
+		return nil
+	}
+
+	visibleIDs, err := s.VisibleOrganizationIDs(ctx)
+	if err != nil {
+		return err
+	}
+	if visibleIDs == nil {
+		return nil
+	}
+	for _, id := range visibleIDs {
+		if id == organizationID {
+			return nil
+		}
+	}
+
+	// Report as not found so private organizations are not revealed
+	return fmt.Errorf("organization not found")
+}
+
+// CheckCanContribute returns an error if the caller cannot create content in the organization
+func (s *OrganizationService) CheckCanContribute(ctx context.Context, organizationID string) error {
+	if s == nil || organizationID == "" {
+		return nil
+	}
+	_, err := s.requireRole(ctx, organizationID, models.OrganizationRole.CanContribute)
+	return err
+}
+
+// ScopeFilter resolves the tenant scope for a list request. Organizations the
+// caller asked for are narrowed to those visible to them; with no request the
+// scope is every visible organization.
+func (s *OrganizationService) ScopeFilter(ctx context.Context, requested []string) ([]string, error) {
+	visibleIDs, err := s.VisibleOrganizationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if requested == nil {
+		return visibleIDs, nil
+	}
+	if visibleIDs == nil {
+		return requested, nil
+	}
+
+	visible := make(map[string]bool, len(visibleIDs))
+	for _, id := range visibleIDs {
+		visible[id] = true
+	}
+	scoped := []string{}
+	for _, id := range requested {
+		if visible[id] {
+			scoped = append(scoped, id)
+		}
+	}
+	if len(scoped) == 0 {
+		return nil, fmt.Errorf("organization not found")
+	}
+	return scoped, nil
+}
+
+// requireRole loads the caller's membership and checks it with the given predicate
+func (s *OrganizationService) requireRole(ctx context.Context, organizationID string, allowed func(models.OrganizationRole) bool) (*models.OrganizationMember, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("access denied: you are not a member of this organization")
+	}
+	if !allowed(member.Role) {
+		return nil, fmt.Errorf("access denied: your role '%s' does not permit this action", member.Role)
+	}
+
+	return member, nil
+}
+
+// ensureAnotherOwner prevents an organization from being left without an owner
+func (s *OrganizationService) ensureAnotherOwner(ctx context.Context, organizationID, excludingUserID string) error {
+	members, err := s.orgRepo.GetMembers(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to list members: %w", err)
+	}
+	for _, m := range members {
+		if m.Role == models.OrganizationRoleOwner && m.UserID != excludingUserID {
+			return nil
+		}
+	}
+	return fmt.Errorf("an organization must have at least one owner")
+}
@@ -3,15 +3,21 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"strings"
 	"time"
 )
 
-// PlayerService handles business logic for player operations
+// PlayerService handles business logic for player operations. Players are
+// owned through their team: only the team's creator can change its roster.
+// A user who has claimed a player profile may edit that profile too.
 type PlayerService struct {
 	playerRepo interfaces.PlayerRepository
 	teamRepo   interfaces.TeamRepository
+	audit      *AuditService
+	orgs       *OrganizationService
 }
 
 // NewPlayerService creates a new player service
@@ -22,20 +28,50 @@ func NewPlayerService(playerRepo interfaces.PlayerRepository, teamRepo interface
 	}
 }
 
-// CreatePlayer creates a new player
+// SetAuditService enables audit logging of player mutations
+func (s *PlayerService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of players by organization
+func (s *PlayerService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// CreatePlayer creates a new player on a team's roster
 func (s *PlayerService) CreatePlayer(ctx context.Context, req *models.CreatePlayerRequest) (*models.Player, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 2 {
+		return nil, fmt.Errorf("player name must be at least 2 characters")
+	}
+
 	// Validate team exists
-	_, err := s.teamRepo.GetByID(ctx, req.TeamID)
+	team, err := s.teamRepo.GetByID(ctx, req.TeamID)
 	if err != nil {
 		return nil, fmt.Errorf("team not found: %w", err)
 	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+	if err := ensureRosterSpace(ctx, s.playerRepo, team.ID, 1); err != nil {
+		return nil, err
+	}
+	profile := normalizeProfile(req.PlayerProfile)
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+	if err := ensureJerseyAvailable(ctx, s.playerRepo, team.ID, "", profile.JerseyNumber); err != nil {
+		return nil, err
+	}
 
-	// Create player model
+	// Create player model, inheriting the team's organization
 	player := &models.Player{
-		Name:      req.Name,
-		TeamID:    req.TeamID,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		OrganizationID: team.OrganizationID,
+		Name:           name,
+		TeamID:         req.TeamID,
+		PlayerProfile:  profile,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	// Save to repository
@@ -43,7 +79,11 @@ func (s *PlayerService) CreatePlayer(ctx context.Context, req *models.CreatePlay
 	if err != nil {
 		return nil, fmt.Errorf("failed to create player: %w", err)
 	}
+	if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, team.ID); err != nil {
+		return nil, err
+	}
 
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityPlayer, player.ID, nil, player)
 	return player, nil
 }
 
@@ -58,6 +98,10 @@ func (s *PlayerService) GetPlayer(ctx context.Context, id string) (*models.Playe
 		return nil, fmt.Errorf("failed to get player: %w", err)
 	}
 
+	if err := s.orgs.CheckVisible(ctx, player.OrganizationID); err != nil {
+		return nil, fmt.Errorf("failed to get player: player not found")
+	}
+
 	return player, nil
 }
 
@@ -71,6 +115,14 @@ func (s *PlayerService) ListPlayers(ctx context.Context, filters *models.PlayerF
 		filters.Limit = 100
 	}
 
+	// Scope to the caller's organizations
+	filters.OnlyOrganizations = filters.OrganizationIDs != nil
+	organizationIDs, err := s.orgs.ScopeFilter(ctx, filters.OrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	filters.OrganizationIDs = organizationIDs
+
 	players, err := s.playerRepo.GetAll(ctx, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list players: %w", err)
@@ -79,7 +131,9 @@ func (s *PlayerService) ListPlayers(ctx context.Context, filters *models.PlayerF
 	return players, nil
 }
 
-// UpdatePlayer updates an existing player
+// UpdatePlayer updates an existing player. Changing team_id moves the player
+// between rosters, which requires owning both teams. A player who has claimed
+// the profile can edit their own name and profile but cannot move teams.
 func (s *PlayerService) UpdatePlayer(ctx context.Context, id string, req *models.UpdatePlayerRequest) (*models.Player, error) {
 	if id == "" {
 		return nil, fmt.Errorf("player ID is required")
@@ -90,18 +144,51 @@ func (s *PlayerService) UpdatePlayer(ctx context.Context, id string, req *models
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player: %w", err)
 	}
+	team, err := s.teamRepo.GetByID(ctx, player.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+	if err := checkProfileEditor(ctx, s.orgs, team, player); err != nil {
+		return nil, err
+	}
+	before := s.audit.Snapshot(player)
+	previousTeamID := player.TeamID
 
 	// Update fields if provided
 	if req.Name != nil {
-		player.Name = *req.Name
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, fmt.Errorf("player name must be at least 2 characters")
+		}
+		player.Name = name
 	}
-	if req.TeamID != nil {
+	applyProfileUpdate(&player.PlayerProfile, req)
+	player.PlayerProfile = normalizeProfile(player.PlayerProfile)
+	if err := validateProfile(player.PlayerProfile); err != nil {
+		return nil, err
+	}
+
+	if req.TeamID != nil && *req.TeamID != player.TeamID {
+		// Only the team owner can move players between rosters
+		if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+			return nil, err
+		}
 		// Validate team exists
-		_, err = s.teamRepo.GetByID(ctx, *req.TeamID)
+		newTeam, err := s.teamRepo.GetByID(ctx, *req.TeamID)
 		if err != nil {
 			return nil, fmt.Errorf("team not found: %w", err)
 		}
-		player.TeamID = *req.TeamID
+		if err := checkTeamOwner(ctx, s.orgs, newTeam); err != nil {
+			return nil, err
+		}
+		if err := ensureRosterSpace(ctx, s.playerRepo, newTeam.ID, 1); err != nil {
+			return nil, err
+		}
+		player.TeamID = newTeam.ID
+		player.OrganizationID = newTeam.OrganizationID
+	}
+	if err := ensureJerseyAvailable(ctx, s.playerRepo, player.TeamID, player.ID, player.JerseyNumber); err != nil {
+		return nil, err
 	}
 
 	player.UpdatedAt = time.Now()
@@ -112,30 +199,227 @@ func (s *PlayerService) UpdatePlayer(ctx context.Context, id string, req *models
 		return nil, fmt.Errorf("failed to update player: %w", err)
 	}
 
+	// Moving a player changes both rosters
+	if player.TeamID != previousTeamID {
+		if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, previousTeamID); err != nil {
+			return nil, err
+		}
+		if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, player.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityPlayer, id, before, player)
+	return player, nil
+}
+
+// ClaimPlayer asks to link the caller's account to a player profile. The
+// claim takes effect once the team owner approves it. A profile can be
+// claimed by one account, and an account can claim one profile per team.
+func (s *PlayerService) ClaimPlayer(ctx context.Context, id string) (*models.Player, error) {
+	if id == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	player, err := s.GetPlayer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if player.UserID == userID || player.ClaimRequestedBy == userID {
+		return player, nil
+	}
+	if player.UserID != "" {
+		return nil, fmt.Errorf("player profile has already been claimed")
+	}
+	if player.ClaimRequestedBy != "" {
+		return nil, fmt.Errorf("player profile already has a claim awaiting approval")
+	}
+	if err := s.checkNoClaimOnTeam(ctx, player.TeamID, userID); err != nil {
+		return nil, err
+	}
+
+	before := s.audit.Snapshot(player)
+	player.ClaimRequestedBy = userID
+	player.UpdatedAt = time.Now()
+	if err := s.playerRepo.Update(ctx, id, player); err != nil {
+		return nil, fmt.Errorf("failed to claim player: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityPlayer, id, before, player)
+	return player, nil
+}
+
+// ApproveClaim links a player profile to the account that asked to claim
+// it. Only the team owner can approve a claim.
+func (s *PlayerService) ApproveClaim(ctx context.Context, id string) (*models.Player, error) {
+	if id == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+	team, err := s.teamRepo.GetByID(ctx, player.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+	if player.ClaimRequestedBy == "" {
+		return nil, fmt.Errorf("player profile has no claim awaiting approval")
+	}
+	if err := s.checkNoClaimOnTeam(ctx, player.TeamID, player.ClaimRequestedBy); err != nil {
+		return nil, err
+	}
+
+	before := s.audit.Snapshot(player)
+	player.UserID = player.ClaimRequestedBy
+	player.ClaimRequestedBy = ""
+	player.UpdatedAt = time.Now()
+	if err := s.playerRepo.Update(ctx, id, player); err != nil {
+		return nil, fmt.Errorf("failed to approve claim: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityPlayer, id, before, player)
+	return player, nil
+}
+
+// ReleasePlayer unlinks the account from a claimed player profile, or drops
+// a claim awaiting approval. Either the claiming user or the team owner can
+// release a claim.
+func (s *PlayerService) ReleasePlayer(ctx context.Context, id string) (*models.Player, error) {
+	if id == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+	if player.UserID == "" && player.ClaimRequestedBy == "" {
+		return nil, fmt.Errorf("player profile has not been claimed")
+	}
+	team, err := s.teamRepo.GetByID(ctx, player.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+	if userID, _ := ctx.Value("user_id").(string); userID == "" || userID != player.ClaimRequestedBy {
+		if err := checkProfileEditor(ctx, s.orgs, team, player); err != nil {
+			return nil, err
+		}
+	}
+
+	before := s.audit.Snapshot(player)
+	player.UserID = ""
+	player.ClaimRequestedBy = ""
+	player.UpdatedAt = time.Now()
+	if err := s.playerRepo.Update(ctx, id, player); err != nil {
+		return nil, fmt.Errorf("failed to release player: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityPlayer, id, before, player)
 	return player, nil
 }
 
-// DeletePlayer deletes a player
+// checkNoClaimOnTeam returns an error if the user has already claimed a
+// player profile on the team
+func (s *PlayerService) checkNoClaimOnTeam(ctx context.Context, teamID, userID string) error {
+	claimed, err := s.playerRepo.GetAll(ctx, &models.PlayerFilters{TeamID: &teamID, UserID: &userID, Limit: 1})
+	if err != nil {
+		return fmt.Errorf("failed to check claimed profiles: %w", err)
+	}
+	if len(claimed) > 0 {
+		return fmt.Errorf("this account has already claimed a player profile on this team")
+	}
+	return nil
+}
+
+// DeletePlayer moves a player to the trash
 func (s *PlayerService) DeletePlayer(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("player ID is required")
 	}
 
 	// Check if player exists
-	_, err := s.playerRepo.GetByID(ctx, id)
+	player, err := s.playerRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("player not found: %w", err)
 	}
+	team, err := s.teamRepo.GetByID(ctx, player.TeamID)
+	if err != nil {
+		return fmt.Errorf("team not found: %w", err)
+	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return err
+	}
 
-	// Delete player
-	err = s.playerRepo.Delete(ctx, id)
+	// Soft delete player
+	err = s.playerRepo.SoftDelete(ctx, id, time.Now().UTC().Truncate(time.Microsecond))
 	if err != nil {
 		return fmt.Errorf("failed to delete player: %w", err)
 	}
+	if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, team.ID); err != nil {
+		return err
+	}
 
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityPlayer, id, player, nil)
 	return nil
 }
 
+// ListDeletedPlayers retrieves players in the trash within the caller's organizations
+func (s *PlayerService) ListDeletedPlayers(ctx context.Context, filters *models.PlayerFilters) ([]*models.Player, error) {
+	filters.Deleted = true
+	players, err := s.ListPlayers(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted players: %w", err)
+	}
+
+	return players, nil
+}
+
+// RestorePlayer brings a player back from the trash
+func (s *PlayerService) RestorePlayer(ctx context.Context, id string) (*models.Player, error) {
+	if id == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("player not found in trash: %w", err)
+	}
+
+	// A player cannot outlive their team
+	team, err := s.teamRepo.GetByID(ctx, player.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot restore player: their team is deleted, restore the team first")
+	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+	if err := ensureRosterSpace(ctx, s.playerRepo, team.ID, 1); err != nil {
+		return nil, err
+	}
+	before := s.audit.Snapshot(player)
+
+	if err := s.playerRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore player: %w", err)
+	}
+	if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, team.ID); err != nil {
+		return nil, err
+	}
+
+	player.DeletedAt = nil
+	s.audit.Record(ctx, models.AuditActionRestore, models.AuditEntityPlayer, id, before, player)
+	return player, nil
+}
+
 // GetPlayersByTeam retrieves all players for a specific team
 func (s *PlayerService) GetPlayersByTeam(ctx context.Context, teamID string) ([]*models.Player, error) {
 	if teamID == "" {
@@ -143,10 +427,13 @@ func (s *PlayerService) GetPlayersByTeam(ctx context.Context, teamID string) ([]
 	}
 
 	// Check if team exists
-	_, err := s.teamRepo.GetByID(ctx, teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("team not found: %w", err)
 	}
+	if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+		return nil, fmt.Errorf("team not found")
+	}
 
 	// Get players for the team
 	players, err := s.playerRepo.GetByTeamID(ctx, teamID)
@@ -156,3 +443,98 @@ func (s *PlayerService) GetPlayersByTeam(ctx context.Context, teamID string) ([]
 
 	return players, nil
 }
+
+// checkProfileEditor allows the user who claimed a player profile or the
+// owner of the player's team
+func checkProfileEditor(ctx context.Context, orgs *OrganizationService, team *models.Team, player *models.Player) error {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return fmt.Errorf("user authentication required")
+	}
+	if player.UserID == userID {
+		return nil
+	}
+	if team.CreatedBy != userID {
+		return fmt.Errorf("access denied: only the player or the team owner can edit this profile")
+	}
+	return orgs.CheckCanContribute(ctx, team.OrganizationID)
+}
+
+// applyProfileUpdate copies the profile fields present in an update request
+func applyProfileUpdate(profile *models.PlayerProfile, req *models.UpdatePlayerRequest) {
+	if req.Role != nil {
+		profile.Role = *req.Role
+	}
+	if req.BattingHand != nil {
+		profile.BattingHand = *req.BattingHand
+	}
+	if req.BowlingArm != nil {
+		profile.BowlingArm = *req.BowlingArm
+	}
+	if req.BowlingStyle != nil {
+		profile.BowlingStyle = *req.BowlingStyle
+	}
+	if req.JerseyNumber != nil {
+		profile.JerseyNumber = *req.JerseyNumber
+	}
+	if req.Nickname != nil {
+		profile.Nickname = *req.Nickname
+	}
+	if req.AvatarURL != nil {
+		profile.AvatarURL = *req.AvatarURL
+	}
+}
+
+// normalizeProfile trims free-text profile fields
+func normalizeProfile(profile models.PlayerProfile) models.PlayerProfile {
+	profile.Nickname = strings.TrimSpace(profile.Nickname)
+	profile.AvatarURL = strings.TrimSpace(profile.AvatarURL)
+	return profile
+}
+
+// validateProfile checks the optional profile fields that are set
+func validateProfile(profile models.PlayerProfile) error {
+	if profile.Role != "" && !profile.Role.IsValid() {
+		return fmt.Errorf("invalid role: must be batter, bowler, all_rounder or wicketkeeper")
+	}
+	if profile.BattingHand != "" && !profile.BattingHand.IsValid() {
+		return fmt.Errorf("invalid batting hand: must be right or left")
+	}
+	if profile.BowlingArm != "" && !profile.BowlingArm.IsValid() {
+		return fmt.Errorf("invalid bowling arm: must be right or left")
+	}
+	if profile.BowlingStyle != "" && !profile.BowlingStyle.IsValid() {
+		return fmt.Errorf("invalid bowling style: must be fast, medium, off_spin, leg_spin, orthodox_spin, wrist_spin or none")
+	}
+	if profile.JerseyNumber < 0 || profile.JerseyNumber > models.MaxJerseyNumber {
+		return fmt.Errorf("jersey number must be between 1 and %d", models.MaxJerseyNumber)
+	}
+	if len(profile.Nickname) > 100 {
+		return fmt.Errorf("nickname must be at most 100 characters")
+	}
+	if profile.AvatarURL != "" {
+		avatar, err := url.Parse(profile.AvatarURL)
+		if err != nil || (avatar.Scheme != "http" && avatar.Scheme != "https") || avatar.Host == "" {
+			return fmt.Errorf("avatar URL must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// ensureJerseyAvailable checks that no other player on the team wears the number
+func ensureJerseyAvailable(ctx context.Context, playerRepo interfaces.PlayerRepository, teamID, playerID string, number int) error {
+	if number == 0 {
+		return nil
+	}
+
+	players, err := playerRepo.GetByTeamID(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to get team players: %w", err)
+	}
+	for _, other := range players {
+		if other.ID != playerID && other.JerseyNumber == number {
+			return fmt.Errorf("jersey number %d is already worn by %s", number, other.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+)
+
+// RetentionService permanently removes soft-deleted rows once they have been
+// in the trash for longer than the retention period
+type RetentionService struct {
+	seriesRepo interfaces.SeriesRepository
+	matchRepo  interfaces.MatchRepository
+	teamRepo   interfaces.TeamRepository
+	playerRepo interfaces.PlayerRepository
+	retention  time.Duration
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, teamRepo interfaces.TeamRepository, playerRepo interfaces.PlayerRepository, retention time.Duration) *RetentionService {
+	return &RetentionService{
+		seriesRepo: seriesRepo,
+		matchRepo:  matchRepo,
+		teamRepo:   teamRepo,
+		playerRepo: playerRepo,
+		retention:  retention,
+	}
+}
+
+// PurgeExpired permanently deletes rows that were soft-deleted before the
+// retention cutoff
+func (s *RetentionService) PurgeExpired(ctx context.Context) (*models.PurgeResult, error) {
+	result := &models.PurgeResult{
+		Cutoff: time.Now().UTC().Add(-s.retention),
+	}
+
+	// Parents go first; their children are removed by ON DELETE CASCADE, so
+	// the child counts only include rows that were trashed on their own
+	var err error
+	if result.Series, err = s.seriesRepo.PurgeDeleted(ctx, result.Cutoff); err != nil {
+		return nil, fmt.Errorf("failed to purge series: %w", err)
+	}
+	if result.Matches, err = s.matchRepo.PurgeDeleted(ctx, result.Cutoff); err != nil {
+		return nil, fmt.Errorf("failed to purge matches: %w", err)
+	}
+	if result.Teams, err = s.teamRepo.PurgeDeleted(ctx, result.Cutoff); err != nil {
+		return nil, fmt.Errorf("failed to purge teams: %w", err)
+	}
+	if result.Players, err = s.playerRepo.PurgeDeleted(ctx, result.Cutoff); err != nil {
+		return nil, fmt.Errorf("failed to purge players: %w", err)
+	}
+
+	return result, nil
+}
+
+// Run purges expired trash immediately and then once every interval
+func (s *RetentionService) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.PurgeExpired(context.Background())
+		if err != nil {
+			log.Printf("Trash purge failed: %v", err)
+		} else {
+			log.Printf("Trash purge removed %d series, %d matches, %d teams, %d players deleted before %s",
+				result.Series, result.Matches, result.Teams, result.Players, result.Cutoff.Format(time.RFC3339))
+		}
+		<-ticker.C
+	}
+}
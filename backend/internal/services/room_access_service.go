@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+// RoomAccessService decides who may follow a match or series live. Match
+// rooms follow the match's access policy; series rooms, like everything an
+// organization owns, are open to those who can see the organization.
+type RoomAccessService struct {
+	matchRepo  interfaces.MatchRepository
+	seriesRepo interfaces.SeriesRepository
+	orgs       *OrganizationService
+}
+
+// NewRoomAccessService creates a new room access service
+func NewRoomAccessService(matchRepo interfaces.MatchRepository, seriesRepo interfaces.SeriesRepository, orgs *OrganizationService) *RoomAccessService {
+	return &RoomAccessService{
+		matchRepo:  matchRepo,
+		seriesRepo: seriesRepo,
+		orgs:       orgs,
+	}
+}
+
+// CheckRoomAccess returns nil when the user in ctx may join a room, or the
+// websocket error refusing it. Rooms of private organizations the user
+// cannot see are reported as not found.
+func (s *RoomAccessService) CheckRoomAccess(ctx context.Context, roomID string) error {
+	if seriesID, isSeries := websocket.SeriesIDFromRoom(roomID); isSeries {
+		series, err := s.seriesRepo.GetByID(ctx, seriesID)
+		if err != nil || s.orgs.CheckVisible(ctx, series.OrganizationID) != nil {
+			return websocket.ErrRoomNotFound
+		}
+		return nil
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, roomID)
+	if err != nil || s.orgs.CheckVisible(ctx, match.OrganizationID) != nil {
+		return websocket.ErrRoomNotFound
+	}
+	if match.Access != models.MatchAccessSeriesMembers && match.Access != models.MatchAccessInviteOnly {
+		return nil
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return websocket.ErrUnauthorized
+	}
+	if userID == match.CreatedBy {
+		return nil
+	}
+
+	if match.Access == models.MatchAccessInviteOnly {
+		for _, invited := range match.InvitedUserIDs {
+			if invited == userID {
+				return nil
+			}
+		}
+		if s.hasRole(ctx, match.OrganizationID, models.OrganizationRole.CanManage) {
+			return nil
+		}
+		return websocket.ErrForbidden
+	}
+
+	// Series members: the series' organization, or its creator when the
+	// series has no organization
+	if match.OrganizationID == "" {
+		series, err := s.seriesRepo.GetByID(ctx, match.SeriesID)
+		if err == nil && series.CreatedBy == userID {
+			return nil
+		}
+		return websocket.ErrForbidden
+	}
+	if s.hasRole(ctx, match.OrganizationID, models.OrganizationRole.IsValid) {
+		return nil
+	}
+	return websocket.ErrForbidden
+}
+
+// hasRole reports whether the caller is a member of an organization with a
+// role the predicate allows
+func (s *RoomAccessService) hasRole(ctx context.Context, organizationID string, allowed func(models.OrganizationRole) bool) bool {
+	if s.orgs == nil || organizationID == "" {
+		return false
+	}
+	_, err := s.orgs.requireRole(ctx, organizationID, allowed)
+	return err == nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+// RoomSnapshotService builds the current state of a WebSocket room for
+// clients that subscribe, resync, or reconnect after their missed messages
+// left the replay buffer
+type RoomSnapshotService struct {
+	scorecards   *ScorecardService
+	standings    *StandingsService
+	stages       *StageService
+	leaderboards *LeaderboardService
+	versions     *ScorecardVersions
+}
+
+// NewRoomSnapshotService creates a new room snapshot service
+func NewRoomSnapshotService(scorecards *ScorecardService, standings *StandingsService, stages *StageService, leaderboards *LeaderboardService) *RoomSnapshotService {
+	return &RoomSnapshotService{
+		scorecards:   scorecards,
+		standings:    standings,
+		stages:       stages,
+		leaderboards: leaderboards,
+	}
+}
+
+// SetScorecardVersions enables stamping match snapshots with the version
+// that scorecard deltas build on
+func (s *RoomSnapshotService) SetScorecardVersions(versions *ScorecardVersions) {
+	s.versions = versions
+}
+
+// Snapshot returns a match room's scorecard, or a series room's standings,
+// bracket and default leaderboards
+func (s *RoomSnapshotService) Snapshot(ctx context.Context, roomID string) (interface{}, error) {
+	seriesID, isSeries := websocket.SeriesIDFromRoom(roomID)
+	if !isSeries {
+		// Read the version first: a change made meanwhile is then in the
+		// scorecard and its delta, applied again, changes nothing
+		var version int64
+		if s.versions != nil {
+			version = s.versions.Current(roomID)
+		}
+		// Access was checked when the client joined the room
+		scorecard, err := s.scorecards.scorecard(ctx, roomID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.MatchSnapshot{Version: version, Scorecard: scorecard}, nil
+	}
+
+	standings, err := s.standings.GetStandings(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	leaderboards, err := s.leaderboards.GetLeaderboards(ctx, seriesID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboards: %w", err)
+	}
+	snapshot := &models.SeriesSnapshot{Standings: standings, Leaderboards: leaderboards}
+
+	stages, err := s.stages.ListStages(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stages: %w", err)
+	}
+	if len(stages) > 0 {
+		if snapshot.Bracket, err = s.stages.GetBracket(ctx, seriesID); err != nil {
+			return nil, fmt.Errorf("failed to get bracket: %w", err)
+		}
+	}
+	return snapshot, nil
+}
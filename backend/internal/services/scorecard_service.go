@@ -7,11 +7,28 @@ import (
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
 	"spark-park-cricket-backend/internal/utils"
+	"spark-park-cricket-backend/pkg/events"
+	"spark-park-cricket-backend/pkg/websocket"
+	"time"
 )
 
 type ScorecardService struct {
 	scorecardRepo interfaces.ScorecardRepository
 	matchRepo     interfaces.MatchRepository
+	squadRepo     interfaces.MatchSquadRepository
+	audit         *AuditService
+	stats         *StatsService
+	standings     *StandingsService
+	stages        *StageService
+	leaderboards  *LeaderboardService
+	awards        *AwardService
+	audience      *AudienceService
+	events        *events.ScoringEvents
+	access        websocket.AccessFunc
+
+	// Called with every scorecard_updated event before the change returns,
+	// e.g. to broadcast it to WebSocket rooms
+	scorecardUpdated func(event *models.ScoringEvent)
 }
 
 // NewScorecardService creates a new scorecard service
@@ -22,6 +39,60 @@ func NewScorecardService(scorecardRepo interfaces.ScorecardRepository, matchRepo
 	}
 }
 
+// SetAuditService enables audit logging of scoring mutations
+func (s *ScorecardService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetMatchSquadRepository enables checking declared squads before scoring starts
+func (s *ScorecardService) SetMatchSquadRepository(squadRepo interfaces.MatchSquadRepository) {
+	s.squadRepo = squadRepo
+}
+
+// SetStatsService enables rolling player statistics up when a match completes
+func (s *ScorecardService) SetStatsService(stats *StatsService) {
+	s.stats = stats
+}
+
+// SetStandingsService enables publishing series standings when a result changes
+func (s *ScorecardService) SetStandingsService(standings *StandingsService) {
+	s.standings = standings
+}
+
+// SetStageService enables advancing series brackets when a result changes
+func (s *ScorecardService) SetStageService(stages *StageService) {
+	s.stages = stages
+}
+
+// SetLeaderboardService enables refreshing series leaderboards after every ball
+func (s *ScorecardService) SetLeaderboardService(leaderboards *LeaderboardService) {
+	s.leaderboards = leaderboards
+}
+
+// SetAwardService enables proposing the player of the match when a match
+// completes and showing it on the scorecard
+func (s *ScorecardService) SetAwardService(awards *AwardService) {
+	s.awards = awards
+}
+
+// SetAudienceService enables saving a match's live audience when it completes
+func (s *ScorecardService) SetAudienceService(audience *AudienceService) {
+	s.audience = audience
+}
+
+// SetScoringEvents enables publishing balls, wickets and completed innings
+// and matches, e.g. to GraphQL subscriptions
+func (s *ScorecardService) SetScoringEvents(scoringEvents *events.ScoringEvents) {
+	s.events = scoringEvents
+}
+
+// SetAccessPolicy makes scorecard reads follow the access policy of the
+// match's live room, so a match that cannot be followed cannot be polled
+// either. Private organizations' matches are reported as not found.
+func (s *ScorecardService) SetAccessPolicy(access websocket.AccessFunc) {
+	s.access = access
+}
+
 // StartScoring starts scoring for a match
 func (s *ScorecardService) StartScoring(ctx context.Context, matchID string) error {
 	log.Printf("Starting scoring for match %s", matchID)
@@ -55,6 +126,11 @@ func (s *ScorecardService) StartScoring(ctx context.Context, matchID string) err
 		return fmt.Errorf("scoring already started for this match")
 	}
 
+	// Player counts come from the declared XIs, so one side cannot be declared alone
+	if err := s.checkSquadsDeclared(ctx, matchID); err != nil {
+		return err
+	}
+
 	// Create first innings with toss winner as batting team
 	firstInnings := &models.Innings{
 		MatchID:       matchID,
@@ -74,6 +150,8 @@ func (s *ScorecardService) StartScoring(ctx context.Context, matchID string) err
 	}
 
 	log.Printf("Successfully started scoring for match %s, first innings batting team: %s", matchID, match.TossWinner)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityInnings, firstInnings.ID, nil, firstInnings)
+	s.publish(&models.ScoringEvent{Type: models.ScoringEventScorecardUpdated, MatchID: matchID, InningsNumber: 1, Innings: firstInnings, Match: match})
 	return nil
 }
 
@@ -146,6 +224,11 @@ func (s *ScorecardService) AddBall(ctx context.Context, req *models.BallEventReq
 		return fmt.Errorf("innings is not in progress, cannot add ball")
 	}
 
+	// Batter and bowler must come from the declared squads
+	if err := s.checkBallPlayers(ctx, req, innings.BattingTeam); err != nil {
+		return err
+	}
+
 	// Get current over or create new one
 	over, err := s.getCurrentOver(ctx, innings.ID)
 	if err != nil {
@@ -185,6 +268,14 @@ func (s *ScorecardService) AddBall(ctx context.Context, req *models.BallEventReq
 		Byes:       byes,
 		IsWicket:   req.IsWicket,
 		WicketType: req.WicketType,
+
+		BatterID:          req.BatterID,
+		BowlerID:          req.BowlerID,
+		FielderID:         req.FielderID,
+		DismissedPlayerID: req.DismissedPlayerID,
+	}
+	if ball.IsWicket && ball.DismissedPlayerID == "" {
+		ball.DismissedPlayerID = ball.BatterID
 	}
 
 	err = s.scorecardRepo.CreateBall(ctx, ball)
@@ -260,7 +351,7 @@ func (s *ScorecardService) AddBall(ctx context.Context, req *models.BallEventReq
 	// Check if innings is complete
 	// For first innings: complete when all wickets are taken or all overs are completed
 	// For second innings: completion is handled by shouldCompleteMatch method
-	maxWickets := match.TeamAPlayerCount - 1 // n-1 wickets for n players
+	maxWickets := maxWicketsFor(match, innings.BattingTeam)
 	if req.InningsNumber == 1 {
 		if innings.TotalWickets >= maxWickets || innings.TotalOvers >= float64(match.TotalOvers) {
 			innings.Status = string(models.InningsStatusCompleted)
@@ -277,6 +368,7 @@ func (s *ScorecardService) AddBall(ctx context.Context, req *models.BallEventReq
 	}
 
 	// Handle match progression
+	var completed *models.ScoringEvent
 	if req.InningsNumber == 1 {
 		// First innings - check if completed and start second innings
 		if innings.Status == string(models.InningsStatusCompleted) {
@@ -305,10 +397,18 @@ func (s *ScorecardService) AddBall(ctx context.Context, req *models.BallEventReq
 				return fmt.Errorf("failed to complete match: %w", err)
 			}
 			log.Printf("Match %s completed - %s", req.MatchID, reason)
+			completed = &models.ScoringEvent{Type: models.ScoringEventMatchCompleted, Match: match, Result: reason}
+			if firstInnings, err := s.scorecardRepo.GetInningsByMatchAndNumber(ctx, req.MatchID, 1); err == nil {
+				completed.WinnerTeamID = matchWinnerTeamID(match, []*models.Innings{firstInnings, innings})
+			}
+			s.resultChanged(ctx, match)
 		}
 	}
 
 	log.Printf("Successfully added ball: %s %d runs, byes: %d, total: %d, wicket: %v", req.RunType, runs, byes, totalRuns, req.IsWicket)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityBall, ball.ID, nil, ball)
+	s.ballRecorded(ctx, match)
+	s.publishBall(req.MatchID, match, req.InningsNumber, ball, over, innings, completed)
 	return nil
 }
 
@@ -334,8 +434,9 @@ func (s *ScorecardService) UndoBall(ctx context.Context, matchID string, innings
 		return fmt.Errorf("access denied: you can only undo balls for matches you created")
 	}
 
-	// Check if match is live
-	if match.Status != models.MatchStatusLive {
+	// A completed match can be reopened by undoing the final ball of the chase
+	reopening := match.Status == models.MatchStatusCompleted && inningsNumber == 2
+	if match.Status != models.MatchStatusLive && !reopening {
 		return fmt.Errorf("match is not live, cannot undo ball")
 	}
 
@@ -347,12 +448,15 @@ func (s *ScorecardService) UndoBall(ctx context.Context, matchID string, innings
 	}
 
 	// Check if innings is in progress
-	if innings.Status != string(models.InningsStatusInProgress) {
+	if innings.Status != string(models.InningsStatusInProgress) && !reopening {
 		return fmt.Errorf("innings is not in progress, cannot undo ball")
 	}
 
-	// Get current over
+	// Get current over; the winning ball may have completed the last over
 	over, err := s.scorecardRepo.GetCurrentOver(ctx, innings.ID)
+	if err != nil && reopening {
+		over, err = s.lastOver(ctx, innings.ID)
+	}
 	if err != nil {
 		log.Printf("Error getting current over: %v", err)
 		return fmt.Errorf("no current over found: %w", err)
@@ -464,10 +568,11 @@ func (s *ScorecardService) UndoBall(ctx context.Context, matchID string, innings
 	}
 	innings.TotalOvers = float64(completedOvers) + currentOverDecimal
 
-	// Check if innings should be marked as in progress (if it was completed)
+	// Check if innings should be marked as in progress (if it was completed).
+	// Reopening a match always reopens the chase, whatever ended it.
 	if innings.Status == string(models.InningsStatusCompleted) {
-		maxWickets := match.TeamAPlayerCount - 1
-		if innings.TotalWickets < maxWickets && innings.TotalOvers < float64(match.TotalOvers) {
+		maxWickets := maxWicketsFor(match, innings.BattingTeam)
+		if reopening || (innings.TotalWickets < maxWickets && innings.TotalOvers < float64(match.TotalOvers)) {
 			innings.Status = string(models.InningsStatusInProgress)
 		}
 	}
@@ -487,12 +592,140 @@ func (s *ScorecardService) UndoBall(ctx context.Context, matchID string, innings
 			return fmt.Errorf("failed to revert match status: %w", err)
 		}
 		log.Printf("Reverted match %s status from completed to live", matchID)
+		s.resultChanged(ctx, match)
 	}
 
 	log.Printf("Successfully undone ball: %s %d runs, byes: %d, total: %d, wicket: %v", lastBall.RunType, runs, byes, totalRuns, lastBall.IsWicket)
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityBall, lastBall.ID, lastBall, nil)
+	s.ballRecorded(ctx, match)
+	s.publishUndo(matchID, match, inningsNumber, lastBall, over, innings)
 	return nil
 }
 
+// resultChanged updates player statistics and awards, saves the audience,
+// advances brackets and publishes standings after a match completes or is
+// reopened. These are side effects of the result, so failures are only
+// logged and never undo the ball that changed it.
+func (s *ScorecardService) resultChanged(ctx context.Context, match *models.Match) {
+	if s.stats != nil {
+		var err error
+		if match.Status == models.MatchStatusCompleted {
+			err = s.stats.RecordMatch(ctx, match.ID)
+		} else {
+			err = s.stats.RemoveMatch(ctx, match.ID)
+		}
+		if err != nil {
+			log.Printf("Error updating player statistics for match %s: %v", match.ID, err)
+		}
+	}
+
+	if s.awards != nil {
+		if err := s.awards.MatchResultChanged(ctx, match, match.Status == models.MatchStatusCompleted); err != nil {
+			log.Printf("Error updating awards for match %s: %v", match.ID, err)
+		}
+	}
+
+	if match.Status == models.MatchStatusCompleted {
+		if err := s.audience.MatchCompleted(ctx, match.ID); err != nil {
+			log.Printf("Error saving audience for match %s: %v", match.ID, err)
+		}
+	}
+
+	if s.stages != nil {
+		if err := s.stages.Advance(ctx, match.SeriesID); err != nil {
+			log.Printf("Error advancing stages for series %s: %v", match.SeriesID, err)
+		}
+	}
+
+	if s.standings != nil {
+		if err := s.standings.PublishStandings(ctx, match.SeriesID); err != nil {
+			log.Printf("Error publishing standings for series %s: %v", match.SeriesID, err)
+		}
+	}
+}
+
+// ballRecorded refreshes the series leaderboards after a ball is added or
+// undone. They are derived data, so failures are only logged.
+func (s *ScorecardService) ballRecorded(ctx context.Context, match *models.Match) {
+	if s.leaderboards == nil {
+		return
+	}
+	if err := s.leaderboards.BallRecorded(ctx, match); err != nil {
+		log.Printf("Error refreshing leaderboards for series %s: %v", match.SeriesID, err)
+	}
+}
+
+// publishBall publishes the events of an added ball: the ball, the wicket
+// if one fell, the innings and match if they completed, and the updated
+// scorecard. completed is the match completion, if the ball decided it.
+func (s *ScorecardService) publishBall(matchID string, match *models.Match, inningsNumber int, ball *models.ScorecardBall, over *models.ScorecardOver, innings *models.Innings, completed *models.ScoringEvent) {
+	if s.events == nil && s.scorecardUpdated == nil {
+		return
+	}
+
+	event := func(eventType models.ScoringEventType) *models.ScoringEvent {
+		return &models.ScoringEvent{Type: eventType, MatchID: matchID, InningsNumber: inningsNumber, Ball: ball, Over: over, Innings: innings}
+	}
+	s.publish(event(models.ScoringEventBallAdded))
+	if ball.IsWicket {
+		s.publish(event(models.ScoringEventWicketFallen))
+	}
+	if innings.Status == string(models.InningsStatusCompleted) {
+		s.publish(&models.ScoringEvent{Type: models.ScoringEventInningsCompleted, MatchID: matchID, InningsNumber: inningsNumber, Innings: innings})
+	}
+	if completed != nil {
+		completed.MatchID, completed.InningsNumber, completed.Innings = matchID, inningsNumber, innings
+		s.publish(completed)
+	}
+	updated := event(models.ScoringEventScorecardUpdated)
+	updated.Cause, updated.Match = models.ScoringEventBallAdded, match
+	if completed != nil {
+		updated.Result, updated.WinnerTeamID = completed.Result, completed.WinnerTeamID
+	}
+	s.publish(updated)
+}
+
+// publishUndo publishes the removal of a ball and the updated scorecard
+func (s *ScorecardService) publishUndo(matchID string, match *models.Match, inningsNumber int, ball *models.ScorecardBall, over *models.ScorecardOver, innings *models.Innings) {
+	s.publish(&models.ScoringEvent{Type: models.ScoringEventBallUndone, MatchID: matchID, InningsNumber: inningsNumber, Ball: ball, Over: over, Innings: innings})
+	s.publish(&models.ScoringEvent{
+		Type: models.ScoringEventScorecardUpdated, Cause: models.ScoringEventBallUndone, MatchID: matchID,
+		InningsNumber: inningsNumber, Ball: ball, Over: over, Innings: innings, Match: match,
+	})
+}
+
+// publish stamps a scoring event and hands it to the event bus and, for
+// scorecard updates, the update listener
+func (s *ScorecardService) publish(event *models.ScoringEvent) {
+	if s.events == nil && s.scorecardUpdated == nil {
+		return
+	}
+	event.OccurredAt = time.Now()
+	s.events.Publish(event)
+	if s.scorecardUpdated != nil && event.Type == models.ScoringEventScorecardUpdated {
+		s.scorecardUpdated(event)
+	}
+}
+
+// lastOver returns the highest-numbered over of an innings
+func (s *ScorecardService) lastOver(ctx context.Context, inningsID string) (*models.ScorecardOver, error) {
+	overs, err := s.scorecardRepo.GetOversByInnings(ctx, inningsID)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *models.ScorecardOver
+	for _, over := range overs {
+		if last == nil || over.OverNumber > last.OverNumber {
+			last = over
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("no overs found")
+	}
+	return last, nil
+}
+
 // getCurrentOver gets the current in-progress over or creates a new one
 func (s *ScorecardService) getCurrentOver(ctx context.Context, inningsID string) (*models.ScorecardOver, error) {
 	// Try to get current over
@@ -582,8 +815,8 @@ func (s *ScorecardService) ShouldCompleteMatch(ctx context.Context, matchID stri
 		return false, "error getting first innings"
 	}
 
-	target := firstInnings.TotalRuns + 1     // Target is first innings score + 1
-	maxWickets := match.TeamAPlayerCount - 1 // n-1 wickets for n players
+	target := firstInnings.TotalRuns + 1 // Target is first innings score + 1
+	maxWickets := maxWicketsFor(match, secondInnings.BattingTeam)
 
 	// Check if target is reached
 	if secondInnings.TotalRuns >= target {
@@ -649,6 +882,14 @@ func (s *ScorecardService) startSecondInnings(ctx context.Context, matchID strin
 func (s *ScorecardService) GetScorecard(ctx context.Context, matchID string) (*models.ScorecardResponse, error) {
 	log.Printf("Getting scorecard for match %s", matchID)
 
+	if err := s.checkAccess(ctx, matchID); err != nil {
+		return nil, err
+	}
+	return s.scorecard(ctx, matchID)
+}
+
+// scorecard builds a match's scorecard without checking the caller's access
+func (s *ScorecardService) scorecard(ctx context.Context, matchID string) (*models.ScorecardResponse, error) {
 	// Get scorecard from repository
 	scorecard, err := s.scorecardRepo.GetScorecard(ctx, matchID)
 	if err != nil {
@@ -656,6 +897,14 @@ func (s *ScorecardService) GetScorecard(ctx context.Context, matchID string) (*m
 		return nil, fmt.Errorf("failed to get scorecard: %w", err)
 	}
 
+	if s.awards != nil {
+		award, err := s.awards.GetPlayerOfTheMatch(ctx, matchID)
+		if err != nil {
+			log.Printf("Error getting player of the match for match %s: %v", matchID, err)
+		}
+		scorecard.PlayerOfTheMatch = award
+	}
+
 	log.Printf("Successfully retrieved scorecard for match %s", matchID)
 	return scorecard, nil
 }
@@ -664,6 +913,10 @@ func (s *ScorecardService) GetScorecard(ctx context.Context, matchID string) (*m
 func (s *ScorecardService) GetCurrentOver(ctx context.Context, matchID string, inningsNumber int) (*models.ScorecardOver, error) {
 	log.Printf("Getting current over for match %s, innings %d", matchID, inningsNumber)
 
+	if err := s.checkAccess(ctx, matchID); err != nil {
+		return nil, err
+	}
+
 	// Get innings
 	innings, err := s.scorecardRepo.GetInningsByMatchAndNumber(ctx, matchID, inningsNumber)
 	if err != nil {
@@ -682,6 +935,15 @@ func (s *ScorecardService) GetCurrentOver(ctx context.Context, matchID string, i
 	return over, nil
 }
 
+// checkAccess returns the access policy's error if the caller may not read
+// a match's scorecard
+func (s *ScorecardService) checkAccess(ctx context.Context, matchID string) error {
+	if s.access == nil {
+		return nil
+	}
+	return s.access(ctx, matchID)
+}
+
 // GetBallsByOver gets all balls for a specific over
 func (s *ScorecardService) GetBallsByOver(ctx context.Context, overID string) ([]*models.ScorecardBall, error) {
 	log.Printf("Getting balls for over %s", overID)
@@ -751,7 +1013,7 @@ func (s *ScorecardService) ValidateInningsOrder(ctx context.Context, matchID str
 			}
 
 			// Check if first innings is complete (all wickets down or overs completed)
-			maxWickets := match.TeamAPlayerCount - 1 // n-1 wickets for n players
+			maxWickets := maxWicketsFor(match, firstInnings.BattingTeam)
 			firstInningsComplete := firstInnings.TotalWickets >= maxWickets || firstInnings.TotalOvers >= float64(match.TotalOvers)
 
 			if !firstInningsComplete {
@@ -781,7 +1043,7 @@ func (s *ScorecardService) ValidateInningsOrder(ctx context.Context, matchID str
 		}
 
 		// First innings is complete if all wickets are down or overs are completed
-		maxWickets := match.TeamAPlayerCount - 1 // n-1 wickets for n players
+		maxWickets := maxWicketsFor(match, firstInnings.BattingTeam)
 		firstInningsComplete := firstInnings.TotalWickets >= maxWickets || firstInnings.TotalOvers >= float64(match.TotalOvers)
 
 		if !firstInningsComplete {
@@ -801,6 +1063,81 @@ func (s *ScorecardService) ValidateInningsOrder(ctx context.Context, matchID str
 	return fmt.Errorf("invalid innings number: %d", inningsNumber)
 }
 
+// checkSquadsDeclared requires either both sides or neither to have declared a squad
+func (s *ScorecardService) checkSquadsDeclared(ctx context.Context, matchID string) error {
+	if s.squadRepo == nil {
+		return nil
+	}
+
+	players, err := s.squadRepo.GetByMatchID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to check squads: %w", err)
+	}
+
+	declared := map[models.TeamType]bool{}
+	for _, player := range players {
+		declared[player.Team] = true
+	}
+	if len(declared) == 1 {
+		return fmt.Errorf("both teams must declare a squad before scoring starts")
+	}
+	return nil
+}
+
+// checkBallPlayers validates the players named on a delivery against the
+// declared squads. Without squads the players are stored as given.
+func (s *ScorecardService) checkBallPlayers(ctx context.Context, req *models.BallEventRequest, battingTeam models.TeamType) error {
+	if s.squadRepo == nil {
+		return nil
+	}
+
+	players, err := s.squadRepo.GetByMatchID(ctx, req.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to check squads: %w", err)
+	}
+	if len(players) == 0 {
+		return nil
+	}
+
+	squad := make(map[string]*models.MatchSquadPlayer, len(players))
+	for _, player := range players {
+		squad[player.PlayerID] = player
+	}
+
+	// playing checks that a player is in the side's XI; fielders may be substitutes
+	playing := func(playerID string, team models.TeamType, allowSubstitute bool) bool {
+		player, ok := squad[playerID]
+		return ok && player.Team == team && (allowSubstitute || !player.IsSubstitute)
+	}
+	fieldingTeam := s.GetNonTossWinner(battingTeam)
+
+	if req.BatterID == "" || req.BowlerID == "" {
+		return fmt.Errorf("batter and bowler are required once squads are declared")
+	}
+	if !playing(req.BatterID, battingTeam, false) {
+		return fmt.Errorf("batter %s is not in the batting side's playing XI", req.BatterID)
+	}
+	if req.DismissedPlayerID != "" && !playing(req.DismissedPlayerID, battingTeam, false) {
+		return fmt.Errorf("dismissed player %s is not in the batting side's playing XI", req.DismissedPlayerID)
+	}
+	if !playing(req.BowlerID, fieldingTeam, false) {
+		return fmt.Errorf("bowler %s is not in the fielding side's playing XI", req.BowlerID)
+	}
+	if req.FielderID != "" && !playing(req.FielderID, fieldingTeam, true) {
+		return fmt.Errorf("fielder %s is not in the fielding side's squad", req.FielderID)
+	}
+	return nil
+}
+
+// maxWicketsFor returns the wickets that end an innings for the batting side.
+// Player counts follow the declared playing XI; n players allow n-1 wickets.
+func maxWicketsFor(match *models.Match, battingTeam models.TeamType) int {
+	if battingTeam == models.TeamTypeB {
+		return match.TeamBPlayerCount - 1
+	}
+	return match.TeamAPlayerCount - 1
+}
+
 // GetNonTossWinner returns the team that didn't win the toss
 func (s *ScorecardService) GetNonTossWinner(tossWinner models.TeamType) models.TeamType {
 	if tossWinner == models.TeamTypeA {
@@ -8,146 +8,195 @@ import (
 	"spark-park-cricket-backend/pkg/websocket"
 )
 
+// Types of the messages broadcast to match rooms: a models.ScorecardDelta
+// for every scoring change, followed by a models.ScorecardMilestone for each
+// milestone it reached
+const (
+	ScorecardDeltaMessageType   = "scorecard_delta"
+	WicketFallenMessageType     = "wicket_fallen"
+	OverCompletedMessageType    = "over_completed"
+	InningsCompletedMessageType = "innings_completed"
+	MatchCompletedMessageType   = "match_completed"
+)
+
 // ScorecardServiceWithGraphQL wraps the scorecard service with GraphQL WebSocket integration
 type ScorecardServiceWithGraphQL struct {
 	*ScorecardService
-	hub *websocket.Hub
+	hub      *websocket.Hub
+	versions *ScorecardVersions
+	webhooks *WebhookService
 }
 
-// NewScorecardServiceWithGraphQL creates a new scorecard service with GraphQL integration
+// NewScorecardServiceWithGraphQL creates a new scorecard service with GraphQL integration.
+// Every scoring change is broadcast to the match's room as a scorecard delta.
 func NewScorecardServiceWithGraphQL(scorecardRepo interfaces.ScorecardRepository, matchRepo interfaces.MatchRepository, hub *websocket.Hub) *ScorecardServiceWithGraphQL {
 	baseService := NewScorecardService(scorecardRepo, matchRepo)
 
-	return &ScorecardServiceWithGraphQL{
+	service := &ScorecardServiceWithGraphQL{
 		ScorecardService: baseService,
 		hub:              hub,
+		versions:         NewScorecardVersions(),
 	}
+	baseService.scorecardUpdated = service.broadcastDelta
+	return service
 }
 
-// AddBall adds a ball and broadcasts the update via WebSocket
-func (s *ScorecardServiceWithGraphQL) AddBall(ctx context.Context, req *models.BallEventRequest) error {
-	// Call the base service to add the ball
-	err := s.ScorecardService.AddBall(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	// Broadcast the update via WebSocket
-	s.broadcastScorecardUpdate(req.MatchID)
-
-	log.Printf("Ball added and broadcasted for match %s", req.MatchID)
-	return nil
+// SetScorecardVersions replaces the service's own version counters, e.g.
+// with those the room snapshots are stamped with
+func (s *ScorecardServiceWithGraphQL) SetScorecardVersions(versions *ScorecardVersions) {
+	s.versions = versions
 }
 
-// UndoBall undoes a ball and broadcasts the update via WebSocket
-func (s *ScorecardServiceWithGraphQL) UndoBall(ctx context.Context, matchID string, inningsNumber int) error {
-	// Call the base service to undo the ball
-	err := s.ScorecardService.UndoBall(ctx, matchID, inningsNumber)
-	if err != nil {
-		return err
-	}
-
-	// Broadcast the update via WebSocket
-	s.broadcastScorecardUpdate(matchID)
-
-	log.Printf("Ball undone and broadcasted for match %s", matchID)
-	return nil
+// SetWebhookService enables posting the messages broadcast to match rooms
+// to the webhooks of the match's series
+func (s *ScorecardServiceWithGraphQL) SetWebhookService(webhooks *WebhookService) {
+	s.webhooks = webhooks
 }
 
-// StartScoring starts scoring and broadcasts the update via WebSocket
-func (s *ScorecardServiceWithGraphQL) StartScoring(ctx context.Context, matchID string) error {
-	// Call the base service to start scoring
-	err := s.ScorecardService.StartScoring(ctx, matchID)
-	if err != nil {
-		return err
-	}
-
-	// Broadcast the update via WebSocket
-	s.broadcastScorecardUpdate(matchID)
+// broadcastDelta numbers a scorecard update and broadcasts what changed to
+// the match room, then the milestones it reached
+func (s *ScorecardServiceWithGraphQL) broadcastDelta(event *models.ScoringEvent) {
+	delta := s.buildDelta(context.Background(), event)
+	delta.Version = s.versions.Next(event.MatchID)
+	delta.BaseVersion = delta.Version - 1
 
-	log.Printf("Scoring started and broadcasted for match %s", matchID)
-	return nil
-}
+	s.broadcast(event.MatchID, ScorecardDeltaMessageType, delta)
+	log.Printf("Broadcasted scorecard delta %d for match %s", delta.Version, event.MatchID)
 
-// broadcastScorecardUpdate broadcasts a scorecard update to WebSocket clients
-func (s *ScorecardServiceWithGraphQL) broadcastScorecardUpdate(matchID string) {
-	// Get the current scorecard
-	scorecard, err := s.ScorecardService.GetScorecard(context.Background(), matchID)
-	if err != nil {
-		log.Printf("Error getting scorecard for broadcast: %v", err)
+	if event.Cause != models.ScoringEventBallAdded {
 		return
 	}
+	milestone := func() *models.ScorecardMilestone {
+		return &models.ScorecardMilestone{MatchID: event.MatchID, Version: delta.Version, CurrentScore: delta.CurrentScore}
+	}
+	if delta.Ball != nil && delta.Ball.IsWicket {
+		wicket := milestone()
+		wicket.Ball = delta.Ball
+		s.broadcast(event.MatchID, WicketFallenMessageType, wicket)
+	}
+	if delta.Over != nil && delta.Over.Status == string(models.OverStatusCompleted) {
+		over := milestone()
+		over.Over = delta.Over
+		s.broadcast(event.MatchID, OverCompletedMessageType, over)
+	}
+	if len(delta.Innings) > 0 && delta.Innings[0].Status == string(models.InningsStatusCompleted) {
+		innings := milestone()
+		innings.Innings = &delta.Innings[0]
+		if innings.Innings.InningsNumber == 1 {
+			innings.Target = innings.Innings.TotalRuns + 1
+		}
+		s.broadcast(event.MatchID, InningsCompletedMessageType, innings)
+	}
+	if delta.MatchStatus == string(models.MatchStatusCompleted) {
+		result := milestone()
+		result.Result, result.WinnerTeamID = event.Result, event.WinnerTeamID
+		s.broadcast(event.MatchID, MatchCompletedMessageType, result)
+	}
+}
 
-	// Calculate current score for live updates
-	currentScore := s.calculateCurrentScore(scorecard)
+// broadcast sends a message to a match room and its series' webhooks
+func (s *ScorecardServiceWithGraphQL) broadcast(matchID, messageType string, data interface{}) {
+	s.hub.BroadcastToRoom(matchID, websocket.Message{Type: messageType, RoomID: matchID, Data: data})
+	s.webhooks.Dispatch(matchID, messageType, data)
+}
 
-	// Get current over
-	currentOver, err := s.ScorecardService.GetCurrentOver(context.Background(), matchID, scorecard.CurrentInnings)
-	if err != nil {
-		currentOver = nil
+// buildDelta converts a scorecard update to a delta. A first innings that
+// completed has started the second, which is included too.
+func (s *ScorecardServiceWithGraphQL) buildDelta(ctx context.Context, event *models.ScoringEvent) *models.ScorecardDelta {
+	delta := &models.ScorecardDelta{
+		MatchID:        event.MatchID,
+		Cause:          event.Cause,
+		CurrentInnings: event.InningsNumber,
+		Innings:        []models.InningsTotals{},
 	}
-
-	// Build the live scorecard response
-	liveScorecard := map[string]interface{}{
-		"match_id":        scorecard.MatchID,
-		"match_number":    scorecard.MatchNumber,
-		"series_name":     scorecard.SeriesName,
-		"team_a":          scorecard.TeamA,
-		"team_b":          scorecard.TeamB,
-		"total_overs":     scorecard.TotalOvers,
-		"toss_winner":     scorecard.TossWinner,
-		"toss_type":       scorecard.TossType,
-		"current_innings": scorecard.CurrentInnings,
-		"match_status":    scorecard.MatchStatus,
-		"current_score":   currentScore,
-		"current_over":    currentOver,
-		"innings":         scorecard.Innings,
+	if event.Match != nil {
+		delta.MatchStatus = string(event.Match.Status)
 	}
-
-	// Create update message
-	updateMessage := map[string]interface{}{
-		"type": "scorecard_update",
-		"data": liveScorecard,
+	if event.Ball != nil {
+		delta.Ball = &models.DeltaBall{InningsNumber: event.InningsNumber, BallSummary: ballSummary(event.Ball)}
+	}
+	if event.Over != nil {
+		if delta.Ball != nil {
+			delta.Ball.OverNumber = event.Over.OverNumber
+		}
+		delta.Over = &models.OverTotals{
+			InningsNumber: event.InningsNumber,
+			OverNumber:    event.Over.OverNumber,
+			TotalRuns:     event.Over.TotalRuns,
+			TotalBalls:    event.Over.TotalBalls,
+			TotalWickets:  event.Over.TotalWickets,
+			Status:        event.Over.Status,
+		}
 	}
 
-	// Broadcast to the match room
-	s.hub.BroadcastToRoom(matchID, updateMessage)
-	log.Printf("Broadcasted scorecard update for match %s", matchID)
+	current := event.Innings
+	if event.Innings != nil {
+		delta.Innings = append(delta.Innings, inningsTotals(event.Innings))
+
+		secondStarted := event.Cause == models.ScoringEventBallAdded && event.InningsNumber == 1 &&
+			event.Innings.Status == string(models.InningsStatusCompleted)
+		if secondStarted {
+			second, err := s.scorecardRepo.GetInningsByMatchAndNumber(ctx, event.MatchID, 2)
+			if err != nil {
+				log.Printf("Error getting second innings for scorecard delta: %v", err)
+			} else {
+				delta.Innings = append(delta.Innings, inningsTotals(second))
+				delta.CurrentInnings = 2
+				current = second
+			}
+		}
+	}
+	delta.CurrentScore = currentScore(current)
+	return delta
 }
 
-// calculateCurrentScore calculates the current score from the scorecard
-func (s *ScorecardServiceWithGraphQL) calculateCurrentScore(scorecard *models.ScorecardResponse) map[string]interface{} {
-	var currentInnings *models.InningsSummary
+// ballSummary converts a ball to its scorecard summary
+func ballSummary(ball *models.ScorecardBall) models.BallSummary {
+	return models.BallSummary{
+		BallNumber:        ball.BallNumber,
+		BallType:          ball.BallType,
+		RunType:           ball.RunType,
+		Runs:              ball.Runs,
+		Byes:              ball.Byes,
+		IsWicket:          ball.IsWicket,
+		WicketType:        ball.WicketType,
+		BatterID:          ball.BatterID,
+		BowlerID:          ball.BowlerID,
+		FielderID:         ball.FielderID,
+		DismissedPlayerID: ball.DismissedPlayerID,
+	}
+}
 
-	// Find the current innings
-	for i := range scorecard.Innings {
-		if scorecard.Innings[i].InningsNumber == scorecard.CurrentInnings {
-			currentInnings = &scorecard.Innings[i]
-			break
-		}
+// inningsTotals converts an innings to its totals
+func inningsTotals(innings *models.Innings) models.InningsTotals {
+	return models.InningsTotals{
+		InningsNumber: innings.InningsNumber,
+		BattingTeam:   innings.BattingTeam,
+		TotalRuns:     innings.TotalRuns,
+		TotalWickets:  innings.TotalWickets,
+		TotalOvers:    innings.TotalOvers,
+		TotalBalls:    innings.TotalBalls,
+		Status:        innings.Status,
 	}
+}
 
-	if currentInnings == nil {
-		return map[string]interface{}{
-			"runs":     0,
-			"wickets":  0,
-			"overs":    0.0,
-			"balls":    0,
-			"run_rate": 0.0,
-		}
+// currentScore calculates the current score from the current innings, as
+// the live scorecard does
+func currentScore(innings *models.Innings) *models.CurrentScore {
+	if innings == nil {
+		return &models.CurrentScore{}
 	}
 
-	// Calculate run rate
 	runRate := 0.0
-	if currentInnings.TotalOvers > 0 {
-		runRate = float64(currentInnings.TotalRuns) / currentInnings.TotalOvers
+	if innings.TotalOvers > 0 {
+		runRate = float64(innings.TotalRuns) / innings.TotalOvers
 	}
 
-	return map[string]interface{}{
-		"runs":     currentInnings.TotalRuns,
-		"wickets":  currentInnings.TotalWickets,
-		"overs":    currentInnings.TotalOvers,
-		"balls":    currentInnings.TotalBalls,
-		"run_rate": runRate,
+	return &models.CurrentScore{
+		Runs:    innings.TotalRuns,
+		Wickets: innings.TotalWickets,
+		Overs:   innings.TotalOvers,
+		Balls:   innings.TotalBalls,
+		RunRate: runRate,
 	}
 }
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"log"
+	"spark-park-cricket-backend/internal/cache"
+	"sync"
+)
+
+// ScorecardVersions numbers the changes to each match's scorecard, so that
+// clients can apply broadcast deltas to a cached copy. With a cache manager
+// the counters live in the cache and every instance shares them; otherwise
+// each instance counts for itself.
+type ScorecardVersions struct {
+	cache *cache.CacheManager
+
+	mutex sync.Mutex
+	local map[string]int64
+}
+
+// NewScorecardVersions creates in-memory scorecard version counters
+func NewScorecardVersions() *ScorecardVersions {
+	return &ScorecardVersions{local: map[string]int64{}}
+}
+
+// SetCacheManager enables sharing the counters through the cache
+func (v *ScorecardVersions) SetCacheManager(cacheManager *cache.CacheManager) {
+	v.cache = cacheManager
+}
+
+// Next records a change to a match's scorecard and returns its version
+func (v *ScorecardVersions) Next(matchID string) int64 {
+	if v.cache != nil {
+		version, err := v.cache.IncrementVersion(v.cache.GetScorecardVersionKey(matchID))
+		if err != nil {
+			log.Printf("Error incrementing scorecard version of match %s: %v", matchID, err)
+		}
+		// A disabled cache returns 0
+		if version > 0 {
+			return version
+		}
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.local[matchID]++
+	return v.local[matchID]
+}
+
+// Current returns the version of a match's latest scorecard change, 0
+// before the first
+func (v *ScorecardVersions) Current(matchID string) int64 {
+	if v.cache != nil {
+		var version int64
+		if err := v.cache.Get(v.cache.GetScorecardVersionKey(matchID), &version); err == nil {
+			return version
+		}
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.local[matchID]
+}
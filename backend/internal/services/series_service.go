@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
 	"time"
@@ -11,15 +12,30 @@ import (
 // SeriesService handles business logic for series operations
 type SeriesService struct {
 	seriesRepo interfaces.SeriesRepository
+	matchRepo  interfaces.MatchRepository
+	audit      *AuditService
+	orgs       *OrganizationService
 }
 
-// NewSeriesService creates a new series service
-func NewSeriesService(seriesRepo interfaces.SeriesRepository) *SeriesService {
+// NewSeriesService creates a new series service. The match repository is used
+// to cascade soft deletes and restores to a series' matches.
+func NewSeriesService(seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository) *SeriesService {
 	return &SeriesService{
 		seriesRepo: seriesRepo,
+		matchRepo:  matchRepo,
 	}
 }
 
+// SetAuditService enables audit logging of series mutations
+func (s *SeriesService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of series by organization
+func (s *SeriesService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
 // CreateSeries creates a new series
 func (s *SeriesService) CreateSeries(ctx context.Context, req *models.CreateSeriesRequest) (*models.Series, error) {
 	fmt.Printf("DEBUG: SeriesService.CreateSeries - Starting creation with request: %+v\n", req)
@@ -38,14 +54,29 @@ func (s *SeriesService) CreateSeries(ctx context.Context, req *models.CreateSeri
 	}
 	fmt.Printf("DEBUG: SeriesService.CreateSeries - User ID from context: %s\n", userID)
 
+	// Only contributors can create series in an organization
+	if err := s.orgs.CheckCanContribute(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePointsRules(req.PointsRules); err != nil {
+		return nil, err
+	}
+	if err := validateImpactFormula(req.ImpactFormula); err != nil {
+		return nil, err
+	}
+
 	// Create series model
 	series := &models.Series{
-		Name:      req.Name,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
-		CreatedBy: userID,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		OrganizationID: req.OrganizationID,
+		Name:           req.Name,
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		PointsRules:    req.PointsRules,
+		ImpactFormula:  req.ImpactFormula,
+		CreatedBy:      userID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 	fmt.Printf("DEBUG: SeriesService.CreateSeries - Created series model: %+v\n", series)
 
@@ -58,6 +89,7 @@ func (s *SeriesService) CreateSeries(ctx context.Context, req *models.CreateSeri
 	}
 
 	fmt.Printf("DEBUG: SeriesService.CreateSeries - Successfully created series with ID: %s\n", series.ID)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntitySeries, series.ID, nil, series)
 	return series, nil
 }
 
@@ -77,6 +109,10 @@ func (s *SeriesService) GetSeries(ctx context.Context, id string) (*models.Serie
 		return nil, fmt.Errorf("failed to get series: %w", err)
 	}
 
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("failed to get series: series not found")
+	}
+
 	fmt.Printf("DEBUG: SeriesService.GetSeries - Successfully retrieved series: %+v\n", series)
 	return series, nil
 }
@@ -92,6 +128,14 @@ func (s *SeriesService) ListSeries(ctx context.Context, filters *models.SeriesFi
 	if filters.Limit > 100 {
 		filters.Limit = 100
 	}
+
+	// Scope to the caller's organizations
+	filters.OnlyOrganizations = filters.OrganizationIDs != nil
+	organizationIDs, err := s.orgs.ScopeFilter(ctx, filters.OrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	filters.OrganizationIDs = organizationIDs
 	fmt.Printf("DEBUG: SeriesService.ListSeries - Using filters: %+v\n", filters)
 
 	fmt.Printf("DEBUG: SeriesService.ListSeries - Calling repository.GetAll\n")
@@ -142,6 +186,7 @@ func (s *SeriesService) UpdateSeries(ctx context.Context, id string, req *models
 		fmt.Printf("DEBUG: SeriesService.UpdateSeries - Access denied: user %s cannot update series created by %s\n", userID, series.CreatedBy)
 		return nil, fmt.Errorf("access denied: you can only update series you created")
 	}
+	before := s.audit.Snapshot(series)
 
 	// Update fields if provided
 	if req.Name != nil {
@@ -157,6 +202,19 @@ func (s *SeriesService) UpdateSeries(ctx context.Context, id string, req *models
 		fmt.Printf("DEBUG: SeriesService.UpdateSeries - Updated end date to: %s\n", req.EndDate.Format(time.RFC3339))
 	}
 
+	if req.PointsRules != nil {
+		if err := validatePointsRules(req.PointsRules); err != nil {
+			return nil, err
+		}
+		series.PointsRules = req.PointsRules
+	}
+	if req.ImpactFormula != nil {
+		if err := validateImpactFormula(req.ImpactFormula); err != nil {
+			return nil, err
+		}
+		series.ImpactFormula = req.ImpactFormula
+	}
+
 	// Validate business rules
 	if series.EndDate.Before(series.StartDate) {
 		fmt.Printf("DEBUG: SeriesService.UpdateSeries - Validation failed: end date before start date\n")
@@ -175,10 +233,11 @@ func (s *SeriesService) UpdateSeries(ctx context.Context, id string, req *models
 	}
 
 	fmt.Printf("DEBUG: SeriesService.UpdateSeries - Successfully updated series: %+v\n", series)
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntitySeries, id, before, series)
 	return series, nil
 }
 
-// DeleteSeries deletes a series
+// DeleteSeries moves a series and its matches to the trash
 func (s *SeriesService) DeleteSeries(ctx context.Context, id string) error {
 	fmt.Printf("DEBUG: SeriesService.DeleteSeries - Starting deletion with ID: %s\n", id)
 
@@ -210,14 +269,156 @@ func (s *SeriesService) DeleteSeries(ctx context.Context, id string) error {
 		return fmt.Errorf("access denied: you can only delete series you created")
 	}
 
-	// Delete series
-	fmt.Printf("DEBUG: SeriesService.DeleteSeries - Calling repository.Delete\n")
-	err = s.seriesRepo.Delete(ctx, id)
+	// Cascade to matches first so a failure leaves the series visible. All
+	// rows share one timestamp so a restore brings back exactly this cascade.
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	matches, err := s.matchRepo.GetBySeriesID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get series matches: %w", err)
+	}
+	for _, match := range matches {
+		if err := s.matchRepo.SoftDelete(ctx, match.ID, deletedAt); err != nil {
+			return fmt.Errorf("failed to delete match %s: %w", match.ID, err)
+		}
+	}
+
+	// Soft delete series
+	fmt.Printf("DEBUG: SeriesService.DeleteSeries - Calling repository.SoftDelete\n")
+	err = s.seriesRepo.SoftDelete(ctx, id, deletedAt)
 	if err != nil {
 		fmt.Printf("DEBUG: SeriesService.DeleteSeries - Repository error: %v\n", err)
 		return fmt.Errorf("failed to delete series: %w", err)
 	}
 
 	fmt.Printf("DEBUG: SeriesService.DeleteSeries - Successfully deleted series with ID: %s\n", id)
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntitySeries, id, series, nil)
+	return nil
+}
+
+// ListDeletedSeries retrieves the caller's series that are in the trash
+func (s *SeriesService) ListDeletedSeries(ctx context.Context, filters *models.SeriesFilters) ([]*models.Series, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	// Set default values
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+	filters.Deleted = true
+	filters.CreatedBy = &userID
+
+	series, err := s.seriesRepo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted series: %w", err)
+	}
+
+	return series, nil
+}
+
+// RestoreSeries brings a series back from the trash together with the matches
+// that were deleted with it
+func (s *SeriesService) RestoreSeries(ctx context.Context, id string) (*models.Series, error) {
+	if id == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	series, err := s.seriesRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("series not found in trash: %w", err)
+	}
+
+	// Check ownership
+	if series.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only restore series you created")
+	}
+	before := s.audit.Snapshot(series)
+	deletedAt := *series.DeletedAt
+
+	if err := s.seriesRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore series: %w", err)
+	}
+
+	// Restore only matches removed by the series delete; matches deleted on
+	// their own beforehand stay in the trash
+	matches, err := s.matchRepo.GetDeletedBySeriesID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted matches: %w", err)
+	}
+	for _, match := range matches {
+		if match.DeletedAt == nil || !match.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		if err := s.matchRepo.Restore(ctx, match.ID); err != nil {
+			return nil, fmt.Errorf("failed to restore match %s: %w", match.ID, err)
+		}
+	}
+
+	series.DeletedAt = nil
+	s.audit.Record(ctx, models.AuditActionRestore, models.AuditEntitySeries, id, before, series)
+	return series, nil
+}
+
+// validatePointsRules checks that custom points rules are usable; nil means defaults
+func validatePointsRules(rules *models.PointsRules) error {
+	if rules == nil {
+		return nil
+	}
+	if rules.Win < 0 || rules.Loss < 0 || rules.Tie < 0 || rules.NoResult < 0 || rules.BonusPoint < 0 {
+		return fmt.Errorf("points cannot be negative")
+	}
+	if rules.Loss > rules.Win {
+		return fmt.Errorf("a loss cannot be worth more points than a win")
+	}
+	if rules.BonusRunRateRatio != 0 && rules.BonusRunRateRatio < 1 {
+		return fmt.Errorf("bonus run rate ratio must be at least 1, or 0 to disable bonus points")
+	}
+	return validateTieBreakers(rules.TieBreakers)
+}
+
+// validateImpactFormula checks that a custom impact formula is usable; nil means defaults
+func validateImpactFormula(formula *models.ImpactFormula) error {
+	if formula == nil {
+		return nil
+	}
+	weights := []float64{
+		formula.RunPoints, formula.BoundaryPoints, formula.StrikeRatePar, formula.StrikeRatePoints,
+		formula.WicketPoints, formula.MaidenPoints, formula.EconomyPar, formula.EconomyPoints,
+		formula.CatchPoints, formula.StumpingPoints, formula.RunOutPoints,
+	}
+	for _, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("impact formula values cannot be negative")
+		}
+	}
+	if formula.WinnerMultiplier < 1 {
+		return fmt.Errorf("winner multiplier must be at least 1")
+	}
+	return nil
+}
+
+// validateTieBreakers checks that each tie-breaker is known and used once
+func validateTieBreakers(tieBreakers []models.TieBreaker) error {
+	seen := make(map[models.TieBreaker]bool, len(tieBreakers))
+	for _, tieBreaker := range tieBreakers {
+		switch tieBreaker {
+		case models.TieBreakerPoints, models.TieBreakerWins, models.TieBreakerNetRunRate, models.TieBreakerHeadToHead:
+		default:
+			return fmt.Errorf("unknown tie-breaker: %s", tieBreaker)
+		}
+		if seen[tieBreaker] {
+			return fmt.Errorf("tie-breaker %s is listed more than once", tieBreaker)
+		}
+		seen[tieBreaker] = true
+	}
 	return nil
 }
@@ -0,0 +1,733 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"spark-park-cricket-backend/pkg/events"
+	"time"
+)
+
+// StageService manages series stages: group stages ranked by their own
+// tables, and knockout brackets whose sides are filled automatically from
+// group positions and earlier results as matches finish.
+type StageService struct {
+	stageRepo     interfaces.StageRepository
+	seriesRepo    interfaces.SeriesRepository
+	matchRepo     interfaces.MatchRepository
+	teamRepo      interfaces.TeamRepository
+	scorecardRepo interfaces.ScorecardRepository
+	standings     *StandingsService
+	matches       *MatchService
+	audit         *AuditService
+	orgs          *OrganizationService
+	broadcaster   *events.EventBroadcaster
+}
+
+// NewStageService creates a new stage service. Bracket matches are created
+// through the match service and ranked with the standings service.
+func NewStageService(stageRepo interfaces.StageRepository, seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, teamRepo interfaces.TeamRepository, scorecardRepo interfaces.ScorecardRepository, standings *StandingsService, matches *MatchService) *StageService {
+	return &StageService{
+		stageRepo:     stageRepo,
+		seriesRepo:    seriesRepo,
+		matchRepo:     matchRepo,
+		teamRepo:      teamRepo,
+		scorecardRepo: scorecardRepo,
+		standings:     standings,
+		matches:       matches,
+	}
+}
+
+// SetAuditService enables audit logging of stage changes and advancement
+func (s *StageService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of stages by organization
+func (s *StageService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// SetBroadcaster enables pushing bracket updates to series WebSocket rooms
+func (s *StageService) SetBroadcaster(broadcaster *events.EventBroadcaster) {
+	s.broadcaster = broadcaster
+}
+
+// CreateStage adds a group stage or knockout bracket to a series. Matches are
+// created for bracket entries that do not name an existing match.
+func (s *StageService) CreateStage(ctx context.Context, seriesID string, req *models.CreateStageRequest) (*models.SeriesStage, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if series.CreatedBy != userID {
+		return nil, fmt.Errorf("access denied: you can only add stages to series you created")
+	}
+
+	existing, err := s.stageRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series stages: %w", err)
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("stage name is required")
+	}
+	if err := validateTieBreakers(req.TieBreakers); err != nil {
+		return nil, err
+	}
+
+	stage := &models.SeriesStage{
+		SeriesID:    seriesID,
+		Name:        req.Name,
+		Position:    len(existing) + 1,
+		Type:        req.Type,
+		TieBreakers: req.TieBreakers,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	switch req.Type {
+	case models.StageTypeGroup:
+		if err := s.validateGroups(ctx, series, req.Groups); err != nil {
+			return nil, err
+		}
+		stage.Groups = req.Groups
+	case models.StageTypeKnockout:
+		bracket, err := s.buildBracket(ctx, series, existing, req)
+		if err != nil {
+			return nil, err
+		}
+		stage.Bracket = bracket
+	default:
+		return nil, fmt.Errorf("stage type must be group or knockout")
+	}
+
+	if err := s.stageRepo.Create(ctx, stage); err != nil {
+		return nil, fmt.Errorf("failed to create stage: %w", err)
+	}
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntitySeriesStage, stage.ID, nil, stage)
+
+	// Fixed teams and finished groups fill the new bracket straight away
+	if err := s.Advance(ctx, seriesID); err != nil {
+		log.Printf("Error advancing stages for series %s: %v", seriesID, err)
+	}
+	return stage, nil
+}
+
+// ListStages retrieves the stages of a series in order
+func (s *StageService) ListStages(ctx context.Context, seriesID string) ([]*models.SeriesStage, error) {
+	if _, err := s.visibleSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	stages, err := s.stageRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series stages: %w", err)
+	}
+	return stages, nil
+}
+
+// DeleteStage removes a stage from a series. Its matches are kept.
+func (s *StageService) DeleteStage(ctx context.Context, seriesID, stageID string) error {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return fmt.Errorf("user authentication required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("series not found: %w", err)
+	}
+	if series.CreatedBy != userID {
+		return fmt.Errorf("access denied: you can only remove stages from series you created")
+	}
+
+	stage, err := s.stageRepo.GetByID(ctx, stageID)
+	if err != nil || stage.SeriesID != seriesID {
+		return fmt.Errorf("stage not found")
+	}
+
+	if err := s.stageRepo.Delete(ctx, stageID); err != nil {
+		return fmt.Errorf("failed to delete stage: %w", err)
+	}
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntitySeriesStage, stageID, stage, nil)
+	return nil
+}
+
+// GetBracket retrieves every stage of a series ready to render: ranked group
+// tables and bracket rounds with the teams filled in so far
+func (s *StageService) GetBracket(ctx context.Context, seriesID string) (*models.SeriesBracket, error) {
+	series, err := s.visibleSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.loadState(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	return s.bracketView(ctx, state), nil
+}
+
+// Advance fills bracket sides from finished groups and bracket results, and
+// empties sides whose source is no longer decided, e.g. after a result is
+// reverted. Matches that have started or finished are left alone.
+func (s *StageService) Advance(ctx context.Context, seriesID string) error {
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("series not found: %w", err)
+	}
+
+	state, err := s.loadState(ctx, series)
+	if err != nil {
+		return err
+	}
+	if len(state.stages) == 0 {
+		return nil
+	}
+
+	for _, stage := range state.stages {
+		if stage.Type != models.StageTypeKnockout {
+			continue
+		}
+		stageChanged := false
+		for _, entry := range stage.Bracket {
+			match := state.matches[entry.MatchID]
+			if match == nil || match.Status != models.MatchStatusLive {
+				continue
+			}
+			innings, err := s.scorecardRepo.GetInningsByMatchID(ctx, match.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get innings for match %s: %w", match.ID, err)
+			}
+			if len(innings) > 0 {
+				continue
+			}
+
+			filledA, filledB := entry.TeamAID, entry.TeamBID
+			teamA := advanceSide(state.resolve(stage, entry.TeamA), &entry.TeamAID, match.TeamAID)
+			teamB := advanceSide(state.resolve(stage, entry.TeamB), &entry.TeamBID, match.TeamBID)
+			if entry.TeamAID != filledA || entry.TeamBID != filledB {
+				stageChanged = true
+			}
+			if teamA == match.TeamAID && teamB == match.TeamBID {
+				continue
+			}
+
+			before := s.audit.Snapshot(match)
+			match.TeamAID, match.TeamBID = teamA, teamB
+			match.UpdatedAt = time.Now()
+			if err := s.matchRepo.Update(ctx, match.ID, match); err != nil {
+				return fmt.Errorf("failed to advance match %s: %w", match.ID, err)
+			}
+			s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityMatch, match.ID, before, match)
+		}
+
+		if stageChanged {
+			stage.UpdatedAt = time.Now()
+			if err := s.stageRepo.Update(ctx, stage.ID, stage); err != nil {
+				return fmt.Errorf("failed to update stage %s: %w", stage.ID, err)
+			}
+		}
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastBracketUpdate(ctx, seriesID, s.bracketView(ctx, state))
+	}
+	return nil
+}
+
+// advanceSide decides a bracket side's team. A decided source always wins; an
+// undecided one empties the side only if it still holds the team filled in
+// automatically, so teams set by hand are kept.
+func advanceSide(resolved string, filled *string, current string) string {
+	previous := *filled
+	*filled = resolved
+	if resolved != "" {
+		return resolved
+	}
+	if current == previous {
+		return ""
+	}
+	return current
+}
+
+// visibleSeries retrieves a series the caller can see
+func (s *StageService) visibleSeries(ctx context.Context, seriesID string) (*models.Series, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("series not found")
+	}
+	return series, nil
+}
+
+// validateGroups checks that groups are named, have at least two teams each,
+// do not share teams and only hold teams that can play in the series
+func (s *StageService) validateGroups(ctx context.Context, series *models.Series, groups []models.StageGroup) error {
+	if len(groups) == 0 {
+		return fmt.Errorf("a group stage needs at least one group")
+	}
+
+	names := map[string]bool{}
+	teams := map[string]bool{}
+	for _, group := range groups {
+		if group.Name == "" {
+			return fmt.Errorf("group name is required")
+		}
+		if names[group.Name] {
+			return fmt.Errorf("group %s is listed more than once", group.Name)
+		}
+		names[group.Name] = true
+		if len(group.TeamIDs) < 2 {
+			return fmt.Errorf("group %s needs at least 2 teams", group.Name)
+		}
+		for _, teamID := range group.TeamIDs {
+			if teams[teamID] {
+				return fmt.Errorf("team %s is in more than one group", teamID)
+			}
+			teams[teamID] = true
+			if err := s.checkTeam(ctx, series, teamID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkTeam checks that a team exists and can play in the series
+func (s *StageService) checkTeam(ctx context.Context, series *models.Series, teamID string) error {
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("team %s not found: %w", teamID, err)
+	}
+	if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+		return fmt.Errorf("team %s not found", teamID)
+	}
+	if series.OrganizationID != "" && team.OrganizationID != "" && team.OrganizationID != series.OrganizationID {
+		return fmt.Errorf("team %s belongs to a different organization than the series", teamID)
+	}
+	return nil
+}
+
+// buildBracket validates a knockout request's sources and creates the matches
+// of entries that do not name an existing one
+func (s *StageService) buildBracket(ctx context.Context, series *models.Series, existing []*models.SeriesStage, req *models.CreateStageRequest) ([]*models.BracketMatch, error) {
+	if len(req.Bracket) == 0 {
+		return nil, fmt.Errorf("a knockout stage needs at least one match")
+	}
+
+	// Group positions default to the latest group stage
+	var groupStages []*models.SeriesStage
+	for _, stage := range existing {
+		if stage.Type == models.StageTypeGroup {
+			groupStages = append(groupStages, stage)
+		}
+	}
+
+	bracket := make([]*models.BracketMatch, len(req.Bracket))
+	for i, entry := range req.Bracket {
+		if entry.Round < 1 {
+			return nil, fmt.Errorf("bracket match %d: round must be at least 1", i+1)
+		}
+		bracket[i] = &models.BracketMatch{
+			Position: i + 1,
+			Round:    entry.Round,
+			Name:     entry.Name,
+			MatchID:  entry.MatchID,
+			TeamA:    entry.TeamA,
+			TeamB:    entry.TeamB,
+		}
+		for _, source := range []*models.SlotSource{&bracket[i].TeamA, &bracket[i].TeamB} {
+			if err := s.checkSlotSource(ctx, series, groupStages, bracket, i, source); err != nil {
+				return nil, fmt.Errorf("bracket match %d: %w", i+1, err)
+			}
+		}
+		if bracket[i].TeamA == bracket[i].TeamB {
+			return nil, fmt.Errorf("bracket match %d: both sides have the same source", i+1)
+		}
+	}
+
+	playerCount := req.TeamPlayerCount
+	if playerCount == 0 {
+		playerCount = models.DefaultFixturePlayerCount
+	}
+	totalOvers := req.TotalOvers
+	if totalOvers == 0 {
+		totalOvers = models.DefaultFixtureTotalOvers
+	}
+
+	// Check named matches before creating any
+	for i, entry := range req.Bracket {
+		if entry.MatchID != "" {
+			match, err := s.matchRepo.GetByID(ctx, entry.MatchID)
+			if err != nil || match.SeriesID != series.ID {
+				return nil, fmt.Errorf("bracket match %d: match %s not found in this series", i+1, entry.MatchID)
+			}
+		} else if entry.Date.IsZero() {
+			return nil, fmt.Errorf("bracket match %d: date is required to create its match", i+1)
+		}
+	}
+
+	for i, entry := range req.Bracket {
+		if entry.MatchID != "" {
+			continue
+		}
+		match, err := s.matches.CreateMatch(ctx, &models.CreateMatchRequest{
+			SeriesID:         series.ID,
+			Date:             entry.Date,
+			Venue:            entry.Venue,
+			TeamAPlayerCount: playerCount,
+			TeamBPlayerCount: playerCount,
+			TotalOvers:       totalOvers,
+			TossWinner:       models.TeamTypeA,
+			TossType:         models.TossTypeHeads,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bracket match %d: %w", i+1, err)
+		}
+		bracket[i].MatchID = match.ID
+	}
+	return bracket, nil
+}
+
+// checkSlotSource validates the source of one side of the index-th bracket
+// match, filling in the default group stage
+func (s *StageService) checkSlotSource(ctx context.Context, series *models.Series, groupStages []*models.SeriesStage, bracket []*models.BracketMatch, index int, source *models.SlotSource) error {
+	switch source.Type {
+	case models.SlotSourceGroupPosition:
+		var stage *models.SeriesStage
+		for _, candidate := range groupStages {
+			if source.StageID == "" || candidate.ID == source.StageID {
+				stage = candidate
+			}
+		}
+		if stage == nil {
+			return fmt.Errorf("no group stage to take positions from")
+		}
+		source.StageID = stage.ID
+		for _, group := range stage.Groups {
+			if group.Name == source.Group {
+				if source.Position < 1 || source.Position > len(group.TeamIDs) {
+					return fmt.Errorf("group %s has no position %d", group.Name, source.Position)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("group %q not found in stage %s", source.Group, stage.Name)
+	case models.SlotSourceWinner, models.SlotSourceLoser:
+		if source.Match < 1 || source.Match > index {
+			return fmt.Errorf("%s must refer to an earlier bracket match", source.Type)
+		}
+		if bracket[source.Match-1].Round >= bracket[index].Round {
+			return fmt.Errorf("%s of match %d must come from an earlier round", source.Type, source.Match)
+		}
+		return nil
+	case models.SlotSourceTeam:
+		return s.checkTeam(ctx, series, source.TeamID)
+	default:
+		return fmt.Errorf("unknown slot source type: %q", source.Type)
+	}
+}
+
+// seriesState is everything needed to rank groups and resolve bracket sides
+type seriesState struct {
+	stages  []*models.SeriesStage
+	matches map[string]*models.Match
+	// Group tables by stage ID and group name
+	groups map[string]map[string]*models.GroupStandings
+	// Results of finished bracket matches by match ID
+	results map[string]*models.MatchResult
+}
+
+// loadState ranks every group and decides every finished bracket match of a series
+func (s *StageService) loadState(ctx context.Context, series *models.Series) (*seriesState, error) {
+	stages, err := s.stageRepo.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series stages: %w", err)
+	}
+	state := &seriesState{
+		stages:  stages,
+		matches: map[string]*models.Match{},
+		groups:  map[string]map[string]*models.GroupStandings{},
+		results: map[string]*models.MatchResult{},
+	}
+	if len(stages) == 0 {
+		return state, nil
+	}
+
+	matches, err := s.matchRepo.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series matches: %w", err)
+	}
+	for _, match := range matches {
+		state.matches[match.ID] = match
+	}
+
+	rules := seriesPointsRules(series)
+	var bracketMatches []*models.Match
+	inBracket := map[string]bool{}
+	for _, stage := range stages {
+		for _, entry := range stage.Bracket {
+			inBracket[entry.MatchID] = true
+			if match := state.matches[entry.MatchID]; match != nil {
+				bracketMatches = append(bracketMatches, match)
+			}
+		}
+	}
+
+	for _, stage := range stages {
+		if stage.Type != models.StageTypeGroup {
+			continue
+		}
+		tieBreakers := stage.TieBreakers
+		if len(tieBreakers) == 0 {
+			tieBreakers = rules.TieBreakers
+		}
+
+		state.groups[stage.ID] = map[string]*models.GroupStandings{}
+		for _, group := range stage.Groups {
+			table, err := s.groupStandings(ctx, group, matches, inBracket, rules, tieBreakers)
+			if err != nil {
+				return nil, err
+			}
+			state.groups[stage.ID][group.Name] = table
+		}
+	}
+
+	_, results, err := s.standings.buildTable(ctx, bracketMatches, rules)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		state.results[result.MatchID] = result
+	}
+	return state, nil
+}
+
+// groupStandings ranks a group from the series' non-bracket matches between its teams
+func (s *StageService) groupStandings(ctx context.Context, group models.StageGroup, matches []*models.Match, inBracket map[string]bool, rules models.PointsRules, tieBreakers []models.TieBreaker) (*models.GroupStandings, error) {
+	members := make(map[string]bool, len(group.TeamIDs))
+	for _, teamID := range group.TeamIDs {
+		members[teamID] = true
+	}
+
+	var groupMatches []*models.Match
+	pairs := map[[2]string]bool{}
+	complete := true
+	for _, match := range matches {
+		if inBracket[match.ID] || !members[match.TeamAID] || !members[match.TeamBID] {
+			continue
+		}
+		groupMatches = append(groupMatches, match)
+		pair := [2]string{match.TeamAID, match.TeamBID}
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		pairs[pair] = true
+		if match.Status == models.MatchStatusLive {
+			complete = false
+		}
+	}
+	n := len(group.TeamIDs)
+	if len(pairs) < n*(n-1)/2 {
+		complete = false
+	}
+
+	rows, results, err := s.standings.buildTable(ctx, groupMatches, rules)
+	if err != nil {
+		return nil, err
+	}
+	// Teams without fixtures yet still get a row
+	listed := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		listed[row.TeamID] = true
+	}
+	for _, teamID := range group.TeamIDs {
+		if !listed[teamID] {
+			rows = append(rows, &models.StandingsRow{TeamID: teamID, TeamName: s.standings.teamName(ctx, teamID)})
+		}
+	}
+	sortStandings(rows, results, rules, tieBreakers)
+
+	return &models.GroupStandings{
+		Name:      group.Name,
+		Complete:  complete,
+		Standings: rows,
+	}, nil
+}
+
+// resolve returns the team a bracket side's source points to, or "" while it is undecided
+func (st *seriesState) resolve(stage *models.SeriesStage, source models.SlotSource) string {
+	switch source.Type {
+	case models.SlotSourceTeam:
+		return source.TeamID
+	case models.SlotSourceGroupPosition:
+		table := st.groups[source.StageID][source.Group]
+		if table == nil || !table.Complete || source.Position > len(table.Standings) {
+			return ""
+		}
+		return table.Standings[source.Position-1].TeamID
+	case models.SlotSourceWinner, models.SlotSourceLoser:
+		if source.Match < 1 || source.Match > len(stage.Bracket) {
+			return ""
+		}
+		result := st.results[stage.Bracket[source.Match-1].MatchID]
+		// Ties and no results have to be settled by hand
+		if result == nil || result.Outcome != models.MatchOutcomeWin {
+			return ""
+		}
+		if source.Type == models.SlotSourceWinner {
+			return result.WinnerTeamID
+		}
+		return result.LoserTeamID
+	}
+	return ""
+}
+
+// label describes a bracket side's source for viewers
+func (st *seriesState) label(stage *models.SeriesStage, source models.SlotSource, names map[int]string) string {
+	switch source.Type {
+	case models.SlotSourceGroupPosition:
+		return fmt.Sprintf("%s in Group %s", ordinal(source.Position), source.Group)
+	case models.SlotSourceWinner:
+		return "Winner of " + names[source.Match]
+	case models.SlotSourceLoser:
+		return "Loser of " + names[source.Match]
+	}
+	return ""
+}
+
+// ordinal formats a finishing position, e.g. 1st, 2nd, 11th
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// bracketMatchNames names each bracket match by position: its own name, or
+// its round's name numbered within the round when the round has several
+func bracketMatchNames(stage *models.SeriesStage) (map[int]string, map[int]string) {
+	lastRound := 0
+	perRound := map[int]int{}
+	for _, entry := range stage.Bracket {
+		perRound[entry.Round]++
+		if entry.Round > lastRound {
+			lastRound = entry.Round
+		}
+	}
+
+	roundNames := map[int]string{}
+	for round := range perRound {
+		// Rounds are named by distance from the final
+		roundNames[round] = knockoutStage(2 << (lastRound - round))
+	}
+
+	names := map[int]string{}
+	seen := map[int]int{}
+	for _, entry := range stage.Bracket {
+		seen[entry.Round]++
+		switch {
+		case entry.Name != "":
+			names[entry.Position] = entry.Name
+		case perRound[entry.Round] > 1:
+			names[entry.Position] = fmt.Sprintf("%s %d", roundNames[entry.Round], seen[entry.Round])
+		default:
+			names[entry.Position] = roundNames[entry.Round]
+		}
+	}
+	return roundNames, names
+}
+
+// bracketView builds the renderable form of every stage
+func (s *StageService) bracketView(ctx context.Context, state *seriesState) *models.SeriesBracket {
+	view := &models.SeriesBracket{
+		Stages:    make([]*models.StageView, 0, len(state.stages)),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, stage := range state.stages {
+		view.SeriesID = stage.SeriesID
+		stageView := &models.StageView{
+			ID:          stage.ID,
+			Name:        stage.Name,
+			Position:    stage.Position,
+			Type:        stage.Type,
+			TieBreakers: stage.TieBreakers,
+		}
+
+		for _, group := range stage.Groups {
+			if table := state.groups[stage.ID][group.Name]; table != nil {
+				stageView.Groups = append(stageView.Groups, table)
+			}
+		}
+
+		roundNames, names := bracketMatchNames(stage)
+		rounds := map[int]*models.BracketRound{}
+		for _, entry := range stage.Bracket {
+			round := rounds[entry.Round]
+			if round == nil {
+				round = &models.BracketRound{Round: entry.Round, Name: roundNames[entry.Round]}
+				rounds[entry.Round] = round
+				stageView.Rounds = append(stageView.Rounds, round)
+			}
+
+			matchView := &models.BracketMatchView{
+				Position: entry.Position,
+				Name:     names[entry.Position],
+				MatchID:  entry.MatchID,
+				TeamA:    &models.BracketSide{Label: state.label(stage, entry.TeamA, names)},
+				TeamB:    &models.BracketSide{Label: state.label(stage, entry.TeamB, names)},
+			}
+			if match := state.matches[entry.MatchID]; match != nil {
+				date := match.Date
+				matchView.MatchNumber = match.MatchNumber
+				matchView.Date = &date
+				matchView.Venue = match.Venue
+				matchView.Status = match.Status
+				matchView.TeamA.TeamID = match.TeamAID
+				matchView.TeamB.TeamID = match.TeamBID
+			}
+			for _, side := range []*models.BracketSide{matchView.TeamA, matchView.TeamB} {
+				if side.TeamID != "" {
+					side.TeamName = s.standings.teamName(ctx, side.TeamID)
+					if side.Label == "" {
+						side.Label = side.TeamName
+					}
+				}
+			}
+			if result := state.results[entry.MatchID]; result != nil {
+				matchView.WinnerTeamID = result.WinnerTeamID
+			}
+			round.Matches = append(round.Matches, matchView)
+		}
+		sort.Slice(stageView.Rounds, func(i, j int) bool {
+			return stageView.Rounds[i].Round < stageView.Rounds[j].Round
+		})
+
+		view.Stages = append(view.Stages, stageView)
+	}
+	return view
+}
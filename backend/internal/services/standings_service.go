@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"spark-park-cricket-backend/pkg/events"
+	"time"
+)
+
+// StandingsService computes series points tables from match results. Results
+// are derived from the innings of completed matches, so standings are always
+// current when read; changes are also pushed to series WebSocket rooms.
+type StandingsService struct {
+	seriesRepo    interfaces.SeriesRepository
+	matchRepo     interfaces.MatchRepository
+	teamRepo      interfaces.TeamRepository
+	scorecardRepo interfaces.ScorecardRepository
+	orgs          *OrganizationService
+	broadcaster   *events.EventBroadcaster
+}
+
+// NewStandingsService creates a new standings service
+func NewStandingsService(seriesRepo interfaces.SeriesRepository, matchRepo interfaces.MatchRepository, teamRepo interfaces.TeamRepository, scorecardRepo interfaces.ScorecardRepository) *StandingsService {
+	return &StandingsService{
+		seriesRepo:    seriesRepo,
+		matchRepo:     matchRepo,
+		teamRepo:      teamRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// SetOrganizationService enables tenant scoping of standings by organization
+func (s *StandingsService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// SetBroadcaster enables pushing standings updates to series WebSocket rooms
+func (s *StandingsService) SetBroadcaster(broadcaster *events.EventBroadcaster) {
+	s.broadcaster = broadcaster
+}
+
+// GetStandings retrieves the points table of a series
+func (s *StandingsService) GetStandings(ctx context.Context, seriesID string) (*models.SeriesStandings, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("series not found")
+	}
+
+	return s.computeStandings(ctx, series)
+}
+
+// PublishStandings recomputes a series' points table and pushes it to the
+// series WebSocket room
+func (s *StandingsService) PublishStandings(ctx context.Context, seriesID string) error {
+	if s.broadcaster == nil {
+		return nil
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("series not found: %w", err)
+	}
+
+	standings, err := s.computeStandings(ctx, series)
+	if err != nil {
+		return err
+	}
+
+	s.broadcaster.BroadcastStandingsUpdate(ctx, seriesID, standings)
+	return nil
+}
+
+// computeStandings builds the points table from every match in the series
+// that is played between two linked teams
+func (s *StandingsService) computeStandings(ctx context.Context, series *models.Series) (*models.SeriesStandings, error) {
+	rules := seriesPointsRules(series)
+
+	matches, err := s.matchRepo.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series matches: %w", err)
+	}
+
+	standings, results, err := s.buildTable(ctx, matches, rules)
+	if err != nil {
+		return nil, err
+	}
+	sortStandings(standings, results, rules, rules.TieBreakers)
+
+	return &models.SeriesStandings{
+		SeriesID:    series.ID,
+		PointsRules: rules,
+		Standings:   standings,
+		Results:     results,
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// buildTable computes unsorted standings rows and the results of finished
+// matches, in match number order, from a set of matches
+func (s *StandingsService) buildTable(ctx context.Context, matches []*models.Match, rules models.PointsRules) ([]*models.StandingsRow, []*models.MatchResult, error) {
+	matches = append([]*models.Match{}, matches...)
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].MatchNumber < matches[j].MatchNumber
+	})
+
+	rows := map[string]*models.StandingsRow{}
+	var order []string
+	row := func(teamID string) *models.StandingsRow {
+		if r, ok := rows[teamID]; ok {
+			return r
+		}
+		r := &models.StandingsRow{TeamID: teamID, TeamName: s.teamName(ctx, teamID)}
+		rows[teamID] = r
+		order = append(order, teamID)
+		return r
+	}
+
+	results := []*models.MatchResult{}
+	for _, match := range matches {
+		if match.TeamAID == "" || match.TeamBID == "" {
+			continue
+		}
+		// Every team with a fixture is listed, even before it has played
+		teamA, teamB := row(match.TeamAID), row(match.TeamBID)
+
+		if match.Status != models.MatchStatusCompleted && match.Status != models.MatchStatusCancelled {
+			continue
+		}
+
+		var innings []*models.Innings
+		if match.Status == models.MatchStatusCompleted {
+			var err error
+			innings, err = s.scorecardRepo.GetInningsByMatchID(ctx, match.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get innings for match %s: %w", match.ID, err)
+			}
+		}
+
+		result := applyMatchResult(match, innings, rules, teamA, teamB)
+		results = append(results, result)
+	}
+
+	standings := make([]*models.StandingsRow, 0, len(rows))
+	for _, teamID := range order {
+		r := rows[teamID]
+		r.OversFor = ballsToOvers(r.BallsFor)
+		r.OversAgainst = ballsToOvers(r.BallsAgainst)
+		if r.BallsFor > 0 && r.BallsAgainst > 0 {
+			runRateFor := float64(r.RunsFor) * 6 / float64(r.BallsFor)
+			runRateAgainst := float64(r.RunsAgainst) * 6 / float64(r.BallsAgainst)
+			r.NetRunRate = math.Round((runRateFor-runRateAgainst)*1000) / 1000
+		}
+		standings = append(standings, r)
+	}
+	return standings, results, nil
+}
+
+// seriesPointsRules returns a series' points rules, or the defaults
+func seriesPointsRules(series *models.Series) models.PointsRules {
+	if series.PointsRules != nil {
+		return *series.PointsRules
+	}
+	return models.DefaultPointsRules()
+}
+
+// applyMatchResult decides a finished match and adds it to both teams' rows.
+// Cancelled matches and matches completed without both innings are no results
+// and do not count towards net run rate.
+func applyMatchResult(match *models.Match, innings []*models.Innings, rules models.PointsRules, teamA, teamB *models.StandingsRow) *models.MatchResult {
+	result := &models.MatchResult{
+		MatchID:     match.ID,
+		MatchNumber: match.MatchNumber,
+		Outcome:     models.MatchOutcomeNoResult,
+		TeamAID:     match.TeamAID,
+		TeamBID:     match.TeamBID,
+	}
+	teamA.Played++
+	teamB.Played++
+
+	var first, second *models.Innings
+	for _, inn := range innings {
+		switch inn.InningsNumber {
+		case 1:
+			first = inn
+		case 2:
+			second = inn
+		}
+	}
+	if first == nil || second == nil {
+		for _, r := range []*models.StandingsRow{teamA, teamB} {
+			r.NoResult++
+			r.Points += rules.NoResult
+		}
+		return result
+	}
+
+	sides := map[models.TeamType]*models.StandingsRow{models.TeamTypeA: teamA, models.TeamTypeB: teamB}
+	balls := map[models.TeamType]int{}
+	runs := map[models.TeamType]int{}
+	for _, inn := range []*models.Innings{first, second} {
+		batting, bowling := sides[inn.BattingTeam], sides[otherSide(inn.BattingTeam)]
+		// Per the laws, a side bowled out is charged its full quota of overs
+		faced := inn.TotalBalls
+		if inn.TotalWickets >= maxWicketsFor(match, inn.BattingTeam) {
+			faced = match.TotalOvers * 6
+		}
+		batting.RunsFor += inn.TotalRuns
+		batting.BallsFor += faced
+		bowling.RunsAgainst += inn.TotalRuns
+		bowling.BallsAgainst += faced
+		runs[inn.BattingTeam] = inn.TotalRuns
+		balls[inn.BattingTeam] = faced
+	}
+
+	if first.TotalRuns == second.TotalRuns {
+		result.Outcome = models.MatchOutcomeTie
+		for _, r := range []*models.StandingsRow{teamA, teamB} {
+			r.Tied++
+			r.Points += rules.Tie
+		}
+		return result
+	}
+
+	winnerSide := first.BattingTeam
+	if second.TotalRuns > first.TotalRuns {
+		winnerSide = second.BattingTeam
+	}
+	loserSide := otherSide(winnerSide)
+	winner, loser := sides[winnerSide], sides[loserSide]
+
+	result.Outcome = models.MatchOutcomeWin
+	result.WinnerTeamID = winner.TeamID
+	result.LoserTeamID = loser.TeamID
+	winner.Won++
+	winner.Points += rules.Win
+	loser.Lost++
+	loser.Points += rules.Loss
+
+	if rules.BonusRunRateRatio > 0 && balls[winnerSide] > 0 && balls[loserSide] > 0 {
+		winnerRate := float64(runs[winnerSide]) / float64(balls[winnerSide])
+		loserRate := float64(runs[loserSide]) / float64(balls[loserSide])
+		if winnerRate >= rules.BonusRunRateRatio*loserRate {
+			result.BonusPoint = true
+			winner.BonusPoints += rules.BonusPoint
+			winner.Points += rules.BonusPoint
+		}
+	}
+	return result
+}
+
+// sortStandings orders teams by the given tie-breakers (the defaults when
+// empty), then by name, and sets their positions
+func sortStandings(standings []*models.StandingsRow, results []*models.MatchResult, rules models.PointsRules, tieBreakers []models.TieBreaker) {
+	if len(tieBreakers) == 0 {
+		tieBreakers = models.DefaultTieBreakers()
+	}
+
+	rankStandings(standings, results, rules, tieBreakers)
+	for i, r := range standings {
+		r.Position = i + 1
+	}
+}
+
+// rankStandings orders a group of teams by the first tie-breaker, then each
+// run of teams still level by the rest, and finally by name. Head-to-head is
+// a mini-league of the matches among the teams level at that point, so it
+// ranks any number of them the same way whatever order they come in.
+func rankStandings(group []*models.StandingsRow, results []*models.MatchResult, rules models.PointsRules, tieBreakers []models.TieBreaker) {
+	if len(group) < 2 {
+		return
+	}
+	if len(tieBreakers) == 0 {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].TeamName < group[j].TeamName
+		})
+		return
+	}
+
+	keys := tieBreakerKeys(group, results, rules, tieBreakers[0])
+	sort.SliceStable(group, func(i, j int) bool {
+		return keys[group[i].TeamID] > keys[group[j].TeamID]
+	})
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && keys[group[end].TeamID] == keys[group[start].TeamID] {
+			end++
+		}
+		rankStandings(group[start:end], results, rules, tieBreakers[1:])
+		start = end
+	}
+}
+
+// tieBreakerKeys returns each team's value for a tie-breaker, higher ranking first
+func tieBreakerKeys(group []*models.StandingsRow, results []*models.MatchResult, rules models.PointsRules, tieBreaker models.TieBreaker) map[string]float64 {
+	var miniLeague map[string]int
+	if tieBreaker == models.TieBreakerHeadToHead {
+		miniLeague = miniLeaguePoints(group, results, rules)
+	}
+
+	keys := make(map[string]float64, len(group))
+	for _, row := range group {
+		switch tieBreaker {
+		case models.TieBreakerPoints:
+			keys[row.TeamID] = float64(row.Points)
+		case models.TieBreakerWins:
+			keys[row.TeamID] = float64(row.Won)
+		case models.TieBreakerNetRunRate:
+			keys[row.TeamID] = row.NetRunRate
+		case models.TieBreakerHeadToHead:
+			keys[row.TeamID] = float64(miniLeague[row.TeamID])
+		}
+	}
+	return keys
+}
+
+// miniLeaguePoints returns the points each team in a group earned in matches
+// against the others in it
+func miniLeaguePoints(group []*models.StandingsRow, results []*models.MatchResult, rules models.PointsRules) map[string]int {
+	inGroup := make(map[string]bool, len(group))
+	for _, row := range group {
+		inGroup[row.TeamID] = true
+	}
+
+	points := make(map[string]int, len(group))
+	for _, result := range results {
+		if !inGroup[result.TeamAID] || !inGroup[result.TeamBID] {
+			continue
+		}
+		switch result.Outcome {
+		case models.MatchOutcomeWin:
+			points[result.WinnerTeamID] += rules.Win
+			if result.WinnerTeamID == result.TeamAID {
+				points[result.TeamBID] += rules.Loss
+			} else {
+				points[result.TeamAID] += rules.Loss
+			}
+		case models.MatchOutcomeTie:
+			points[result.TeamAID] += rules.Tie
+			points[result.TeamBID] += rules.Tie
+		case models.MatchOutcomeNoResult:
+			points[result.TeamAID] += rules.NoResult
+			points[result.TeamBID] += rules.NoResult
+		}
+	}
+	return points
+}
+
+// teamName resolves a team's name, falling back to the trash and then the ID
+func (s *StandingsService) teamName(ctx context.Context, teamID string) string {
+	if team, err := s.teamRepo.GetByID(ctx, teamID); err == nil {
+		return team.Name
+	}
+	if team, err := s.teamRepo.GetDeletedByID(ctx, teamID); err == nil {
+		return team.Name
+	}
+	return teamID
+}
+
+// otherSide returns the opposing side of a match
+func otherSide(team models.TeamType) models.TeamType {
+	if team == models.TeamTypeA {
+		return models.TeamTypeB
+	}
+	return models.TeamTypeA
+}
+
+// ballsToOvers converts legal balls to cricket notation, e.g. 118 balls is 19.4
+func ballsToOvers(balls int) float64 {
+	return float64(balls/6) + float64(balls%6)/10
+}
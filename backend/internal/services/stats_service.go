@@ -0,0 +1,570 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"time"
+)
+
+// rebuildPageSize is how many completed matches a full rebuild loads at a time
+const rebuildPageSize = 100
+
+// StatsService rolls ball-by-ball scorecards up into per-match player lines
+// and career and per-series aggregates. Aggregates are refreshed for the
+// players of each match as it completes and can be rebuilt in full.
+type StatsService struct {
+	statsRepo     interfaces.PlayerStatsRepository
+	matchRepo     interfaces.MatchRepository
+	seriesRepo    interfaces.SeriesRepository
+	playerRepo    interfaces.PlayerRepository
+	scorecardRepo interfaces.ScorecardRepository
+	squadRepo     interfaces.MatchSquadRepository
+	awardRepo     interfaces.AwardRepository
+	orgs          *OrganizationService
+}
+
+// NewStatsService creates a new statistics service
+func NewStatsService(statsRepo interfaces.PlayerStatsRepository, matchRepo interfaces.MatchRepository, seriesRepo interfaces.SeriesRepository, playerRepo interfaces.PlayerRepository, scorecardRepo interfaces.ScorecardRepository) *StatsService {
+	return &StatsService{
+		statsRepo:     statsRepo,
+		matchRepo:     matchRepo,
+		seriesRepo:    seriesRepo,
+		playerRepo:    playerRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// SetMatchSquadRepository enables counting a match for every player in the
+// declared XIs, including those who neither batted nor bowled
+func (s *StatsService) SetMatchSquadRepository(squadRepo interfaces.MatchSquadRepository) {
+	s.squadRepo = squadRepo
+}
+
+// SetAwardRepository enables listing a player's awards on their profile
+func (s *StatsService) SetAwardRepository(awardRepo interfaces.AwardRepository) {
+	s.awardRepo = awardRepo
+}
+
+// SetOrganizationService enables tenant scoping of statistics by organization
+func (s *StatsService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// RecordMatch stores the player lines of a completed match and refreshes the
+// aggregates of everyone who played in it
+func (s *StatsService) RecordMatch(ctx context.Context, matchID string) error {
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("match not found: %w", err)
+	}
+	if match.Status != models.MatchStatusCompleted {
+		return fmt.Errorf("match is not completed, cannot record statistics")
+	}
+
+	stats, err := s.computeMatch(ctx, match, map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	// Players dropped from a recomputed match need their aggregates refreshed too
+	previous, err := s.statsRepo.GetMatchStatsByMatchID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match statistics: %w", err)
+	}
+
+	if err := s.statsRepo.ReplaceMatchStats(ctx, matchID, stats); err != nil {
+		return fmt.Errorf("failed to save match statistics: %w", err)
+	}
+
+	return s.refreshPlayers(ctx, append(previous, stats...))
+}
+
+// RemoveMatch drops a match from the statistics, e.g. when it is deleted or
+// reopened, and refreshes the aggregates of everyone who played in it
+func (s *StatsService) RemoveMatch(ctx context.Context, matchID string) error {
+	previous, err := s.statsRepo.GetMatchStatsByMatchID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match statistics: %w", err)
+	}
+	if len(previous) == 0 {
+		return nil
+	}
+
+	if err := s.statsRepo.DeleteMatchStats(ctx, matchID); err != nil {
+		return fmt.Errorf("failed to delete match statistics: %w", err)
+	}
+
+	return s.refreshPlayers(ctx, previous)
+}
+
+// RebuildAll discards every stored statistic and recomputes them from the
+// scorecards of all completed matches
+func (s *StatsService) RebuildAll(ctx context.Context) (*models.StatsRebuildResponse, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	if err := s.statsRepo.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to clear statistics: %w", err)
+	}
+
+	status := models.MatchStatusCompleted
+	names := map[string]string{}
+	var all []*models.PlayerMatchStats
+	matchCount := 0
+
+	for offset := 0; ; offset += rebuildPageSize {
+		matches, err := s.matchRepo.GetAll(ctx, &models.MatchFilters{Status: &status, Limit: rebuildPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list completed matches: %w", err)
+		}
+
+		for _, match := range matches {
+			stats, err := s.computeMatch(ctx, match, names)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.statsRepo.ReplaceMatchStats(ctx, match.ID, stats); err != nil {
+				return nil, fmt.Errorf("failed to save match statistics: %w", err)
+			}
+			all = append(all, stats...)
+			matchCount++
+		}
+
+		if len(matches) < rebuildPageSize {
+			break
+		}
+	}
+
+	if err := s.refreshPlayers(ctx, all); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Rebuilt statistics from %d matches for %d players", matchCount, len(uniquePlayerIDs(all)))
+	return &models.StatsRebuildResponse{Matches: matchCount, Players: len(uniquePlayerIDs(all))}, nil
+}
+
+// GetPlayerStats retrieves a player's career record and series breakdown,
+// optionally narrowed to a single series
+func (s *StatsService) GetPlayerStats(ctx context.Context, playerID, seriesID string) (*models.PlayerStatsResponse, error) {
+	if playerID == "" {
+		return nil, fmt.Errorf("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("player not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, player.OrganizationID); err != nil {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	rows, err := s.statsRepo.GetPlayerStats(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player statistics: %w", err)
+	}
+
+	response := &models.PlayerStatsResponse{
+		PlayerID: playerID,
+		Career:   &models.PlayerStats{PlayerID: playerID, PlayerName: player.Name},
+		Series:   []*models.PlayerStats{},
+		Awards:   []*models.Award{},
+	}
+	for _, row := range rows {
+		fillRates(row)
+		switch {
+		case row.SeriesID == "":
+			response.Career = row
+		case seriesID == "" || row.SeriesID == seriesID:
+			response.Series = append(response.Series, row)
+		}
+	}
+	sort.Slice(response.Series, func(i, j int) bool {
+		return response.Series[i].SeriesID < response.Series[j].SeriesID
+	})
+
+	if s.awardRepo != nil {
+		awards, err := s.awardRepo.GetByPlayerID(ctx, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get player awards: %w", err)
+		}
+		for _, award := range awards {
+			if seriesID == "" || award.SeriesID == seriesID {
+				response.Awards = append(response.Awards, award)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// GetSeriesStats retrieves the aggregated records of every player in a series
+func (s *StatsService) GetSeriesStats(ctx context.Context, seriesID string) ([]*models.PlayerStats, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, series.OrganizationID); err != nil {
+		return nil, fmt.Errorf("series not found")
+	}
+
+	stats, err := s.statsRepo.GetSeriesStats(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series statistics: %w", err)
+	}
+	for _, row := range stats {
+		fillRates(row)
+	}
+
+	return stats, nil
+}
+
+// GetMatchPlayerStats computes the player lines of a match from its current
+// scorecard, so live matches are covered too
+func (s *StatsService) GetMatchPlayerStats(ctx context.Context, matchID string) ([]*models.PlayerMatchStats, error) {
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("match not found: %w", err)
+	}
+	if err := s.orgs.CheckVisible(ctx, match.OrganizationID); err != nil {
+		return nil, fmt.Errorf("match not found")
+	}
+
+	return s.computeMatch(ctx, match, map[string]string{})
+}
+
+// computeMatch loads a match's scorecard and squads and derives its player
+// lines. Names are cached across calls through the given map.
+func (s *StatsService) computeMatch(ctx context.Context, match *models.Match, names map[string]string) ([]*models.PlayerMatchStats, error) {
+	scorecard, err := s.scorecardRepo.GetScorecard(ctx, match.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scorecard: %w", err)
+	}
+
+	var squad []*models.MatchSquadPlayer
+	if s.squadRepo != nil {
+		squad, err = s.squadRepo.GetByMatchID(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get squads: %w", err)
+		}
+	}
+
+	stats := computeMatchStats(match, scorecard, squad)
+	for _, line := range stats {
+		if line.PlayerName == "" {
+			line.PlayerName = s.playerName(ctx, line.PlayerID, names)
+		}
+	}
+	return stats, nil
+}
+
+// playerName resolves a player's name, falling back to the trash and then the ID
+func (s *StatsService) playerName(ctx context.Context, playerID string, names map[string]string) string {
+	if name, ok := names[playerID]; ok {
+		return name
+	}
+
+	name := playerID
+	if player, err := s.playerRepo.GetByID(ctx, playerID); err == nil {
+		name = player.Name
+	} else if player, err := s.playerRepo.GetDeletedByID(ctx, playerID); err == nil {
+		name = player.Name
+	}
+	names[playerID] = name
+	return name
+}
+
+// refreshPlayers recomputes the career and series aggregates of each player
+// that appears in the given match lines
+func (s *StatsService) refreshPlayers(ctx context.Context, lines []*models.PlayerMatchStats) error {
+	for _, playerID := range uniquePlayerIDs(lines) {
+		rows, err := s.statsRepo.GetMatchStatsByPlayer(ctx, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to get statistics for player %s: %w", playerID, err)
+		}
+		if err := s.statsRepo.ReplacePlayerStats(ctx, playerID, aggregatePlayerStats(playerID, rows)); err != nil {
+			return fmt.Errorf("failed to save statistics for player %s: %w", playerID, err)
+		}
+	}
+	return nil
+}
+
+// computeMatchStats derives each player's line from the scorecard. Only
+// deliveries that name their players are counted. Byes and leg byes are not
+// charged to the batter or the bowler, and run outs are not credited to the
+// bowler.
+func computeMatchStats(match *models.Match, scorecard *models.ScorecardResponse, squad []*models.MatchSquadPlayer) []*models.PlayerMatchStats {
+	now := time.Now()
+	lines := map[string]*models.PlayerMatchStats{}
+	var order []string
+
+	line := func(playerID string, team models.TeamType) *models.PlayerMatchStats {
+		if stats, ok := lines[playerID]; ok {
+			return stats
+		}
+		teamID := match.TeamAID
+		if team == models.TeamTypeB {
+			teamID = match.TeamBID
+		}
+		stats := &models.PlayerMatchStats{
+			MatchID:   match.ID,
+			SeriesID:  match.SeriesID,
+			PlayerID:  playerID,
+			TeamID:    teamID,
+			CreatedAt: now,
+		}
+		lines[playerID] = stats
+		order = append(order, playerID)
+		return stats
+	}
+
+	// Everyone in a playing XI gets the match, even without touching the ball
+	for _, player := range squad {
+		if !player.IsSubstitute {
+			line(player.PlayerID, player.Team).PlayerName = player.PlayerName
+		}
+	}
+
+	for _, innings := range scorecard.Innings {
+		battingTeam := innings.BattingTeam
+		fieldingTeam := models.TeamTypeA
+		if battingTeam == models.TeamTypeA {
+			fieldingTeam = models.TeamTypeB
+		}
+
+		batted := map[string]bool{}
+		dismissed := map[string]bool{}
+
+		for _, over := range innings.Overs {
+			bowlers := map[string]bool{}
+			legalBalls, conceded := 0, 0
+
+			for _, ball := range over.Balls {
+				batterRuns, bowlerRuns := deliveryRuns(ball)
+				legal := ball.BallType == models.BallTypeGood
+				if legal {
+					legalBalls++
+				}
+				conceded += bowlerRuns
+
+				if ball.BatterID != "" {
+					batter := line(ball.BatterID, battingTeam)
+					batted[ball.BatterID] = true
+					if legal || ball.BallType == models.BallTypeNoBall {
+						batter.BallsFaced++
+					}
+					batter.Runs += batterRuns
+					if legal && ball.RunType == models.RunTypeFour {
+						batter.Fours++
+					}
+					if legal && ball.RunType == models.RunTypeSix {
+						batter.Sixes++
+					}
+				}
+
+				if ball.BowlerID != "" {
+					bowler := line(ball.BowlerID, fieldingTeam)
+					bowler.Bowled = true
+					bowlers[ball.BowlerID] = true
+					if legal {
+						bowler.BallsBowled++
+					}
+					bowler.RunsConceded += bowlerRuns
+					if ball.IsWicket && ball.WicketType != string(models.WicketTypeRunOut) {
+						bowler.Wickets++
+					}
+				}
+
+				if !ball.IsWicket {
+					continue
+				}
+				out := ball.DismissedPlayerID
+				if out == "" {
+					out = ball.BatterID
+				}
+				if out != "" {
+					line(out, battingTeam)
+					batted[out] = true
+					dismissed[out] = true
+				}
+				if ball.FielderID != "" {
+					fielder := line(ball.FielderID, fieldingTeam)
+					switch models.WicketType(ball.WicketType) {
+					case models.WicketTypeCaught:
+						fielder.Catches++
+					case models.WicketTypeStumped:
+						fielder.Stumpings++
+					case models.WicketTypeRunOut:
+						fielder.RunOuts++
+					}
+				}
+			}
+
+			// A maiden is a full over from one bowler with nothing charged to them
+			if len(bowlers) == 1 && legalBalls >= 6 && conceded == 0 {
+				for bowlerID := range bowlers {
+					lines[bowlerID].Maidens++
+				}
+			}
+		}
+
+		for playerID := range batted {
+			lines[playerID].Batted = true
+			lines[playerID].NotOut = !dismissed[playerID]
+		}
+	}
+
+	stats := make([]*models.PlayerMatchStats, len(order))
+	for i, playerID := range order {
+		stats[i] = lines[playerID]
+	}
+	return stats
+}
+
+// deliveryRuns splits a delivery's runs between the batter and the bowler.
+// Wides and no-balls are charged to the bowler only, including any runs
+// taken off them; byes off a legal delivery are charged to neither.
+func deliveryRuns(ball models.BallSummary) (batter int, bowler int) {
+	switch ball.BallType {
+	case models.BallTypeGood:
+		if ball.RunType == models.RunTypeLB {
+			return 0, 0
+		}
+		return ball.Runs, ball.Runs
+	case models.BallTypeWide, models.BallTypeNoBall:
+		return 0, ball.Runs + ball.Byes
+	}
+	return 0, 0
+}
+
+// aggregatePlayerStats rolls a player's match lines up into a career record
+// followed by one record per series
+func aggregatePlayerStats(playerID string, rows []*models.PlayerMatchStats) []*models.PlayerStats {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	career := &models.PlayerStats{PlayerID: playerID, UpdatedAt: now}
+	aggregates := []*models.PlayerStats{career}
+	bySeries := map[string]*models.PlayerStats{}
+
+	for _, row := range rows {
+		series, ok := bySeries[row.SeriesID]
+		if !ok {
+			series = &models.PlayerStats{PlayerID: playerID, SeriesID: row.SeriesID, UpdatedAt: now}
+			bySeries[row.SeriesID] = series
+			aggregates = append(aggregates, series)
+		}
+		addMatchStats(career, row)
+		addMatchStats(series, row)
+	}
+
+	// The most recent name wins so renames show up everywhere
+	name := rows[len(rows)-1].PlayerName
+	for _, stats := range aggregates {
+		stats.PlayerName = name
+	}
+	return aggregates
+}
+
+// addMatchStats adds one match line to an aggregate. Hauls are exclusive: a
+// five-wicket haul does not also count as a three-wicket haul.
+func addMatchStats(stats *models.PlayerStats, row *models.PlayerMatchStats) {
+	stats.Matches++
+
+	if row.Batted {
+		stats.Innings++
+		if row.NotOut {
+			stats.NotOuts++
+		} else if row.Runs == 0 {
+			stats.Ducks++
+		}
+		stats.Runs += row.Runs
+		stats.BallsFaced += row.BallsFaced
+		stats.Fours += row.Fours
+		stats.Sixes += row.Sixes
+		switch {
+		case row.Runs >= 100:
+			stats.Hundreds++
+		case row.Runs >= 50:
+			stats.Fifties++
+		}
+		if row.Runs > stats.HighScore || (row.Runs == stats.HighScore && row.NotOut) {
+			stats.HighScore = row.Runs
+			stats.HighScoreNotOut = row.NotOut
+		}
+	}
+
+	if row.Bowled {
+		stats.BowlingInnings++
+		stats.BallsBowled += row.BallsBowled
+		stats.RunsConceded += row.RunsConceded
+		stats.Wickets += row.Wickets
+		stats.Maidens += row.Maidens
+		switch {
+		case row.Wickets >= 5:
+			stats.FiveWicketHauls++
+		case row.Wickets >= 3:
+			stats.ThreeWicketHauls++
+		}
+		// Best figures: most wickets, then fewest runs
+		if stats.BowlingInnings == 1 || row.Wickets > stats.BestWickets ||
+			(row.Wickets == stats.BestWickets && row.RunsConceded < stats.BestRuns) {
+			stats.BestWickets = row.Wickets
+			stats.BestRuns = row.RunsConceded
+		}
+	}
+
+	stats.Catches += row.Catches
+	stats.Stumpings += row.Stumpings
+	stats.RunOuts += row.RunOuts
+}
+
+// fillRates derives averages, rates and overs from the stored counts
+func fillRates(stats *models.PlayerStats) {
+	if dismissals := stats.Innings - stats.NotOuts; dismissals > 0 {
+		stats.BattingAverage = roundTo2(float64(stats.Runs) / float64(dismissals))
+	}
+	if stats.BallsFaced > 0 {
+		stats.StrikeRate = roundTo2(float64(stats.Runs) * 100 / float64(stats.BallsFaced))
+	}
+
+	stats.Overs = float64(stats.BallsBowled/6) + float64(stats.BallsBowled%6)/10
+	if stats.BallsBowled > 0 {
+		stats.Economy = roundTo2(float64(stats.RunsConceded) * 6 / float64(stats.BallsBowled))
+	}
+	if stats.Wickets > 0 {
+		stats.BowlingAverage = roundTo2(float64(stats.RunsConceded) / float64(stats.Wickets))
+	}
+	if stats.BowlingInnings > 0 {
+		stats.BestFigures = fmt.Sprintf("%d/%d", stats.BestWickets, stats.BestRuns)
+	}
+}
+
+func roundTo2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// uniquePlayerIDs lists the distinct players of the given lines in first-seen order
+func uniquePlayerIDs(lines []*models.PlayerMatchStats) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, line := range lines {
+		if !seen[line.PlayerID] {
+			seen[line.PlayerID] = true
+			ids = append(ids, line.PlayerID)
+		}
+	}
+	return ids
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"spark-park-cricket-backend/internal/models"
 	"spark-park-cricket-backend/internal/repository/interfaces"
+	"strings"
 	"time"
 )
 
@@ -13,6 +14,8 @@ import (
 type TeamService struct {
 	teamRepo   interfaces.TeamRepository
 	playerRepo interfaces.PlayerRepository
+	audit      *AuditService
+	orgs       *OrganizationService
 }
 
 // NewTeamService creates a new team service
@@ -23,25 +26,46 @@ func NewTeamService(teamRepo interfaces.TeamRepository, playerRepo interfaces.Pl
 	}
 }
 
-// CreateTeam creates a new team
+// SetAuditService enables audit logging of team mutations
+func (s *TeamService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of teams by organization
+func (s *TeamService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// CreateTeam creates a new team with an empty roster
 func (s *TeamService) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
 	log.Printf("DEBUG: CreateTeam called with request: %+v", req)
 
+	// Get user ID from context
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
 	// Validate business rules
-	if req.PlayersCount < 1 || req.PlayersCount > 20 {
-		log.Printf("DEBUG: Validation failed - players count out of range: %d", req.PlayersCount)
-		return nil, fmt.Errorf("players count must be between 1 and 20")
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 3 {
+		return nil, fmt.Errorf("team name must be at least 3 characters")
 	}
 
-	// Create team model
-	team := &models.Team{
-		Name:         req.Name,
-		PlayersCount: req.PlayersCount,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+	// Only contributors can create teams in an organization
+	if err := s.orgs.CheckCanContribute(ctx, req.OrganizationID); err != nil {
+		return nil, err
 	}
 
-	log.Printf("DEBUG: Created team model: ID='%s', Name='%s', PlayersCount=%d", team.ID, team.Name, team.PlayersCount)
+	// Create team model; players_count follows the roster
+	team := &models.Team{
+		OrganizationID: req.OrganizationID,
+		Name:           name,
+		PlayersCount:   0,
+		CreatedBy:      userID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
 
 	// Save to repository
 	log.Printf("DEBUG: Calling teamRepo.Create with team: %+v", team)
@@ -52,6 +76,7 @@ func (s *TeamService) CreateTeam(ctx context.Context, req *models.CreateTeamRequ
 	}
 
 	log.Printf("DEBUG: Team created successfully: %+v", team)
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityTeam, team.ID, nil, team)
 	return team, nil
 }
 
@@ -66,6 +91,10 @@ func (s *TeamService) GetTeam(ctx context.Context, id string) (*models.Team, err
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
+	if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+		return nil, fmt.Errorf("failed to get team: team not found")
+	}
+
 	return team, nil
 }
 
@@ -79,6 +108,14 @@ func (s *TeamService) ListTeams(ctx context.Context, filters *models.TeamFilters
 		filters.Limit = 100
 	}
 
+	// Scope to the caller's organizations
+	filters.OnlyOrganizations = filters.OrganizationIDs != nil
+	organizationIDs, err := s.orgs.ScopeFilter(ctx, filters.OrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	filters.OrganizationIDs = organizationIDs
+
 	teams, err := s.teamRepo.GetAll(ctx, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list teams: %w", err)
@@ -98,17 +135,19 @@ func (s *TeamService) UpdateTeam(ctx context.Context, id string, req *models.Upd
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+
+	before := s.audit.Snapshot(team)
 
 	// Update fields if provided
 	if req.Name != nil {
-		team.Name = *req.Name
-	}
-	if req.PlayersCount != nil {
-		// Validate business rules
-		if *req.PlayersCount < 1 || *req.PlayersCount > 20 {
-			return nil, fmt.Errorf("players count must be between 1 and 20")
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 3 {
+			return nil, fmt.Errorf("team name must be at least 3 characters")
 		}
-		team.PlayersCount = *req.PlayersCount
+		team.Name = name
 	}
 
 	team.UpdatedAt = time.Now()
@@ -119,30 +158,110 @@ func (s *TeamService) UpdateTeam(ctx context.Context, id string, req *models.Upd
 		return nil, fmt.Errorf("failed to update team: %w", err)
 	}
 
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityTeam, id, before, team)
 	return team, nil
 }
 
-// DeleteTeam deletes a team
+// DeleteTeam moves a team and its players to the trash
 func (s *TeamService) DeleteTeam(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("team ID is required")
 	}
 
 	// Check if team exists
-	_, err := s.teamRepo.GetByID(ctx, id)
+	team, err := s.teamRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("team not found: %w", err)
 	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return err
+	}
 
-	// Delete team
-	err = s.teamRepo.Delete(ctx, id)
+	// Cascade to players first so a failure leaves the team visible. All rows
+	// share one timestamp so a restore brings back exactly this cascade.
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	players, err := s.playerRepo.GetByTeamID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get team players: %w", err)
+	}
+	for _, player := range players {
+		if err := s.playerRepo.SoftDelete(ctx, player.ID, deletedAt); err != nil {
+			return fmt.Errorf("failed to delete player %s: %w", player.ID, err)
+		}
+	}
+
+	// Soft delete team
+	err = s.teamRepo.SoftDelete(ctx, id, deletedAt)
 	if err != nil {
 		return fmt.Errorf("failed to delete team: %w", err)
 	}
 
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityTeam, id, team, nil)
 	return nil
 }
 
+// ListDeletedTeams retrieves the caller's teams that are in the trash
+func (s *TeamService) ListDeletedTeams(ctx context.Context, filters *models.TeamFilters) ([]*models.Team, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	filters.Deleted = true
+	filters.CreatedBy = &userID
+	teams, err := s.ListTeams(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// RestoreTeam brings a team back from the trash together with the players
+// that were deleted with it
+func (s *TeamService) RestoreTeam(ctx context.Context, id string) (*models.Team, error) {
+	if id == "" {
+		return nil, fmt.Errorf("team ID is required")
+	}
+
+	team, err := s.teamRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("team not found in trash: %w", err)
+	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+	before := s.audit.Snapshot(team)
+	deletedAt := *team.DeletedAt
+
+	if err := s.teamRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore team: %w", err)
+	}
+
+	// Restore only players removed by the team delete; players deleted on
+	// their own beforehand stay in the trash
+	players, err := s.playerRepo.GetDeletedByTeamID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted players: %w", err)
+	}
+	for _, player := range players {
+		if player.DeletedAt == nil || !player.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		if err := s.playerRepo.Restore(ctx, player.ID); err != nil {
+			return nil, fmt.Errorf("failed to restore player %s: %w", player.ID, err)
+		}
+	}
+
+	if team.PlayersCount, err = syncPlayersCount(ctx, s.teamRepo, s.playerRepo, id); err != nil {
+		return nil, err
+	}
+
+	team.DeletedAt = nil
+	s.audit.Record(ctx, models.AuditActionRestore, models.AuditEntityTeam, id, before, team)
+	return team, nil
+}
+
 // GetTeamPlayers retrieves all players for a specific team
 func (s *TeamService) GetTeamPlayers(ctx context.Context, teamID string) ([]*models.Player, error) {
 	if teamID == "" {
@@ -150,10 +269,13 @@ func (s *TeamService) GetTeamPlayers(ctx context.Context, teamID string) ([]*mod
 	}
 
 	// Check if team exists
-	_, err := s.teamRepo.GetByID(ctx, teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("team not found: %w", err)
 	}
+	if err := s.orgs.CheckVisible(ctx, team.OrganizationID); err != nil {
+		return nil, fmt.Errorf("team not found")
+	}
 
 	// Get players for the team
 	players, err := s.playerRepo.GetByTeamID(ctx, teamID)
@@ -166,29 +288,163 @@ func (s *TeamService) GetTeamPlayers(ctx context.Context, teamID string) ([]*mod
 
 // AddPlayerToTeam adds a player to a team
 func (s *TeamService) AddPlayerToTeam(ctx context.Context, teamID string, req *models.CreatePlayerRequest) (*models.Player, error) {
+	players, err := s.AddPlayersToTeam(ctx, teamID, &models.AddTeamPlayersRequest{
+		Players: []models.TeamPlayerRequest{{Name: req.Name, PlayerProfile: req.PlayerProfile}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return players[0], nil
+}
+
+// AddPlayersToTeam adds several players to a team's roster in one request.
+// Every player and the roster space are checked before the first write, and
+// if a write still fails the players already added are removed again.
+func (s *TeamService) AddPlayersToTeam(ctx context.Context, teamID string, req *models.AddTeamPlayersRequest) ([]*models.Player, error) {
 	if teamID == "" {
 		return nil, fmt.Errorf("team ID is required")
 	}
+	if len(req.Players) == 0 {
+		return nil, fmt.Errorf("at least one player is required")
+	}
+	jerseys := make(map[int]bool, len(req.Players))
+	for _, p := range req.Players {
+		if len(strings.TrimSpace(p.Name)) < 2 {
+			return nil, fmt.Errorf("player name must be at least 2 characters")
+		}
+		profile := normalizeProfile(p.PlayerProfile)
+		if err := validateProfile(profile); err != nil {
+			return nil, err
+		}
+		if profile.JerseyNumber != 0 {
+			if jerseys[profile.JerseyNumber] {
+				return nil, fmt.Errorf("jersey number %d is used more than once", profile.JerseyNumber)
+			}
+			jerseys[profile.JerseyNumber] = true
+		}
+	}
 
 	// Check if team exists
-	_, err := s.teamRepo.GetByID(ctx, teamID)
+	team, err := s.teamRepo.GetByID(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("team not found: %w", err)
 	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return nil, err
+	}
+	if err := ensureRosterSpace(ctx, s.playerRepo, teamID, len(req.Players)); err != nil {
+		return nil, err
+	}
+	for number := range jerseys {
+		if err := ensureJerseyAvailable(ctx, s.playerRepo, teamID, "", number); err != nil {
+			return nil, err
+		}
+	}
 
-	// Create player model
-	player := &models.Player{
-		Name:      req.Name,
-		TeamID:    teamID,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	players := make([]*models.Player, 0, len(req.Players))
+	for _, p := range req.Players {
+		// Create player model, inheriting the team's organization
+		player := &models.Player{
+			OrganizationID: team.OrganizationID,
+			Name:           strings.TrimSpace(p.Name),
+			TeamID:         teamID,
+			PlayerProfile:  normalizeProfile(p.PlayerProfile),
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+
+		// Save to repository
+		if err := s.playerRepo.Create(ctx, player); err != nil {
+			s.removeAddedPlayers(ctx, teamID, players)
+			return nil, fmt.Errorf("failed to create player: %w", err)
+		}
+		players = append(players, player)
 	}
 
-	// Save to repository
-	err = s.playerRepo.Create(ctx, player)
+	if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, teamID); err != nil {
+		return nil, err
+	}
+
+	for _, player := range players {
+		s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityPlayer, player.ID, nil, player)
+	}
+	return players, nil
+}
+
+// removeAddedPlayers undoes a bulk add that failed partway, keeping the
+// team's player count accurate for any player that could not be removed
+func (s *TeamService) removeAddedPlayers(ctx context.Context, teamID string, players []*models.Player) {
+	for _, player := range players {
+		if err := s.playerRepo.Delete(ctx, player.ID); err != nil {
+			log.Printf("Error removing player %s after a failed bulk add: %v", player.ID, err)
+		}
+	}
+	_, _ = syncPlayersCount(ctx, s.teamRepo, s.playerRepo, teamID)
+}
+
+// RemovePlayerFromTeam takes a player off a team's roster, moving them to the trash
+func (s *TeamService) RemovePlayerFromTeam(ctx context.Context, teamID, playerID string) error {
+	if teamID == "" || playerID == "" {
+		return fmt.Errorf("team ID and player ID are required")
+	}
+
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("team not found: %w", err)
+	}
+	if err := checkTeamOwner(ctx, s.orgs, team); err != nil {
+		return err
+	}
+
+	player, err := s.playerRepo.GetByID(ctx, playerID)
+	if err != nil || player.TeamID != teamID {
+		return fmt.Errorf("player not found in team")
+	}
+
+	if err := s.playerRepo.SoftDelete(ctx, playerID, time.Now().UTC().Truncate(time.Microsecond)); err != nil {
+		return fmt.Errorf("failed to remove player: %w", err)
+	}
+	if _, err := syncPlayersCount(ctx, s.teamRepo, s.playerRepo, teamID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityPlayer, playerID, player, nil)
+	return nil
+}
+
+// checkTeamOwner ensures the caller created the team and may still contribute
+// to its organization. Player writes go through the same check on their team.
+func checkTeamOwner(ctx context.Context, orgs *OrganizationService, team *models.Team) error {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return fmt.Errorf("user authentication required")
+	}
+	if team.CreatedBy != userID {
+		return fmt.Errorf("access denied: you can only manage teams you created")
+	}
+	return orgs.CheckCanContribute(ctx, team.OrganizationID)
+}
+
+// ensureRosterSpace checks that adding players keeps the team within the roster limit
+func ensureRosterSpace(ctx context.Context, playerRepo interfaces.PlayerRepository, teamID string, adding int) error {
+	players, err := playerRepo.GetByTeamID(ctx, teamID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create player: %w", err)
+		return fmt.Errorf("failed to get team players: %w", err)
 	}
+	if len(players)+adding > models.MaxTeamPlayers {
+		return fmt.Errorf("team roster is limited to %d players (has %d, adding %d)", models.MaxTeamPlayers, len(players), adding)
+	}
+	return nil
+}
 
-	return player, nil
+// syncPlayersCount recomputes a team's players_count from its live roster
+func syncPlayersCount(ctx context.Context, teamRepo interfaces.TeamRepository, playerRepo interfaces.PlayerRepository, teamID string) (int, error) {
+	players, err := playerRepo.GetByTeamID(ctx, teamID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count team players: %w", err)
+	}
+	if err := teamRepo.UpdatePlayersCount(ctx, teamID, len(players)); err != nil {
+		return 0, fmt.Errorf("failed to update team players count: %w", err)
+	}
+	return len(players), nil
 }
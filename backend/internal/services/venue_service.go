@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/interfaces"
+	"strings"
+	"time"
+)
+
+// VenueService handles business logic for venues and their records
+type VenueService struct {
+	venueRepo     interfaces.VenueRepository
+	matchRepo     interfaces.MatchRepository
+	scorecardRepo interfaces.ScorecardRepository
+	audit         *AuditService
+	orgs          *OrganizationService
+}
+
+// NewVenueService creates a new venue service. Venue records are read from
+// the innings of the matches played there.
+func NewVenueService(venueRepo interfaces.VenueRepository, matchRepo interfaces.MatchRepository, scorecardRepo interfaces.ScorecardRepository) *VenueService {
+	return &VenueService{
+		venueRepo:     venueRepo,
+		matchRepo:     matchRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// SetAuditService enables audit logging of venue mutations
+func (s *VenueService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// SetOrganizationService enables tenant scoping of venues by organization
+func (s *VenueService) SetOrganizationService(orgs *OrganizationService) {
+	s.orgs = orgs
+}
+
+// CreateVenue creates a new venue
+func (s *VenueService) CreateVenue(ctx context.Context, req *models.CreateVenueRequest) (*models.Venue, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, fmt.Errorf("user authentication required")
+	}
+
+	venue := &models.Venue{
+		OrganizationID: req.OrganizationID,
+		Name:           strings.TrimSpace(req.Name),
+		Address:        strings.TrimSpace(req.Address),
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		BoundaryNotes:  strings.TrimSpace(req.BoundaryNotes),
+		Pitches:        req.Pitches,
+		CreatedBy:      userID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if venue.Pitches == 0 {
+		venue.Pitches = 1
+	}
+	if err := validateVenue(venue); err != nil {
+		return nil, err
+	}
+
+	// Only contributors can create venues in an organization
+	if err := s.orgs.CheckCanContribute(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := s.venueRepo.Create(ctx, venue); err != nil {
+		return nil, fmt.Errorf("failed to create venue: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionCreate, models.AuditEntityVenue, venue.ID, nil, venue)
+	return venue, nil
+}
+
+// GetVenue retrieves a venue by ID
+func (s *VenueService) GetVenue(ctx context.Context, id string) (*models.Venue, error) {
+	if id == "" {
+		return nil, fmt.Errorf("venue ID is required")
+	}
+
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if err := s.orgs.CheckVisible(ctx, venue.OrganizationID); err != nil {
+		return nil, fmt.Errorf("failed to get venue: venue not found")
+	}
+
+	return venue, nil
+}
+
+// ListVenues retrieves venues by name
+func (s *VenueService) ListVenues(ctx context.Context, filters *models.VenueFilters) ([]*models.Venue, error) {
+	// Set default values
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+
+	// Scope to the caller's organizations
+	filters.OnlyOrganizations = filters.OrganizationIDs != nil
+	organizationIDs, err := s.orgs.ScopeFilter(ctx, filters.OrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	filters.OrganizationIDs = organizationIDs
+
+	venues, err := s.venueRepo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+// UpdateVenue updates an existing venue. A venue cannot lose a pitch that
+// upcoming matches are booked on.
+func (s *VenueService) UpdateVenue(ctx context.Context, id string, req *models.UpdateVenueRequest) (*models.Venue, error) {
+	if id == "" {
+		return nil, fmt.Errorf("venue ID is required")
+	}
+
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	if err := checkVenueOwner(ctx, s.orgs, venue); err != nil {
+		return nil, err
+	}
+
+	before := s.audit.Snapshot(venue)
+	pitches := venue.Pitches
+
+	// Update fields if provided
+	if req.Name != nil {
+		venue.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Address != nil {
+		venue.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.Latitude != nil {
+		venue.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		venue.Longitude = req.Longitude
+	}
+	if req.BoundaryNotes != nil {
+		venue.BoundaryNotes = strings.TrimSpace(*req.BoundaryNotes)
+	}
+	if req.Pitches != nil {
+		venue.Pitches = *req.Pitches
+	}
+	if err := validateVenue(venue); err != nil {
+		return nil, err
+	}
+
+	if venue.Pitches < pitches {
+		matches, err := s.matchRepo.GetByVenueID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get venue matches: %w", err)
+		}
+		for _, match := range matches {
+			if match.Status == models.MatchStatusLive && match.PitchNumber > venue.Pitches {
+				return nil, fmt.Errorf("match %d is booked on pitch %d; move it before removing the pitch", match.MatchNumber, match.PitchNumber)
+			}
+		}
+	}
+
+	venue.UpdatedAt = time.Now()
+
+	if err := s.venueRepo.Update(ctx, id, venue); err != nil {
+		return nil, fmt.Errorf("failed to update venue: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionUpdate, models.AuditEntityVenue, id, before, venue)
+	return venue, nil
+}
+
+// DeleteVenue removes a venue that has no live matches booked. Its past
+// matches keep the venue's name.
+func (s *VenueService) DeleteVenue(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("venue ID is required")
+	}
+
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("venue not found: %w", err)
+	}
+	if err := checkVenueOwner(ctx, s.orgs, venue); err != nil {
+		return err
+	}
+
+	matches, err := s.matchRepo.GetByVenueID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get venue matches: %w", err)
+	}
+	for _, match := range matches {
+		if match.Status == models.MatchStatusLive {
+			return fmt.Errorf("cannot delete a venue with live matches booked")
+		}
+	}
+
+	if err := s.venueRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+
+	s.audit.Record(ctx, models.AuditActionDelete, models.AuditEntityVenue, id, venue, nil)
+	return nil
+}
+
+// GetVenueMatches retrieves the matches booked at a venue in date order,
+// leaving out those of organizations the caller cannot see
+func (s *VenueService) GetVenueMatches(ctx context.Context, id string) ([]*models.Match, error) {
+	if _, err := s.GetVenue(ctx, id); err != nil {
+		return nil, err
+	}
+
+	matches, err := s.visibleVenueMatches(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Date.Before(matches[j].Date)
+	})
+	return matches, nil
+}
+
+// GetVenueRecords computes a venue's records from the completed matches the
+// caller can see. Matches completed without both innings are left out.
+func (s *VenueService) GetVenueRecords(ctx context.Context, id string) (*models.VenueRecords, error) {
+	venue, err := s.GetVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.visibleVenueMatches(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	records := &models.VenueRecords{
+		VenueID:   venue.ID,
+		VenueName: venue.Name,
+		UpdatedAt: time.Now(),
+	}
+	firstInningsRuns := 0
+	for _, match := range matches {
+		if match.Status != models.MatchStatusCompleted {
+			continue
+		}
+		innings, err := s.scorecardRepo.GetInningsByMatchID(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get innings for match %s: %w", match.ID, err)
+		}
+
+		var first, second *models.Innings
+		for _, inn := range innings {
+			switch inn.InningsNumber {
+			case 1:
+				first = inn
+			case 2:
+				second = inn
+			}
+		}
+		if first == nil || second == nil {
+			continue
+		}
+
+		records.MatchesPlayed++
+		firstInningsRuns += first.TotalRuns
+		for _, inn := range []*models.Innings{first, second} {
+			if records.HighestTotal == nil || inn.TotalRuns > records.HighestTotal.Runs {
+				records.HighestTotal = &models.VenueTotal{MatchID: match.ID, Runs: inn.TotalRuns, Wickets: inn.TotalWickets}
+			}
+		}
+
+		switch {
+		case second.TotalRuns > first.TotalRuns:
+			records.WinsChasing++
+			if records.HighestChase == nil || second.TotalRuns > records.HighestChase.Runs {
+				records.HighestChase = &models.VenueChase{
+					MatchID: match.ID,
+					Target:  first.TotalRuns + 1,
+					Runs:    second.TotalRuns,
+					Wickets: second.TotalWickets,
+				}
+			}
+		case first.TotalRuns > second.TotalRuns:
+			records.WinsBattingFirst++
+		default:
+			records.Ties++
+		}
+	}
+
+	if records.MatchesPlayed > 0 {
+		average := float64(firstInningsRuns) / float64(records.MatchesPlayed)
+		records.AverageFirstInnings = math.Round(average*10) / 10
+	}
+	return records, nil
+}
+
+// visibleVenueMatches returns the matches booked at a venue, leaving out
+// those of organizations the caller cannot see. A venue without an
+// organization can be booked by private organizations' series too.
+func (s *VenueService) visibleVenueMatches(ctx context.Context, id string) ([]*models.Match, error) {
+	matches, err := s.matchRepo.GetByVenueID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue matches: %w", err)
+	}
+
+	visibleIDs, err := s.orgs.VisibleOrganizationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if visibleIDs == nil {
+		return matches, nil
+	}
+	visible := make(map[string]bool, len(visibleIDs))
+	for _, organizationID := range visibleIDs {
+		visible[organizationID] = true
+	}
+
+	filtered := make([]*models.Match, 0, len(matches))
+	for _, match := range matches {
+		if match.OrganizationID == "" || visible[match.OrganizationID] {
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered, nil
+}
+
+// validateVenue checks a venue's name, pitches and coordinates
+func validateVenue(venue *models.Venue) error {
+	if len(venue.Name) < 2 {
+		return fmt.Errorf("venue name must be at least 2 characters")
+	}
+	if venue.Pitches < 1 || venue.Pitches > models.MaxVenuePitches {
+		return fmt.Errorf("pitches must be between 1 and %d", models.MaxVenuePitches)
+	}
+	if (venue.Latitude == nil) != (venue.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be provided together")
+	}
+	if venue.Latitude != nil && (*venue.Latitude < -90 || *venue.Latitude > 90) {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if venue.Longitude != nil && (*venue.Longitude < -180 || *venue.Longitude > 180) {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// checkVenueOwner ensures the caller created the venue and may still
+// contribute to its organization
+func checkVenueOwner(ctx context.Context, orgs *OrganizationService, venue *models.Venue) error {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return fmt.Errorf("user authentication required")
+	}
+	if venue.CreatedBy != userID {
+		return fmt.Errorf("access denied: you can only manage venues you created")
+	}
+	return orgs.CheckCanContribute(ctx, venue.OrganizationID)
+}
@@ -123,12 +123,8 @@ func (v *CricketValidator) ValidateMatchStatus(currentStatus, newStatus models.M
 
 // ValidateTeamComposition validates team composition
 func (v *CricketValidator) ValidateTeamComposition(team *models.Team, players []*models.Player) error {
-	if team.PlayersCount < 1 || team.PlayersCount > 20 {
-		return fmt.Errorf("team must have 1-20 players")
-	}
-
-	if len(players) > team.PlayersCount {
-		return fmt.Errorf("team has more players than allowed")
+	if len(players) < 1 || len(players) > models.MaxTeamPlayers {
+		return fmt.Errorf("team must have 1-%d players", models.MaxTeamPlayers)
 	}
 
 	// Check for duplicate player names in the same team
@@ -238,6 +234,21 @@ func ValidateBallEventRequest(req *models.BallEventRequest) error {
 		}
 	}
 
+	// Validate players involved in the dismissal
+	if !req.IsWicket && (req.FielderID != "" || req.DismissedPlayerID != "") {
+		return fmt.Errorf("fielder and dismissed player can only be set on a wicket")
+	}
+	if req.FielderID != "" {
+		switch models.WicketType(req.WicketType) {
+		case models.WicketTypeCaught, models.WicketTypeStumped, models.WicketTypeRunOut:
+		default:
+			return fmt.Errorf("fielder can only be set for caught, stumped or run out dismissals")
+		}
+	}
+	if req.BatterID != "" && req.BatterID == req.BowlerID {
+		return fmt.Errorf("batter and bowler must be different players")
+	}
+
 	return nil
 }
 
@@ -10,9 +10,14 @@ import (
 
 // EventBroadcaster handles broadcasting events to WebSocket clients
 type EventBroadcaster struct {
-	hub *websocket.Hub
+	hub      *websocket.Hub
+	observer RoomObserver
 }
 
+// RoomObserver is told of every message the broadcaster sends to a room,
+// e.g. to forward it to webhooks
+type RoomObserver func(roomID, messageType string, data interface{})
+
 // NewEventBroadcaster creates a new event broadcaster
 func NewEventBroadcaster(hub *websocket.Hub) *EventBroadcaster {
 	return &EventBroadcaster{
@@ -20,6 +25,19 @@ func NewEventBroadcaster(hub *websocket.Hub) *EventBroadcaster {
 	}
 }
 
+// SetObserver passes every broadcast to an observer as well as the room
+func (eb *EventBroadcaster) SetObserver(observer RoomObserver) {
+	eb.observer = observer
+}
+
+// broadcast sends a message to a room and its observer
+func (eb *EventBroadcaster) broadcast(roomID string, message websocket.Message) {
+	eb.hub.BroadcastToRoom(roomID, message)
+	if eb.observer != nil {
+		eb.observer(roomID, message.Type, message.Data)
+	}
+}
+
 // BroadcastBallEvent broadcasts a ball event to all clients watching the match
 func (eb *EventBroadcaster) BroadcastBallEvent(ctx context.Context, matchID string, ballEvent *models.BallEvent, scoreboard *models.LiveScoreboard) {
 	message := websocket.Message{
@@ -44,7 +62,7 @@ func (eb *EventBroadcaster) BroadcastBallEvent(ctx context.Context, matchID stri
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted ball event for match %s: %s, %s", matchID, ballEvent.BallType, ballEvent.RunType)
 }
 
@@ -66,7 +84,7 @@ func (eb *EventBroadcaster) BroadcastScoreUpdate(ctx context.Context, matchID st
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted score update for match %s: %d/%d in %.1f overs", matchID, scoreboard.Score, scoreboard.Wickets, scoreboard.Overs)
 }
 
@@ -92,7 +110,7 @@ func (eb *EventBroadcaster) BroadcastWicketUpdate(ctx context.Context, matchID s
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted wicket update for match %s: %d wickets", matchID, scoreboard.Wickets)
 }
 
@@ -121,7 +139,7 @@ func (eb *EventBroadcaster) BroadcastOverCompletion(ctx context.Context, matchID
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted over completion for match %s: Over %d completed with %d runs", matchID, over.OverNumber, over.TotalRuns)
 }
 
@@ -143,7 +161,7 @@ func (eb *EventBroadcaster) BroadcastMatchStatusUpdate(ctx context.Context, matc
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted match status update for match %s: Status changed to %s", matchID, match.Status)
 }
 
@@ -165,7 +183,7 @@ func (eb *EventBroadcaster) BroadcastMatchStart(ctx context.Context, matchID str
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted match start for match %s", matchID)
 }
 
@@ -195,7 +213,7 @@ func (eb *EventBroadcaster) BroadcastMatchEnd(ctx context.Context, matchID strin
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted match end for match %s: Final score %d/%d", matchID, finalScoreboard.Score, finalScoreboard.Wickets)
 }
 
@@ -210,6 +228,54 @@ func (eb *EventBroadcaster) BroadcastCustomMessage(ctx context.Context, matchID
 		},
 	}
 
-	eb.hub.BroadcastToRoom(matchID, message)
+	eb.broadcast(matchID, message)
 	log.Printf("Broadcasted custom message %s for match %s", messageType, matchID)
 }
+
+// BroadcastStandingsUpdate broadcasts a series points table to all clients watching the series
+func (eb *EventBroadcaster) BroadcastStandingsUpdate(ctx context.Context, seriesID string, standings *models.SeriesStandings) {
+	roomID := websocket.SeriesRoomID(seriesID)
+	message := websocket.Message{
+		Type:   "standings_update",
+		RoomID: roomID,
+		Data: map[string]interface{}{
+			"standings": standings,
+			"timestamp": time.Now().Unix(),
+		},
+	}
+
+	eb.broadcast(roomID, message)
+	log.Printf("Broadcasted standings update for series %s", seriesID)
+}
+
+// BroadcastBracketUpdate broadcasts a series' stages and bracket to all clients watching the series
+func (eb *EventBroadcaster) BroadcastBracketUpdate(ctx context.Context, seriesID string, bracket *models.SeriesBracket) {
+	roomID := websocket.SeriesRoomID(seriesID)
+	message := websocket.Message{
+		Type:   "bracket_update",
+		RoomID: roomID,
+		Data: map[string]interface{}{
+			"bracket":   bracket,
+			"timestamp": time.Now().Unix(),
+		},
+	}
+
+	eb.broadcast(roomID, message)
+	log.Printf("Broadcasted bracket update for series %s", seriesID)
+}
+
+// BroadcastLeaderboardUpdate broadcasts a series' leaderboards to all clients watching the series
+func (eb *EventBroadcaster) BroadcastLeaderboardUpdate(ctx context.Context, seriesID string, leaderboards *models.SeriesLeaderboards) {
+	roomID := websocket.SeriesRoomID(seriesID)
+	message := websocket.Message{
+		Type:   "leaderboard_update",
+		RoomID: roomID,
+		Data: map[string]interface{}{
+			"leaderboards": leaderboards,
+			"timestamp":    time.Now().Unix(),
+		},
+	}
+
+	eb.broadcast(roomID, message)
+	log.Printf("Broadcasted leaderboard update for series %s", seriesID)
+}
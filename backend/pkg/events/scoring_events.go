@@ -0,0 +1,93 @@
+package events
+
+import (
+	"log"
+	"spark-park-cricket-backend/internal/models"
+	"sync"
+)
+
+// scoringSubscriberBuffer is how many events a subscriber may fall behind
+// before further events are dropped for it
+const scoringSubscriberBuffer = 64
+
+// ScoringEvents fans the scoring service's events out to in-process
+// subscribers, such as GraphQL subscriptions
+type ScoringEvents struct {
+	mutex       sync.RWMutex
+	subscribers map[*scoringSubscriber]bool
+}
+
+type scoringSubscriber struct {
+	matchID string
+	types   map[models.ScoringEventType]bool
+	events  chan *models.ScoringEvent
+}
+
+// NewScoringEvents creates an empty scoring event bus
+func NewScoringEvents() *ScoringEvents {
+	return &ScoringEvents{
+		subscribers: make(map[*scoringSubscriber]bool),
+	}
+}
+
+// Subscribe returns a channel of a match's events of the given types, all
+// types when none are given, and a function that ends the subscription and
+// closes the channel
+func (e *ScoringEvents) Subscribe(matchID string, types ...models.ScoringEventType) (<-chan *models.ScoringEvent, func()) {
+	subscriber := &scoringSubscriber{
+		matchID: matchID,
+		types:   make(map[models.ScoringEventType]bool, len(types)),
+		events:  make(chan *models.ScoringEvent, scoringSubscriberBuffer),
+	}
+	for _, eventType := range types {
+		subscriber.types[eventType] = true
+	}
+
+	e.mutex.Lock()
+	e.subscribers[subscriber] = true
+	e.mutex.Unlock()
+
+	var once sync.Once
+	return subscriber.events, func() {
+		once.Do(func() {
+			e.mutex.Lock()
+			defer e.mutex.Unlock()
+
+			delete(e.subscribers, subscriber)
+			close(subscriber.events)
+		})
+	}
+}
+
+// Publish sends an event to every subscriber of its match. Subscribers that
+// have fallen too far behind miss the event rather than hold up scoring.
+func (e *ScoringEvents) Publish(event *models.ScoringEvent) {
+	if e == nil {
+		return
+	}
+
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	for subscriber := range e.subscribers {
+		if subscriber.matchID != event.MatchID {
+			continue
+		}
+		if len(subscriber.types) > 0 && !subscriber.types[event.Type] {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			log.Printf("Dropped %s event for match %s: subscriber is too slow", event.Type, event.MatchID)
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions
+func (e *ScoringEvents) Subscribers() int {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return len(e.subscribers)
+}
@@ -0,0 +1,102 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AccessFunc decides whether the user a context carries may join a room,
+// returning nil, ErrUnauthorized, ErrForbidden or ErrRoomNotFound
+type AccessFunc func(ctx context.Context, roomID string) error
+
+// Errors an AccessFunc returns to refuse a room
+var (
+	ErrUnauthorized = errors.New("sign in to follow this room")
+	ErrForbidden    = errors.New("not allowed to follow this room")
+	ErrRoomNotFound = errors.New("room not found")
+)
+
+// SetAccessPolicy makes the hub check every room a client joins: from the
+// URL, by subscribing, or as a stream. Without one every room is open.
+// Call before Run.
+func (h *Hub) SetAccessPolicy(access AccessFunc) {
+	h.access = access
+}
+
+// SetAllowedOrigins restricts which browser origins may open connections.
+// Without any, only pages served from the same host may.
+func (h *Hub) SetAllowedOrigins(origins []string) {
+	allowed := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed = append(allowed, origin)
+		}
+	}
+	h.allowedOrigins = allowed
+}
+
+// CheckOrigin reports whether a WebSocket upgrade comes from an allowed
+// origin. Requests without an Origin header are not from browsers and are
+// allowed. A nil hub allows the same host only.
+func (h *Hub) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if h == nil || len(h.allowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, allowed := range h.allowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAccess returns nil when the user in ctx may join a room; a nil hub
+// or one without an access policy lets everyone in
+func (h *Hub) CheckAccess(ctx context.Context, roomID string) error {
+	if h == nil || h.access == nil {
+		return nil
+	}
+	return h.access(ctx, roomID)
+}
+
+// authorize checks that a client may join a room, as the user it connected as
+func (h *Hub) authorize(client *Client, roomID string) error {
+	ctx, cancel := context.WithTimeout(client.identity, 5*time.Second)
+	defer cancel()
+
+	return h.CheckAccess(ctx, roomID)
+}
+
+// accessStatus returns the HTTP status refusing a connection for an access error
+func accessStatus(err error) int {
+	switch err {
+	case ErrUnauthorized:
+		return http.StatusUnauthorized
+	case ErrRoomNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusForbidden
+	}
+}
+
+// accessErrorCode returns the error frame code refusing a subscription for
+// an access error
+func accessErrorCode(err error) string {
+	switch err {
+	case ErrUnauthorized:
+		return ErrorUnauthorized
+	case ErrRoomNotFound:
+		return ErrorInvalidRoom
+	default:
+		return ErrorForbidden
+	}
+}
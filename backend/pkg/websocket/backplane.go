@@ -0,0 +1,199 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultStatsInterval is how often an instance reports its connection
+// counts to the backplane; a report expires after three intervals
+const DefaultStatsInterval = 10 * time.Second
+
+// Envelope carries a broadcast between instances. RoomID is empty for
+// messages to every client; Seq is the room's sequence number, 0 if unnumbered.
+type Envelope struct {
+	InstanceID string          `json:"instance_id"`
+	RoomID     string          `json:"room_id,omitempty"`
+	Seq        int64           `json:"seq,omitempty"`
+	Message    json.RawMessage `json:"message"`
+}
+
+// InstanceStats are the connection counts and health metrics of one
+// server instance
+type InstanceStats struct {
+	InstanceID string         `json:"instance_id"`
+	Clients    int            `json:"clients"`
+	Rooms      map[string]int `json:"rooms"` // Clients per room
+	Metrics    Metrics        `json:"metrics"`
+}
+
+// ClusterStats sums the connection counts and health metrics of every live
+// instance
+type ClusterStats struct {
+	Instances    int            `json:"instances"`
+	TotalClients int            `json:"total_clients"`
+	Rooms        map[string]int `json:"rooms"` // Clients per room
+	Metrics      Metrics        `json:"metrics"`
+}
+
+// Backplane links the hubs of several server instances so that a broadcast
+// on one reaches the clients of all
+type Backplane interface {
+	// InstanceID identifies this instance on the backplane
+	InstanceID() string
+
+	// Publish sends a broadcast to every other instance
+	Publish(envelope Envelope) error
+
+	// Listen passes broadcasts published by other instances to deliver
+	// until the backplane is closed
+	Listen(deliver func(envelope Envelope)) error
+
+	// ReportStats records this instance's connection counts
+	ReportStats(stats InstanceStats) error
+
+	// Stats returns the latest counts of every live instance
+	Stats() ([]InstanceStats, error)
+}
+
+// RedisBackplane links instances over Redis pub/sub; connection counts are
+// kept in per-instance keys that expire when an instance stops reporting
+type RedisBackplane struct {
+	client     *redis.Client
+	instanceID string
+	statsTTL   time.Duration
+}
+
+const (
+	backplaneChannel      = "ws:broadcast"
+	backplaneInstancesKey = "ws:instances"
+)
+
+// NewRedisBackplane creates a Redis backplane for this instance
+func NewRedisBackplane(client *redis.Client) *RedisBackplane {
+	return &RedisBackplane{
+		client:     client,
+		instanceID: uuid.New().String(),
+		statsTTL:   3 * DefaultStatsInterval,
+	}
+}
+
+func backplaneStatsKey(instanceID string) string {
+	return "ws:instance:" + instanceID
+}
+
+// InstanceID identifies this instance
+func (b *RedisBackplane) InstanceID() string {
+	return b.instanceID
+}
+
+// Publish sends a broadcast to the other instances
+func (b *RedisBackplane) Publish(envelope Envelope) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	envelope.InstanceID = b.instanceID
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to marshal broadcast: %w", err)
+	}
+	if err := b.client.Publish(ctx, backplaneChannel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish broadcast: %w", err)
+	}
+	return nil
+}
+
+// Listen delivers broadcasts from the other instances. The subscription
+// reconnects on its own if the connection to Redis drops.
+func (b *RedisBackplane) Listen(deliver func(envelope Envelope)) error {
+	ctx := context.Background()
+	pubsub := b.client.Subscribe(ctx, backplaneChannel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to broadcasts: %w", err)
+	}
+
+	for message := range pubsub.Channel() {
+		var envelope Envelope
+		if err := json.Unmarshal([]byte(message.Payload), &envelope); err != nil {
+			log.Printf("Error decoding broadcast from backplane: %v", err)
+			continue
+		}
+		if envelope.InstanceID == b.instanceID {
+			continue
+		}
+		deliver(envelope)
+	}
+	return nil
+}
+
+// ReportStats records this instance's connection counts
+func (b *RedisBackplane) ReportStats(stats InstanceStats) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	stats.InstanceID = b.instanceID
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+
+	pipe := b.client.TxPipeline()
+	pipe.Set(ctx, backplaneStatsKey(b.instanceID), data, b.statsTTL)
+	pipe.SAdd(ctx, backplaneInstancesKey, b.instanceID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to report stats: %w", err)
+	}
+	return nil
+}
+
+// Stats returns the counts of every instance that reported recently,
+// forgetting instances whose report has expired
+func (b *RedisBackplane) Stats() ([]InstanceStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	instanceIDs, err := b.client.SMembers(ctx, backplaneInstancesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		keys[i] = backplaneStatsKey(instanceID)
+	}
+	values, err := b.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance stats: %w", err)
+	}
+
+	stats := make([]InstanceStats, 0, len(values))
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, instanceIDs[i])
+			continue
+		}
+		var instance InstanceStats
+		if err := json.Unmarshal([]byte(data), &instance); err != nil {
+			log.Printf("Error decoding stats of instance %s: %v", instanceIDs[i], err)
+			continue
+		}
+		stats = append(stats, instance)
+	}
+	if len(expired) > 0 {
+		b.client.SRem(ctx, backplaneInstancesKey, expired...)
+	}
+	return stats, nil
+}
@@ -0,0 +1,182 @@
+package websocket
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// writeWait is how long a write to a connection may take
+const writeWait = 10 * time.Second
+
+// Close codes sent when the hub ends a connection
+const (
+	CloseSlowConsumer = 4008 // The client fell too far behind reading its messages
+)
+
+// Keepalive sets how the hub detects dead connections: it pings each
+// connection every PingInterval and drops one it has heard nothing from,
+// pongs included, for PongTimeout
+type Keepalive struct {
+	PingInterval time.Duration
+	PongTimeout  time.Duration
+}
+
+// DefaultKeepalive returns the keepalive used when the hub is not configured
+func DefaultKeepalive() Keepalive {
+	return Keepalive{
+		PingInterval: 30 * time.Second,
+		PongTimeout:  60 * time.Second,
+	}
+}
+
+// withDefaults fills unset timings from DefaultKeepalive, keeping the pong
+// timeout longer than the ping interval
+func (k Keepalive) withDefaults() Keepalive {
+	defaults := DefaultKeepalive()
+	if k.PingInterval <= 0 {
+		k.PingInterval = defaults.PingInterval
+	}
+	if k.PongTimeout <= 0 {
+		k.PongTimeout = defaults.PongTimeout
+	}
+	if k.PongTimeout <= k.PingInterval {
+		k.PongTimeout = 2 * k.PingInterval
+	}
+	return k
+}
+
+// Capacity caps how many connections an instance accepts; 0 is unlimited.
+// WebSocket connections and streams both count.
+type Capacity struct {
+	MaxConnectionsPerIP   int // Connections from one client address
+	MaxConnectionsPerRoom int // Clients in one room, counted per instance
+}
+
+// Errors returned when a connection or subscription is refused for capacity
+var (
+	ErrTooManyConnections = errors.New("too many connections from this address")
+	ErrRoomFull           = errors.New("room is full")
+)
+
+// Metrics are an instance's connection health counters. QueuedBytes is
+// current; the rest count since the instance started.
+type Metrics struct {
+	QueuedBytes         int64 `json:"queued_bytes"`          // Bytes waiting in send buffers
+	MessagesDropped     int64 `json:"messages_dropped"`      // Messages not queued because a send buffer was full
+	SlowClientEvictions int64 `json:"slow_client_evictions"` // Clients disconnected for falling behind
+	PongTimeouts        int64 `json:"pong_timeouts"`         // Connections dropped for not answering pings
+	Rejections          int64 `json:"rejections"`            // Connections and subscriptions refused by a capacity cap
+}
+
+// add sums another instance's metrics into these
+func (m *Metrics) add(other Metrics) {
+	m.QueuedBytes += other.QueuedBytes
+	m.MessagesDropped += other.MessagesDropped
+	m.SlowClientEvictions += other.SlowClientEvictions
+	m.PongTimeouts += other.PongTimeouts
+	m.Rejections += other.Rejections
+}
+
+// hubCounters are the hub's running metrics
+type hubCounters struct {
+	messagesDropped     atomic.Int64
+	slowClientEvictions atomic.Int64
+	pongTimeouts        atomic.Int64
+	rejections          atomic.Int64
+}
+
+// SetKeepalive replaces the ping interval and pong timeout; unset fields
+// keep their defaults. Call before Run.
+func (h *Hub) SetKeepalive(keepalive Keepalive) {
+	h.keepalive = keepalive.withDefaults()
+}
+
+// SetCapacity caps the connections per client address and per room. Call
+// before Run.
+func (h *Hub) SetCapacity(capacity Capacity) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.capacity = capacity
+}
+
+// admitLocked registers a client unless its address, or the room it joins
+// from the URL, is at capacity; the caller holds the write lock
+func (h *Hub) admitLocked(client *Client) error {
+	if limit := h.capacity.MaxConnectionsPerIP; limit > 0 && h.addresses[client.ip] >= limit {
+		h.counters.rejections.Add(1)
+		return ErrTooManyConnections
+	}
+	if client.roomID != "" && h.roomFullLocked(client.roomID) {
+		h.counters.rejections.Add(1)
+		return ErrRoomFull
+	}
+
+	h.clients[client] = true
+	h.addresses[client.ip]++
+	return nil
+}
+
+// roomFullLocked reports whether a room has no place for another client;
+// the caller holds the lock
+func (h *Hub) roomFullLocked(roomID string) bool {
+	limit := h.capacity.MaxConnectionsPerRoom
+	return limit > 0 && len(h.rooms[roomID]) >= limit
+}
+
+// evictLocked disconnects a client that fell too far behind, telling it why
+// in the close frame; the caller holds the write lock
+func (h *Hub) evictLocked(client *Client) {
+	if !h.clients[client] {
+		return
+	}
+	client.closeReason = "slow consumer"
+	client.closeCode.Store(CloseSlowConsumer)
+	h.counters.slowClientEvictions.Add(1)
+	h.removeLocked(client)
+}
+
+// enqueue queues an encoded message unless the client's buffer is full,
+// counting its bytes until they are written
+func (c *Client) enqueue(message []byte) bool {
+	c.queued.Add(int64(len(message)))
+	select {
+	case c.send <- message:
+		return true
+	default:
+		c.queued.Add(-int64(len(message)))
+		c.hub.counters.messagesDropped.Add(1)
+		return false
+	}
+}
+
+// closeMessage returns the close frame ending the connection, giving the
+// reason when the hub evicted the client
+func (c *Client) closeMessage() []byte {
+	code := int(c.closeCode.Load())
+	if code == 0 {
+		return []byte{}
+	}
+	return websocket.FormatCloseMessage(code, c.closeReason)
+}
+
+// isTimeout reports whether a read failed because its deadline passed
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// clientIP returns the address a request came from. RemoteAddr holds the
+// real client address when the RealIP middleware runs in front.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -1,10 +1,16 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,22 +24,62 @@ type Hub struct {
 	// Inbound messages from the clients
 	broadcast chan []byte
 
-	// Register requests from the clients
-	register chan *Client
-
 	// Unregister requests from clients
 	unregister chan *Client
 
 	// Rooms for match-specific updates
 	rooms map[string]map[*Client]bool
 
+	// Per-connection limits
+	limits Limits
+
+	// How connections are pinged and when an unresponsive one is dropped
+	keepalive Keepalive
+
+	// Decides who may join a room; nil leaves every room open
+	access AccessFunc
+
+	// Browser origins allowed to connect; empty allows the same host only
+	allowedOrigins []string
+
+	// Connection caps, and the connections open from each client address
+	capacity  Capacity
+	addresses map[string]int
+
+	// Connection health metrics
+	counters hubCounters
+
+	// How often viewer counts are sampled, 0 when presence is off; who is
+	// told the counts; and rooms joined since the last sample
+	presenceInterval time.Duration
+	presence         PresenceFunc
+	joined           map[string]bool
+
+	// Numbers room messages and buffers them for reconnecting clients
+	replay ReplayStore
+
+	// Builds the current state of a room for clients too far behind to replay
+	snapshot SnapshotFunc
+
+	// Shares broadcasts and connection counts with other instances, if any
+	backplane Backplane
+
+	// Per-room locks that keep numbering, delivery and catch-up in order,
+	// kept only while held or waited for
+	roomLocks      map[string]*roomLock
+	roomLocksMutex sync.Mutex
+
 	// Mutex for thread-safe operations
 	mutex sync.RWMutex
 }
 
+// SnapshotFunc returns the current state of a room, sent to a client that
+// subscribes or resyncs, or whose missed messages are no longer buffered
+type SnapshotFunc func(ctx context.Context, roomID string) (interface{}, error)
+
 // Client represents a websocket client
 type Client struct {
-	// The websocket connection
+	// The websocket connection, nil for streams
 	conn *websocket.Conn
 
 	// Buffered channel of outbound messages
@@ -42,39 +88,129 @@ type Client struct {
 	// The hub
 	hub *Hub
 
-	// Room ID (match ID for cricket scoring)
+	// Room joined on connect from the URL, if any
 	roomID string
 
+	// Rooms the client is subscribed to, guarded by the hub's mutex
+	rooms map[string]bool
+
+	// Seq of each room the client has caught up to on subscribing, so a
+	// broadcast from another instance that was also replayed is not sent twice
+	caughtUp map[string]int64
+
 	// Client ID for identification
 	clientID string
+
+	// Address the client connected from
+	ip string
+
+	// The connecting request's values, such as its user, for access checks
+	identity context.Context
+
+	// Bytes queued in send and not yet written
+	queued atomic.Int64
+
+	// Close code and reason sent when the hub evicts the client; the reason
+	// is set before the code
+	closeCode   atomic.Int32
+	closeReason string
+
+	// Inbound frames this minute, only touched by readPump
+	rate rateWindow
 }
 
-// Message represents a websocket message
+// Message represents a websocket message. ID echoes the client message a
+// reply answers; Seq numbers the messages broadcast to a room.
 type Message struct {
 	Type     string      `json:"type"`
+	ID       string      `json:"id,omitempty"`
+	Seq      int64       `json:"seq,omitempty"`
 	RoomID   string      `json:"room_id,omitempty"`
 	Data     interface{} `json:"data"`
 	ClientID string      `json:"client_id,omitempty"`
 }
 
+// SeriesRoomID returns the room for clients following a series rather than
+// a single match; the prefix keeps it apart from match rooms
+func SeriesRoomID(seriesID string) string {
+	return seriesRoomPrefix + seriesID
+}
+
+// SeriesIDFromRoom returns the series a room follows, and false for match rooms
+func SeriesIDFromRoom(roomID string) (string, bool) {
+	if !strings.HasPrefix(roomID, seriesRoomPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(roomID, seriesRoomPrefix), true
+}
+
+const seriesRoomPrefix = "series:"
+
 // NewHub creates a new websocket hub
 func NewHub() *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
 		broadcast:  make(chan []byte),
-		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		rooms:      make(map[string]map[*Client]bool),
+		limits:     DefaultLimits(),
+		keepalive:  DefaultKeepalive(),
+		addresses:  make(map[string]int),
+		joined:     make(map[string]bool),
+		replay:     NewMemoryReplayStore(DefaultReplaySize, DefaultReplayTTL),
+		roomLocks:  make(map[string]*roomLock),
 	}
 }
 
+// SetReplayStore replaces the in-memory replay buffer, e.g. with Redis so
+// that every instance numbers a room's messages alike. Call before Run.
+func (h *Hub) SetReplayStore(replay ReplayStore) {
+	h.replay = replay
+}
+
+// SetSnapshotProvider enables sending a room's current state to clients
+// that subscribe without a last seq, ask to resync, or reconnect after their
+// missed messages have left the replay buffer
+func (h *Hub) SetSnapshotProvider(snapshot SnapshotFunc) {
+	h.snapshot = snapshot
+}
+
+// SetBackplane links the hub with the hubs of other server instances: room
+// broadcasts are published to them and theirs delivered to local clients.
+// Call before Run.
+func (h *Hub) SetBackplane(backplane Backplane) {
+	h.backplane = backplane
+}
+
+// SetLimits replaces the per-connection limits; unset fields keep their
+// defaults. Call before Run.
+func (h *Hub) SetLimits(limits Limits) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.limits = limits.withDefaults()
+}
+
+// Limits returns the per-connection limits
+func (h *Hub) Limits() Limits {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.limits
+}
+
 // Run starts the hub
 func (h *Hub) Run() {
+	if h.backplane != nil {
+		go h.listen()
+		go h.reportStats(DefaultStatsInterval)
+	}
+	if h.presenceInterval > 0 {
+		go h.trackPresence(h.presenceInterval)
+	}
+
 	for {
 		select {
-		case client := <-h.register:
-			h.registerClient(client)
-
 		case client := <-h.unregister:
 			h.unregisterClient(client)
 
@@ -84,22 +220,28 @@ func (h *Hub) Run() {
 	}
 }
 
-// registerClient registers a new client
-func (h *Hub) registerClient(client *Client) {
+// registerClient registers a new client, unless it is over capacity, and
+// greets it with the room it is joining from the URL and its limits
+func (h *Hub) registerClient(client *Client) error {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	h.clients[client] = true
+	if err := h.admitLocked(client); err != nil {
+		return err
+	}
 
-	// Add client to room
+	subscriptions := []string{}
 	if client.roomID != "" {
-		if h.rooms[client.roomID] == nil {
-			h.rooms[client.roomID] = make(map[*Client]bool)
-		}
-		h.rooms[client.roomID][client] = true
+		subscriptions = append(subscriptions, client.roomID)
 	}
+	h.sendLocked(client, Message{
+		Type:     TypeConnected,
+		ClientID: client.clientID,
+		Data:     ConnectedData{Subscriptions: subscriptions, Limits: h.limits},
+	})
 
 	log.Printf("Client %s connected to room %s", client.clientID, client.roomID)
+	return nil
 }
 
 // unregisterClient unregisters a client
@@ -107,44 +249,515 @@ func (h *Hub) unregisterClient(client *Client) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	if _, ok := h.clients[client]; ok {
-		delete(h.clients, client)
-		close(client.send)
+	h.removeLocked(client)
 
-		// Remove client from room
-		if client.roomID != "" && h.rooms[client.roomID] != nil {
-			delete(h.rooms[client.roomID], client)
-			if len(h.rooms[client.roomID]) == 0 {
-				delete(h.rooms, client.roomID)
-			}
+	log.Printf("Client %s disconnected", client.clientID)
+}
+
+// removeLocked drops a client from the hub and all its rooms and closes its
+// send channel. Safe to call more than once; the caller holds the write lock.
+func (h *Hub) removeLocked(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	close(client.send)
+	if h.addresses[client.ip]--; h.addresses[client.ip] <= 0 {
+		delete(h.addresses, client.ip)
+	}
+
+	for roomID := range client.rooms {
+		h.leave(client, roomID)
+	}
+}
+
+// join adds a client to a room; the caller holds the write lock
+func (h *Hub) join(client *Client, roomID string) {
+	if h.rooms[roomID] == nil {
+		h.rooms[roomID] = make(map[*Client]bool)
+	}
+	h.rooms[roomID][client] = true
+	client.rooms[roomID] = true
+	h.markJoinedLocked(roomID)
+}
+
+// leave removes a client from a room, dropping the room once empty; the
+// caller holds the write lock
+func (h *Hub) leave(client *Client, roomID string) {
+	delete(client.rooms, roomID)
+	delete(client.caughtUp, roomID)
+	if room := h.rooms[roomID]; room != nil {
+		delete(room, client)
+		if len(room) == 0 {
+			delete(h.rooms, roomID)
 		}
 	}
+}
 
-	log.Printf("Client %s disconnected from room %s", client.clientID, client.roomID)
+// Subscribe adds a client to a room. Subscribing twice is a no-op.
+func (h *Hub) Subscribe(client *Client, roomID string) error {
+	return h.subscribe(client, roomID, nil, nil)
 }
 
-// broadcastMessage broadcasts a message to all clients
-func (h *Hub) broadcastMessage(message []byte) {
+// subscribe adds a client to a room and queues the ack, if any, followed by
+// what the client missed after lastSeq: the buffered messages in order, or
+// a snapshot of the room when they are gone. Without a lastSeq the client
+// gets a snapshot to start from, if the hub can build one. A snapshot reads
+// the repositories, so it is built before taking the room's lock, which
+// broadcasts to the room wait for; the messages broadcast meanwhile follow
+// it. Holding the lock while joining keeps live messages from overtaking
+// the catch-up.
+func (h *Hub) subscribe(client *Client, roomID string, lastSeq *int64, ack *Message) error {
 	h.mutex.RLock()
-	defer h.mutex.RUnlock()
+	joining := !client.rooms[roomID]
+	full := joining && len(client.rooms) >= h.limits.MaxSubscriptions
+	roomFull := joining && h.roomFullLocked(roomID)
+	h.mutex.RUnlock()
+	if full {
+		return ErrSubscriptionLimit
+	}
+	if roomFull {
+		h.counters.rejections.Add(1)
+		return ErrRoomFull
+	}
 
-	for client := range h.clients {
-		select {
-		case client.send <- message:
-		default:
-			close(client.send)
-			delete(h.clients, client)
+	from := lastSeq
+	var catchUp *Message
+	if lastSeq == nil || !h.canReplay(roomID, *lastSeq) {
+		seq, message := h.prepareSnapshot(roomID, lastSeq != nil)
+		from, catchUp = &seq, message
+	}
+
+	unlock := h.lockRoom(roomID)
+	defer unlock()
+
+	missed, seq, ok, err := h.replay.Since(roomID, *from)
+	if err != nil {
+		log.Printf("Error reading replay buffer of room %s: %v", roomID, err)
+	}
+	if !ok {
+		missed = nil
+		if catchUp == nil && lastSeq != nil {
+			catchUp = replayUnavailable(roomID, seq)
 		}
 	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return ErrClientGone
+	}
+	if !client.rooms[roomID] {
+		if len(client.rooms) >= h.limits.MaxSubscriptions {
+			return ErrSubscriptionLimit
+		}
+		if h.roomFullLocked(roomID) {
+			h.counters.rejections.Add(1)
+			return ErrRoomFull
+		}
+		h.join(client, roomID)
+		client.caughtUp[roomID] = seq
+		log.Printf("Client %s subscribed to room %s at seq %d", client.clientID, roomID, seq)
+	}
+
+	if ack != nil {
+		ack.Data = AckData{RoomID: roomID, Subscriptions: client.subscriptions(), Seq: seq}
+		h.sendLocked(client, *ack)
+	}
+	if catchUp != nil {
+		h.sendLocked(client, *catchUp)
+	}
+	return h.enqueueMissedLocked(client, roomID, missed)
+}
+
+// canReplay reports whether the messages a room has had after lastSeq are
+// still buffered
+func (h *Hub) canReplay(roomID string, lastSeq int64) bool {
+	_, _, ok, err := h.replay.Since(roomID, lastSeq)
+	if err != nil {
+		log.Printf("Error reading replay buffer of room %s: %v", roomID, err)
+	}
+	return ok
+}
+
+// prepareSnapshot reads a room's seq and then builds its snapshot, without
+// holding the room's lock. Messages broadcast in between are reflected in
+// the snapshot already and change nothing when applied again. Without a
+// snapshot the frame is nil, or when one is required an error asking the
+// client to reload the room's state.
+func (h *Hub) prepareSnapshot(roomID string, required bool) (int64, *Message) {
+	seq, err := h.replay.LastSeq(roomID)
+	if err != nil {
+		log.Printf("Error reading sequence number of room %s: %v", roomID, err)
+	}
+	if h.snapshot != nil {
+		if data, err := h.buildSnapshot(roomID); err == nil {
+			return seq, &Message{Type: TypeSnapshot, RoomID: roomID, Seq: seq, Data: data}
+		}
+	}
+	if required {
+		return seq, replayUnavailable(roomID, seq)
+	}
+	return seq, nil
+}
+
+// replayUnavailable builds the error frame sent instead of a replay that is
+// no longer possible, asking the client to reload the room's state
+func replayUnavailable(roomID string, seq int64) *Message {
+	return &Message{Type: TypeError, RoomID: roomID, Seq: seq, Data: ErrorData{
+		Code:    ErrorReplayUnavailable,
+		Message: "missed messages are no longer available, reload the current state",
+	}}
+}
+
+// enqueueMissedLocked queues a room's messages a client missed, evicting
+// it if they do not fit; the caller holds the write lock
+func (h *Hub) enqueueMissedLocked(client *Client, roomID string, missed [][]byte) error {
+	for _, message := range missed {
+		if !client.enqueue(message) {
+			log.Printf("Client %s is too slow to catch up on room %s, disconnecting", client.clientID, roomID)
+			h.evictLocked(client)
+			return ErrClientGone
+		}
+	}
+	return nil
+}
+
+// Stream is a room subscription read by something other than a WebSocket
+// connection, such as a Server-Sent Events response
+type Stream struct {
+	client *Client
+}
+
+// Frames returns the frames sent to the room's WebSocket clients. It is
+// closed once the stream has left or fallen too far behind.
+func (s *Stream) Frames() <-chan []byte {
+	return s.client.send
+}
+
+// Sent records that a frame from Frames has been written out
+func (s *Stream) Sent(frame []byte) {
+	s.client.queued.Add(-int64(len(frame)))
+}
+
+// Close leaves the room
+func (s *Stream) Close() {
+	s.client.hub.unregisterClient(s.client)
+}
+
+// Stream joins a room for a client without a WebSocket connection. Like a
+// WebSocket client it must be allowed in the room, counts towards the
+// connection caps, and catches up after lastSeq or starts from a snapshot
+// without one.
+func (h *Hub) Stream(r *http.Request, roomID, clientID string, lastSeq *int64) (*Stream, error) {
+	client := h.newClient(r, roomID, clientID)
+	if err := h.authorize(client, roomID); err != nil {
+		return nil, err
+	}
+
+	h.mutex.Lock()
+	err := h.admitLocked(client)
+	h.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.subscribe(client, roomID, lastSeq, nil); err != nil {
+		h.unregisterClient(client)
+		return nil, err
+	}
+	log.Printf("Stream %s joined room %s", clientID, roomID)
+	return &Stream{client: client}, nil
+}
+
+// newClient creates a client for a request, not yet registered
+func (h *Hub) newClient(r *http.Request, roomID, clientID string) *Client {
+	return &Client{
+		send:     make(chan []byte, 256),
+		hub:      h,
+		roomID:   roomID,
+		rooms:    make(map[string]bool),
+		caughtUp: make(map[string]int64),
+		clientID: clientID,
+		ip:       clientIP(r),
+		identity: context.WithoutCancel(r.Context()),
+	}
+}
+
+// resync sends a client a snapshot of a room it follows, followed by the
+// messages broadcast while it was built. The snapshot is built without
+// holding the room's lock; holding it while sending keeps live messages
+// from overtaking the catch-up.
+func (h *Hub) resync(client *Client, roomID, id string) error {
+	h.mutex.RLock()
+	subscribed := client.rooms[roomID]
+	h.mutex.RUnlock()
+	if !subscribed {
+		return ErrNotSubscribed
+	}
+	if h.snapshot == nil {
+		return ErrNoSnapshot
+	}
+
+	seq, snapshot := h.prepareSnapshot(roomID, false)
+	if snapshot == nil {
+		return ErrNoSnapshot
+	}
+	snapshot.ID = id
+
+	unlock := h.lockRoom(roomID)
+	defer unlock()
+
+	missed, _, ok, err := h.replay.Since(roomID, seq)
+	if err != nil {
+		log.Printf("Error reading replay buffer of room %s: %v", roomID, err)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !client.rooms[roomID] {
+		return ErrNotSubscribed
+	}
+	h.sendLocked(client, *snapshot)
+	if !ok {
+		return nil
+	}
+	return h.enqueueMissedLocked(client, roomID, missed)
+}
+
+// buildSnapshot asks the snapshot provider for a room's current state
+func (h *Hub) buildSnapshot(roomID string) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := h.snapshot(ctx, roomID)
+	if err != nil {
+		log.Printf("Error building snapshot of room %s: %v", roomID, err)
+	}
+	return data, err
+}
+
+// roomLock orders a room's messages; refs counts the callers holding or
+// waiting for it
+type roomLock struct {
+	sync.Mutex
+	refs int
 }
 
-// BroadcastToRoom broadcasts a message to all clients in a specific room
+// lockRoom takes the lock that orders a room's messages and returns the
+// function that releases it. The lock is dropped once nobody holds or waits
+// for it, so rooms that have gone quiet do not keep one; the room's numbering
+// lives in the replay store.
+func (h *Hub) lockRoom(roomID string) func() {
+	h.roomLocksMutex.Lock()
+	lock, ok := h.roomLocks[roomID]
+	if !ok {
+		lock = &roomLock{}
+		h.roomLocks[roomID] = lock
+	}
+	lock.refs++
+	h.roomLocksMutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		h.roomLocksMutex.Lock()
+		defer h.roomLocksMutex.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(h.roomLocks, roomID)
+		}
+	}
+}
+
+// Unsubscribe removes a client from a room
+func (h *Hub) Unsubscribe(client *Client, roomID string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !client.rooms[roomID] {
+		return ErrNotSubscribed
+	}
+
+	h.leave(client, roomID)
+	log.Printf("Client %s unsubscribed from room %s", client.clientID, roomID)
+	return nil
+}
+
+// Subscriptions returns the rooms a client is in, sorted
+func (h *Hub) Subscriptions(client *Client) []string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return client.subscriptions()
+}
+
+// subscriptions lists the client's rooms; the caller holds the lock
+func (c *Client) subscriptions() []string {
+	rooms := make([]string, 0, len(c.rooms))
+	for roomID := range c.rooms {
+		rooms = append(rooms, roomID)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+// broadcastMessage broadcasts a message to all clients of every instance
+func (h *Hub) broadcastMessage(message []byte) {
+	h.deliver("", 0, message)
+	h.publish(Envelope{Message: message})
+}
+
+// BroadcastToRoom numbers a message with the room's next sequence number,
+// buffers it for replay and sends it to all clients in the room. Messages
+// must encode as JSON objects; the number is added as "seq".
 func (h *Hub) BroadcastToRoom(roomID string, message interface{}) {
+	unlock := h.lockRoom(roomID)
+	defer unlock()
+
+	var messageSeq int64
+	messageBytes, err := h.replay.Append(roomID, func(seq int64) ([]byte, error) {
+		messageSeq = seq
+		return encodeWithSeq(message, seq)
+	})
+	if err != nil {
+		log.Printf("Error numbering message for room %s: %v", roomID, err)
+		if messageBytes == nil {
+			if messageBytes, err = json.Marshal(message); err != nil {
+				log.Printf("Error marshaling message: %v", err)
+				return
+			}
+			messageSeq = 0
+		}
+	}
+
+	h.deliver(roomID, messageSeq, messageBytes)
+	h.publish(Envelope{RoomID: roomID, Seq: messageSeq, Message: messageBytes})
+}
+
+// publish sends a broadcast to the other instances, if linked
+func (h *Hub) publish(envelope Envelope) {
+	if h.backplane == nil {
+		return
+	}
+	if err := h.backplane.Publish(envelope); err != nil {
+		log.Printf("Error publishing broadcast for room %q: %v", envelope.RoomID, err)
+	}
+}
+
+// listen delivers broadcasts from other instances to local clients,
+// retrying while the backplane is unavailable
+func (h *Hub) listen() {
+	for {
+		err := h.backplane.Listen(h.deliverRemote)
+		if err == nil {
+			return
+		}
+		log.Printf("Error listening on WebSocket backplane: %v", err)
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// deliverRemote delivers a broadcast from another instance, in order with
+// the room's catch-up
+func (h *Hub) deliverRemote(envelope Envelope) {
+	if envelope.RoomID == "" {
+		h.deliver("", 0, envelope.Message)
+		return
+	}
+
+	unlock := h.lockRoom(envelope.RoomID)
+	defer unlock()
+
+	h.deliver(envelope.RoomID, envelope.Seq, envelope.Message)
+}
+
+// reportStats records the hub's connection counts on the backplane every
+// interval
+func (h *Hub) reportStats(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if err := h.backplane.ReportStats(h.LocalStats()); err != nil {
+			log.Printf("Error reporting WebSocket stats: %v", err)
+		}
+	}
+}
+
+// encodeWithSeq marshals a room message with its sequence number
+func encodeWithSeq(message interface{}, seq int64) ([]byte, error) {
+	switch msg := message.(type) {
+	case Message:
+		msg.Seq = seq
+		return json.Marshal(msg)
+	case *Message:
+		numbered := *msg
+		numbered.Seq = seq
+		return json.Marshal(numbered)
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("room messages must be JSON objects: %w", err)
+	}
+	fields["seq"] = json.RawMessage(strconv.FormatInt(seq, 10))
+	return json.Marshal(fields)
+}
+
+// deliver queues a message for each client in a room, or every client when
+// roomID is empty, skipping clients that already caught up past seq and
+// disconnecting clients whose send buffer is full so a slow reader cannot
+// hold up the rest
+func (h *Hub) deliver(roomID string, seq int64, message []byte) {
+	var slow []*Client
+
+	h.mutex.RLock()
+	clients := h.clients
+	if roomID != "" {
+		clients = h.rooms[roomID]
+	}
+	for client := range clients {
+		if seq > 0 && seq <= client.caughtUp[roomID] {
+			continue
+		}
+		if !client.enqueue(message) {
+			slow = append(slow, client)
+		}
+	}
+	h.mutex.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, client := range slow {
+		log.Printf("Client %s is too slow, disconnecting", client.clientID)
+		h.evictLocked(client)
+	}
+}
+
+// send queues a reply for a single client unless it has disconnected
+func (h *Hub) send(client *Client, message Message) {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
 
-	room, exists := h.rooms[roomID]
-	if !exists {
+	h.sendLocked(client, message)
+}
+
+// sendLocked queues a reply for a single client; the caller holds the lock.
+// Replies to a client with a full buffer are dropped.
+func (h *Hub) sendLocked(client *Client, message Message) {
+	if !h.clients[client] {
 		return
 	}
 
@@ -154,14 +767,8 @@ func (h *Hub) BroadcastToRoom(roomID string, message interface{}) {
 		return
 	}
 
-	for client := range room {
-		select {
-		case client.send <- messageBytes:
-		default:
-			close(client.send)
-			delete(h.clients, client)
-			delete(room, client)
-		}
+	if !client.enqueue(messageBytes) {
+		log.Printf("Dropped %s reply to client %s: send buffer full", message.Type, client.clientID)
 	}
 }
 
@@ -194,87 +801,244 @@ func (h *Hub) GetTotalRooms() int {
 	return len(h.rooms)
 }
 
+// LocalStats returns this instance's connection counts and health metrics
+func (h *Hub) LocalStats() InstanceStats {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	stats := InstanceStats{
+		Clients: len(h.clients),
+		Rooms:   make(map[string]int, len(h.rooms)),
+		Metrics: Metrics{
+			MessagesDropped:     h.counters.messagesDropped.Load(),
+			SlowClientEvictions: h.counters.slowClientEvictions.Load(),
+			PongTimeouts:        h.counters.pongTimeouts.Load(),
+			Rejections:          h.counters.rejections.Load(),
+		},
+	}
+	if h.backplane != nil {
+		stats.InstanceID = h.backplane.InstanceID()
+	}
+	for roomID, room := range h.rooms {
+		stats.Rooms[roomID] = len(room)
+	}
+	for client := range h.clients {
+		stats.Metrics.QueuedBytes += client.queued.Load()
+	}
+	return stats
+}
+
+// ClusterStats sums the connection counts of every instance on the
+// backplane, using this instance's current counts rather than its last
+// report. Without a backplane, or when it cannot be read, only this
+// instance is counted.
+func (h *Hub) ClusterStats() ClusterStats {
+	local := h.LocalStats()
+	instances := []InstanceStats{local}
+	if h.backplane != nil {
+		reported, err := h.backplane.Stats()
+		if err != nil {
+			log.Printf("Error reading WebSocket stats of other instances: %v", err)
+		}
+		for _, instance := range reported {
+			if instance.InstanceID != local.InstanceID {
+				instances = append(instances, instance)
+			}
+		}
+	}
+
+	stats := ClusterStats{Instances: len(instances), Rooms: make(map[string]int)}
+	for _, instance := range instances {
+		stats.TotalClients += instance.Clients
+		stats.Metrics.add(instance.Metrics)
+		for roomID, clients := range instance.Rooms {
+			stats.Rooms[roomID] += clients
+		}
+	}
+	return stats
+}
+
 // Client methods
 
-// readPump pumps messages from the websocket connection to the hub
+// readPump reads client messages and dispatches them until the connection
+// closes
 func (c *Client) readPump() {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
 
-	c.conn.SetReadLimit(512)
-	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	limits := c.hub.Limits()
+	pongTimeout := c.hub.keepalive.PongTimeout
+	c.conn.SetReadLimit(limits.MaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
 
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, data, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			if isTimeout(err) {
+				c.hub.counters.pongTimeouts.Add(1)
+				log.Printf("Client %s stopped answering pings, disconnecting", c.clientID)
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
+		c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+
+		if !c.rate.allow(time.Now(), limits.MaxMessagesPerMinute) {
+			c.replyError("", ErrorRateLimited, "too many messages, slow down")
+			continue
+		}
+		c.handleMessage(data)
+	}
+}
+
+// handleMessage dispatches one client message and replies to it
+func (c *Client) handleMessage(data []byte) {
+	var msg ClientMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.replyError("", ErrorInvalidMessage, "message must be a JSON object")
+		return
+	}
+
+	switch msg.Type {
+	case TypePing:
+		c.hub.send(c, Message{Type: TypePong, ID: msg.ID, Data: map[string]int64{"timestamp": time.Now().Unix()}})
+
+	case TypeSubscribe, TypeUnsubscribe:
+		roomID, err := msg.roomFor()
+		if err != nil {
+			c.replyError(msg.ID, ErrorInvalidRoom, err.Error())
+			return
+		}
+
+		if msg.Type == TypeSubscribe {
+			if err = c.hub.authorize(c, roomID); err != nil {
+				c.replyError(msg.ID, accessErrorCode(err), err.Error())
+				return
+			}
+			err = c.hub.subscribe(c, roomID, msg.LastSeq, &Message{Type: TypeAck, ID: msg.ID, RoomID: roomID})
+		} else if err = c.hub.Unsubscribe(c, roomID); err == nil {
+			c.hub.send(c, Message{Type: TypeAck, ID: msg.ID, RoomID: roomID, Data: AckData{RoomID: roomID, Subscriptions: c.hub.Subscriptions(c)}})
+		}
+		switch err {
+		case ErrSubscriptionLimit:
+			c.replyError(msg.ID, ErrorSubscriptionLimit, fmt.Sprintf("a connection can follow at most %d rooms", c.hub.Limits().MaxSubscriptions))
+		case ErrRoomFull:
+			c.replyError(msg.ID, ErrorRoomFull, roomID+" is full, try again later")
+		case ErrNotSubscribed:
+			c.replyError(msg.ID, ErrorNotSubscribed, "not subscribed to "+roomID)
+		}
+
+	case TypeResync:
+		roomID, err := msg.roomFor()
+		if err != nil {
+			c.replyError(msg.ID, ErrorInvalidRoom, err.Error())
+			return
+		}
+
+		switch c.hub.resync(c, roomID, msg.ID) {
+		case ErrNotSubscribed:
+			c.replyError(msg.ID, ErrorNotSubscribed, "not subscribed to "+roomID)
+		case ErrNoSnapshot:
+			c.replyError(msg.ID, ErrorSnapshotUnavailable, "the current state of "+roomID+" is unavailable, try again")
+		}
+
+	default:
+		c.replyError(msg.ID, ErrorUnknownType, fmt.Sprintf("unknown message type %q", msg.Type))
 	}
 }
 
-// writePump pumps messages from the hub to the websocket connection
+// replyError sends an error frame answering the client message with the given ID
+func (c *Client) replyError(id, code, message string) {
+	c.hub.send(c, Message{Type: TypeError, ID: id, Data: ErrorData{Code: code, Message: message}})
+}
+
+// writePump pumps messages from the hub to the websocket connection, one
+// JSON message per frame, and pings it so that dead connections are noticed.
+// An evicted client is sent the close frame without the rest of its buffer.
 func (c *Client) writePump() {
-	defer c.conn.Close()
+	ticker := time.NewTicker(c.hub.keepalive.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
 
-	//nolint:staticcheck,gosimple // websocket write pump requires for{select{}} pattern
 	for {
 		select {
 		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok || c.closeCode.Load() != 0 {
+				c.conn.WriteMessage(websocket.CloseMessage, c.closeMessage())
 				return
 			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+			c.queued.Add(-int64(len(message)))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
 				return
 			}
-			w.Write(message)
 
-			// Add queued chat messages to the current websocket message
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-c.send)
-			}
-
-			if err := w.Close(); err != nil {
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
 		}
 	}
 }
 
-// ServeWS handles websocket requests from clients
+// ServeWS handles websocket requests from clients. The client joins roomID
+// when it is set, catching up from the last_seq query parameter if given,
+// and can subscribe to further rooms over the connection. The user the
+// request carries must be allowed in each room. A request from another
+// origin, for a room it may not join, or over the address or room cap is
+// refused before upgrading.
 func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, roomID, clientID string) {
-	upgrader := websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			return true // Allow all origins for development
-		},
+	upgrader := websocket.Upgrader{CheckOrigin: h.CheckOrigin}
+
+	client := h.newClient(r, roomID, clientID)
+	if roomID != "" {
+		if err := h.authorize(client, roomID); err != nil {
+			log.Printf("Refused client %s for room %s: %v", clientID, roomID, err)
+			http.Error(w, err.Error(), accessStatus(err))
+			return
+		}
+	}
+	switch err := h.registerClient(client); err {
+	case ErrTooManyConnections:
+		log.Printf("Refused client %s from %s: %v", clientID, client.ip, err)
+		http.Error(w, "Too many connections", http.StatusTooManyRequests)
+		return
+	case ErrRoomFull:
+		log.Printf("Refused client %s for room %s: %v", clientID, roomID, err)
+		http.Error(w, "Room is full", http.StatusServiceUnavailable)
+		return
 	}
 
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
+		h.unregisterClient(client)
 		return
 	}
+	client.conn = conn
 
-	client := &Client{
-		conn:     conn,
-		send:     make(chan []byte, 256),
-		hub:      h,
-		roomID:   roomID,
-		clientID: clientID,
+	if roomID != "" {
+		var lastSeq *int64
+		if value, err := strconv.ParseInt(r.URL.Query().Get("last_seq"), 10, 64); err == nil && value >= 0 {
+			lastSeq = &value
+		}
+		if err := h.subscribe(client, roomID, lastSeq, nil); err != nil {
+			log.Printf("Error joining client %s to room %s: %v", clientID, roomID, err)
+			if err == ErrRoomFull {
+				client.replyError("", ErrorRoomFull, roomID+" is full, try again later")
+			}
+		}
 	}
 
-	client.hub.register <- client
-
 	// Allow collection of memory referenced by the caller by doing all work in
 	// new goroutines
 	go client.writePump()
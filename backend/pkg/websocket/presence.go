@@ -0,0 +1,82 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// DefaultPresenceInterval is how often viewer counts are sampled and sent
+const DefaultPresenceInterval = 2 * time.Second
+
+// PresenceFunc receives the viewers of every room with any, counted across
+// instances, each time the hub samples presence. Rooms missing from the map
+// have no viewers.
+type PresenceFunc func(at time.Time, viewers map[string]int)
+
+// SetPresence makes the hub count each room's viewers every interval, send
+// a viewer_count frame to rooms whose count changed or that someone joined,
+// and pass the counts to listener, if any. Counts made so far apart carry
+// any burst of joins and leaves as a single update. Without it the hub
+// keeps no presence. Call before Run.
+func (h *Hub) SetPresence(interval time.Duration, listener PresenceFunc) {
+	if interval <= 0 {
+		interval = DefaultPresenceInterval
+	}
+	h.presenceInterval = interval
+	h.presence = listener
+}
+
+// trackPresence samples presence every interval
+func (h *Hub) trackPresence(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	sent := map[string]int{}
+	for at := range ticker.C {
+		sent = h.samplePresence(at, sent)
+	}
+}
+
+// samplePresence counts every room's viewers, sends local clients the
+// counts that changed since sent or that new viewers have not had, and
+// returns the counts sent. With a backplane, other instances are counted as
+// of their last report.
+func (h *Hub) samplePresence(at time.Time, sent map[string]int) map[string]int {
+	viewers := h.ClusterStats().Rooms
+
+	h.mutex.Lock()
+	joined := h.joined
+	h.joined = make(map[string]bool)
+	h.mutex.Unlock()
+
+	for roomID, count := range viewers {
+		previous, known := sent[roomID]
+		if known && previous == count && !joined[roomID] {
+			continue
+		}
+		if h.GetRoomClients(roomID) == 0 {
+			continue
+		}
+
+		message, err := json.Marshal(Message{Type: TypeViewerCount, RoomID: roomID, Data: ViewerCountData{Viewers: count}})
+		if err != nil {
+			log.Printf("Error marshaling viewer count: %v", err)
+			continue
+		}
+		h.deliver(roomID, 0, message)
+	}
+
+	if h.presence != nil {
+		h.presence(at, viewers)
+	}
+	return viewers
+}
+
+// markJoinedLocked notes that a room has a new viewer owed its count; the
+// caller holds the write lock
+func (h *Hub) markJoinedLocked(roomID string) {
+	if h.presenceInterval > 0 {
+		h.joined[roomID] = true
+	}
+}
@@ -0,0 +1,145 @@
+package websocket
+
+import (
+	"errors"
+	"time"
+)
+
+// Inbound message types sent by clients
+const (
+	TypeSubscribe   = "subscribe"
+	TypeUnsubscribe = "unsubscribe"
+	TypePing        = "ping"
+	TypeResync      = "resync" // Asks for a snapshot of a subscribed room
+)
+
+// Outbound message types sent in reply to clients; room broadcasts use
+// their own types
+const (
+	TypeConnected   = "connected"
+	TypeAck         = "ack"
+	TypePong        = "pong"
+	TypeError       = "error"
+	TypeSnapshot    = "snapshot"     // A room's current state, sent on subscribing, on resync and when missed messages can no longer be replayed
+	TypeViewerCount = "viewer_count" // How many are following a room; unnumbered, sent when it changes
+)
+
+// Error codes carried by error frames
+const (
+	ErrorInvalidMessage      = "invalid_message"
+	ErrorUnknownType         = "unknown_type"
+	ErrorInvalidRoom         = "invalid_room"
+	ErrorNotSubscribed       = "not_subscribed"
+	ErrorSubscriptionLimit   = "subscription_limit"
+	ErrorRateLimited         = "rate_limited"
+	ErrorReplayUnavailable   = "replay_unavailable"
+	ErrorSnapshotUnavailable = "snapshot_unavailable"
+	ErrorRoomFull            = "room_full"
+	ErrorUnauthorized        = "unauthorized" // The room needs a signed-in user
+	ErrorForbidden           = "forbidden"    // The user may not follow the room
+)
+
+// ClientMessage represents a frame sent by a client. Subscriptions and
+// resyncs name exactly one of a match or a series; the ID is echoed on the
+// reply. A client resubscribing after a drop sends the last seq it saw in
+// the room.
+type ClientMessage struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	MatchID  string `json:"match_id,omitempty"`
+	SeriesID string `json:"series_id,omitempty"`
+	LastSeq  *int64 `json:"last_seq,omitempty"`
+}
+
+// ErrorData is the payload of an error frame
+type ErrorData struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// AckData is the payload of an ack frame
+type AckData struct {
+	RoomID        string   `json:"room_id,omitempty"`
+	Subscriptions []string `json:"subscriptions"`
+	Seq           int64    `json:"seq,omitempty"` // Subscribe only: the room's latest seq when the client joined
+}
+
+// ViewerCountData is the payload of a viewer_count frame
+type ViewerCountData struct {
+	Viewers int `json:"viewers"`
+}
+
+// ConnectedData is the payload of the frame sent when a client connects
+type ConnectedData struct {
+	Subscriptions []string `json:"subscriptions"`
+	Limits        Limits   `json:"limits"`
+}
+
+// Limits caps what a single connection may do
+type Limits struct {
+	MaxMessageSize       int64 `json:"max_message_size"`        // Bytes per inbound frame; larger frames close the connection
+	MaxSubscriptions     int   `json:"max_subscriptions"`       // Rooms a connection may be in at once
+	MaxMessagesPerMinute int   `json:"max_messages_per_minute"` // Inbound frames; the excess is answered with an error
+}
+
+// DefaultLimits returns the limits used when the hub is not configured
+func DefaultLimits() Limits {
+	return Limits{
+		MaxMessageSize:       4096,
+		MaxSubscriptions:     50,
+		MaxMessagesPerMinute: 120,
+	}
+}
+
+// withDefaults fills unset limits from DefaultLimits
+func (l Limits) withDefaults() Limits {
+	defaults := DefaultLimits()
+	if l.MaxMessageSize <= 0 {
+		l.MaxMessageSize = defaults.MaxMessageSize
+	}
+	if l.MaxSubscriptions <= 0 {
+		l.MaxSubscriptions = defaults.MaxSubscriptions
+	}
+	if l.MaxMessagesPerMinute <= 0 {
+		l.MaxMessagesPerMinute = defaults.MaxMessagesPerMinute
+	}
+	return l
+}
+
+// Errors returned by Subscribe, Unsubscribe and resync
+var (
+	ErrSubscriptionLimit = errors.New("subscription limit reached")
+	ErrNotSubscribed     = errors.New("not subscribed to room")
+	ErrClientGone        = errors.New("client is disconnected")
+	ErrNoSnapshot        = errors.New("snapshot of room is unavailable")
+)
+
+// roomFor returns the room a subscribe or unsubscribe frame names
+func (m *ClientMessage) roomFor() (string, error) {
+	switch {
+	case m.MatchID != "" && m.SeriesID != "":
+		return "", errors.New("name either match_id or series_id, not both")
+	case m.MatchID != "":
+		return m.MatchID, nil
+	case m.SeriesID != "":
+		return SeriesRoomID(m.SeriesID), nil
+	default:
+		return "", errors.New("match_id or series_id is required")
+	}
+}
+
+// rateWindow counts a connection's inbound frames per minute
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// allow records a frame and reports whether it is within the limit
+func (w *rateWindow) allow(now time.Time, limit int) bool {
+	if now.Sub(w.start) >= time.Minute {
+		w.start = now
+		w.count = 0
+	}
+	w.count++
+	return w.count <= limit
+}
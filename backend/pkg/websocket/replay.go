@@ -0,0 +1,263 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Replay buffer defaults: a room keeps its last messages until it has been
+// quiet for the TTL
+const (
+	DefaultReplaySize = 100
+	DefaultReplayTTL  = 10 * time.Minute
+)
+
+// ReplayStore numbers the messages broadcast to each room and keeps a short
+// buffer of them so reconnecting clients can catch up
+type ReplayStore interface {
+	// Append assigns the room's next sequence number, encodes the message
+	// with it and buffers the result
+	Append(roomID string, encode func(seq int64) ([]byte, error)) ([]byte, error)
+
+	// LastSeq returns the room's latest sequence number, 0 before the first message
+	LastSeq(roomID string) (int64, error)
+
+	// Since returns the buffered messages after lastSeq in order and the
+	// room's latest sequence number. ok is false when some of the messages
+	// after lastSeq are no longer buffered, or lastSeq is from a numbering
+	// that has since expired.
+	Since(roomID string, lastSeq int64) (messages [][]byte, seq int64, ok bool, err error)
+}
+
+// MemoryReplayStore keeps replay buffers in process memory
+type MemoryReplayStore struct {
+	size  int
+	ttl   time.Duration
+	mutex sync.Mutex
+	rooms map[string]*replayRoom
+	swept time.Time
+}
+
+type replayRoom struct {
+	seq      int64
+	entries  []replayEntry
+	appended time.Time
+}
+
+type replayEntry struct {
+	seq  int64
+	data []byte
+}
+
+// NewMemoryReplayStore creates an in-memory replay store that keeps the last
+// size messages of each room until the room has been quiet for ttl
+func NewMemoryReplayStore(size int, ttl time.Duration) *MemoryReplayStore {
+	if size <= 0 {
+		size = DefaultReplaySize
+	}
+	if ttl <= 0 {
+		ttl = DefaultReplayTTL
+	}
+	return &MemoryReplayStore{
+		size:  size,
+		ttl:   ttl,
+		rooms: make(map[string]*replayRoom),
+		swept: time.Now(),
+	}
+}
+
+// Append numbers and buffers a room message
+func (s *MemoryReplayStore) Append(roomID string, encode func(seq int64) ([]byte, error)) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	room := s.room(roomID, now)
+	if room == nil {
+		room = &replayRoom{}
+		s.rooms[roomID] = room
+	}
+
+	data, err := encode(room.seq + 1)
+	if err != nil {
+		return nil, err
+	}
+	room.seq++
+	room.appended = now
+	room.entries = append(room.entries, replayEntry{seq: room.seq, data: data})
+	if len(room.entries) > s.size {
+		room.entries = room.entries[len(room.entries)-s.size:]
+	}
+	return data, nil
+}
+
+// LastSeq returns a room's latest sequence number
+func (s *MemoryReplayStore) LastSeq(roomID string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if room := s.room(roomID, time.Now()); room != nil {
+		return room.seq, nil
+	}
+	return 0, nil
+}
+
+// Since returns a room's buffered messages after lastSeq
+func (s *MemoryReplayStore) Since(roomID string, lastSeq int64) ([][]byte, int64, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	room := s.room(roomID, time.Now())
+	if room == nil {
+		return nil, 0, lastSeq == 0, nil
+	}
+	if lastSeq >= room.seq {
+		return nil, room.seq, lastSeq == room.seq, nil
+	}
+	if len(room.entries) == 0 || room.entries[0].seq > lastSeq+1 {
+		return nil, room.seq, false, nil
+	}
+
+	messages := make([][]byte, 0, room.seq-lastSeq)
+	for _, entry := range room.entries {
+		if entry.seq > lastSeq {
+			messages = append(messages, entry.data)
+		}
+	}
+	return messages, room.seq, true, nil
+}
+
+// room returns a room's buffer unless it has expired; the caller holds the lock
+func (s *MemoryReplayStore) room(roomID string, now time.Time) *replayRoom {
+	room := s.rooms[roomID]
+	if room == nil || now.Sub(room.appended) >= s.ttl {
+		return nil
+	}
+	return room
+}
+
+// sweep drops expired rooms at most once per TTL; the caller holds the lock
+func (s *MemoryReplayStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < s.ttl {
+		return
+	}
+	s.swept = now
+	for roomID, room := range s.rooms {
+		if now.Sub(room.appended) >= s.ttl {
+			delete(s.rooms, roomID)
+		}
+	}
+}
+
+// RedisReplayStore keeps replay buffers in Redis so every server instance
+// numbers a room's messages from the same counter
+type RedisReplayStore struct {
+	client *redis.Client
+	size   int
+	ttl    time.Duration
+}
+
+// NewRedisReplayStore creates a Redis replay store that keeps the last size
+// messages of each room until the room has been quiet for ttl
+func NewRedisReplayStore(client *redis.Client, size int, ttl time.Duration) *RedisReplayStore {
+	if size <= 0 {
+		size = DefaultReplaySize
+	}
+	if ttl <= 0 {
+		ttl = DefaultReplayTTL
+	}
+	return &RedisReplayStore{client: client, size: size, ttl: ttl}
+}
+
+func replaySeqKey(roomID string) string {
+	return "ws:seq:" + roomID
+}
+
+func replayBufferKey(roomID string) string {
+	return "ws:replay:" + roomID
+}
+
+// Append numbers and buffers a room message
+func (s *RedisReplayStore) Append(roomID string, encode func(seq int64) ([]byte, error)) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	seq, err := s.client.Incr(ctx, replaySeqKey(roomID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to number message: %w", err)
+	}
+	data, err := encode(seq)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.ZAdd(ctx, replayBufferKey(roomID), redis.Z{Score: float64(seq), Member: data})
+	pipe.ZRemRangeByRank(ctx, replayBufferKey(roomID), 0, int64(-s.size-1))
+	pipe.Expire(ctx, replayBufferKey(roomID), s.ttl)
+	pipe.Expire(ctx, replaySeqKey(roomID), s.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return data, fmt.Errorf("failed to buffer message: %w", err)
+	}
+	return data, nil
+}
+
+// LastSeq returns a room's latest sequence number
+func (s *RedisReplayStore) LastSeq(roomID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	seq, err := s.client.Get(ctx, replaySeqKey(roomID)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sequence number: %w", err)
+	}
+	return seq, nil
+}
+
+// Since returns a room's buffered messages after lastSeq
+func (s *RedisReplayStore) Since(roomID string, lastSeq int64) ([][]byte, int64, bool, error) {
+	seq, err := s.LastSeq(roomID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if lastSeq >= seq {
+		return nil, seq, lastSeq == seq, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	entries, err := s.client.ZRangeByScoreWithScores(ctx, replayBufferKey(roomID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(lastSeq, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to read replay buffer: %w", err)
+	}
+	if len(entries) == 0 || int64(entries[0].Score) > lastSeq+1 {
+		return nil, seq, false, nil
+	}
+
+	messages := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		member, ok := entry.Member.(string)
+		if !ok {
+			return nil, 0, false, fmt.Errorf("unexpected replay entry in room %s", roomID)
+		}
+		messages = append(messages, []byte(member))
+		if entrySeq := int64(entry.Score); entrySeq > seq {
+			seq = entrySeq
+		}
+	}
+	return messages, seq, true, nil
+}
@@ -30,10 +30,11 @@ func TestScorecardInningsValidation_Integration(t *testing.T) {
 	seriesRepo := supabase.NewSeriesRepository(testDB.Supabase)
 	matchRepo := supabase.NewMatchRepository(testDB.Supabase)
 	scorecardRepo := supabase.NewScorecardRepository(testDB.Supabase)
+	teamRepo := supabase.NewTeamRepository(testDB.Supabase)
 
 	// Create services
-	seriesService := services.NewSeriesService(seriesRepo)
-	matchService := services.NewMatchService(matchRepo, seriesRepo)
+	seriesService := services.NewSeriesService(seriesRepo, matchRepo)
+	matchService := services.NewMatchService(matchRepo, seriesRepo, teamRepo)
 	scorecardService := services.NewScorecardService(scorecardRepo, matchRepo)
 
 	ctx := context.Background()
@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockAudienceRepository is a mock implementation of AudienceRepository
+type MockAudienceRepository struct {
+	mock.Mock
+}
+
+func (m *MockAudienceRepository) Save(ctx context.Context, audience *models.MatchAudience) error {
+	args := m.Called(ctx, audience)
+	return args.Error(0)
+}
+
+func (m *MockAudienceRepository) GetByMatchID(ctx context.Context, matchID string) (*models.MatchAudience, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MatchAudience), args.Error(1)
+}
+
+func TestAudienceService_TracksPeakAndAverageViewers(t *testing.T) {
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{ID: "match-1", Status: models.MatchStatusLive}, nil)
+	audienceRepo := new(MockAudienceRepository)
+	audienceRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	audience := services.NewAudienceService(audienceRepo, matchRepo)
+
+	start := time.Date(2025, 5, 10, 14, 0, 0, 0, time.UTC)
+	audience.RecordViewers(start, map[string]int{"match-1": 2})
+	audience.RecordViewers(start.Add(30*time.Second), map[string]int{"match-1": 4, "series:series-1": 9})
+	audience.RecordViewers(start.Add(60*time.Second), map[string]int{"match-1": 4})
+	audience.RecordViewers(start.Add(120*time.Second), map[string]int{})
+	audience.RecordViewers(start.Add(180*time.Second), map[string]int{"match-1": 1})
+
+	live, err := audience.GetAudience(context.Background(), "match-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, live.CurrentViewers)
+	assert.Equal(t, 4, live.PeakViewers)
+	assert.Equal(t, start.Add(30*time.Second), *live.PeakAt)
+	assert.Equal(t, 2.33, live.AverageViewers) // (2*30 + 4*30 + 4*60 + 0*60) / 180 seconds
+	assert.Equal(t, []models.AudiencePoint{
+		{At: start, Viewers: 4},
+		{At: start.Add(time.Minute), Viewers: 4},
+		{At: start.Add(2 * time.Minute), Viewers: 0},
+		{At: start.Add(3 * time.Minute), Viewers: 1},
+	}, live.Timeline)
+
+	require.NoError(t, audience.MatchCompleted(context.Background(), "match-1"))
+	audienceRepo.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(saved *models.MatchAudience) bool {
+		return saved.MatchID == "match-1" && saved.PeakViewers == 4 && saved.AverageViewers == 2.33 && saved.RecordedAt != nil
+	}))
+}
+
+func TestAudienceService_ServesSavedAudienceOfCompletedMatches(t *testing.T) {
+	recordedAt := time.Date(2025, 5, 10, 17, 0, 0, 0, time.UTC)
+	saved := &models.MatchAudience{MatchID: "match-1", PeakViewers: 43, AverageViewers: 20.5, RecordedAt: &recordedAt}
+
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{ID: "match-1", Status: models.MatchStatusCompleted}, nil)
+	matchRepo.On("GetByID", mock.Anything, "match-2").Return(&models.Match{ID: "match-2", Status: models.MatchStatusCompleted}, nil)
+	audienceRepo := new(MockAudienceRepository)
+	audienceRepo.On("GetByMatchID", mock.Anything, "match-1").Return(saved, nil)
+	audienceRepo.On("GetByMatchID", mock.Anything, "match-2").Return(nil, errors.New("not found"))
+	audienceRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	audience := services.NewAudienceService(audienceRepo, matchRepo)
+
+	// Viewers still on the final scorecard are shown with the saved audience
+	audience.RecordViewers(recordedAt.Add(time.Minute), map[string]int{"match-1": 3})
+	result, err := audience.GetAudience(context.Background(), "match-1")
+	require.NoError(t, err)
+	assert.Equal(t, 43, result.PeakViewers)
+	assert.Equal(t, 3, result.CurrentViewers)
+
+	// A match nobody followed has no audience
+	result, err = audience.GetAudience(context.Background(), "match-2")
+	require.NoError(t, err)
+	assert.Zero(t, result.PeakViewers)
+	assert.Empty(t, result.Timeline)
+
+	require.NoError(t, audience.MatchCompleted(context.Background(), "match-2"))
+	audienceRepo.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(saved *models.MatchAudience) bool {
+		return saved.MatchID == "match-2" && saved.PeakViewers == 0 && saved.RecordedAt != nil
+	}))
+}
@@ -0,0 +1,231 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/handlers"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetAll(ctx context.Context, filters *models.AuditLogFilters) ([]*models.AuditLog, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestAuditService_Record(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	auditService := services.NewAuditService(auditRepo)
+
+	ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
+	ctx = context.WithValue(ctx, "user_email", "scorer@example.com")
+	ctx = context.WithValue(ctx, chimiddleware.RequestIDKey, "req-42")
+
+	series := &models.Series{ID: "series-1", Name: "Summer League"}
+	before := auditService.Snapshot(series)
+	series.Name = "Summer League 2025"
+
+	var recorded *models.AuditLog
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.AuditLog")).Return(nil).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*models.AuditLog)
+	})
+
+	auditService.Record(ctx, models.AuditActionUpdate, models.AuditEntitySeries, series.ID, before, series)
+
+	auditRepo.AssertExpectations(t)
+	assert.Equal(t, "test-user-123", recorded.ActorID)
+	assert.Equal(t, "scorer@example.com", recorded.ActorEmail)
+	assert.Equal(t, "req-42", recorded.RequestID)
+	assert.Equal(t, models.AuditActionUpdate, recorded.Action)
+	assert.Equal(t, models.AuditEntitySeries, recorded.EntityType)
+	assert.Equal(t, "series-1", recorded.EntityID)
+
+	var beforeState, afterState models.Series
+	assert.NoError(t, json.Unmarshal(recorded.Before, &beforeState))
+	assert.NoError(t, json.Unmarshal(recorded.After, &afterState))
+	assert.Equal(t, "Summer League", beforeState.Name)
+	assert.Equal(t, "Summer League 2025", afterState.Name)
+}
+
+func TestAuditService_RecordCreateHasNoBefore(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	auditService := services.NewAuditService(auditRepo)
+
+	var recorded *models.AuditLog
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.AuditLog")).Return(nil).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*models.AuditLog)
+	})
+
+	auditService.Record(context.Background(), models.AuditActionCreate, models.AuditEntityMatch, "match-1", nil, &models.Match{ID: "match-1"})
+
+	assert.Nil(t, recorded.Before)
+	assert.NotNil(t, recorded.After)
+	assert.Empty(t, recorded.ActorID)
+}
+
+func TestAuditService_RecordFailureIsIgnored(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	auditService := services.NewAuditService(auditRepo)
+
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	assert.NotPanics(t, func() {
+		auditService.Record(context.Background(), models.AuditActionDelete, models.AuditEntityTeam, "team-1", &models.Team{ID: "team-1"}, nil)
+	})
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_NilServiceIsNoop(t *testing.T) {
+	var auditService *services.AuditService
+
+	assert.NotPanics(t, func() {
+		assert.Nil(t, auditService.Snapshot(&models.Series{ID: "series-1"}))
+		auditService.Record(context.Background(), models.AuditActionCreate, models.AuditEntitySeries, "series-1", nil, nil)
+	})
+}
+
+func TestAuditService_ListAuditLogs(t *testing.T) {
+	from := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		filters       *models.AuditLogFilters
+		mockSetup     func(*MockAuditRepository)
+		expectedLimit int
+		expectedError string
+	}{
+		{
+			name:    "applies default limit",
+			filters: &models.AuditLogFilters{},
+			mockSetup: func(repo *MockAuditRepository) {
+				repo.On("GetAll", mock.Anything, mock.Anything).Return([]*models.AuditLog{{ID: "log-1"}}, nil)
+			},
+			expectedLimit: 50,
+		},
+		{
+			name:    "caps limit at 100",
+			filters: &models.AuditLogFilters{Limit: 500},
+			mockSetup: func(repo *MockAuditRepository) {
+				repo.On("GetAll", mock.Anything, mock.Anything).Return([]*models.AuditLog{}, nil)
+			},
+			expectedLimit: 100,
+		},
+		{
+			name:          "rejects inverted time range",
+			filters:       &models.AuditLogFilters{From: &from, To: &to},
+			mockSetup:     func(repo *MockAuditRepository) {},
+			expectedError: "'to' must be after 'from'",
+		},
+		{
+			name:    "repository error",
+			filters: &models.AuditLogFilters{Limit: 10},
+			mockSetup: func(repo *MockAuditRepository) {
+				repo.On("GetAll", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedError: "failed to list audit logs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := new(MockAuditRepository)
+			tt.mockSetup(auditRepo)
+			auditService := services.NewAuditService(auditRepo)
+
+			logs, err := auditService.ListAuditLogs(context.Background(), tt.filters)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, logs)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, logs)
+				assert.Equal(t, tt.expectedLimit, tt.filters.Limit)
+			}
+
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditHandler_ListAuditLogs(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(repo *MockAuditRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "lists logs in a time range",
+			query: "?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z",
+			mockSetup: func(repo *MockAuditRepository) {
+				repo.On("GetAll", mock.Anything, mock.Anything).Return([]*models.AuditLog{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects inverted time range",
+			query:          "?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z",
+			mockSetup:      func(repo *MockAuditRepository) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "rejects malformed timestamp",
+			query:          "?from=yesterday",
+			mockSetup:      func(repo *MockAuditRepository) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "repository error",
+			query: "",
+			mockSetup: func(repo *MockAuditRepository) {
+				repo.On("GetAll", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := new(MockAuditRepository)
+			tt.mockSetup(auditRepo)
+			handler := handlers.NewAuditHandler(services.NewAuditService(auditRepo))
+
+			w := httptest.NewRecorder()
+			handler.ListAuditLogs(w, httptest.NewRequest(http.MethodGet, "/api/v1/audit/"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
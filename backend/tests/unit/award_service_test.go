@@ -0,0 +1,345 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockAwardRepository is a mock implementation of AwardRepository
+type MockAwardRepository struct {
+	mock.Mock
+}
+
+func (m *MockAwardRepository) Create(ctx context.Context, award *models.Award) error {
+	args := m.Called(ctx, award)
+	return args.Error(0)
+}
+
+func (m *MockAwardRepository) Update(ctx context.Context, id string, award *models.Award) error {
+	args := m.Called(ctx, id, award)
+	return args.Error(0)
+}
+
+func (m *MockAwardRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAwardRepository) GetByMatchID(ctx context.Context, matchID string) ([]*models.Award, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Award), args.Error(1)
+}
+
+func (m *MockAwardRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Award, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Award), args.Error(1)
+}
+
+func (m *MockAwardRepository) GetByPlayerID(ctx context.Context, playerID string) ([]*models.Award, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Award), args.Error(1)
+}
+
+// awardRow is an award as it reads on the awards list
+type awardRow struct {
+	AwardType models.AwardType
+	PlayerID  string
+	MatchID   string
+	Points    float64
+	Status    models.AwardStatus
+}
+
+// awardSetup registers one completed match that side A won by 50 runs:
+// a1 makes 20 off 6 and is caught by b2 off b1, a2 makes 4* off 6 against b2
+func awardSetup(match *models.Match, series *models.Series, seriesRepo *MockSeriesRepository, matchRepo *MockMatchRepository, playerRepo *MockPlayerRepository, scorecardRepo *MockScorecardRepository) {
+	six, four, one, dot, out := models.RunTypeSix, models.RunTypeFour, models.RunTypeOne, models.RunTypeZero, models.RunTypeWC
+	seriesRepo.On("GetByID", mock.Anything, "series-1").Return(series, nil)
+	for _, id := range []string{"a1", "a2", "b1", "b2"} {
+		playerRepo.On("GetByID", mock.Anything, id).Return(&models.Player{ID: id, Name: "Player " + id}, nil)
+	}
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(match, nil)
+	matchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Match{
+		match,
+		standingsMatch("match-2", 2, models.MatchStatusLive, "team-a", "team-b"),
+	}, nil)
+	scorecardRepo.On("GetScorecard", mock.Anything, "match-1").Return(leaderboardScorecard("match-1",
+		leaderboardOver(1, "a1", "b1", six, six, four, four, dot, out),
+		leaderboardOver(2, "a2", "b2", one, one, one, one, dot, dot),
+	), nil)
+	scorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return([]*models.Innings{
+		standingsInnings(1, models.TeamTypeA, 150, 7, 120),
+		standingsInnings(2, models.TeamTypeB, 100, 10, 110),
+	}, nil)
+}
+
+func TestAwardService_MatchResultChanged(t *testing.T) {
+	confirmedAt := time.Now()
+	confirmed := &models.Award{
+		ID: "award-1", AwardType: models.AwardPlayerOfTheMatch, SeriesID: "series-1", MatchID: "match-1",
+		PlayerID: "b2", ProposedPlayerID: "a1", Status: models.AwardStatusConfirmed, Overridden: true, ConfirmedAt: &confirmedAt,
+	}
+	proposed := &models.Award{
+		ID: "award-1", AwardType: models.AwardPlayerOfTheMatch, SeriesID: "series-1", MatchID: "match-1",
+		PlayerID: "a1", ProposedPlayerID: "a1", Points: 39.6, Status: models.AwardStatusProposed,
+	}
+	// The series awards are refreshed whatever happens to the match award
+	seriesAwards := []awardRow{
+		{models.AwardMostValuable, "a1", "", 39.6, models.AwardStatusProposed},
+		{models.AwardBestBatter, "a1", "", 39.6, models.AwardStatusProposed},
+		{models.AwardBestBowler, "b1", "", 8, models.AwardStatusProposed},
+	}
+
+	tests := []struct {
+		name            string
+		existing        *models.Award
+		counts          bool
+		expectedCreated []awardRow
+		expectedDeleted bool
+	}{
+		{
+			name:   "proposes awards",
+			counts: true,
+			// 33 batting points scaled for the winning side
+			expectedCreated: append([]awardRow{{models.AwardPlayerOfTheMatch, "a1", "match-1", 39.6, models.AwardStatusProposed}}, seriesAwards...),
+		},
+		{
+			name:            "keeps a confirmed award when the match counts",
+			existing:        confirmed,
+			counts:          true,
+			expectedCreated: seriesAwards,
+		},
+		{
+			name:            "keeps a confirmed award when the match stops counting",
+			existing:        confirmed,
+			expectedCreated: seriesAwards,
+		},
+		{
+			name:            "withdraws a proposal when the match stops counting",
+			existing:        proposed,
+			expectedCreated: seriesAwards,
+			expectedDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockAwardRepo := new(MockAwardRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			match := standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-a", "team-b")
+			awardSetup(match, &models.Series{ID: "series-1"}, mockSeriesRepo, mockMatchRepo, mockPlayerRepo, mockScorecardRepo)
+			matchAwards := []*models.Award{}
+			if tt.existing != nil {
+				existing := *tt.existing
+				matchAwards = append(matchAwards, &existing)
+			}
+			mockAwardRepo.On("GetByMatchID", mock.Anything, "match-1").Return(matchAwards, nil)
+			mockAwardRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Award{}, nil)
+			created := []awardRow{}
+			mockAwardRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				award := args.Get(1).(*models.Award)
+				created = append(created, awardRow{award.AwardType, award.PlayerID, award.MatchID, award.Points, award.Status})
+			}).Return(nil)
+			if tt.expectedDeleted {
+				mockAwardRepo.On("Delete", mock.Anything, "award-1").Return(nil)
+			}
+
+			// Create service
+			stats := services.NewStatsService(new(MockPlayerStatsRepository), mockMatchRepo, mockSeriesRepo, mockPlayerRepo, mockScorecardRepo)
+			service := services.NewAwardService(mockAwardRepo, mockSeriesRepo, mockMatchRepo, mockScorecardRepo, stats)
+
+			// Test
+			err := service.MatchResultChanged(context.Background(), match, tt.counts)
+
+			// Assertions
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCreated, created)
+			mockAwardRepo.AssertNotCalled(t, "Update", mock.Anything, "award-1", mock.Anything)
+			if !tt.expectedDeleted {
+				mockAwardRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+
+			// Verify all expectations were met
+			mockAwardRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAwardService_ConfirmMatchAward(t *testing.T) {
+	tests := []struct {
+		name               string
+		matchCreatedBy     string
+		req                *models.ConfirmAwardRequest
+		expectedPlayerID   string
+		expectedPoints     float64
+		expectedOverridden bool
+		expectedError      string
+	}{
+		{
+			name:               "overrides the proposal",
+			matchCreatedBy:     "test-user-123",
+			req:                &models.ConfirmAwardRequest{PlayerID: "b2", Note: "Took the key catch"},
+			expectedPlayerID:   "b2",
+			expectedPoints:     12,
+			expectedOverridden: true,
+		},
+		{
+			name:             "confirms the proposal",
+			matchCreatedBy:   "test-user-123",
+			req:              &models.ConfirmAwardRequest{},
+			expectedPlayerID: "a1",
+			expectedPoints:   39.6,
+		},
+		{
+			name:           "player did not take part",
+			matchCreatedBy: "test-user-123",
+			req:            &models.ConfirmAwardRequest{PlayerID: "z9"},
+			expectedError:  "did not take part",
+		},
+		{
+			name:           "not the match creator",
+			matchCreatedBy: "someone-else",
+			req:            &models.ConfirmAwardRequest{},
+			expectedError:  "access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockAwardRepo := new(MockAwardRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			match := standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-a", "team-b")
+			match.CreatedBy = tt.matchCreatedBy
+			awardSetup(match, &models.Series{ID: "series-1"}, mockSeriesRepo, mockMatchRepo, mockPlayerRepo, mockScorecardRepo)
+			mockAwardRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.Award{{
+				ID: "award-1", AwardType: models.AwardPlayerOfTheMatch, SeriesID: "series-1", MatchID: "match-1",
+				PlayerID: "a1", ProposedPlayerID: "a1", Points: 39.6, Status: models.AwardStatusProposed,
+			}}, nil)
+			mockAwardRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Award{}, nil)
+			if tt.expectedError == "" {
+				mockAwardRepo.On("Update", mock.Anything, "award-1", mock.Anything).Return(nil)
+			}
+
+			// Create service
+			stats := services.NewStatsService(new(MockPlayerStatsRepository), mockMatchRepo, mockSeriesRepo, mockPlayerRepo, mockScorecardRepo)
+			service := services.NewAwardService(mockAwardRepo, mockSeriesRepo, mockMatchRepo, mockScorecardRepo, stats)
+
+			// Test
+			award, err := service.ConfirmMatchAward(userContext(), "match-1", tt.req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, award)
+				mockAwardRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPlayerID, award.PlayerID)
+			assert.Equal(t, "a1", award.ProposedPlayerID)
+			assert.Equal(t, tt.expectedOverridden, award.Overridden)
+			assert.Equal(t, tt.expectedPoints, award.Points)
+			assert.Equal(t, models.AwardStatusConfirmed, award.Status)
+			assert.Equal(t, "test-user-123", award.ConfirmedBy)
+
+			// Verify all expectations were met
+			mockAwardRepo.AssertCalled(t, "Update", mock.Anything, "award-1", award)
+		})
+	}
+}
+
+func TestAwardService_GetMatchAwards(t *testing.T) {
+	formula := models.ImpactFormula{WicketPoints: 50, CatchPoints: 5, WinnerMultiplier: 1}
+
+	tests := []struct {
+		name            string
+		formula         *models.ImpactFormula
+		expectedFormula models.ImpactFormula
+		expectedImpacts []models.PlayerImpact
+	}{
+		{
+			name:            "uses the default formula",
+			expectedFormula: models.DefaultImpactFormula(),
+			expectedImpacts: []models.PlayerImpact{
+				{Rank: 1, PlayerID: "a1", Total: 39.6, Winner: true},
+				{Rank: 2, PlayerID: "b2", Total: 12},
+				{Rank: 3, PlayerID: "b1", Total: 8},
+				{Rank: 4, PlayerID: "a2", Total: 3.6, Winner: true},
+			},
+		},
+		{
+			name:            "uses the series formula",
+			formula:         &formula,
+			expectedFormula: formula,
+			// Batters without points share the rank
+			expectedImpacts: []models.PlayerImpact{
+				{Rank: 1, PlayerID: "b1", Total: 50},
+				{Rank: 2, PlayerID: "b2", Total: 5},
+				{Rank: 3, PlayerID: "a1", Total: 0, Winner: true},
+				{Rank: 3, PlayerID: "a2", Total: 0, Winner: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockAwardRepo := new(MockAwardRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			match := standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-a", "team-b")
+			awardSetup(match, &models.Series{ID: "series-1", ImpactFormula: tt.formula}, mockSeriesRepo, mockMatchRepo, mockPlayerRepo, mockScorecardRepo)
+			mockAwardRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.Award{}, nil)
+
+			// Create service
+			stats := services.NewStatsService(new(MockPlayerStatsRepository), mockMatchRepo, mockSeriesRepo, mockPlayerRepo, mockScorecardRepo)
+			service := services.NewAwardService(mockAwardRepo, mockSeriesRepo, mockMatchRepo, mockScorecardRepo, stats)
+
+			// Test
+			awards, err := service.GetMatchAwards(context.Background(), "match-1")
+
+			// Assertions
+			require.NoError(t, err)
+			assert.Nil(t, awards.PlayerOfTheMatch)
+			assert.Equal(t, tt.expectedFormula, awards.Formula)
+			impacts := []models.PlayerImpact{}
+			for _, impact := range awards.Impacts {
+				impacts = append(impacts, models.PlayerImpact{Rank: impact.Rank, PlayerID: impact.PlayerID, Total: impact.Total, Winner: impact.Winner})
+			}
+			assert.Equal(t, tt.expectedImpacts, impacts)
+		})
+	}
+}
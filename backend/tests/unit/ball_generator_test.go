@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/seed"
+	"spark-park-cricket-backend/internal/utils"
+)
+
+func TestBallGenerator_SameSeedSameSequence(t *testing.T) {
+	a := seed.NewBallGenerator(42, seed.TendenciesBalanced, seed.DefaultExtras)
+	b := seed.NewBallGenerator(42, seed.TendenciesBalanced, seed.DefaultExtras)
+
+	for i := 0; i < 500; i++ {
+		assert.Equal(t, a.Next("match-1", 1), b.Next("match-1", 1), "ball %d differs", i)
+	}
+}
+
+func TestBallGenerator_ProducesValidBalls(t *testing.T) {
+	gen := seed.NewBallGenerator(7, seed.TendenciesAggressive, seed.Extras{WideRate: 0.1, NoBallRate: 0.05, LegByeRate: 0.05, ByeRate: 0.05})
+
+	for i := 0; i < 2000; i++ {
+		ball := gen.Next("match-1", 2)
+		require.NoError(t, utils.ValidateBallEventRequest(ball), "ball %d: %+v", i, ball)
+		assert.Equal(t, 2, ball.InningsNumber)
+		if ball.IsWicket {
+			assert.Equal(t, models.BallTypeGood, ball.BallType)
+			assert.Equal(t, models.RunTypeWC, ball.RunType)
+		}
+	}
+}
+
+func TestBallGenerator_RespectsTendencies(t *testing.T) {
+	onlySixes := seed.Tendencies{Six: 1}
+	gen := seed.NewBallGenerator(1, onlySixes, seed.Extras{})
+
+	for i := 0; i < 100; i++ {
+		ball := gen.Next("match-1", 1)
+		assert.Equal(t, models.BallTypeGood, ball.BallType)
+		assert.Equal(t, models.RunTypeSix, ball.RunType)
+	}
+}
+
+func TestSeedConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *seed.Config)
+		wantErr bool
+	}{
+		{"default config", func(c *seed.Config) {}, false},
+		{"single team", func(c *seed.Config) { c.Teams = 1 }, true},
+		{"no matches", func(c *seed.Config) { c.Matches = 0 }, true},
+		{"too many overs", func(c *seed.Config) { c.Format.Overs = 50 }, true},
+		{"one player", func(c *seed.Config) { c.Format.PlayersPerSide = 1 }, true},
+		{"no tendencies", func(c *seed.Config) { c.Tendencies = seed.Tendencies{} }, true},
+		{"extras always", func(c *seed.Config) { c.Extras = seed.Extras{WideRate: 1} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := seed.DefaultConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
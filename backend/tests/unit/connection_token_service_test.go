@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/config"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+func TestConnectionTokenService_VerifiesItsOwnTokens(t *testing.T) {
+	tokens := services.NewConnectionTokenService("secret", time.Minute)
+
+	token, err := tokens.Issue(&models.User{ID: "user-1", Email: "scorer@example.com"})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), token.ExpiresAt, time.Second)
+
+	user, err := tokens.Verify(token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.ID)
+	assert.Equal(t, "scorer@example.com", user.Email)
+
+	// Another server's secret, a tampered payload and an expired token are refused
+	_, err = services.NewConnectionTokenService("other", time.Minute).Verify(token.Token)
+	assert.Error(t, err)
+	payload, signature, _ := strings.Cut(token.Token, ".")
+	_, err = tokens.Verify(payload[:len(payload)-2] + "xx." + signature)
+	assert.Error(t, err)
+	expired, err := services.NewConnectionTokenService("secret", -time.Second).Issue(&models.User{ID: "user-1"})
+	require.NoError(t, err)
+	_, err = tokens.Verify(expired.Token)
+	assert.Error(t, err)
+}
+
+func TestConfig_RefusesPlaceholderSecrets(t *testing.T) {
+	cfg := &config.Config{SessionSecret: "your-super-secret-session-key-change-this-in-production"}
+	assert.Error(t, cfg.Validate())
+	cfg.SessionSecret = ""
+	assert.Error(t, cfg.Validate())
+
+	cfg.SessionSecret = "a-random-session-secret"
+	assert.NoError(t, cfg.Validate())
+	cfg.WebSocketTokenSecret = cfg.SessionSecret
+	assert.Error(t, cfg.Validate(), "connection tokens need a key of their own")
+}
@@ -0,0 +1,323 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// fixtureRow is a fixture as it reads on a schedule; sides still to be
+// decided are shown by their label
+type fixtureRow struct {
+	MatchNumber int
+	Stage       string
+	Date        string
+	Venue       string
+	TeamA       string
+	TeamB       string
+}
+
+func fixtureTeams(n int) []string {
+	teams := make([]string, n)
+	for i := range teams {
+		teams[i] = fmt.Sprintf("t%d", i+1)
+	}
+	return teams
+}
+
+func TestFixtureService_PreviewFixtures(t *testing.T) {
+	end := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		req              *models.GenerateFixturesRequest
+		expectedFixtures []fixtureRow
+		expectedError    string
+	}{
+		{
+			name: "round robin",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:  models.FixtureFormatRoundRobin,
+				TeamIDs: fixtureTeams(4),
+				Slots:   []string{"09:00", "14:00"},
+				Venues:  []string{"North Field", "South Field"},
+			},
+			// Numbered after existing matches; nobody plays twice a day
+			expectedFixtures: []fixtureRow{
+				{3, "Round 1", "2025-06-07 09:00", "North Field", "t1", "t4"},
+				{4, "Round 1", "2025-06-07 09:00", "South Field", "t2", "t3"},
+				{5, "Round 2", "2025-06-08 09:00", "North Field", "t3", "t1"},
+				{6, "Round 2", "2025-06-08 09:00", "South Field", "t4", "t2"},
+				{7, "Round 3", "2025-06-09 09:00", "North Field", "t1", "t2"},
+				{8, "Round 3", "2025-06-09 09:00", "South Field", "t3", "t4"},
+			},
+		},
+		{
+			name: "respects match days and rest",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:    models.FixtureFormatDoubleRoundRobin,
+				TeamIDs:   fixtureTeams(3),
+				MatchDays: []int{int(time.Saturday), int(time.Sunday)},
+				Slots:     []string{"10:00", "15:00"},
+				RestDays:  1,
+			},
+			// With a rest day, a side playing on Saturday cannot play on Sunday
+			expectedFixtures: []fixtureRow{
+				{3, "Round 1", "2025-06-07 10:00", "", "t2", "t3"},
+				{4, "Round 2", "2025-06-14 10:00", "", "t3", "t1"},
+				{5, "Round 3", "2025-06-21 10:00", "", "t1", "t2"},
+				{6, "Round 4", "2025-06-28 10:00", "", "t3", "t2"},
+				{7, "Round 5", "2025-07-05 10:00", "", "t1", "t3"},
+				{8, "Round 6", "2025-07-12 10:00", "", "t2", "t1"},
+			},
+		},
+		{
+			name: "groups",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:     models.FixtureFormatGroups,
+				TeamIDs:    fixtureTeams(6),
+				GroupCount: 2,
+				Venues:     []string{"North Field", "South Field", "East Field"},
+			},
+			// Snake seeding: 1, 4 and 5 in group A; 2, 3 and 6 in group B
+			expectedFixtures: []fixtureRow{
+				{3, "Group A", "2025-06-07 10:00", "North Field", "t4", "t5"},
+				{4, "Group B", "2025-06-07 10:00", "South Field", "t3", "t6"},
+				{5, "Group A", "2025-06-08 10:00", "North Field", "t5", "t1"},
+				{6, "Group B", "2025-06-08 10:00", "South Field", "t6", "t2"},
+				{7, "Group A", "2025-06-09 10:00", "North Field", "t1", "t4"},
+				{8, "Group B", "2025-06-09 10:00", "South Field", "t2", "t3"},
+			},
+		},
+		{
+			name: "knockout with byes",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:  models.FixtureFormatKnockout,
+				TeamIDs: fixtureTeams(6),
+				Venues:  []string{"North Field", "South Field"},
+			},
+			// Top seeds get byes, and a round starts after the previous one
+			expectedFixtures: []fixtureRow{
+				{3, "Quarter-final", "2025-06-07 10:00", "North Field", "t4", "t5"},
+				{4, "Quarter-final", "2025-06-07 10:00", "South Field", "t3", "t6"},
+				{5, "Semi-final", "2025-06-08 10:00", "North Field", "t1", "Winner of match 3"},
+				{6, "Semi-final", "2025-06-08 10:00", "South Field", "t2", "Winner of match 4"},
+				{7, "Final", "2025-06-09 10:00", "North Field", "Winner of match 5", "Winner of match 6"},
+			},
+		},
+		{
+			name: "knockout winners get their rest days",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:   models.FixtureFormatKnockout,
+				TeamIDs:  fixtureTeams(6),
+				Venues:   []string{"North Field", "South Field"},
+				RestDays: 2,
+			},
+			expectedFixtures: []fixtureRow{
+				{3, "Quarter-final", "2025-06-07 10:00", "North Field", "t4", "t5"},
+				{4, "Quarter-final", "2025-06-07 10:00", "South Field", "t3", "t6"},
+				{5, "Semi-final", "2025-06-10 10:00", "North Field", "t1", "Winner of match 3"},
+				{6, "Semi-final", "2025-06-10 10:00", "South Field", "t2", "Winner of match 4"},
+				{7, "Final", "2025-06-13 10:00", "North Field", "Winner of match 5", "Winner of match 6"},
+			},
+		},
+		{
+			name: "fails when dates run out",
+			ctx:  userContext(),
+			req: &models.GenerateFixturesRequest{
+				Format:  models.FixtureFormatRoundRobin,
+				TeamIDs: fixtureTeams(6),
+				EndDate: &end,
+			},
+			expectedError: "not enough match dates",
+		},
+		{
+			name:          "duplicate team",
+			ctx:           userContext(),
+			req:           &models.GenerateFixturesRequest{Format: models.FixtureFormatRoundRobin, TeamIDs: []string{"t1", "t1"}},
+			expectedError: "listed more than once",
+		},
+		{
+			name:          "too few teams for groups",
+			ctx:           userContext(),
+			req:           &models.GenerateFixturesRequest{Format: models.FixtureFormatGroups, TeamIDs: fixtureTeams(3), GroupCount: 2},
+			expectedError: "cannot fill 2 groups",
+		},
+		{
+			name:          "bad slot",
+			ctx:           userContext(),
+			req:           &models.GenerateFixturesRequest{Format: models.FixtureFormatKnockout, TeamIDs: fixtureTeams(4), Slots: []string{"noon"}},
+			expectedError: "invalid slot",
+		},
+		{
+			name:          "unknown format",
+			ctx:           userContext(),
+			req:           &models.GenerateFixturesRequest{Format: "swiss", TeamIDs: fixtureTeams(4)},
+			expectedError: "unknown fixture format",
+		},
+		{
+			name:          "requires authentication",
+			ctx:           context.Background(),
+			req:           &models.GenerateFixturesRequest{Format: models.FixtureFormatRoundRobin, TeamIDs: fixtureTeams(2)},
+			expectedError: "user authentication required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+
+			// Setup expectations: the series starts on Saturday 7 June 2025
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{
+				ID:        "series-1",
+				StartDate: time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			}, nil)
+			for _, teamID := range tt.req.TeamIDs {
+				mockTeamRepo.On("GetByID", mock.Anything, teamID).Return(&models.Team{ID: teamID, Name: "Team " + teamID}, nil)
+			}
+			mockMatchRepo.On("GetNextMatchNumber", mock.Anything, "series-1").Return(3, nil)
+
+			// Create service
+			matchService := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service := services.NewFixtureService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, matchService)
+
+			// Test
+			schedule, err := service.PreviewFixtures(tt.ctx, "series-1", tt.req)
+
+			// Assertions
+			mockMatchRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, schedule)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, schedule.Created)
+			rows := make([]fixtureRow, len(schedule.Fixtures))
+			for i, fixture := range schedule.Fixtures {
+				rows[i] = fixtureRow{fixture.MatchNumber, fixture.Stage, fixture.Date.Format("2006-01-02 15:04"), fixture.Venue, fixture.TeamALabel, fixture.TeamBLabel}
+				if fixture.TeamAID != "" {
+					rows[i].TeamA = fixture.TeamAID
+					assert.Equal(t, "Team "+fixture.TeamAID, fixture.TeamAName)
+				}
+				if fixture.TeamBID != "" {
+					rows[i].TeamB = fixture.TeamBID
+					assert.Equal(t, "Team "+fixture.TeamBID, fixture.TeamBName)
+				}
+			}
+			assert.Equal(t, tt.expectedFixtures, rows)
+
+			// Verify all expectations were met
+			mockSeriesRepo.AssertExpectations(t)
+			mockMatchRepo.AssertExpectations(t)
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFixtureService_GenerateFixtures(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.GenerateFixturesRequest
+		expectedTeams [][2]string
+		expectedError string
+	}{
+		{
+			name: "creates matches",
+			req: &models.GenerateFixturesRequest{
+				Format:     models.FixtureFormatKnockout,
+				TeamIDs:    fixtureTeams(4),
+				Venues:     []string{"North Field"},
+				Slots:      []string{"09:00", "14:00"},
+				TotalOvers: 10,
+			},
+			// The final waits for the semi-final winners
+			expectedTeams: [][2]string{{"t1", "t4"}, {"t2", "t3"}, {"", ""}},
+		},
+		{
+			name:          "creates nothing for an invalid request",
+			req:           &models.GenerateFixturesRequest{Format: models.FixtureFormatRoundRobin, TeamIDs: []string{"t1", "t1"}},
+			expectedError: "listed more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{
+				ID:        "series-1",
+				StartDate: time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			}, nil)
+			for _, teamID := range tt.req.TeamIDs {
+				mockTeamRepo.On("GetByID", mock.Anything, teamID).Return(&models.Team{ID: teamID, Name: "Team " + teamID}, nil)
+			}
+			mockMatchRepo.On("GetNextMatchNumber", mock.Anything, "series-1").Return(3, nil)
+			var created []*models.Match
+			if tt.expectedError == "" {
+				mockMatchRepo.On("ExistsBySeriesAndMatchNumber", mock.Anything, "series-1", mock.Anything).Return(false, nil)
+				mockMatchRepo.On("GetByDateRange", mock.Anything, mock.Anything, mock.Anything).Return([]*models.Match{}, nil)
+				mockMatchRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					match := args.Get(1).(*models.Match)
+					match.ID = fmt.Sprintf("match-%d", match.MatchNumber)
+					created = append(created, match)
+				}).Return(nil)
+			}
+
+			// Create service
+			matchService := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service := services.NewFixtureService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, matchService)
+
+			// Test
+			schedule, err := service.GenerateFixtures(userContext(), "series-1", tt.req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, schedule)
+				mockMatchRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, schedule.Created)
+			require.Len(t, created, len(tt.expectedTeams))
+			for i, match := range created {
+				assert.Equal(t, tt.expectedTeams[i], [2]string{match.TeamAID, match.TeamBID})
+				assert.Equal(t, 3+i, match.MatchNumber)
+				assert.Equal(t, "North Field", match.Venue)
+				assert.Equal(t, 10, match.TotalOvers)
+				assert.Equal(t, models.DefaultFixturePlayerCount, match.TeamAPlayerCount)
+				assert.Equal(t, schedule.Fixtures[i].Date, match.Date)
+				assert.Equal(t, match.ID, schedule.Fixtures[i].MatchID)
+			}
+
+			// Verify all expectations were met
+			mockMatchRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/graphql"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/pkg/events"
+	"spark-park-cricket-backend/tests/unit/mocks"
+)
+
+// transportFrame is a graphql-transport-ws frame as a client decodes it
+type transportFrame struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// dialGraphQLWS serves a GraphQL handler fed by scoringEvents and connects
+// a graphql-transport-ws client to it
+func dialGraphQLWS(t *testing.T, scoringEvents *events.ScoringEvents) *gorillaws.Conn {
+	handler := graphql.NewGraphQLHandler(&mocks.MockScorecardService{}, nil)
+	handler.SetScoringEvents(scoringEvents)
+	server := httptest.NewServer(http.HandlerFunc(handler.ServeWS))
+	t.Cleanup(server.Close)
+
+	dialer := gorillaws.Dialer{Subprotocols: []string{graphql.GraphQLTransportWSProtocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func exchange(t *testing.T, conn *gorillaws.Conn, frame transportFrame) transportFrame {
+	t.Helper()
+	require.NoError(t, conn.WriteJSON(frame))
+	return readTransportFrame(t, conn)
+}
+
+func readTransportFrame(t *testing.T, conn *gorillaws.Conn) transportFrame {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var frame transportFrame
+	require.NoError(t, conn.ReadJSON(&frame))
+	return frame
+}
+
+func subscribeFrame(id, query string) transportFrame {
+	payload, _ := json.Marshal(map[string]string{"query": query})
+	return transportFrame{ID: id, Type: "subscribe", Payload: payload}
+}
+
+func TestGraphQLTransportWS_StreamsSelectedFields(t *testing.T) {
+	scoringEvents := events.NewScoringEvents()
+	conn := dialGraphQLWS(t, scoringEvents)
+
+	assert.Equal(t, "connection_ack", exchange(t, conn, transportFrame{Type: "connection_init"}).Type)
+	assert.Equal(t, "pong", exchange(t, conn, transportFrame{Type: "ping"}).Type)
+
+	require.NoError(t, conn.WriteJSON(subscribeFrame("1", `subscription {
+		wicketFallen(match_id: "match-1") { ball_number wicket_type score { runs wickets } }
+	}`)))
+	require.Eventually(t, func() bool { return scoringEvents.Subscribers() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	innings := &models.Innings{InningsNumber: 1, BattingTeam: models.TeamTypeA, TotalRuns: 12, TotalWickets: 1, TotalBalls: 4}
+	ball := &models.ScorecardBall{BallNumber: 4, RunType: models.RunTypeWC, IsWicket: true, WicketType: "bowled"}
+	scoringEvents.Publish(&models.ScoringEvent{Type: models.ScoringEventBallAdded, MatchID: "match-1", Ball: ball, Innings: innings})
+	scoringEvents.Publish(&models.ScoringEvent{Type: models.ScoringEventWicketFallen, MatchID: "match-2", Ball: ball, Innings: innings})
+	scoringEvents.Publish(&models.ScoringEvent{Type: models.ScoringEventWicketFallen, MatchID: "match-1", Ball: ball, Innings: innings})
+
+	next := readTransportFrame(t, conn)
+	assert.Equal(t, "next", next.Type)
+	assert.Equal(t, "1", next.ID)
+	assert.JSONEq(t, `{"data": {"wicketFallen": {"ball_number": 4, "wicket_type": "bowled", "score": {"runs": 12, "wickets": 1}}}}`, string(next.Payload))
+
+	require.NoError(t, conn.WriteJSON(transportFrame{ID: "1", Type: "complete"}))
+	assert.Eventually(t, func() bool { return scoringEvents.Subscribers() == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestGraphQLTransportWS_FollowsProtocolRules(t *testing.T) {
+	early := dialGraphQLWS(t, events.NewScoringEvents())
+	require.NoError(t, early.WriteJSON(subscribeFrame("1", `subscription { ballAdded(match_id: "m") { runs } }`)))
+	_, _, err := early.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, 4401), "subscribing before connection_init is unauthorized: %v", err)
+
+	conn := dialGraphQLWS(t, events.NewScoringEvents())
+	exchange(t, conn, transportFrame{Type: "connection_init"})
+
+	invalid := exchange(t, conn, subscribeFrame("2", `subscription { ballAdded(match_id: "m") { no_such_field } }`))
+	assert.Equal(t, "error", invalid.Type)
+	assert.Equal(t, "2", invalid.ID)
+	assert.Contains(t, string(invalid.Payload), "no_such_field")
+
+	require.NoError(t, conn.WriteJSON(transportFrame{Type: "connection_init"}))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, 4429), "a second connection_init closes the connection: %v", err)
+}
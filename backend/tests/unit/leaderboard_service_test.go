@@ -0,0 +1,321 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/cache"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// memoryCache is an in-memory CacheInterface that stores values as JSON, like Redis
+type memoryCache struct {
+	values map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string][]byte{}}
+}
+
+func (c *memoryCache) Set(key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.values[key] = data
+	return nil
+}
+
+func (c *memoryCache) Get(key string, dest interface{}) error {
+	data, ok := c.values[key]
+	if !ok {
+		return errors.New("cache miss")
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *memoryCache) Delete(key string) error {
+	delete(c.values, key)
+	return nil
+}
+
+func (c *memoryCache) Exists(key string) (bool, error) {
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+func (c *memoryCache) SetNX(key string, value interface{}, ttl time.Duration) (bool, error) {
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+	return true, c.Set(key, value, ttl)
+}
+
+func (c *memoryCache) Increment(key string) (int64, error) { return 0, nil }
+func (c *memoryCache) Expire(key string, ttl time.Duration) error {
+	return nil
+}
+func (c *memoryCache) Close() error                          { return nil }
+func (c *memoryCache) HealthCheck() error                    { return nil }
+func (c *memoryCache) GetSeriesKey(seriesID string) string   { return "series:" + seriesID }
+func (c *memoryCache) GetMatchKey(matchID string) string     { return "match:" + matchID }
+func (c *memoryCache) GetScorecardKey(matchID string) string { return "scorecard:" + matchID }
+func (c *memoryCache) GetScorecardVersionKey(matchID string) string {
+	return "scorecard:version:" + matchID
+}
+func (c *memoryCache) GetMatchesBySeriesKey(seriesID string) string {
+	return "matches:series:" + seriesID
+}
+
+// leaderboardOver is an over by one bowler to one batter, one ball per run type
+func leaderboardOver(number int, batter, bowler string, runs ...models.RunType) models.OverSummary {
+	over := models.OverSummary{OverNumber: number}
+	for i, runType := range runs {
+		ball := models.BallSummary{BallNumber: i + 1, BallType: models.BallTypeGood, RunType: runType, BatterID: batter, BowlerID: bowler}
+		if runs, err := strconv.Atoi(string(runType)); err == nil {
+			ball.Runs = runs
+		}
+		if runType == models.RunTypeWC {
+			ball.IsWicket = true
+			ball.WicketType = "caught"
+			ball.FielderID = "b2"
+		}
+		over.Balls = append(over.Balls, ball)
+	}
+	return over
+}
+
+// leaderboardScorecard is one innings of side A against bowlers b1 and b2
+func leaderboardScorecard(matchID string, overs ...models.OverSummary) *models.ScorecardResponse {
+	return &models.ScorecardResponse{
+		MatchID: matchID,
+		Innings: []models.InningsSummary{{InningsNumber: 1, BattingTeam: models.TeamTypeA, Overs: overs}},
+	}
+}
+
+// leaderboardRow is a leaderboard entry as it reads on the board
+type leaderboardRow struct {
+	Rank     int
+	PlayerID string
+	Display  string
+	MatchID  string
+	Matches  int
+}
+
+// leaderboardRows returns each leaderboard's entries by category
+func leaderboardRows(leaderboards *models.SeriesLeaderboards) map[models.LeaderboardCategory][]leaderboardRow {
+	rows := map[models.LeaderboardCategory][]leaderboardRow{}
+	for _, board := range leaderboards.Leaderboards {
+		for _, entry := range board.Entries {
+			rows[board.Category] = append(rows[board.Category], leaderboardRow{entry.Rank, entry.PlayerID, entry.Display, entry.MatchID, entry.Matches})
+		}
+	}
+	return rows
+}
+
+func TestLeaderboardService_GetLeaderboards(t *testing.T) {
+	six, four, one, dot, out := models.RunTypeSix, models.RunTypeFour, models.RunTypeOne, models.RunTypeZero, models.RunTypeWC
+
+	tests := []struct {
+		name           string
+		seriesID       string
+		query          *models.LeaderboardQuery
+		scorecards     map[string]*models.ScorecardResponse
+		expectedLimit  int
+		expectedBoards map[models.LeaderboardCategory][]leaderboardRow
+		expectedError  string
+	}{
+		{
+			name:     "ranks series players",
+			seriesID: "series-1",
+			query:    &models.LeaderboardQuery{MinBallsFaced: 10, MinBallsBowled: 6},
+			scorecards: map[string]*models.ScorecardResponse{
+				// a1 makes 20 off 6 and is caught off b1; a2 makes 4* off 6 against b2
+				"match-1": leaderboardScorecard("match-1",
+					leaderboardOver(1, "a1", "b1", six, six, four, four, dot, out),
+					leaderboardOver(2, "a2", "b2", one, one, one, one, dot, dot),
+				),
+				// Live matches count: a2 makes 24* off 6 against b1
+				"match-2": leaderboardScorecard("match-2",
+					leaderboardOver(1, "a2", "b1", six, six, six, six, dot, dot),
+				),
+			},
+			expectedLimit: models.DefaultLeaderboardLimit,
+			expectedBoards: map[models.LeaderboardCategory][]leaderboardRow{
+				models.LeaderboardMostRuns:     {{1, "a2", "28", "", 2}, {2, "a1", "20", "", 1}},
+				models.LeaderboardMostWickets:  {{1, "b1", "1", "", 2}},
+				models.LeaderboardHighestScore: {{1, "a2", "24*", "match-2", 0}, {2, "a1", "20", "match-1", 0}, {3, "a2", "4*", "match-1", 0}},
+				models.LeaderboardBestBowling:  {{1, "b1", "1/20", "match-1", 0}},
+				models.LeaderboardMostSixes:    {{1, "a2", "4", "", 2}, {2, "a1", "2", "", 1}},
+				// a1 has not faced enough balls
+				models.LeaderboardBestStrikeRate: {{1, "a2", "233.33", "", 2}},
+				models.LeaderboardBestEconomy:    {{1, "b2", "4.00", "", 1}, {2, "b1", "22.00", "", 2}},
+				models.LeaderboardMostCatches:    {{1, "b2", "1", "", 1}},
+			},
+		},
+		{
+			name:     "filters and limits",
+			seriesID: "series-1",
+			query:    &models.LeaderboardQuery{Category: models.LeaderboardMostRuns, Limit: 1},
+			scorecards: map[string]*models.ScorecardResponse{
+				"match-1": leaderboardScorecard("match-1",
+					leaderboardOver(1, "a1", "b1", four),
+					leaderboardOver(2, "a2", "b2", four),
+					leaderboardOver(3, "b1", "a1", one),
+				),
+				"match-2": leaderboardScorecard("match-2"),
+			},
+			expectedLimit: 1,
+			// Players level at the cut-off share the rank
+			expectedBoards: map[models.LeaderboardCategory][]leaderboardRow{
+				models.LeaderboardMostRuns: {{1, "a1", "4", "", 1}, {1, "a2", "4", "", 1}},
+			},
+		},
+		{
+			name:          "unknown category",
+			seriesID:      "series-1",
+			query:         &models.LeaderboardQuery{Category: "most_ducks"},
+			expectedError: "unknown leaderboard category",
+		},
+		{
+			name:          "limit too high",
+			seriesID:      "series-1",
+			query:         &models.LeaderboardQuery{Limit: models.MaxLeaderboardLimit + 1},
+			expectedError: "limit must be between 1 and 50",
+		},
+		{
+			name:          "series not found",
+			seriesID:      "missing",
+			expectedError: "series not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1"}, nil)
+			mockSeriesRepo.On("GetByID", mock.Anything, "missing").Return(nil, errors.New("not found"))
+			for _, id := range []string{"a1", "a2", "b1", "b2"} {
+				mockPlayerRepo.On("GetByID", mock.Anything, id).Return(&models.Player{ID: id, Name: "Player " + id}, nil)
+			}
+			mockMatchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Match{
+				standingsMatch("match-2", 2, models.MatchStatusLive, "team-a", "team-b"),
+				standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-a", "team-b"),
+				standingsMatch("match-3", 3, models.MatchStatusCancelled, "team-a", "team-b"),
+			}, nil)
+			for matchID, scorecard := range tt.scorecards {
+				mockScorecardRepo.On("GetScorecard", mock.Anything, matchID).Return(scorecard, nil)
+			}
+
+			// Create service
+			stats := services.NewStatsService(new(MockPlayerStatsRepository), mockMatchRepo, mockSeriesRepo, mockPlayerRepo, mockScorecardRepo)
+			service := services.NewLeaderboardService(mockSeriesRepo, mockMatchRepo, stats)
+
+			// Test
+			leaderboards, err := service.GetLeaderboards(context.Background(), tt.seriesID, tt.query)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, leaderboards)
+				mockScorecardRepo.AssertNotCalled(t, "GetScorecard", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, leaderboards.Leaderboards, len(tt.expectedBoards))
+			assert.Equal(t, tt.expectedLimit, leaderboards.Limit)
+			assert.Equal(t, tt.expectedBoards, leaderboardRows(leaderboards))
+
+			// Verify all expectations were met; cancelled matches are not read
+			mockScorecardRepo.AssertExpectations(t)
+			mockScorecardRepo.AssertNotCalled(t, "GetScorecard", mock.Anything, "match-3")
+		})
+	}
+}
+
+func TestLeaderboardService_BallRecorded(t *testing.T) {
+	tests := []struct {
+		name             string
+		ballMatchID      string
+		expectedMostRuns []leaderboardRow
+	}{
+		{
+			// A six in the live match moves a2 to the top
+			name:             "invalidates the cached match",
+			ballMatchID:      "match-2",
+			expectedMostRuns: []leaderboardRow{{1, "a2", "8", "", 1}, {2, "a1", "4", "", 1}},
+		},
+		{
+			// The six is not read until a ball is recorded in match 2
+			name:             "keeps other matches cached",
+			ballMatchID:      "match-1",
+			expectedMostRuns: []leaderboardRow{{1, "a1", "4", "", 1}, {2, "a2", "2", "", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1"}, nil)
+			for _, id := range []string{"a1", "a2", "b1"} {
+				mockPlayerRepo.On("GetByID", mock.Anything, id).Return(&models.Player{ID: id, Name: "Player " + id}, nil)
+			}
+			mockMatchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Match{
+				standingsMatch("match-2", 2, models.MatchStatusLive, "team-a", "team-b"),
+				standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-a", "team-b"),
+			}, nil)
+			mockScorecardRepo.On("GetScorecard", mock.Anything, "match-1").Return(leaderboardScorecard("match-1",
+				leaderboardOver(1, "a1", "b1", models.RunTypeFour),
+			), nil)
+			mockScorecardRepo.On("GetScorecard", mock.Anything, "match-2").Return(leaderboardScorecard("match-2",
+				leaderboardOver(1, "a2", "b1", models.RunTypeTwo),
+			), nil).Once()
+			mockScorecardRepo.On("GetScorecard", mock.Anything, "match-2").Return(leaderboardScorecard("match-2",
+				leaderboardOver(1, "a2", "b1", models.RunTypeTwo, models.RunTypeSix),
+			), nil)
+
+			// Create service
+			stats := services.NewStatsService(new(MockPlayerStatsRepository), mockMatchRepo, mockSeriesRepo, mockPlayerRepo, mockScorecardRepo)
+			service := services.NewLeaderboardService(mockSeriesRepo, mockMatchRepo, stats)
+			service.SetCacheManager(cache.NewCacheManager(newMemoryCache(), true))
+
+			// Test: the second read is served from the cache
+			_, err := service.GetLeaderboards(context.Background(), "series-1", nil)
+			require.NoError(t, err)
+			_, err = service.GetLeaderboards(context.Background(), "series-1", nil)
+			require.NoError(t, err)
+			mockScorecardRepo.AssertNumberOfCalls(t, "GetScorecard", 2)
+
+			err = service.BallRecorded(context.Background(), standingsMatch(tt.ballMatchID, 2, models.MatchStatusLive, "team-a", "team-b"))
+			require.NoError(t, err)
+			leaderboards, err := service.GetLeaderboards(context.Background(), "series-1", nil)
+
+			// Assertions
+			require.NoError(t, err)
+			mockScorecardRepo.AssertNumberOfCalls(t, "GetScorecard", 3)
+			assert.Equal(t, tt.expectedMostRuns, leaderboardRows(leaderboards)[models.LeaderboardMostRuns])
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -136,6 +137,16 @@ func (m *MockMatchRepository) GetBySeriesID(ctx context.Context, seriesID string
 	return args.Get(0).([]*models.Match), args.Error(1)
 }
 
+func (m *MockMatchRepository) GetByVenueID(ctx context.Context, venueID string) ([]*models.Match, error) {
+	args := m.Called(ctx, venueID)
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*models.Match, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
 func (m *MockMatchRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -151,6 +162,31 @@ func (m *MockMatchRepository) ExistsBySeriesAndMatchNumber(ctx context.Context,
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *MockMatchRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetDeletedByID(ctx context.Context, id string) (*models.Match, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetDeletedBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
+	args := m.Called(ctx, seriesID)
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func TestShouldCompleteMatch_TargetReached(t *testing.T) {
 	// Setup
 	mockScorecardRepo := &MockScorecardRepository{}
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/handlers"
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+// sseEvent is one Server-Sent Event as a client parses it; comment-only
+// blocks such as heartbeats have just a comment
+type sseEvent struct {
+	id, event, data, comment string
+}
+
+// readSSEEvent reads the next blank-line terminated block of a stream
+func readSSEEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, ":"):
+			event.comment = strings.TrimSpace(line[1:])
+		case strings.HasPrefix(line, "id: "):
+			event.id = line[len("id: "):]
+		case strings.HasPrefix(line, "event: "):
+			event.event = line[len("event: "):]
+		case strings.HasPrefix(line, "data: "):
+			event.data = line[len("data: "):]
+		}
+	}
+}
+
+func newMatchEventsServer(t *testing.T, hub *websocket.Hub) *httptest.Server {
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{ID: "match-1", Status: models.MatchStatusLive}, nil)
+	matchRepo.On("GetByID", mock.Anything, "missing").Return(nil, errors.New("not found"))
+
+	handler := handlers.NewMatchEventsHandler(hub, services.NewMatchService(matchRepo, nil, nil))
+	handler.SetHeartbeatInterval(100 * time.Millisecond)
+	router := chi.NewRouter()
+	router.Get("/matches/{id}/events", handler.StreamMatchEvents)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMatchEventsHandler_StreamsRoomMessagesWithResume(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{})
+	server := newMatchEventsServer(t, hub)
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: services.ScorecardDeltaMessageType, RoomID: "match-1", Data: "ball 1"})
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: services.WicketFallenMessageType, RoomID: "match-1", Data: "ball 1"})
+
+	// A scoreboard that saw the first event reconnects
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/matches/match-1/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readSSEEvent(t, reader) // retry hint
+
+	missed := readSSEEvent(t, reader)
+	assert.Equal(t, "2", missed.id)
+	assert.Equal(t, services.WicketFallenMessageType, missed.event)
+	assert.Contains(t, missed.data, `"seq":2`)
+
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: services.MatchCompletedMessageType, RoomID: "match-1"})
+	live := readSSEEvent(t, reader)
+	assert.Equal(t, "3", live.id)
+	assert.Equal(t, services.MatchCompletedMessageType, live.event)
+
+	assert.Equal(t, "heartbeat", readSSEEvent(t, reader).comment)
+}
+
+func TestMatchEventsHandler_RejectsUnknownMatch(t *testing.T) {
+	server := newMatchEventsServer(t, newRunningHub(websocket.Limits{}))
+
+	resp, err := http.Get(server.URL + "/matches/missing/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -156,7 +156,7 @@ func TestMatchService_CreateMatch(t *testing.T) {
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
 			result, err := service.CreateMatch(ctx, tt.request)
 
 			if tt.expectedError != "" {
@@ -242,7 +242,7 @@ func TestMatchService_GetMatch(t *testing.T) {
 			mockSeriesRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockMatchRepo)
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
 			result, err := service.GetMatch(context.Background(), tt.matchID)
 
 			if tt.expectedError != "" {
@@ -377,7 +377,7 @@ func TestMatchService_ListMatches(t *testing.T) {
 			mockSeriesRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockMatchRepo)
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
 			result, err := service.ListMatches(context.Background(), tt.filters)
 
 			if tt.expectedError != "" {
@@ -520,7 +520,7 @@ func TestMatchService_UpdateMatch(t *testing.T) {
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
 			result, err := service.UpdateMatch(ctx, tt.matchID, tt.request)
 
 			if tt.expectedError != "" {
@@ -543,6 +543,7 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 		matchID       string
 		mockSetup     func(*MockMatchRepository)
 		expectedError string
+		expectAudit   bool
 	}{
 		{
 			name:    "successful match deletion",
@@ -562,8 +563,9 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 					CreatedBy:        "test-user-123",
 				}
 				mockRepo.On("GetByID", mock.Anything, "match-1").Return(existingMatch, nil)
-				mockRepo.On("Delete", mock.Anything, "match-1").Return(nil)
+				mockRepo.On("SoftDelete", mock.Anything, "match-1", mock.AnythingOfType("time.Time")).Return(nil)
 			},
+			expectAudit: true,
 		},
 		{
 			name:    "empty match ID",
@@ -599,7 +601,7 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 					CreatedBy:        "test-user-123",
 				}
 				mockRepo.On("GetByID", mock.Anything, "match-1").Return(existingMatch, nil)
-				// No Delete mock setup since the method should return early
+				// No SoftDelete mock setup since the method should return early
 			},
 			expectedError: "cannot delete a live match",
 		},
@@ -621,7 +623,7 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 					CreatedBy:        "test-user-123",
 				}
 				mockRepo.On("GetByID", mock.Anything, "match-1").Return(existingMatch, nil)
-				mockRepo.On("Delete", mock.Anything, "match-1").Return(errors.New("database error"))
+				mockRepo.On("SoftDelete", mock.Anything, "match-1", mock.AnythingOfType("time.Time")).Return(errors.New("database error"))
 			},
 			expectedError: "failed to delete match",
 		},
@@ -631,12 +633,21 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockMatchRepo := new(MockMatchRepository)
 			mockSeriesRepo := new(MockSeriesRepository)
+			auditRepo := new(MockAuditRepository)
 			tt.mockSetup(mockMatchRepo)
 
+			var recorded *models.AuditLog
+			if tt.expectAudit {
+				auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.AuditLog")).Return(nil).Run(func(args mock.Arguments) {
+					recorded = args.Get(1).(*models.AuditLog)
+				})
+			}
+
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
+			service.SetAuditService(services.NewAuditService(auditRepo))
 			err := service.DeleteMatch(ctx, tt.matchID)
 
 			if tt.expectedError != "" {
@@ -647,6 +658,16 @@ func TestMatchService_DeleteMatch(t *testing.T) {
 			}
 
 			mockMatchRepo.AssertExpectations(t)
+			mockMatchRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			auditRepo.AssertExpectations(t)
+			if tt.expectAudit {
+				assert.Equal(t, models.AuditActionDelete, recorded.Action)
+				assert.Equal(t, models.AuditEntityMatch, recorded.EntityType)
+				assert.Equal(t, "match-1", recorded.EntityID)
+				assert.NotEmpty(t, recorded.Before)
+			} else {
+				auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -742,7 +763,7 @@ func TestMatchService_GetMatchesBySeries(t *testing.T) {
 			mockSeriesRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockMatchRepo)
 
-			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo)
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, new(MockTeamRepository))
 			result, err := service.GetMatchesBySeries(context.Background(), tt.seriesID)
 
 			if tt.expectedError != "" {
@@ -0,0 +1,395 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockMatchSquadRepository is a mock implementation of MatchSquadRepository
+type MockMatchSquadRepository struct {
+	mock.Mock
+}
+
+func (m *MockMatchSquadRepository) GetByMatchID(ctx context.Context, matchID string) ([]*models.MatchSquadPlayer, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MatchSquadPlayer), args.Error(1)
+}
+
+func (m *MockMatchSquadRepository) GetByMatchAndTeam(ctx context.Context, matchID string, team models.TeamType) ([]*models.MatchSquadPlayer, error) {
+	args := m.Called(ctx, matchID, team)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MatchSquadPlayer), args.Error(1)
+}
+
+func (m *MockMatchSquadRepository) ReplaceSquad(ctx context.Context, matchID string, team models.TeamType, players []*models.MatchSquadPlayer) error {
+	args := m.Called(ctx, matchID, team, players)
+	return args.Error(0)
+}
+
+func (m *MockMatchSquadRepository) Update(ctx context.Context, id string, player *models.MatchSquadPlayer) error {
+	args := m.Called(ctx, id, player)
+	return args.Error(0)
+}
+
+// MockScorecardRepository is a mock implementation of ScorecardRepository
+type MockScorecardRepository struct {
+	mock.Mock
+}
+
+func (m *MockScorecardRepository) CreateInnings(ctx context.Context, innings *models.Innings) error {
+	args := m.Called(ctx, innings)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) GetInningsByMatchID(ctx context.Context, matchID string) ([]*models.Innings, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Innings), args.Error(1)
+}
+
+func (m *MockScorecardRepository) GetInningsByMatchAndNumber(ctx context.Context, matchID string, inningsNumber int) (*models.Innings, error) {
+	args := m.Called(ctx, matchID, inningsNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Innings), args.Error(1)
+}
+
+func (m *MockScorecardRepository) UpdateInnings(ctx context.Context, innings *models.Innings) error {
+	args := m.Called(ctx, innings)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) CompleteInnings(ctx context.Context, inningsID string) error {
+	args := m.Called(ctx, inningsID)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) CreateOver(ctx context.Context, over *models.ScorecardOver) error {
+	args := m.Called(ctx, over)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) GetOverByInningsAndNumber(ctx context.Context, inningsID string, overNumber int) (*models.ScorecardOver, error) {
+	args := m.Called(ctx, inningsID, overNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScorecardOver), args.Error(1)
+}
+
+func (m *MockScorecardRepository) GetCurrentOver(ctx context.Context, inningsID string) (*models.ScorecardOver, error) {
+	args := m.Called(ctx, inningsID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScorecardOver), args.Error(1)
+}
+
+func (m *MockScorecardRepository) GetOversByInnings(ctx context.Context, inningsID string) ([]*models.ScorecardOver, error) {
+	args := m.Called(ctx, inningsID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ScorecardOver), args.Error(1)
+}
+
+func (m *MockScorecardRepository) UpdateOver(ctx context.Context, over *models.ScorecardOver) error {
+	args := m.Called(ctx, over)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) CompleteOver(ctx context.Context, overID string) error {
+	args := m.Called(ctx, overID)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) CreateBall(ctx context.Context, ball *models.ScorecardBall) error {
+	args := m.Called(ctx, ball)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) GetBallsByOver(ctx context.Context, overID string) ([]*models.ScorecardBall, error) {
+	args := m.Called(ctx, overID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ScorecardBall), args.Error(1)
+}
+
+func (m *MockScorecardRepository) GetLastBall(ctx context.Context, overID string) (*models.ScorecardBall, error) {
+	args := m.Called(ctx, overID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScorecardBall), args.Error(1)
+}
+
+func (m *MockScorecardRepository) DeleteBall(ctx context.Context, ballID string) error {
+	args := m.Called(ctx, ballID)
+	return args.Error(0)
+}
+
+func (m *MockScorecardRepository) GetScorecard(ctx context.Context, matchID string) (*models.ScorecardResponse, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScorecardResponse), args.Error(1)
+}
+
+func (m *MockScorecardRepository) StartScoring(ctx context.Context, matchID string) error {
+	args := m.Called(ctx, matchID)
+	return args.Error(0)
+}
+
+func TestMatchSquadService_SetSquad(t *testing.T) {
+	tests := []struct {
+		name                 string
+		innings              []*models.Innings
+		players              []models.SquadPlayerRequest
+		expectedPlayers      []string
+		expectedBattingOrder []int
+		expectedPlayingCount int
+		expectedError        string
+	}{
+		{
+			name: "assigns batting order and player count",
+			players: []models.SquadPlayerRequest{
+				{PlayerID: "p4", IsSubstitute: true},
+				{PlayerID: "p2", IsCaptain: true},
+				{PlayerID: "p1", IsWicketkeeper: true},
+				{PlayerID: "p3"},
+			},
+			expectedPlayers:      []string{"p2", "p1", "p3", "p4"},
+			expectedBattingOrder: []int{1, 2, 3, 0},
+			expectedPlayingCount: 3,
+		},
+		{
+			name:          "player not on roster",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true}, {PlayerID: "stranger"}},
+			expectedError: "not on the team roster",
+		},
+		{
+			name:          "two captains",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true}, {PlayerID: "p2", IsCaptain: true}},
+			expectedError: "exactly one captain",
+		},
+		{
+			name:          "no wicketkeeper",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true}, {PlayerID: "p2"}},
+			expectedError: "exactly one wicketkeeper",
+		},
+		{
+			name:          "partial batting order",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true, BattingOrder: 1}, {PlayerID: "p2"}},
+			expectedError: "all playing players or none",
+		},
+		{
+			name:          "duplicate batting position",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true, BattingOrder: 1}, {PlayerID: "p2", BattingOrder: 1}},
+			expectedError: "each position from 1 to 2 once",
+		},
+		{
+			name:          "duplicate player",
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true}, {PlayerID: "p1"}},
+			expectedError: "selected more than once",
+		},
+		{
+			name:          "locked after first ball",
+			innings:       []*models.Innings{{InningsNumber: 1, TotalBalls: 1}},
+			players:       []models.SquadPlayerRequest{{PlayerID: "p1", IsCaptain: true, IsWicketkeeper: true}, {PlayerID: "p2"}},
+			expectedError: "locked once the first ball is bowled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockMatchRepo := new(MockMatchRepository)
+			mockSquadRepo := new(MockMatchSquadRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockMatchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+				ID:               "match-1",
+				Status:           models.MatchStatusLive,
+				TeamAID:          "team-a",
+				TeamBID:          "team-b",
+				TeamAPlayerCount: 11,
+				TeamBPlayerCount: 11,
+				CreatedBy:        "test-user-123",
+			}, nil)
+			innings := tt.innings
+			if innings == nil {
+				innings = []*models.Innings{}
+			}
+			mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return(innings, nil)
+			mockPlayerRepo.On("GetByTeamID", mock.Anything, "team-a").Return([]*models.Player{
+				{ID: "p1", Name: "Asha", TeamID: "team-a"},
+				{ID: "p2", Name: "Bilal", TeamID: "team-a"},
+				{ID: "p3", Name: "Chen", TeamID: "team-a"},
+				{ID: "p4", Name: "Dev", TeamID: "team-a"},
+			}, nil)
+			if tt.expectedError == "" {
+				mockSquadRepo.On("GetByMatchAndTeam", mock.Anything, "match-1", models.TeamTypeA).Return([]*models.MatchSquadPlayer{}, nil)
+				mockSquadRepo.On("ReplaceSquad", mock.Anything, "match-1", models.TeamTypeA, mock.Anything).Return(nil)
+				mockMatchRepo.On("Update", mock.Anything, "match-1", mock.MatchedBy(func(match *models.Match) bool {
+					return match.TeamAPlayerCount == tt.expectedPlayingCount && match.TeamBPlayerCount == 11
+				})).Return(nil)
+			}
+
+			// Create service
+			service := services.NewMatchSquadService(mockMatchRepo, mockSquadRepo, mockPlayerRepo, mockScorecardRepo)
+
+			// Test
+			squad, err := service.SetSquad(userContext(), "match-1", models.TeamTypeA, &models.SetMatchSquadRequest{Players: tt.players})
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, squad)
+				mockSquadRepo.AssertNotCalled(t, "ReplaceSquad", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPlayingCount, squad.PlayingCount)
+			assert.Equal(t, "team-a", squad.TeamID)
+			players, battingOrder := []string{}, []int{}
+			for _, player := range squad.Players {
+				players = append(players, player.PlayerID)
+				battingOrder = append(battingOrder, player.BattingOrder)
+			}
+			assert.Equal(t, tt.expectedPlayers, players)
+			assert.Equal(t, tt.expectedBattingOrder, battingOrder)
+			assert.Equal(t, "Bilal", squad.Players[0].PlayerName)
+
+			// Verify all expectations were met
+			mockMatchRepo.AssertExpectations(t)
+			mockSquadRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMatchSquadService_Substitute(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.SquadSubstitutionRequest
+		expectedError string
+	}{
+		{
+			name: "swaps playing player",
+			req:  &models.SquadSubstitutionRequest{PlayerOutID: "p2", PlayerInID: "p3", Reason: "concussion"},
+		},
+		{
+			name:          "requires reason",
+			req:           &models.SquadSubstitutionRequest{PlayerOutID: "p2", PlayerInID: "p3"},
+			expectedError: "reason is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockMatchRepo := new(MockMatchRepository)
+			mockSquadRepo := new(MockMatchSquadRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockMatchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+				ID:        "match-1",
+				Status:    models.MatchStatusLive,
+				TeamAID:   "team-a",
+				TeamBID:   "team-b",
+				CreatedBy: "test-user-123",
+			}, nil)
+			mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return([]*models.Innings{{InningsNumber: 1, TotalBalls: 14}}, nil)
+			mockSquadRepo.On("GetByMatchAndTeam", mock.Anything, "match-1", models.TeamTypeA).Return([]*models.MatchSquadPlayer{
+				{ID: "s1", PlayerID: "p1", Team: models.TeamTypeA, BattingOrder: 1, IsCaptain: true},
+				{ID: "s2", PlayerID: "p2", Team: models.TeamTypeA, BattingOrder: 2, IsWicketkeeper: true},
+				{ID: "s3", PlayerID: "p3", Team: models.TeamTypeA, IsSubstitute: true},
+			}, nil)
+			if tt.expectedError == "" {
+				mockSquadRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+			}
+
+			// Create service
+			service := services.NewMatchSquadService(mockMatchRepo, mockSquadRepo, new(MockPlayerRepository), mockScorecardRepo)
+
+			// Test
+			squad, err := service.Substitute(userContext(), "match-1", models.TeamTypeA, tt.req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, squad)
+				mockSquadRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, squad.PlayingCount)
+			assert.Equal(t, "p3", squad.Players[1].PlayerID)
+			assert.Equal(t, 2, squad.Players[1].BattingOrder)
+			assert.True(t, squad.Players[1].IsWicketkeeper)
+			assert.True(t, squad.Players[2].IsSubstitute)
+
+			// Verify all expectations were met
+			mockSquadRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScorecardService_StartScoringRequiresBothSquads(t *testing.T) {
+	matchRepo := new(MockMatchRepository)
+	scorecardRepo := new(MockScorecardRepository)
+	squadRepo := new(MockMatchSquadRepository)
+	scorecardService := services.NewScorecardService(scorecardRepo, matchRepo)
+	scorecardService.SetMatchSquadRepository(squadRepo)
+
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+		ID:         "match-1",
+		Status:     models.MatchStatusLive,
+		TossWinner: models.TeamTypeA,
+		CreatedBy:  "test-user-123",
+	}, nil)
+	scorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return([]*models.Innings{}, nil)
+	squadRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.MatchSquadPlayer{
+		{PlayerID: "p1", Team: models.TeamTypeA},
+	}, nil)
+
+	err := scorecardService.StartScoring(userContext(), "match-1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "both teams must declare a squad")
+	scorecardRepo.AssertNotCalled(t, "CreateInnings", mock.Anything, mock.Anything)
+}
+
+func TestScorecardService_ShouldCompleteMatchUsesBattingSideXI(t *testing.T) {
+	scorecardRepo := new(MockScorecardRepository)
+	scorecardService := services.NewScorecardService(scorecardRepo, new(MockMatchRepository))
+
+	scorecardRepo.On("GetInningsByMatchAndNumber", mock.Anything, "match-1", 1).Return(&models.Innings{TotalRuns: 80}, nil)
+	match := &models.Match{ID: "match-1", TeamAPlayerCount: 11, TeamBPlayerCount: 3, TotalOvers: 20}
+	secondInnings := &models.Innings{InningsNumber: 2, BattingTeam: models.TeamTypeB, TotalRuns: 30, TotalWickets: 2, TotalOvers: 5.0}
+
+	complete, reason := scorecardService.ShouldCompleteMatch(context.Background(), "match-1", secondInnings, match)
+
+	assert.True(t, complete)
+	assert.Contains(t, reason, "all wickets lost: 2/2")
+}
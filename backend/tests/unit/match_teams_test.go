@@ -0,0 +1,330 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	supabasego "github.com/supabase-community/supabase-go"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/supabase"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockMatchRepository is a mock implementation of MatchRepository
+type MockMatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockMatchRepository) Create(ctx context.Context, match *models.Match) error {
+	args := m.Called(ctx, match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetByID(ctx context.Context, id string) (*models.Match, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetAll(ctx context.Context, filters *models.MatchFilters) ([]*models.Match, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) Update(ctx context.Context, id string, match *models.Match) error {
+	args := m.Called(ctx, id, match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetByVenueID(ctx context.Context, venueID string) ([]*models.Match, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*models.Match, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetNextMatchNumber(ctx context.Context, seriesID string) (int, error) {
+	args := m.Called(ctx, seriesID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMatchRepository) ExistsBySeriesAndMatchNumber(ctx context.Context, seriesID string, matchNumber int) (bool, error) {
+	args := m.Called(ctx, seriesID, matchNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMatchRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetDeletedByID(ctx context.Context, id string) (*models.Match, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetDeletedBySeriesID(ctx context.Context, seriesID string) ([]*models.Match, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+// MockSeriesRepository is a mock implementation of SeriesRepository
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) Create(ctx context.Context, series *models.Series) error {
+	args := m.Called(ctx, series)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetByID(ctx context.Context, id string) (*models.Series, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetAll(ctx context.Context, filters *models.SeriesFilters) ([]*models.Series, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) Update(ctx context.Context, id string, series *models.Series) error {
+	args := m.Called(ctx, id, series)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSeriesRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetDeletedByID(ctx context.Context, id string) (*models.Series, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func newMatchTeamsRequest(teamAID, teamBID string) *models.CreateMatchRequest {
+	return &models.CreateMatchRequest{
+		SeriesID:         "series-1",
+		TeamAID:          teamAID,
+		TeamBID:          teamBID,
+		Date:             time.Now(),
+		TeamAPlayerCount: 11,
+		TeamBPlayerCount: 11,
+		TotalOvers:       20,
+		TossWinner:       models.TeamTypeA,
+		TossType:         models.TossTypeHeads,
+	}
+}
+
+func TestMatchService_CreateMatchLinksTeams(t *testing.T) {
+	matchRepo := new(MockMatchRepository)
+	seriesRepo := new(MockSeriesRepository)
+	teamRepo := new(MockTeamRepository)
+	matchService := services.NewMatchService(matchRepo, seriesRepo, teamRepo)
+
+	seriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-a").Return(&models.Team{ID: "team-a", Name: "Riverside XI"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-b").Return(&models.Team{ID: "team-b", Name: "Hilltop XI"}, nil)
+	matchRepo.On("GetNextMatchNumber", mock.Anything, "series-1").Return(1, nil)
+	matchRepo.On("GetByDateRange", mock.Anything, mock.Anything, mock.Anything).Return([]*models.Match{}, nil)
+	matchRepo.On("Create", mock.Anything, mock.MatchedBy(func(match *models.Match) bool {
+		return match.TeamAID == "team-a" && match.TeamBID == "team-b"
+	})).Return(nil)
+
+	match, err := matchService.CreateMatch(userContext(), newMatchTeamsRequest("team-a", "team-b"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", match.TeamAID)
+	assert.Equal(t, "team-b", match.TeamBID)
+	matchRepo.AssertExpectations(t)
+}
+
+func TestMatchService_CreateMatchRejectsInvalidTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		teamAID string
+		teamBID string
+		wantErr string
+	}{
+		{"only one team", "team-a", "", "must be provided together"},
+		{"same team twice", "team-a", "team-a", "must be different teams"},
+		{"unknown team", "team-a", "missing", "team B not found"},
+		{"other organization", "team-a", "team-other-org", "different organization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchRepo := new(MockMatchRepository)
+			seriesRepo := new(MockSeriesRepository)
+			teamRepo := new(MockTeamRepository)
+			matchService := services.NewMatchService(matchRepo, seriesRepo, teamRepo)
+
+			seriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", OrganizationID: "org-1"}, nil)
+			teamRepo.On("GetByID", mock.Anything, "team-a").Return(&models.Team{ID: "team-a", OrganizationID: "org-1"}, nil)
+			teamRepo.On("GetByID", mock.Anything, "team-other-org").Return(&models.Team{ID: "team-other-org", OrganizationID: "org-2"}, nil)
+			teamRepo.On("GetByID", mock.Anything, "missing").Return(nil, assert.AnError)
+
+			match, err := matchService.CreateMatch(userContext(), newMatchTeamsRequest(tt.teamAID, tt.teamBID))
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, match)
+			matchRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMatchService_UpdateMatchChangesTeams(t *testing.T) {
+	matchRepo := new(MockMatchRepository)
+	seriesRepo := new(MockSeriesRepository)
+	teamRepo := new(MockTeamRepository)
+	matchService := services.NewMatchService(matchRepo, seriesRepo, teamRepo)
+
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+		ID: "match-1", SeriesID: "series-1", TeamAID: "team-a", TeamBID: "team-b", CreatedBy: "test-user-123",
+	}, nil)
+	seriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-a").Return(&models.Team{ID: "team-a"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-c").Return(&models.Team{ID: "team-c"}, nil)
+	matchRepo.On("GetByDateRange", mock.Anything, mock.Anything, mock.Anything).Return([]*models.Match{}, nil)
+	matchRepo.On("Update", mock.Anything, "match-1", mock.MatchedBy(func(match *models.Match) bool {
+		return match.TeamAID == "team-a" && match.TeamBID == "team-c"
+	})).Return(nil)
+
+	teamBID := "team-c"
+	match, err := matchService.UpdateMatch(userContext(), "match-1", &models.UpdateMatchRequest{TeamBID: &teamBID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "team-c", match.TeamBID)
+	matchRepo.AssertExpectations(t)
+}
+
+func TestMatchRepository_UpdateWritesTeams(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client, err := supabasego.NewClient(server.URL, "test-key", nil)
+	assert.NoError(t, err)
+	matchRepo := supabase.NewMatchRepository(client)
+
+	err = matchRepo.Update(context.Background(), "match-1", &models.Match{ID: "match-1", TeamAID: "team-c"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "team-c", body["team_a_id"])
+	assert.Contains(t, body, "team_b_id")
+	assert.Nil(t, body["team_b_id"])
+}
+
+func TestMatchRepository_GetAllOrganizationScope(t *testing.T) {
+	var scope string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope = r.URL.Query().Get("or")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client, err := supabasego.NewClient(server.URL, "test-key", nil)
+	assert.NoError(t, err)
+	matchRepo := supabase.NewMatchRepository(client)
+
+	_, err = matchRepo.GetAll(context.Background(), &models.MatchFilters{OrganizationIDs: []string{"org-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "(organization_id.in.(org-1),organization_id.is.null)", scope)
+
+	_, err = matchRepo.GetAll(context.Background(), &models.MatchFilters{OrganizationIDs: []string{"org-1"}, OnlyOrganizations: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "(organization_id.in.(org-1))", scope)
+}
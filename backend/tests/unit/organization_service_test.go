@@ -0,0 +1,264 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockOrganizationRepository is a mock implementation of OrganizationRepository
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	args := m.Called(ctx, org)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetAll(ctx context.Context, filters *models.OrganizationFilters) ([]*models.Organization, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetPublic(ctx context.Context) ([]*models.Organization, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Update(ctx context.Context, id string, org *models.Organization) error {
+	args := m.Called(ctx, id, org)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetMember(ctx context.Context, organizationID, userID string) (*models.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembers(ctx context.Context, organizationID string) ([]*models.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembershipsByUser(ctx context.Context, userID string) ([]*models.OrganizationMember, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateMember(ctx context.Context, member *models.OrganizationMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID string) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	orgRepo := new(MockOrganizationRepository)
+	orgService := services.NewOrganizationService(orgRepo)
+	ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
+
+	orgRepo.On("GetBySlug", mock.Anything, "riverside-park-cc").Return(nil, errors.New("organization not found"))
+	orgRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Organization")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Organization).ID = "org-1"
+	})
+	orgRepo.On("AddMember", mock.Anything, mock.MatchedBy(func(m *models.OrganizationMember) bool {
+		return m.OrganizationID == "org-1" && m.UserID == "test-user-123" && m.Role == models.OrganizationRoleOwner
+	})).Return(nil)
+
+	org, err := orgService.CreateOrganization(ctx, &models.CreateOrganizationRequest{Name: "Riverside Park CC!"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "riverside-park-cc", org.Slug)
+	assert.Equal(t, "test-user-123", org.CreatedBy)
+	orgRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_CreateOrganizationSlugTaken(t *testing.T) {
+	orgRepo := new(MockOrganizationRepository)
+	orgService := services.NewOrganizationService(orgRepo)
+	ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
+
+	orgRepo.On("GetBySlug", mock.Anything, "riverside").Return(&models.Organization{ID: "org-1"}, nil)
+
+	org, err := orgService.CreateOrganization(ctx, &models.CreateOrganizationRequest{Name: "Riverside"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already taken")
+	assert.Nil(t, org)
+	orgRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrganizationService_VisibleOrganizationIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mockSetup func(*MockOrganizationRepository)
+		expected  []string
+	}{
+		{
+			name: "anonymous sees public organizations only",
+			ctx:  context.Background(),
+			mockSetup: func(repo *MockOrganizationRepository) {
+				repo.On("GetPublic", mock.Anything).Return([]*models.Organization{{ID: "org-public"}}, nil)
+			},
+			expected: []string{"org-public"},
+		},
+		{
+			name: "member sees memberships and public organizations without duplicates",
+			ctx:  context.WithValue(context.Background(), "user_id", "test-user-123"),
+			mockSetup: func(repo *MockOrganizationRepository) {
+				repo.On("GetMembershipsByUser", mock.Anything, "test-user-123").Return([]*models.OrganizationMember{
+					{OrganizationID: "org-private"},
+					{OrganizationID: "org-public"},
+				}, nil)
+				repo.On("GetPublic", mock.Anything).Return([]*models.Organization{{ID: "org-public"}}, nil)
+			},
+			expected: []string{"org-private", "org-public"},
+		},
+		{
+			name:      "admin is unscoped",
+			ctx:       context.WithValue(context.Background(), "is_admin", true),
+			mockSetup: func(repo *MockOrganizationRepository) {},
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgRepo := new(MockOrganizationRepository)
+			tt.mockSetup(orgRepo)
+			orgService := services.NewOrganizationService(orgRepo)
+
+			ids, err := orgService.VisibleOrganizationIDs(tt.ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+			orgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationService_ScopeFilterRejectsHiddenOrganization(t *testing.T) {
+	orgRepo := new(MockOrganizationRepository)
+	orgService := services.NewOrganizationService(orgRepo)
+
+	orgRepo.On("GetPublic", mock.Anything).Return([]*models.Organization{{ID: "org-public"}}, nil)
+
+	ids, err := orgService.ScopeFilter(context.Background(), []string{"org-private"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "organization not found")
+	assert.Nil(t, ids)
+}
+
+func TestOrganizationService_CheckCanContribute(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          models.OrganizationRole
+		expectedError string
+	}{
+		{name: "owner can contribute", role: models.OrganizationRoleOwner},
+		{name: "member can contribute", role: models.OrganizationRoleMember},
+		{name: "viewer cannot contribute", role: models.OrganizationRoleViewer, expectedError: "access denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgRepo := new(MockOrganizationRepository)
+			orgRepo.On("GetMember", mock.Anything, "org-1", "test-user-123").Return(&models.OrganizationMember{Role: tt.role}, nil)
+			orgService := services.NewOrganizationService(orgRepo)
+			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
+
+			err := orgService.CheckCanContribute(ctx, "org-1")
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrganizationService_RemoveLastOwner(t *testing.T) {
+	orgRepo := new(MockOrganizationRepository)
+	orgService := services.NewOrganizationService(orgRepo)
+	ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
+
+	owner := &models.OrganizationMember{OrganizationID: "org-1", UserID: "test-user-123", Role: models.OrganizationRoleOwner}
+	orgRepo.On("GetMember", mock.Anything, "org-1", "test-user-123").Return(owner, nil)
+	orgRepo.On("GetMembers", mock.Anything, "org-1").Return([]*models.OrganizationMember{owner}, nil)
+
+	err := orgService.RemoveMember(ctx, "org-1", "test-user-123")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one owner")
+	orgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrganizationService_NilServiceIsUnscoped(t *testing.T) {
+	var orgService *services.OrganizationService
+
+	ids, err := orgService.VisibleOrganizationIDs(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, ids)
+	assert.NoError(t, orgService.CheckVisible(context.Background(), "org-1"))
+	assert.NoError(t, orgService.CheckCanContribute(context.Background(), "org-1"))
+}
@@ -0,0 +1,170 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+func TestPlayerService_ClaimedPlayerCanEditOwnProfile(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", UserID: "test-user-123"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "team-owner"}, nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "player-2", Name: "Bilal", PlayerProfile: models.PlayerProfile{JerseyNumber: 7}}}, nil)
+	playerRepo.On("Update", mock.Anything, "player-1", mock.MatchedBy(func(player *models.Player) bool {
+		return player.Role == models.PlayingRoleAllRounder && player.JerseyNumber == 18 && player.Nickname == "Ash"
+	})).Return(nil)
+
+	role := models.PlayingRoleAllRounder
+	jersey := 18
+	nickname := " Ash "
+	player, err := playerService.UpdatePlayer(userContext(), "player-1", &models.UpdatePlayerRequest{
+		Role:         &role,
+		JerseyNumber: &jersey,
+		Nickname:     &nickname,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ash", player.Nickname)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_UpdatePlayerRejectsInvalidProfile(t *testing.T) {
+	badRole := models.PlayingRole("captain")
+	takenJersey := 7
+	avatar := "ftp://example.com/a.png"
+	otherTeam := "team-2"
+
+	tests := []struct {
+		name    string
+		req     *models.UpdatePlayerRequest
+		wantErr string
+	}{
+		{"unknown role", &models.UpdatePlayerRequest{Role: &badRole}, "invalid role"},
+		{"jersey already worn", &models.UpdatePlayerRequest{JerseyNumber: &takenJersey}, "jersey number 7 is already worn by Bilal"},
+		{"avatar not http", &models.UpdatePlayerRequest{AvatarURL: &avatar}, "avatar URL"},
+		{"claimed player moving teams", &models.UpdatePlayerRequest{TeamID: &otherTeam}, "access denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamRepo := new(MockTeamRepository)
+			playerRepo := new(MockPlayerRepository)
+			playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+			playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", UserID: "test-user-123"}, nil)
+			teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "team-owner"}, nil)
+			playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "player-2", Name: "Bilal", PlayerProfile: models.PlayerProfile{JerseyNumber: 7}}}, nil)
+
+			player, err := playerService.UpdatePlayer(userContext(), "player-1", tt.req)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, player)
+			playerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPlayerService_UpdatePlayerRequiresOwnerOrClaimant(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", UserID: "someone-else"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "team-owner"}, nil)
+
+	name := "Asha Rao"
+	player, err := playerService.UpdatePlayer(userContext(), "player-1", &models.UpdatePlayerRequest{Name: &name})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only the player or the team owner")
+	assert.Nil(t, player)
+}
+
+func TestPlayerService_ClaimPlayerAwaitsApproval(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1"}, nil)
+	playerRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(filters *models.PlayerFilters) bool {
+		return *filters.TeamID == "team-1" && *filters.UserID == "test-user-123"
+	})).Return([]*models.Player{}, nil)
+	playerRepo.On("Update", mock.Anything, "player-1", mock.MatchedBy(func(player *models.Player) bool {
+		return player.UserID == "" && player.ClaimRequestedBy == "test-user-123"
+	})).Return(nil)
+
+	player, err := playerService.ClaimPlayer(userContext(), "player-1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, player.UserID)
+	assert.Equal(t, "test-user-123", player.ClaimRequestedBy)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_PendingClaimCannotEditProfile(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", ClaimRequestedBy: "test-user-123"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "team-owner"}, nil)
+
+	name := "Asha Rao"
+	player, err := playerService.UpdatePlayer(userContext(), "player-1", &models.UpdatePlayerRequest{Name: &name})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only the player or the team owner")
+	assert.Nil(t, player)
+
+	_, err = playerService.ApproveClaim(userContext(), "player-1")
+	assert.Contains(t, err.Error(), "access denied")
+	playerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlayerService_ApproveClaimLinksAccount(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", ClaimRequestedBy: "asha-account"}, nil)
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123"}, nil)
+	playerRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(filters *models.PlayerFilters) bool {
+		return *filters.TeamID == "team-1" && *filters.UserID == "asha-account"
+	})).Return([]*models.Player{}, nil)
+	playerRepo.On("Update", mock.Anything, "player-1", mock.MatchedBy(func(player *models.Player) bool {
+		return player.UserID == "asha-account" && player.ClaimRequestedBy == ""
+	})).Return(nil)
+
+	player, err := playerService.ApproveClaim(userContext(), "player-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "asha-account", player.UserID)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_ClaimPlayerRejectsClaimedProfile(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+
+	playerRepo.On("GetByID", mock.Anything, "player-1").Return(&models.Player{ID: "player-1", Name: "Asha", TeamID: "team-1", UserID: "someone-else"}, nil)
+
+	player, err := playerService.ClaimPlayer(userContext(), "player-1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already been claimed")
+	assert.Nil(t, player)
+
+	_, err = playerService.ClaimPlayer(context.Background(), "player-1")
+	assert.Contains(t, err.Error(), "authentication required")
+}
@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+// newRoomAccessService returns a room access service over one public
+// organization where member-1 is a member and admin-1 an admin
+func newRoomAccessService(matches ...*models.Match) (*services.RoomAccessService, *MockSeriesRepository) {
+	matchRepo := new(MockMatchRepository)
+	for _, match := range matches {
+		matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+	}
+	matchRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	orgRepo := new(MockOrganizationRepository)
+	orgRepo.On("GetPublic", mock.Anything).Return([]*models.Organization{{ID: "org-1", IsPublic: true}}, nil)
+	orgRepo.On("GetMembershipsByUser", mock.Anything, mock.Anything).Return([]*models.OrganizationMember{}, nil)
+	orgRepo.On("GetMember", mock.Anything, "org-1", "member-1").Return(&models.OrganizationMember{OrganizationID: "org-1", UserID: "member-1", Role: models.OrganizationRoleMember}, nil)
+	orgRepo.On("GetMember", mock.Anything, "org-1", "admin-1").Return(&models.OrganizationMember{OrganizationID: "org-1", UserID: "admin-1", Role: models.OrganizationRoleAdmin}, nil)
+	orgRepo.On("GetMember", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not a member"))
+
+	seriesRepo := new(MockSeriesRepository)
+	return services.NewRoomAccessService(matchRepo, seriesRepo, services.NewOrganizationService(orgRepo)), seriesRepo
+}
+
+func asUser(userID string) context.Context {
+	return context.WithValue(context.Background(), "user_id", userID)
+}
+
+func TestRoomAccessService_AppliesMatchPolicies(t *testing.T) {
+	access, _ := newRoomAccessService(
+		&models.Match{ID: "public", OrganizationID: "org-1"},
+		&models.Match{ID: "members", OrganizationID: "org-1", Access: models.MatchAccessSeriesMembers, CreatedBy: "scorer-1"},
+		&models.Match{ID: "invite", OrganizationID: "org-1", Access: models.MatchAccessInviteOnly, CreatedBy: "scorer-1", InvitedUserIDs: []string{"guest-1"}},
+	)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		roomID string
+		want   error
+	}{
+		{"public match is open to anyone", context.Background(), "public", nil},
+		{"members only needs a signed-in user", context.Background(), "members", websocket.ErrUnauthorized},
+		{"organization member follows members only", asUser("member-1"), "members", nil},
+		{"creator follows members only", asUser("scorer-1"), "members", nil},
+		{"outsider cannot follow members only", asUser("guest-1"), "members", websocket.ErrForbidden},
+		{"invitee follows invite only", asUser("guest-1"), "invite", nil},
+		{"organization admin follows invite only", asUser("admin-1"), "invite", nil},
+		{"plain member cannot follow invite only", asUser("member-1"), "invite", websocket.ErrForbidden},
+		{"unknown match is not found", asUser("member-1"), "missing", websocket.ErrRoomNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, access.CheckRoomAccess(tt.ctx, tt.roomID))
+		})
+	}
+}
+
+func TestRoomAccessService_SeriesMembersWithoutOrganizationMeansSeriesCreator(t *testing.T) {
+	access, seriesRepo := newRoomAccessService(&models.Match{ID: "match-1", SeriesID: "series-1", Access: models.MatchAccessSeriesMembers})
+	seriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", CreatedBy: "organiser-1"}, nil)
+
+	assert.NoError(t, access.CheckRoomAccess(asUser("organiser-1"), "match-1"))
+	assert.Equal(t, websocket.ErrForbidden, access.CheckRoomAccess(asUser("member-1"), "match-1"))
+	assert.NoError(t, access.CheckRoomAccess(context.Background(), websocket.SeriesRoomID("series-1")))
+}
+
+func TestScorecardService_ReadsFollowMatchAccessPolicy(t *testing.T) {
+	access, _ := newRoomAccessService(
+		&models.Match{ID: "private", OrganizationID: "org-private"},
+		&models.Match{ID: "invite", OrganizationID: "org-1", Access: models.MatchAccessInviteOnly, CreatedBy: "scorer-1", InvitedUserIDs: []string{"guest-1"}},
+	)
+	scorecardRepo := new(MockScorecardRepository)
+	scorecardRepo.On("GetScorecard", mock.Anything, "invite").Return(&models.ScorecardResponse{MatchID: "invite"}, nil)
+	scorecardService := services.NewScorecardService(scorecardRepo, new(MockMatchRepository))
+	scorecardService.SetAccessPolicy(access.CheckRoomAccess)
+
+	_, err := scorecardService.GetScorecard(context.Background(), "private")
+	assert.Equal(t, websocket.ErrRoomNotFound, err)
+	_, err = scorecardService.GetCurrentOver(asUser("member-1"), "private", 1)
+	assert.Equal(t, websocket.ErrRoomNotFound, err)
+
+	_, err = scorecardService.GetScorecard(context.Background(), "invite")
+	assert.Equal(t, websocket.ErrUnauthorized, err)
+	_, err = scorecardService.GetScorecard(asUser("member-1"), "invite")
+	assert.Equal(t, websocket.ErrForbidden, err)
+
+	scorecard, err := scorecardService.GetScorecard(asUser("guest-1"), "invite")
+	assert.NoError(t, err)
+	assert.Equal(t, "invite", scorecard.MatchID)
+	scorecardRepo.AssertNotCalled(t, "GetInningsByMatchAndNumber", mock.Anything, mock.Anything, mock.Anything)
+}
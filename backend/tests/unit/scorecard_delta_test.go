@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+func TestScorecardServiceWithGraphQL_BroadcastsVersionedDeltas(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{})
+	conn := dialHub(t, hub, "match-1")
+
+	matchRepo := new(MockMatchRepository)
+	scorecardRepo := new(MockScorecardRepository)
+	service := services.NewScorecardServiceWithGraphQL(scorecardRepo, matchRepo, hub)
+
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+		ID:         "match-1",
+		Status:     models.MatchStatusLive,
+		TossWinner: models.TeamTypeA,
+		TotalOvers: 20,
+		CreatedBy:  "test-user-123",
+	}, nil)
+	scorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return([]*models.Innings{}, nil)
+	scorecardRepo.On("CreateInnings", mock.Anything, mock.Anything).Return(nil)
+	require.NoError(t, service.StartScoring(userContext(), "match-1"))
+
+	started := readDelta(t, conn)
+	assert.Equal(t, int64(1), started.Version)
+	assert.Equal(t, int64(0), started.BaseVersion)
+	assert.Empty(t, started.Cause)
+	assert.Equal(t, string(models.MatchStatusLive), started.MatchStatus)
+	require.Len(t, started.Innings, 1)
+	assert.Equal(t, models.TeamTypeA, started.Innings[0].BattingTeam)
+
+	// Undoing a boundary sends the removed ball and the totals without it
+	innings := &models.Innings{ID: "innings-1", MatchID: "match-1", InningsNumber: 1, BattingTeam: models.TeamTypeA,
+		TotalRuns: 4, TotalBalls: 1, TotalOvers: 0.1, Status: string(models.InningsStatusInProgress)}
+	over := &models.ScorecardOver{ID: "over-1", InningsID: "innings-1", OverNumber: 1, TotalRuns: 4, TotalBalls: 1,
+		Status: string(models.OverStatusInProgress)}
+	scorecardRepo.On("GetInningsByMatchAndNumber", mock.Anything, "match-1", 1).Return(innings, nil)
+	scorecardRepo.On("GetCurrentOver", mock.Anything, "innings-1").Return(over, nil)
+	scorecardRepo.On("GetBallsByOver", mock.Anything, "over-1").Return([]*models.ScorecardBall{
+		{ID: "ball-1", OverID: "over-1", BallNumber: 1, BallType: models.BallTypeGood, RunType: models.RunTypeFour, Runs: 4},
+	}, nil)
+	scorecardRepo.On("DeleteBall", mock.Anything, "ball-1").Return(nil)
+	scorecardRepo.On("UpdateOver", mock.Anything, over).Return(nil)
+	scorecardRepo.On("GetOversByInnings", mock.Anything, "innings-1").Return([]*models.ScorecardOver{over}, nil)
+	scorecardRepo.On("UpdateInnings", mock.Anything, innings).Return(nil)
+	require.NoError(t, service.UndoBall(userContext(), "match-1", 1))
+
+	undone := readDelta(t, conn)
+	assert.Equal(t, int64(2), undone.Version)
+	assert.Equal(t, int64(1), undone.BaseVersion, "each delta builds on the one before")
+	assert.Equal(t, models.ScoringEventBallUndone, undone.Cause)
+	require.NotNil(t, undone.Ball)
+	assert.Equal(t, 1, undone.Ball.OverNumber)
+	assert.Equal(t, 1, undone.Ball.BallNumber)
+	require.NotNil(t, undone.Over)
+	assert.Equal(t, 0, undone.Over.TotalRuns)
+	assert.Equal(t, 0, undone.Over.TotalBalls)
+	assert.Equal(t, &models.CurrentScore{}, undone.CurrentScore)
+}
+
+// readDelta reads the next room message and decodes its scorecard delta
+func readDelta(t *testing.T, conn *gorillaws.Conn) models.ScorecardDelta {
+	t.Helper()
+	frame := readFrame(t, conn)
+	require.Equal(t, services.ScorecardDeltaMessageType, frame.Type)
+
+	var delta models.ScorecardDelta
+	require.NoError(t, json.Unmarshal(frame.Data, &delta))
+	return delta
+}
@@ -52,6 +52,29 @@ func (m *MockSeriesRepository) Count(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockSeriesRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetDeletedByID(ctx context.Context, id string) (*models.Series, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func TestSeriesService_CreateSeries(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -103,7 +126,7 @@ func TestSeriesService_CreateSeries(t *testing.T) {
 			mockRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockRepo)
 
-			service := services.NewSeriesService(mockRepo)
+			service := services.NewSeriesService(mockRepo, new(MockMatchRepository))
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
@@ -171,7 +194,7 @@ func TestSeriesService_GetSeries(t *testing.T) {
 			mockRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockRepo)
 
-			service := services.NewSeriesService(mockRepo)
+			service := services.NewSeriesService(mockRepo, new(MockMatchRepository))
 			ctx := context.Background()
 
 			result, err := service.GetSeries(ctx, tt.seriesID)
@@ -244,7 +267,7 @@ func TestSeriesService_ListSeries(t *testing.T) {
 			mockRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockRepo)
 
-			service := services.NewSeriesService(mockRepo)
+			service := services.NewSeriesService(mockRepo, new(MockMatchRepository))
 			ctx := context.Background()
 
 			result, err := service.ListSeries(ctx, tt.filters)
@@ -355,7 +378,7 @@ func TestSeriesService_UpdateSeries(t *testing.T) {
 			mockRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockRepo)
 
-			service := services.NewSeriesService(mockRepo)
+			service := services.NewSeriesService(mockRepo, new(MockMatchRepository))
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
@@ -389,7 +412,7 @@ func TestSeriesService_DeleteSeries(t *testing.T) {
 			mockSetup: func(mockRepo *MockSeriesRepository) {
 				series := &models.Series{ID: "test-series-id", Name: "Test Series", CreatedBy: "test-user-123"}
 				mockRepo.On("GetByID", mock.Anything, "test-series-id").Return(series, nil)
-				mockRepo.On("Delete", mock.Anything, "test-series-id").Return(nil)
+				mockRepo.On("SoftDelete", mock.Anything, "test-series-id", mock.AnythingOfType("time.Time")).Return(nil)
 			},
 			expectError: false,
 		},
@@ -415,8 +438,10 @@ func TestSeriesService_DeleteSeries(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockSeriesRepository)
 			tt.mockSetup(mockRepo)
+			matchRepo := new(MockMatchRepository)
+			matchRepo.On("GetBySeriesID", mock.Anything, tt.seriesID).Return([]*models.Match{}, nil)
 
-			service := services.NewSeriesService(mockRepo)
+			service := services.NewSeriesService(mockRepo, matchRepo)
 			// Create context with user_id for authentication
 			ctx := context.WithValue(context.Background(), "user_id", "test-user-123")
 
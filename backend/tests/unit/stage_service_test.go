@@ -0,0 +1,495 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockStageRepository is a mock implementation of StageRepository
+type MockStageRepository struct {
+	mock.Mock
+}
+
+func (m *MockStageRepository) Create(ctx context.Context, stage *models.SeriesStage) error {
+	args := m.Called(ctx, stage)
+	return args.Error(0)
+}
+
+func (m *MockStageRepository) GetByID(ctx context.Context, id string) (*models.SeriesStage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SeriesStage), args.Error(1)
+}
+
+func (m *MockStageRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.SeriesStage, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SeriesStage), args.Error(1)
+}
+
+func (m *MockStageRepository) Update(ctx context.Context, id string, stage *models.SeriesStage) error {
+	args := m.Called(ctx, id, stage)
+	return args.Error(0)
+}
+
+func (m *MockStageRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// twoGroupsThenKnockout returns a group stage with groups A and B followed by
+// semi-finals (A1 v B2, B1 v A2) and a final between their winners
+func twoGroupsThenKnockout() []*models.SeriesStage {
+	return []*models.SeriesStage{
+		{
+			ID:       "stage-groups",
+			SeriesID: "series-1",
+			Name:     "Group stage",
+			Position: 1,
+			Type:     models.StageTypeGroup,
+			Groups: []models.StageGroup{
+				{Name: "A", TeamIDs: []string{"a1", "a2"}},
+				{Name: "B", TeamIDs: []string{"b1", "b2"}},
+			},
+		},
+		{
+			ID:       "stage-knockout",
+			SeriesID: "series-1",
+			Name:     "Playoffs",
+			Position: 2,
+			Type:     models.StageTypeKnockout,
+			Bracket: []*models.BracketMatch{
+				{
+					Position: 1, Round: 1, MatchID: "sf-1",
+					TeamA: models.SlotSource{Type: models.SlotSourceGroupPosition, StageID: "stage-groups", Group: "A", Position: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceGroupPosition, StageID: "stage-groups", Group: "B", Position: 2},
+				},
+				{
+					Position: 2, Round: 1, MatchID: "sf-2",
+					TeamA: models.SlotSource{Type: models.SlotSourceGroupPosition, StageID: "stage-groups", Group: "B", Position: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceGroupPosition, StageID: "stage-groups", Group: "A", Position: 2},
+				},
+				{
+					Position: 3, Round: 2, MatchID: "final",
+					TeamA: models.SlotSource{Type: models.SlotSourceWinner, Match: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceWinner, Match: 2},
+				},
+			},
+		},
+	}
+}
+
+// oneGroup returns a single group stage ranked by the given tie-breakers
+func oneGroup(teamIDs []string, tieBreakers ...models.TieBreaker) []*models.SeriesStage {
+	return []*models.SeriesStage{
+		{
+			ID:          "stage-groups",
+			SeriesID:    "series-1",
+			Name:        "Group stage",
+			Position:    1,
+			Type:        models.StageTypeGroup,
+			TieBreakers: tieBreakers,
+			Groups:      []models.StageGroup{{Name: "A", TeamIDs: teamIDs}},
+		},
+	}
+}
+
+func TestStageService_Advance(t *testing.T) {
+	// The final was filled from a semi-final result that has since been reverted,
+	// and its other side was set by hand before the second semi-final was decided
+	filledBracket := twoGroupsThenKnockout()
+	bracket := filledBracket[1].Bracket
+	bracket[0].TeamAID, bracket[0].TeamBID = "a2", "b2"
+	bracket[1].TeamAID, bracket[1].TeamBID = "b1", "a1"
+	bracket[2].TeamAID = "a2"
+
+	tests := []struct {
+		name             string
+		stages           []*models.SeriesStage
+		matches          []*models.Match
+		margins          map[string]int // Completed matches, won by the side batting first
+		expectedAdvanced map[string][2]string
+		expectedBracket  [][2]string // Teams stored on the knockout stage, nil when it is not updated
+	}{
+		{
+			name:   "fills semi-finals from complete groups",
+			stages: twoGroupsThenKnockout(),
+			matches: []*models.Match{
+				standingsMatch("group-a", 1, models.MatchStatusCompleted, "a2", "a1"),
+				standingsMatch("group-b", 2, models.MatchStatusCompleted, "b1", "b2"),
+				standingsMatch("sf-1", 3, models.MatchStatusLive, "", ""),
+				standingsMatch("sf-2", 4, models.MatchStatusLive, "", ""),
+				standingsMatch("final", 5, models.MatchStatusLive, "", ""),
+			},
+			margins: map[string]int{"group-a": 10, "group-b": 20},
+			// The final waits for the semi-finals
+			expectedAdvanced: map[string][2]string{"sf-1": {"a2", "b2"}, "sf-2": {"b1", "a1"}},
+			expectedBracket:  [][2]string{{"a2", "b2"}, {"b1", "a1"}, {"", ""}},
+		},
+		{
+			name:   "waits for incomplete groups",
+			stages: twoGroupsThenKnockout(),
+			matches: []*models.Match{
+				standingsMatch("group-a", 1, models.MatchStatusCompleted, "a2", "a1"),
+				standingsMatch("group-b", 2, models.MatchStatusLive, "b1", "b2"),
+				standingsMatch("sf-1", 3, models.MatchStatusLive, "", ""),
+				standingsMatch("sf-2", 4, models.MatchStatusLive, "", ""),
+				standingsMatch("final", 5, models.MatchStatusLive, "", ""),
+			},
+			margins: map[string]int{"group-a": 10},
+			// Group A is decided, group B is still playing
+			expectedAdvanced: map[string][2]string{"sf-1": {"a2", ""}, "sf-2": {"", "a1"}},
+			expectedBracket:  [][2]string{{"a2", ""}, {"", "a1"}, {"", ""}},
+		},
+		{
+			name:   "fills final and clears reverted results",
+			stages: filledBracket,
+			matches: []*models.Match{
+				standingsMatch("group-a", 1, models.MatchStatusCompleted, "a2", "a1"),
+				standingsMatch("group-b", 2, models.MatchStatusCompleted, "b1", "b2"),
+				standingsMatch("sf-1", 3, models.MatchStatusLive, "a2", "b2"),
+				standingsMatch("sf-2", 4, models.MatchStatusCompleted, "b1", "a1"),
+				standingsMatch("final", 5, models.MatchStatusLive, "a2", "c1"),
+			},
+			margins: map[string]int{"group-a": 10, "group-b": 20, "sf-1": 5, "sf-2": 5},
+			// Matches that have started are left alone
+			expectedAdvanced: map[string][2]string{"final": {"", "b1"}},
+			expectedBracket:  [][2]string{{"a2", "b2"}, {"b1", "a1"}, {"", "b1"}},
+		},
+		{
+			name:   "keeps manual teams while undecided",
+			stages: twoGroupsThenKnockout(),
+			matches: []*models.Match{
+				standingsMatch("group-a", 1, models.MatchStatusLive, "a2", "a1"),
+				standingsMatch("group-b", 2, models.MatchStatusLive, "b1", "b2"),
+				standingsMatch("sf-1", 3, models.MatchStatusLive, "c1", "d1"),
+				standingsMatch("sf-2", 4, models.MatchStatusLive, "", ""),
+				standingsMatch("final", 5, models.MatchStatusLive, "", ""),
+			},
+			expectedAdvanced: map[string][2]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStageRepo := new(MockStageRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", CreatedBy: "test-user-123"}, nil)
+			for _, id := range []string{"a1", "a2", "b1", "b2", "c1", "d1"} {
+				mockTeamRepo.On("GetByID", mock.Anything, id).Return(&models.Team{ID: id, Name: "Team " + id}, nil)
+			}
+			mockStageRepo.On("GetBySeriesID", mock.Anything, "series-1").Return(tt.stages, nil)
+			mockMatchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return(tt.matches, nil)
+			for _, match := range tt.matches {
+				if margin, ok := tt.margins[match.ID]; ok {
+					mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, match.ID).Return([]*models.Innings{
+						standingsInnings(1, models.TeamTypeA, 150, 5, 120),
+						standingsInnings(2, models.TeamTypeB, 150-margin, 9, 120),
+					}, nil)
+				} else {
+					mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, match.ID).Return([]*models.Innings{}, nil)
+				}
+			}
+			advanced := map[string][2]string{}
+			mockMatchRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				match := args.Get(2).(*models.Match)
+				advanced[match.ID] = [2]string{match.TeamAID, match.TeamBID}
+			}).Return(nil)
+			var stored [][2]string
+			mockStageRepo.On("Update", mock.Anything, "stage-knockout", mock.Anything).Run(func(args mock.Arguments) {
+				for _, match := range args.Get(2).(*models.SeriesStage).Bracket {
+					stored = append(stored, [2]string{match.TeamAID, match.TeamBID})
+				}
+			}).Return(nil)
+
+			// Create service
+			standings := services.NewStandingsService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo)
+			matches := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service := services.NewStageService(mockStageRepo, mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo, standings, matches)
+
+			// Test
+			err := service.Advance(context.Background(), "series-1")
+
+			// Assertions
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAdvanced, advanced)
+			assert.Equal(t, tt.expectedBracket, stored)
+		})
+	}
+}
+
+func TestStageService_GetBracket(t *testing.T) {
+	// a1 beat b1, b1 beat c1 and c1 beat a1
+	cycle := []*models.Match{
+		standingsMatch("m1", 1, models.MatchStatusCompleted, "a1", "b1"),
+		standingsMatch("m2", 2, models.MatchStatusCompleted, "b1", "c1"),
+		standingsMatch("m3", 3, models.MatchStatusCompleted, "c1", "a1"),
+	}
+	cycleMargins := map[string]int{"m1": 10, "m2": 30, "m3": 5}
+	cycleTieBreakers := []models.TieBreaker{models.TieBreakerPoints, models.TieBreakerHeadToHead, models.TieBreakerNetRunRate}
+
+	tests := []struct {
+		name           string
+		stages         []*models.SeriesStage
+		matches        []*models.Match
+		margins        map[string]int // Completed matches, won by the side batting first
+		expectedGroups []string       // Each group of the first stage as "A: first second ...", with "(complete)" once decided
+		expectedRounds []string       // Each knockout match of the second stage as "Round: Match: side v side"
+	}{
+		{
+			name:   "ranks groups by head to head",
+			stages: oneGroup([]string{"a1", "b1", "c1", "d1"}, models.TieBreakerPoints, models.TieBreakerHeadToHead),
+			matches: []*models.Match{
+				standingsMatch("m1", 1, models.MatchStatusCompleted, "a1", "b1"),
+				standingsMatch("m2", 2, models.MatchStatusCompleted, "b1", "d1"),
+				standingsMatch("m3", 3, models.MatchStatusCompleted, "c1", "a1"),
+				standingsMatch("m4", 4, models.MatchStatusCompleted, "c1", "d1"),
+			},
+			// a1 and b1 both finish on 2 points: b1 has the far better net run rate
+			// but a1 won their meeting. Two pairs have not played yet.
+			margins:        map[string]int{"m1": 1, "m2": 100, "m3": 1, "m4": 50},
+			expectedGroups: []string{"A: c1 a1 b1 d1"},
+		},
+		{
+			// Level on points and in their mini-league, so net run rate decides
+			// whatever order they are listed in
+			name:           "ranks head to head cycle by mini-league",
+			stages:         oneGroup([]string{"a1", "b1", "c1"}, cycleTieBreakers...),
+			matches:        cycle,
+			margins:        cycleMargins,
+			expectedGroups: []string{"A: b1 a1 c1 (complete)"},
+		},
+		{
+			name:           "ranks head to head cycle by mini-league listed in reverse",
+			stages:         oneGroup([]string{"c1", "b1", "a1"}, cycleTieBreakers...),
+			matches:        cycle,
+			margins:        cycleMargins,
+			expectedGroups: []string{"A: b1 a1 c1 (complete)"},
+		},
+		{
+			name:           "ranks head to head cycle by mini-league listed from the middle",
+			stages:         oneGroup([]string{"b1", "c1", "a1"}, cycleTieBreakers...),
+			matches:        cycle,
+			margins:        cycleMargins,
+			expectedGroups: []string{"A: b1 a1 c1 (complete)"},
+		},
+		{
+			name:   "labels undecided sides",
+			stages: twoGroupsThenKnockout(),
+			matches: []*models.Match{
+				standingsMatch("sf-1", 3, models.MatchStatusLive, "", ""),
+				standingsMatch("sf-2", 4, models.MatchStatusLive, "", ""),
+				standingsMatch("final", 5, models.MatchStatusLive, "", ""),
+			},
+			expectedGroups: []string{"A: a1 a2", "B: b1 b2"},
+			expectedRounds: []string{
+				"Semi-final: Semi-final 1: 1st in Group A v 2nd in Group B",
+				"Semi-final: Semi-final 2: 1st in Group B v 2nd in Group A",
+				"Final: Final: Winner of Semi-final 1 v Winner of Semi-final 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStageRepo := new(MockStageRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", CreatedBy: "test-user-123"}, nil)
+			for _, id := range []string{"a1", "a2", "b1", "b2", "c1", "d1"} {
+				mockTeamRepo.On("GetByID", mock.Anything, id).Return(&models.Team{ID: id, Name: "Team " + id}, nil)
+			}
+			mockStageRepo.On("GetBySeriesID", mock.Anything, "series-1").Return(tt.stages, nil)
+			mockMatchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return(tt.matches, nil)
+			for matchID, margin := range tt.margins {
+				mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, matchID).Return([]*models.Innings{
+					standingsInnings(1, models.TeamTypeA, 150, 5, 120),
+					standingsInnings(2, models.TeamTypeB, 150-margin, 9, 120),
+				}, nil)
+			}
+
+			// Create service
+			standings := services.NewStandingsService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo)
+			matches := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service := services.NewStageService(mockStageRepo, mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo, standings, matches)
+
+			// Test
+			bracket, err := service.GetBracket(context.Background(), "series-1")
+
+			// Assertions
+			require.NoError(t, err)
+			require.Len(t, bracket.Stages, len(tt.stages))
+			groups := []string{}
+			for _, group := range bracket.Stages[0].Groups {
+				line := group.Name + ":"
+				for _, row := range group.Standings {
+					line += " " + row.TeamID
+				}
+				if group.Complete {
+					line += " (complete)"
+				}
+				groups = append(groups, line)
+			}
+			assert.Equal(t, tt.expectedGroups, groups)
+			if len(tt.stages) > 1 {
+				rounds := []string{}
+				for _, round := range bracket.Stages[1].Rounds {
+					for _, match := range round.Matches {
+						rounds = append(rounds, round.Name+": "+match.Name+": "+match.TeamA.Label+" v "+match.TeamB.Label)
+					}
+				}
+				assert.Equal(t, tt.expectedRounds, rounds)
+			}
+		})
+	}
+}
+
+func TestStageService_CreateStage(t *testing.T) {
+	tests := []struct {
+		name                string
+		seriesID            string
+		req                 *models.CreateStageRequest
+		expectedPosition    int
+		expectedSourceStage string
+		expectedError       string
+	}{
+		{
+			name:     "knockout defaults to latest group stage",
+			seriesID: "series-1",
+			req: &models.CreateStageRequest{Name: "Final", Type: models.StageTypeKnockout, Bracket: []models.BracketMatchRequest{
+				{Round: 1, MatchID: "final",
+					TeamA: models.SlotSource{Type: models.SlotSourceGroupPosition, Group: "A", Position: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceGroupPosition, Group: "B", Position: 1}},
+			}},
+			expectedPosition:    2,
+			expectedSourceStage: "stage-groups",
+		},
+		{
+			name:          "not the series creator",
+			seriesID:      "series-2",
+			req:           &models.CreateStageRequest{Name: "Groups", Type: models.StageTypeGroup},
+			expectedError: "access denied",
+		},
+		{
+			name:     "team in two groups",
+			seriesID: "series-1",
+			req: &models.CreateStageRequest{Name: "Groups", Type: models.StageTypeGroup, Groups: []models.StageGroup{
+				{Name: "A", TeamIDs: []string{"a1", "a2"}},
+				{Name: "B", TeamIDs: []string{"a2", "b1"}},
+			}},
+			expectedError: "more than one group",
+		},
+		{
+			name:     "unknown team",
+			seriesID: "series-1",
+			req: &models.CreateStageRequest{Name: "Groups", Type: models.StageTypeGroup, Groups: []models.StageGroup{
+				{Name: "A", TeamIDs: []string{"a1", "missing"}},
+			}},
+			expectedError: "team missing not found",
+		},
+		{
+			name:          "unknown tie-breaker",
+			seriesID:      "series-1",
+			req:           &models.CreateStageRequest{Name: "Groups", Type: models.StageTypeGroup, TieBreakers: []models.TieBreaker{"coin_toss"}},
+			expectedError: "tie-breaker",
+		},
+		{
+			name:     "position beyond the group size",
+			seriesID: "series-1",
+			req: &models.CreateStageRequest{Name: "Playoffs", Type: models.StageTypeKnockout, Bracket: []models.BracketMatchRequest{
+				{Round: 1, MatchID: "sf-1",
+					TeamA: models.SlotSource{Type: models.SlotSourceGroupPosition, Group: "A", Position: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceGroupPosition, Group: "B", Position: 3}},
+			}},
+			expectedError: "group B has no position 3",
+		},
+		{
+			name:     "winner of a match in the same round",
+			seriesID: "series-1",
+			req: &models.CreateStageRequest{Name: "Playoffs", Type: models.StageTypeKnockout, Bracket: []models.BracketMatchRequest{
+				{Round: 1, MatchID: "sf-1",
+					TeamA: models.SlotSource{Type: models.SlotSourceTeam, TeamID: "a1"},
+					TeamB: models.SlotSource{Type: models.SlotSourceTeam, TeamID: "b1"}},
+				{Round: 1, MatchID: "sf-2",
+					TeamA: models.SlotSource{Type: models.SlotSourceWinner, Match: 1},
+					TeamB: models.SlotSource{Type: models.SlotSourceTeam, TeamID: "b2"}},
+			}},
+			expectedError: "earlier round",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStageRepo := new(MockStageRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations: series-1 already has the group stage
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", CreatedBy: "test-user-123"}, nil)
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-2").Return(&models.Series{ID: "series-2", CreatedBy: "someone-else"}, nil)
+			for _, id := range []string{"a1", "a2", "b1", "b2"} {
+				mockTeamRepo.On("GetByID", mock.Anything, id).Return(&models.Team{ID: id, Name: "Team " + id}, nil)
+			}
+			mockTeamRepo.On("GetByID", mock.Anything, "missing").Return(nil, errors.New("not found"))
+			mockStageRepo.On("GetBySeriesID", mock.Anything, "series-1").Return(twoGroupsThenKnockout()[:1], nil)
+			if tt.expectedError == "" {
+				mockStageRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.SeriesStage")).Return(nil)
+				mockMatchRepo.On("GetByID", mock.Anything, "final").Return(standingsMatch("final", 5, models.MatchStatusLive, "", ""), nil)
+				mockMatchRepo.On("GetBySeriesID", mock.Anything, "series-1").Return([]*models.Match{}, nil)
+			}
+
+			// Create service
+			standings := services.NewStandingsService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo)
+			matches := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service := services.NewStageService(mockStageRepo, mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo, standings, matches)
+
+			// Test
+			stage, err := service.CreateStage(userContext(), tt.seriesID, tt.req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, stage)
+				mockStageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPosition, stage.Position)
+			assert.Equal(t, "test-user-123", stage.CreatedBy)
+			require.Len(t, stage.Bracket, 1)
+			assert.Equal(t, 1, stage.Bracket[0].Position)
+			assert.Equal(t, tt.expectedSourceStage, stage.Bracket[0].TeamA.StageID)
+			assert.Equal(t, tt.expectedSourceStage, stage.Bracket[0].TeamB.StageID)
+
+			// Verify all expectations were met
+			mockStageRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// standingsMatch builds a 20-over series-1 match between two full sides
+func standingsMatch(id string, number int, status models.MatchStatus, teamA, teamB string) *models.Match {
+	return &models.Match{
+		ID:               id,
+		SeriesID:         "series-1",
+		MatchNumber:      number,
+		Status:           status,
+		TeamAID:          teamA,
+		TeamBID:          teamB,
+		TeamAPlayerCount: 11,
+		TeamBPlayerCount: 11,
+		TotalOvers:       20,
+	}
+}
+
+func standingsInnings(number int, batting models.TeamType, runs, wickets, balls int) *models.Innings {
+	return &models.Innings{
+		InningsNumber: number,
+		BattingTeam:   batting,
+		TotalRuns:     runs,
+		TotalWickets:  wickets,
+		TotalBalls:    balls,
+	}
+}
+
+func TestStandingsService_GetStandings(t *testing.T) {
+	tests := []struct {
+		name              string
+		seriesID          string
+		rules             *models.PointsRules
+		matches           []*models.Match
+		innings           map[string][]*models.Innings
+		expectedRules     models.PointsRules
+		expectedStandings []*models.StandingsRow
+		expectedResults   []*models.MatchResult
+		expectedError     string
+	}{
+		{
+			name:     "computes points table",
+			seriesID: "series-1",
+			rules:    &models.PointsRules{Win: 4, Loss: 0, Tie: 2, NoResult: 2, BonusPoint: 1, BonusRunRateRatio: 1.25},
+			matches: []*models.Match{
+				standingsMatch("match-4", 4, models.MatchStatusCompleted, "team-x", "team-z"),
+				standingsMatch("match-2", 2, models.MatchStatusCompleted, "team-y", "team-z"),
+				standingsMatch("match-5", 5, models.MatchStatusLive, "team-y", "team-z"),
+				standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-x", "team-y"),
+				standingsMatch("match-3", 3, models.MatchStatusCancelled, "team-x", "team-z"),
+				standingsMatch("match-6", 6, models.MatchStatusCompleted, "", ""),
+			},
+			innings: map[string][]*models.Innings{
+				// X bowled out for 160 in 100 balls, Y chase it down without a bonus point
+				"match-1": {standingsInnings(1, models.TeamTypeA, 160, 10, 100), standingsInnings(2, models.TeamTypeB, 161, 3, 110)},
+				// Y win by 100 runs and earn the bonus point
+				"match-2": {standingsInnings(1, models.TeamTypeA, 200, 5, 120), standingsInnings(2, models.TeamTypeB, 100, 10, 80)},
+				"match-4": {standingsInnings(1, models.TeamTypeA, 150, 6, 120), standingsInnings(2, models.TeamTypeB, 150, 8, 120)},
+			},
+			expectedRules: models.PointsRules{Win: 4, Loss: 0, Tie: 2, NoResult: 2, BonusPoint: 1, BonusRunRateRatio: 1.25},
+			expectedStandings: []*models.StandingsRow{
+				// Sides bowled out are charged their full overs
+				{Position: 1, TeamID: "team-y", TeamName: "Yellow Caps", Played: 2, Won: 2, BonusPoints: 1, Points: 9,
+					RunsFor: 361, OversFor: 38.2, RunsAgainst: 260, OversAgainst: 40, NetRunRate: 2.917, BallsFor: 230, BallsAgainst: 240},
+				// Level on points and wins with Z, X has the better net run rate;
+				// the cancelled match does not count towards it
+				{Position: 2, TeamID: "team-x", TeamName: "Xavier XI", Played: 3, Lost: 1, Tied: 1, NoResult: 1, Points: 4,
+					RunsFor: 310, OversFor: 40, RunsAgainst: 311, OversAgainst: 38.2, NetRunRate: -0.363, BallsFor: 240, BallsAgainst: 230},
+				// Deleted teams keep their name
+				{Position: 3, TeamID: "team-z", TeamName: "Zebras", Played: 3, Lost: 1, Tied: 1, NoResult: 1, Points: 4,
+					RunsFor: 250, OversFor: 40, RunsAgainst: 350, OversAgainst: 40, NetRunRate: -2.5, BallsFor: 240, BallsAgainst: 240},
+			},
+			expectedResults: []*models.MatchResult{
+				{MatchID: "match-1", MatchNumber: 1, Outcome: models.MatchOutcomeWin, TeamAID: "team-x", TeamBID: "team-y", WinnerTeamID: "team-y", LoserTeamID: "team-x"},
+				{MatchID: "match-2", MatchNumber: 2, Outcome: models.MatchOutcomeWin, TeamAID: "team-y", TeamBID: "team-z", WinnerTeamID: "team-y", LoserTeamID: "team-z", BonusPoint: true},
+				{MatchID: "match-3", MatchNumber: 3, Outcome: models.MatchOutcomeNoResult, TeamAID: "team-x", TeamBID: "team-z"},
+				{MatchID: "match-4", MatchNumber: 4, Outcome: models.MatchOutcomeTie, TeamAID: "team-x", TeamBID: "team-z"},
+			},
+		},
+		{
+			name:     "uses default rules",
+			seriesID: "series-1",
+			matches: []*models.Match{
+				standingsMatch("match-1", 1, models.MatchStatusCompleted, "team-x", "team-y"),
+				standingsMatch("match-2", 2, models.MatchStatusCompleted, "team-x", "team-y"),
+			},
+			innings: map[string][]*models.Innings{
+				"match-1": {standingsInnings(1, models.TeamTypeA, 120, 4, 120), standingsInnings(2, models.TeamTypeB, 60, 10, 60)},
+				// Completed without a second innings is a no result
+				"match-2": {standingsInnings(1, models.TeamTypeA, 90, 2, 72)},
+			},
+			expectedRules: models.DefaultPointsRules(),
+			expectedStandings: []*models.StandingsRow{
+				// Bonus points are off by default
+				{Position: 1, TeamID: "team-x", TeamName: "Xavier XI", Played: 2, Won: 1, NoResult: 1, Points: 3,
+					RunsFor: 120, OversFor: 20, RunsAgainst: 60, OversAgainst: 20, NetRunRate: 3, BallsFor: 120, BallsAgainst: 120},
+				{Position: 2, TeamID: "team-y", TeamName: "Yellow Caps", Played: 2, Lost: 1, NoResult: 1, Points: 1,
+					RunsFor: 60, OversFor: 20, RunsAgainst: 120, OversAgainst: 20, NetRunRate: -3, BallsFor: 120, BallsAgainst: 120},
+			},
+			expectedResults: []*models.MatchResult{
+				{MatchID: "match-1", MatchNumber: 1, Outcome: models.MatchOutcomeWin, TeamAID: "team-x", TeamBID: "team-y", WinnerTeamID: "team-x", LoserTeamID: "team-y"},
+				{MatchID: "match-2", MatchNumber: 2, Outcome: models.MatchOutcomeNoResult, TeamAID: "team-x", TeamBID: "team-y"},
+			},
+		},
+		{
+			name:          "series not found",
+			seriesID:      "missing",
+			expectedError: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+
+			// Setup expectations
+			if tt.expectedError != "" {
+				mockSeriesRepo.On("GetByID", mock.Anything, tt.seriesID).Return(nil, errors.New(tt.expectedError))
+			} else {
+				mockSeriesRepo.On("GetByID", mock.Anything, tt.seriesID).Return(&models.Series{ID: tt.seriesID, PointsRules: tt.rules}, nil)
+				mockMatchRepo.On("GetBySeriesID", mock.Anything, tt.seriesID).Return(tt.matches, nil)
+				for matchID, innings := range tt.innings {
+					mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, matchID).Return(innings, nil)
+				}
+				mockTeamRepo.On("GetByID", mock.Anything, "team-x").Return(&models.Team{ID: "team-x", Name: "Xavier XI"}, nil)
+				mockTeamRepo.On("GetByID", mock.Anything, "team-y").Return(&models.Team{ID: "team-y", Name: "Yellow Caps"}, nil)
+				mockTeamRepo.On("GetByID", mock.Anything, "team-z").Return(nil, errors.New("not found"))
+				mockTeamRepo.On("GetDeletedByID", mock.Anything, "team-z").Return(&models.Team{ID: "team-z", Name: "Zebras"}, nil)
+			}
+
+			// Create service
+			service := services.NewStandingsService(mockSeriesRepo, mockMatchRepo, mockTeamRepo, mockScorecardRepo)
+
+			// Test
+			standings, err := service.GetStandings(context.Background(), tt.seriesID)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, standings)
+				mockMatchRepo.AssertNotCalled(t, "GetBySeriesID", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRules, standings.PointsRules)
+			assert.Equal(t, tt.expectedStandings, standings.Standings)
+			assert.Equal(t, tt.expectedResults, standings.Results)
+
+			// Verify all expectations were met; live and cancelled matches have no innings to load
+			mockSeriesRepo.AssertExpectations(t)
+			mockMatchRepo.AssertExpectations(t)
+			mockScorecardRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSeriesService_CreateSeriesRejectsInvalidPointsRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         models.PointsRules
+		expectedError string
+	}{
+		{
+			name:          "negative points",
+			rules:         models.PointsRules{Win: 2, NoResult: -1},
+			expectedError: "points cannot be negative",
+		},
+		{
+			name:          "loss above win",
+			rules:         models.PointsRules{Win: 1, Loss: 2},
+			expectedError: "a loss cannot be worth more points than a win",
+		},
+		{
+			name:          "bonus ratio below one",
+			rules:         models.PointsRules{Win: 2, BonusPoint: 1, BonusRunRateRatio: 0.5},
+			expectedError: "bonus run rate ratio must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockSeriesRepo := new(MockSeriesRepository)
+
+			// Create service
+			service := services.NewSeriesService(mockSeriesRepo, new(MockMatchRepository))
+
+			// Test
+			rules := tt.rules
+			series, err := service.CreateSeries(userContext(), &models.CreateSeriesRequest{
+				Name:        "League",
+				StartDate:   time.Now(),
+				EndDate:     time.Now().Add(24 * time.Hour),
+				PointsRules: &rules,
+			})
+
+			// Assertions
+			assert.Nil(t, series)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockSeriesRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
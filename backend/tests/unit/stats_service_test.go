@@ -0,0 +1,470 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockPlayerStatsRepository is a mock implementation of PlayerStatsRepository
+type MockPlayerStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlayerStatsRepository) ReplaceMatchStats(ctx context.Context, matchID string, stats []*models.PlayerMatchStats) error {
+	args := m.Called(ctx, matchID, stats)
+	return args.Error(0)
+}
+
+func (m *MockPlayerStatsRepository) GetMatchStatsByMatchID(ctx context.Context, matchID string) ([]*models.PlayerMatchStats, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerMatchStats), args.Error(1)
+}
+
+func (m *MockPlayerStatsRepository) GetMatchStatsByPlayer(ctx context.Context, playerID string) ([]*models.PlayerMatchStats, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerMatchStats), args.Error(1)
+}
+
+func (m *MockPlayerStatsRepository) DeleteMatchStats(ctx context.Context, matchID string) error {
+	args := m.Called(ctx, matchID)
+	return args.Error(0)
+}
+
+func (m *MockPlayerStatsRepository) ReplacePlayerStats(ctx context.Context, playerID string, stats []*models.PlayerStats) error {
+	args := m.Called(ctx, playerID, stats)
+	return args.Error(0)
+}
+
+func (m *MockPlayerStatsRepository) GetPlayerStats(ctx context.Context, playerID string) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
+}
+
+func (m *MockPlayerStatsRepository) GetSeriesStats(ctx context.Context, seriesID string) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
+}
+
+func (m *MockPlayerStatsRepository) DeleteAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// statsFigures are the parts of a player's match line that deliveries change
+type statsFigures struct {
+	Batted, NotOut                              bool
+	Runs, BallsFaced, Fours, Sixes              int
+	Bowled                                      bool
+	BallsBowled, RunsConceded, Wickets, Maidens int
+	Catches, RunOuts                            int
+}
+
+func TestStatsService_RecordMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        models.MatchStatus
+		overs         []models.OverSummary
+		expected      map[string]statsFigures
+		expectedError string
+	}{
+		{
+			name:   "attributes every delivery",
+			status: models.MatchStatusCompleted,
+			overs: []models.OverSummary{
+				{OverNumber: 1, Balls: []models.BallSummary{
+					{BallNumber: 1, BallType: models.BallTypeGood, RunType: models.RunTypeFour, Runs: 4, BatterID: "a1", BowlerID: "b1"},
+					{BallNumber: 2, BallType: models.BallTypeWide, RunType: models.RunTypeWD, Runs: 1, BatterID: "a1", BowlerID: "b1"},
+					{BallNumber: 3, BallType: models.BallTypeGood, RunType: models.RunTypeLB, Runs: 1, BatterID: "a1", BowlerID: "b1"},
+					{BallNumber: 4, BallType: models.BallTypeGood, RunType: models.RunTypeSix, Runs: 6, BatterID: "a2", BowlerID: "b1"},
+					{BallNumber: 5, BallType: models.BallTypeNoBall, RunType: models.RunTypeNB, Runs: 1, BatterID: "a2", BowlerID: "b1"},
+					{BallNumber: 6, BallType: models.BallTypeGood, RunType: models.RunTypeWC, IsWicket: true, WicketType: "caught", BatterID: "a2", BowlerID: "b1", FielderID: "b2"},
+					{BallNumber: 7, BallType: models.BallTypeGood, RunType: models.RunTypeWC, IsWicket: true, WicketType: "run_out", BatterID: "a1", BowlerID: "b1", FielderID: "b2", DismissedPlayerID: "a3"},
+					{BallNumber: 8, BallType: models.BallTypeGood, RunType: models.RunTypeZero, BatterID: "a1", BowlerID: "b1"},
+				}},
+				{OverNumber: 2, Balls: []models.BallSummary{
+					{BallNumber: 1, BallType: models.BallTypeGood, RunType: models.RunTypeZero, BatterID: "a1", BowlerID: "b2"},
+					{BallNumber: 2, BallType: models.BallTypeGood, RunType: models.RunTypeZero, BatterID: "a1", BowlerID: "b2"},
+					{BallNumber: 3, BallType: models.BallTypeGood, RunType: models.RunTypeZero, BatterID: "a1", BowlerID: "b2"},
+					{BallNumber: 4, BallType: models.BallTypeGood, RunType: models.RunTypeZero, Byes: 2, BatterID: "a1", BowlerID: "b2"},
+					{BallNumber: 5, BallType: models.BallTypeGood, RunType: models.RunTypeZero, BatterID: "a1", BowlerID: "b2"},
+					{BallNumber: 6, BallType: models.BallTypeGood, RunType: models.RunTypeWC, IsWicket: true, WicketType: "bowled", BatterID: "a1", BowlerID: "b2"},
+				}},
+			},
+			expected: map[string]statsFigures{
+				// Leg byes and byes are not the batter's, and wides are not balls faced
+				"a1": {Batted: true, Runs: 4, BallsFaced: 10, Fours: 1},
+				// No-balls are balls faced
+				"a2": {Batted: true, Runs: 6, BallsFaced: 3, Sixes: 1},
+				// A run out non-striker has batted
+				"a3": {Batted: true},
+				// XI players count the match without batting
+				"a4": {},
+				// Wides and no-balls are charged, leg byes and run outs are not
+				"b1": {Bowled: true, BallsBowled: 6, RunsConceded: 12, Wickets: 1},
+				// Byes are not charged
+				"b2": {Bowled: true, BallsBowled: 6, Wickets: 1, Maidens: 1, Catches: 1, RunOuts: 1},
+			},
+		},
+		{
+			name:   "batter not out",
+			status: models.MatchStatusCompleted,
+			overs: []models.OverSummary{
+				{OverNumber: 1, Balls: []models.BallSummary{
+					{BallNumber: 1, BallType: models.BallTypeGood, RunType: models.RunTypeTwo, Runs: 2, BatterID: "a1", BowlerID: "b1"},
+				}},
+			},
+			expected: map[string]statsFigures{
+				"a1": {Batted: true, NotOut: true, Runs: 2, BallsFaced: 1},
+				"a2": {},
+				"a3": {},
+				"a4": {},
+				"b1": {Bowled: true, BallsBowled: 1, RunsConceded: 2},
+				"b2": {},
+			},
+		},
+		{
+			name:   "wide that runs to the boundary",
+			status: models.MatchStatusCompleted,
+			overs: []models.OverSummary{
+				{OverNumber: 1, Balls: []models.BallSummary{
+					{BallNumber: 1, BallType: models.BallTypeWide, RunType: models.RunTypeWD, Runs: 1, Byes: 4, BatterID: "a1", BowlerID: "b1"},
+				}},
+			},
+			expected: map[string]statsFigures{
+				"a1": {Batted: true, NotOut: true},
+				"a2": {},
+				"a3": {},
+				"a4": {},
+				// The runs off a wide are extras charged to the bowler
+				"b1": {Bowled: true, RunsConceded: 5},
+				"b2": {},
+			},
+		},
+		{
+			name:   "no-ball with runs taken",
+			status: models.MatchStatusCompleted,
+			overs: []models.OverSummary{
+				{OverNumber: 1, Balls: []models.BallSummary{
+					{BallNumber: 1, BallType: models.BallTypeNoBall, RunType: models.RunTypeNB, Runs: 1, Byes: 2, BatterID: "a1", BowlerID: "b1"},
+				}},
+			},
+			expected: map[string]statsFigures{
+				"a1": {Batted: true, NotOut: true, BallsFaced: 1},
+				"a2": {},
+				"a3": {},
+				"a4": {},
+				"b1": {Bowled: true, RunsConceded: 3},
+				"b2": {},
+			},
+		},
+		{
+			name:          "requires completed match",
+			status:        models.MatchStatusLive,
+			expectedError: "not completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStatsRepo := new(MockPlayerStatsRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+			mockSquadRepo := new(MockMatchSquadRepository)
+
+			// Setup expectations
+			mockMatchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+				ID:       "match-1",
+				SeriesID: "series-1",
+				Status:   tt.status,
+				TeamAID:  "team-a",
+				TeamBID:  "team-b",
+			}, nil)
+			var lines []*models.PlayerMatchStats
+			if tt.expectedError == "" {
+				mockScorecardRepo.On("GetScorecard", mock.Anything, "match-1").Return(&models.ScorecardResponse{
+					MatchID: "match-1",
+					Innings: []models.InningsSummary{{InningsNumber: 1, BattingTeam: models.TeamTypeA, Overs: tt.overs}},
+				}, nil)
+				mockSquadRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.MatchSquadPlayer{
+					{PlayerID: "a1", PlayerName: "Asha", Team: models.TeamTypeA},
+					{PlayerID: "a2", PlayerName: "Bilal", Team: models.TeamTypeA},
+					{PlayerID: "a3", PlayerName: "Chen", Team: models.TeamTypeA},
+					{PlayerID: "a4", PlayerName: "Dev", Team: models.TeamTypeA},
+					{PlayerID: "a5", PlayerName: "Esi", Team: models.TeamTypeA, IsSubstitute: true},
+					{PlayerID: "b1", PlayerName: "Farah", Team: models.TeamTypeB},
+					{PlayerID: "b2", PlayerName: "Gita", Team: models.TeamTypeB},
+				}, nil)
+				mockStatsRepo.On("GetMatchStatsByMatchID", mock.Anything, "match-1").Return([]*models.PlayerMatchStats{}, nil)
+				mockStatsRepo.On("ReplaceMatchStats", mock.Anything, "match-1", mock.Anything).Run(func(args mock.Arguments) {
+					lines = args.Get(2).([]*models.PlayerMatchStats)
+				}).Return(nil)
+				mockStatsRepo.On("GetMatchStatsByPlayer", mock.Anything, mock.Anything).Return([]*models.PlayerMatchStats{}, nil)
+				mockStatsRepo.On("ReplacePlayerStats", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(len(tt.expected))
+			}
+
+			// Create service
+			service := services.NewStatsService(mockStatsRepo, mockMatchRepo, new(MockSeriesRepository), new(MockPlayerRepository), mockScorecardRepo)
+			service.SetMatchSquadRepository(mockSquadRepo)
+
+			// Test
+			err := service.RecordMatch(context.Background(), "match-1")
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockStatsRepo.AssertNotCalled(t, "ReplaceMatchStats", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			// Substitutes who never took part get no line
+			figures := map[string]statsFigures{}
+			for _, line := range lines {
+				assert.Equal(t, "series-1", line.SeriesID)
+				figures[line.PlayerID] = statsFigures{
+					Batted: line.Batted, NotOut: line.NotOut,
+					Runs: line.Runs, BallsFaced: line.BallsFaced, Fours: line.Fours, Sixes: line.Sixes,
+					Bowled:      line.Bowled,
+					BallsBowled: line.BallsBowled, RunsConceded: line.RunsConceded, Wickets: line.Wickets, Maidens: line.Maidens,
+					Catches: line.Catches, RunOuts: line.RunOuts,
+				}
+			}
+			assert.Equal(t, tt.expected, figures)
+
+			// Verify all expectations were met
+			mockStatsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStatsService_RecordMatchRefreshesAggregates(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []*models.PlayerMatchStats
+		expected []*models.PlayerStats
+	}{
+		{
+			name: "rolls up career and series",
+			history: []*models.PlayerMatchStats{
+				{SeriesID: "series-1", PlayerName: "Asha", Batted: true, Runs: 55, BallsFaced: 40, Bowled: true, BallsBowled: 24, RunsConceded: 30, Wickets: 3},
+				{SeriesID: "series-1", PlayerName: "Asha", Batted: true, NotOut: true, Runs: 102, BallsFaced: 60, Bowled: true, BallsBowled: 18, RunsConceded: 12, Wickets: 3},
+				{SeriesID: "series-2", PlayerName: "Asha K", Batted: true, Runs: 0, BallsFaced: 2, Bowled: true, BallsBowled: 24, RunsConceded: 20, Wickets: 5, Catches: 2},
+			},
+			expected: []*models.PlayerStats{
+				// The latest name wins
+				{PlayerID: "p1", PlayerName: "Asha K", Matches: 3, Innings: 3, NotOuts: 1, Runs: 157, BallsFaced: 102, HighScore: 102, HighScoreNotOut: true, Fifties: 1, Hundreds: 1, Ducks: 1,
+					BowlingInnings: 3, BallsBowled: 66, RunsConceded: 62, Wickets: 11, BestWickets: 5, BestRuns: 20, ThreeWicketHauls: 2, FiveWicketHauls: 1, Catches: 2},
+				// Equal wickets prefer fewer runs
+				{PlayerID: "p1", PlayerName: "Asha K", SeriesID: "series-1", Matches: 2, Innings: 2, NotOuts: 1, Runs: 157, BallsFaced: 100, HighScore: 102, HighScoreNotOut: true, Fifties: 1, Hundreds: 1,
+					BowlingInnings: 2, BallsBowled: 42, RunsConceded: 42, Wickets: 6, BestWickets: 3, BestRuns: 12, ThreeWicketHauls: 2},
+				{PlayerID: "p1", PlayerName: "Asha K", SeriesID: "series-2", Matches: 1, Innings: 1, BallsFaced: 2, Ducks: 1,
+					BowlingInnings: 1, BallsBowled: 24, RunsConceded: 20, Wickets: 5, BestWickets: 5, BestRuns: 20, FiveWicketHauls: 1, Catches: 2},
+			},
+		},
+		{
+			name:     "player left without matches",
+			history:  []*models.PlayerMatchStats{},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStatsRepo := new(MockPlayerStatsRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+			mockSquadRepo := new(MockMatchSquadRepository)
+
+			// Setup expectations
+			mockMatchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+				ID:       "match-1",
+				SeriesID: "series-1",
+				Status:   models.MatchStatusCompleted,
+				TeamAID:  "team-a",
+				TeamBID:  "team-b",
+			}, nil)
+			mockScorecardRepo.On("GetScorecard", mock.Anything, "match-1").Return(&models.ScorecardResponse{MatchID: "match-1"}, nil)
+			mockSquadRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.MatchSquadPlayer{}, nil)
+			mockStatsRepo.On("ReplaceMatchStats", mock.Anything, "match-1", mock.Anything).Return(nil)
+			// The match is being recomputed and p1 no longer appears in it
+			mockStatsRepo.On("GetMatchStatsByMatchID", mock.Anything, "match-1").Return([]*models.PlayerMatchStats{{PlayerID: "p1"}}, nil)
+			mockStatsRepo.On("GetMatchStatsByPlayer", mock.Anything, "p1").Return(tt.history, nil)
+			var aggregates []*models.PlayerStats
+			mockStatsRepo.On("ReplacePlayerStats", mock.Anything, "p1", mock.Anything).Run(func(args mock.Arguments) {
+				aggregates = args.Get(2).([]*models.PlayerStats)
+			}).Return(nil)
+
+			// Create service
+			service := services.NewStatsService(mockStatsRepo, mockMatchRepo, new(MockSeriesRepository), new(MockPlayerRepository), mockScorecardRepo)
+			service.SetMatchSquadRepository(mockSquadRepo)
+
+			// Test
+			err := service.RecordMatch(context.Background(), "match-1")
+
+			// Assertions
+			assert.NoError(t, err)
+			for _, aggregate := range aggregates {
+				aggregate.UpdatedAt = time.Time{}
+			}
+			assert.Equal(t, tt.expected, aggregates)
+
+			// Verify all expectations were met
+			mockStatsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStatsService_GetPlayerStats(t *testing.T) {
+	tests := []struct {
+		name           string
+		seriesID       string
+		expectedSeries []string
+	}{
+		{
+			name:           "derives rates",
+			expectedSeries: []string{"series-1", "series-2"},
+		},
+		{
+			name:           "narrows to one series",
+			seriesID:       "series-2",
+			expectedSeries: []string{"series-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockStatsRepo := new(MockPlayerStatsRepository)
+			mockPlayerRepo := new(MockPlayerRepository)
+
+			// Setup expectations
+			mockPlayerRepo.On("GetByID", mock.Anything, "p1").Return(&models.Player{ID: "p1", Name: "Asha"}, nil)
+			mockStatsRepo.On("GetPlayerStats", mock.Anything, "p1").Return([]*models.PlayerStats{
+				{PlayerID: "p1", SeriesID: "series-2", Innings: 1, Runs: 10, BallsFaced: 10},
+				{PlayerID: "p1", Innings: 3, NotOuts: 1, Runs: 157, BallsFaced: 102, BowlingInnings: 3, BallsBowled: 66, RunsConceded: 62, Wickets: 11, BestWickets: 5, BestRuns: 20},
+				{PlayerID: "p1", SeriesID: "series-1", Innings: 2, Runs: 147, BallsFaced: 92},
+			}, nil)
+
+			// Create service
+			service := services.NewStatsService(mockStatsRepo, new(MockMatchRepository), new(MockSeriesRepository), mockPlayerRepo, new(MockScorecardRepository))
+
+			// Test
+			stats, err := service.GetPlayerStats(context.Background(), "p1", tt.seriesID)
+
+			// Assertions
+			assert.NoError(t, err)
+			assert.Equal(t, 78.5, stats.Career.BattingAverage)
+			assert.Equal(t, 153.92, stats.Career.StrikeRate)
+			assert.Equal(t, 11.0, stats.Career.Overs)
+			assert.Equal(t, 5.64, stats.Career.Economy)
+			assert.Equal(t, 5.64, stats.Career.BowlingAverage)
+			assert.Equal(t, "5/20", stats.Career.BestFigures)
+			seriesIDs := []string{}
+			for _, series := range stats.Series {
+				seriesIDs = append(seriesIDs, series.SeriesID)
+			}
+			assert.Equal(t, tt.expectedSeries, seriesIDs)
+
+			// Verify all expectations were met
+			mockStatsRepo.AssertExpectations(t)
+			mockPlayerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScorecardService_AddBallRequiresSquadPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.BallEventRequest
+		wantErr string
+	}{
+		{
+			name:    "missing bowler",
+			req:     models.BallEventRequest{BatterID: "a1"},
+			wantErr: "batter and bowler are required",
+		},
+		{
+			name:    "bowler from batting side",
+			req:     models.BallEventRequest{BatterID: "a1", BowlerID: "a2"},
+			wantErr: "not in the fielding side's playing XI",
+		},
+		{
+			name:    "substitute batting",
+			req:     models.BallEventRequest{BatterID: "a3", BowlerID: "b1"},
+			wantErr: "not in the batting side's playing XI",
+		},
+		{
+			name:    "fielder from batting side",
+			req:     models.BallEventRequest{BatterID: "a1", BowlerID: "b1", IsWicket: true, WicketType: "caught", FielderID: "a2"},
+			wantErr: "not in the fielding side's squad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchRepo := new(MockMatchRepository)
+			scorecardRepo := new(MockScorecardRepository)
+			squadRepo := new(MockMatchSquadRepository)
+			scorecardService := services.NewScorecardService(scorecardRepo, matchRepo)
+			scorecardService.SetMatchSquadRepository(squadRepo)
+
+			matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{
+				ID:          "match-1",
+				Status:      models.MatchStatusLive,
+				TossWinner:  models.TeamTypeA,
+				BattingTeam: models.TeamTypeA,
+				CreatedBy:   "test-user-123",
+			}, nil)
+			scorecardRepo.On("GetInningsByMatchID", mock.Anything, "match-1").Return([]*models.Innings{}, nil)
+			scorecardRepo.On("GetInningsByMatchAndNumber", mock.Anything, "match-1", 1).Return(&models.Innings{
+				ID:            "innings-1",
+				InningsNumber: 1,
+				BattingTeam:   models.TeamTypeA,
+				Status:        string(models.InningsStatusInProgress),
+			}, nil)
+			squadRepo.On("GetByMatchID", mock.Anything, "match-1").Return([]*models.MatchSquadPlayer{
+				{PlayerID: "a1", Team: models.TeamTypeA},
+				{PlayerID: "a2", Team: models.TeamTypeA},
+				{PlayerID: "a3", Team: models.TeamTypeA, IsSubstitute: true},
+				{PlayerID: "b1", Team: models.TeamTypeB},
+				{PlayerID: "b2", Team: models.TeamTypeB},
+			}, nil)
+
+			req := tt.req
+			req.MatchID = "match-1"
+			req.InningsNumber = 1
+			req.BallType = models.BallTypeGood
+			req.RunType = models.RunTypeZero
+			if req.IsWicket {
+				req.RunType = models.RunTypeWC
+			}
+
+			err := scorecardService.AddBall(userContext(), &req)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			scorecardRepo.AssertNotCalled(t, "CreateBall", mock.Anything, mock.Anything)
+		})
+	}
+}
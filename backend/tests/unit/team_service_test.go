@@ -0,0 +1,346 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockTeamRepository is a mock implementation of TeamRepository
+type MockTeamRepository struct {
+	mock.Mock
+}
+
+func (m *MockTeamRepository) Create(ctx context.Context, team *models.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetByID(ctx context.Context, id string) (*models.Team, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetAll(ctx context.Context, filters *models.TeamFilters) ([]*models.Team, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Update(ctx context.Context, id string, team *models.Team) error {
+	args := m.Called(ctx, id, team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) UpdatePlayersCount(ctx context.Context, id string, count int) error {
+	args := m.Called(ctx, id, count)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTeamRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetDeletedByID(ctx context.Context, id string) (*models.Team, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+// MockPlayerRepository is a mock implementation of PlayerRepository
+type MockPlayerRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlayerRepository) Create(ctx context.Context, player *models.Player) error {
+	args := m.Called(ctx, player)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) GetByID(ctx context.Context, id string) (*models.Player, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetAll(ctx context.Context, filters *models.PlayerFilters) ([]*models.Player, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) Update(ctx context.Context, id string, player *models.Player) error {
+	args := m.Called(ctx, id, player)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) GetByTeamID(ctx context.Context, teamID string) ([]*models.Player, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPlayerRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) GetDeletedByID(ctx context.Context, id string) (*models.Player, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetDeletedByTeamID(ctx context.Context, teamID string) ([]*models.Player, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Player), args.Error(1)
+}
+
+// userContext returns a context authenticated as the test user
+func userContext() context.Context {
+	return context.WithValue(context.Background(), "user_id", "test-user-123")
+}
+
+func TestTeamService_CreateTeamStartsWithEmptyRoster(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	teamService := services.NewTeamService(teamRepo, new(MockPlayerRepository))
+
+	teamRepo.On("Create", mock.Anything, mock.MatchedBy(func(team *models.Team) bool {
+		return team.Name == "Riverside XI" && team.CreatedBy == "test-user-123" && team.PlayersCount == 0
+	})).Return(nil)
+
+	team, err := teamService.CreateTeam(userContext(), &models.CreateTeamRequest{Name: "  Riverside XI "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-user-123", team.CreatedBy)
+	teamRepo.AssertExpectations(t)
+}
+
+func TestTeamService_CreateTeamRequiresAuthentication(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	teamService := services.NewTeamService(teamRepo, new(MockPlayerRepository))
+
+	team, err := teamService.CreateTeam(context.Background(), &models.CreateTeamRequest{Name: "Riverside XI"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication required")
+	assert.Nil(t, team)
+	teamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTeamService_UpdateTeamRequiresOwnership(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	teamService := services.NewTeamService(teamRepo, new(MockPlayerRepository))
+
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "someone-else"}, nil)
+
+	name := "Renamed XI"
+	team, err := teamService.UpdateTeam(userContext(), "team-1", &models.UpdateTeamRequest{Name: &name})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+	assert.Nil(t, team)
+	teamRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamService_AddPlayersToTeamSyncsPlayersCount(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123"}, nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "existing"}}, nil).Once()
+	playerRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Player")).Return(nil).Twice()
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "existing"}, {ID: "p-1"}, {ID: "p-2"}}, nil).Once()
+	teamRepo.On("UpdatePlayersCount", mock.Anything, "team-1", 3).Return(nil)
+
+	players, err := teamService.AddPlayersToTeam(userContext(), "team-1", &models.AddTeamPlayersRequest{
+		Players: []models.TeamPlayerRequest{{Name: "Asha Patel"}, {Name: "Ben Carter"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, players, 2)
+	assert.Equal(t, "team-1", players[0].TeamID)
+	teamRepo.AssertExpectations(t)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestTeamService_AddPlayersToTeamRemovesAddedPlayersOnFailure(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123"}, nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{}, nil)
+	playerRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Player")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Player).ID = "p-1"
+	}).Return(nil).Once()
+	playerRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Player")).Return(errors.New("database error")).Once()
+	playerRepo.On("Delete", mock.Anything, "p-1").Return(nil)
+	teamRepo.On("UpdatePlayersCount", mock.Anything, "team-1", 0).Return(nil)
+
+	players, err := teamService.AddPlayersToTeam(userContext(), "team-1", &models.AddTeamPlayersRequest{
+		Players: []models.TeamPlayerRequest{{Name: "Asha Patel"}, {Name: "Ben Carter"}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create player")
+	assert.Nil(t, players)
+	teamRepo.AssertExpectations(t)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestTeamService_AddPlayersToTeamRejectsFullRoster(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	roster := make([]*models.Player, models.MaxTeamPlayers)
+	for i := range roster {
+		roster[i] = &models.Player{}
+	}
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123"}, nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return(roster, nil)
+
+	players, err := teamService.AddPlayersToTeam(userContext(), "team-1", &models.AddTeamPlayersRequest{
+		Players: []models.TeamPlayerRequest{{Name: "Asha Patel"}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "limited to 20 players")
+	assert.Nil(t, players)
+	playerRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTeamService_DeleteTeamCascadesToPlayers(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	teamRepo.On("GetByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123"}, nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "player-1"}, {ID: "player-2"}}, nil)
+
+	var deletedAt []time.Time
+	record := func(args mock.Arguments) { deletedAt = append(deletedAt, args.Get(2).(time.Time)) }
+	playerRepo.On("SoftDelete", mock.Anything, "player-1", mock.AnythingOfType("time.Time")).Return(nil).Run(record)
+	playerRepo.On("SoftDelete", mock.Anything, "player-2", mock.AnythingOfType("time.Time")).Return(nil).Run(record)
+	teamRepo.On("SoftDelete", mock.Anything, "team-1", mock.AnythingOfType("time.Time")).Return(nil).Run(record)
+
+	err := teamService.DeleteTeam(userContext(), "team-1")
+
+	assert.NoError(t, err)
+	assert.Len(t, deletedAt, 3)
+	assert.True(t, deletedAt[0].Equal(deletedAt[1]) && deletedAt[1].Equal(deletedAt[2]), "cascade should share one timestamp")
+	teamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	teamRepo.AssertExpectations(t)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestTeamService_RestoreTeamOnlyRestoresCascadedPlayers(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	teamDeletedAt := time.Date(2025, 2, 15, 10, 0, 0, 0, time.UTC)
+	earlier := teamDeletedAt.Add(-time.Hour)
+
+	teamRepo.On("GetDeletedByID", mock.Anything, "team-1").Return(&models.Team{ID: "team-1", CreatedBy: "test-user-123", DeletedAt: &teamDeletedAt}, nil)
+	teamRepo.On("Restore", mock.Anything, "team-1").Return(nil)
+	playerRepo.On("GetDeletedByTeamID", mock.Anything, "team-1").Return([]*models.Player{
+		{ID: "player-cascaded", DeletedAt: &teamDeletedAt},
+		{ID: "player-removed-earlier", DeletedAt: &earlier},
+	}, nil)
+	playerRepo.On("Restore", mock.Anything, "player-cascaded").Return(nil)
+	playerRepo.On("GetByTeamID", mock.Anything, "team-1").Return([]*models.Player{{ID: "player-cascaded"}}, nil)
+	teamRepo.On("UpdatePlayersCount", mock.Anything, "team-1", 1).Return(nil)
+
+	team, err := teamService.RestoreTeam(userContext(), "team-1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, team.DeletedAt)
+	assert.Equal(t, 1, team.PlayersCount)
+	playerRepo.AssertNotCalled(t, "Restore", mock.Anything, "player-removed-earlier")
+	teamRepo.AssertExpectations(t)
+	playerRepo.AssertExpectations(t)
+}
+
+func TestTeamService_RestoreTeamNotInTrash(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	playerRepo := new(MockPlayerRepository)
+	teamService := services.NewTeamService(teamRepo, playerRepo)
+
+	teamRepo.On("GetDeletedByID", mock.Anything, "team-1").Return(nil, assert.AnError)
+
+	team, err := teamService.RestoreTeam(userContext(), "team-1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found in trash")
+	assert.Nil(t, team)
+	teamRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
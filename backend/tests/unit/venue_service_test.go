@@ -0,0 +1,538 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// MockVenueRepository is a mock implementation of VenueRepository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	args := m.Called(ctx, venue)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetByID(ctx context.Context, id string) (*models.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetAll(ctx context.Context, filters *models.VenueFilters) ([]*models.Venue, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) Update(ctx context.Context, id string, venue *models.Venue) error {
+	args := m.Called(ctx, id, venue)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// bookingStart is 10:00 on the day of every booking test; a 10-over match
+// holds its pitch until 11:40
+var bookingStart = time.Date(2025, 6, 7, 10, 0, 0, 0, time.UTC)
+
+// bookedMatch is a 10-over match at venue-1 that starts offset after bookingStart
+func bookedMatch(id string, offset time.Duration, pitch int, status models.MatchStatus, teamA, teamB string) *models.Match {
+	match := standingsMatch(id, 1, status, teamA, teamB)
+	match.Date = bookingStart.Add(offset)
+	match.TotalOvers = 10
+	match.VenueID = "venue-1"
+	match.Venue = "Riverside Park"
+	match.PitchNumber = pitch
+	return match
+}
+
+func TestMatchService_CreateMatchBooksVenue(t *testing.T) {
+	tests := []struct {
+		name          string
+		booked        []*models.Match
+		pitch         int
+		expectedPitch int
+		expectedError string
+	}{
+		{
+			name: "books the first pitch by default",
+			booked: []*models.Match{
+				bookedMatch("other-pitch", 0, 2, models.MatchStatusLive, "team-c", "team-d"),
+				bookedMatch("cancelled", 0, 1, models.MatchStatusCancelled, "team-a", "team-b"),
+				bookedMatch("finished-earlier", -100*time.Minute, 1, models.MatchStatusCompleted, "team-a", "team-c"),
+			},
+			expectedPitch: 1,
+		},
+		{
+			name:          "books the requested pitch",
+			booked:        []*models.Match{bookedMatch("m1", 0, 1, models.MatchStatusLive, "team-c", "team-d")},
+			pitch:         2,
+			expectedPitch: 2,
+		},
+		{
+			name:          "pitch double-booked",
+			booked:        []*models.Match{bookedMatch("m1", 90*time.Minute, 1, models.MatchStatusLive, "team-c", "team-d")},
+			pitch:         1,
+			expectedError: "pitch 1 at Riverside Park is already booked",
+		},
+		{
+			name:          "team already playing",
+			booked:        []*models.Match{bookedMatch("m1", -30*time.Minute, 2, models.MatchStatusLive, "team-c", "team-b")},
+			pitch:         1,
+			expectedError: "team B is already playing",
+		},
+		{
+			name:          "pitch does not exist",
+			booked:        []*models.Match{},
+			pitch:         3,
+			expectedError: "pitch 3 does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockMatchRepo := new(MockMatchRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockVenueRepo := new(MockVenueRepository)
+
+			// Setup expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1"}, nil)
+			for _, id := range []string{"team-a", "team-b"} {
+				mockTeamRepo.On("GetByID", mock.Anything, id).Return(&models.Team{ID: id}, nil)
+			}
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2}, nil)
+			mockMatchRepo.On("GetNextMatchNumber", mock.Anything, "series-1").Return(5, nil)
+			mockMatchRepo.On("GetByDateRange", mock.Anything, mock.Anything, mock.Anything).Return(tt.booked, nil)
+			if tt.expectedError == "" {
+				mockMatchRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			// Create service
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service.SetVenueRepository(mockVenueRepo)
+
+			// Test
+			req := newMatchTeamsRequest("team-a", "team-b")
+			req.Date = bookingStart
+			req.TotalOvers = 10
+			req.VenueID = "venue-1"
+			req.PitchNumber = tt.pitch
+			match, err := service.CreateMatch(userContext(), req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, match)
+				mockMatchRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "venue-1", match.VenueID)
+			assert.Equal(t, tt.expectedPitch, match.PitchNumber)
+			assert.Equal(t, "Riverside Park", match.Venue)
+
+			// Verify all expectations were met
+			mockMatchRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMatchService_UpdateMatchChecksNewTimeSlot(t *testing.T) {
+	tests := []struct {
+		name          string
+		moveBy        time.Duration
+		expectedError string
+	}{
+		{
+			name:          "clashes with the morning match",
+			moveBy:        30 * time.Minute,
+			expectedError: "pitch 1 at Riverside Park is already booked",
+		},
+		{
+			name:   "free after the morning match",
+			moveBy: 2 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockMatchRepo := new(MockMatchRepository)
+			mockSeriesRepo := new(MockSeriesRepository)
+			mockTeamRepo := new(MockTeamRepository)
+			mockVenueRepo := new(MockVenueRepository)
+
+			// Setup expectations
+			existing := bookedMatch("match-1", 3*time.Hour, 1, models.MatchStatusLive, "team-a", "team-b")
+			existing.CreatedBy = "test-user-123"
+			mockMatchRepo.On("GetByID", mock.Anything, "match-1").Return(existing, nil)
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2}, nil)
+			mockMatchRepo.On("GetByDateRange", mock.Anything, mock.Anything, mock.Anything).Return([]*models.Match{
+				existing,
+				bookedMatch("morning", 0, 1, models.MatchStatusLive, "team-c", "team-d"),
+			}, nil)
+			if tt.expectedError == "" {
+				mockMatchRepo.On("Update", mock.Anything, "match-1", mock.Anything).Return(nil)
+			}
+
+			// Create service
+			service := services.NewMatchService(mockMatchRepo, mockSeriesRepo, mockTeamRepo)
+			service.SetVenueRepository(mockVenueRepo)
+
+			// Test
+			moved := bookingStart.Add(tt.moveBy)
+			match, err := service.UpdateMatch(userContext(), "match-1", &models.UpdateMatchRequest{Date: &moved})
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, match)
+				mockMatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, moved, match.Date)
+
+			// Verify all expectations were met
+			mockMatchRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVenueService_GetVenueRecords(t *testing.T) {
+	private := standingsMatch("private", 5, models.MatchStatusCompleted, "team-a", "team-b")
+	private.OrganizationID = "org-private"
+
+	tests := []struct {
+		name            string
+		matches         []*models.Match
+		innings         map[string][]*models.Innings
+		expectedRecords *models.VenueRecords
+	}{
+		{
+			name: "computes records from completed matches",
+			matches: []*models.Match{
+				standingsMatch("chased", 1, models.MatchStatusCompleted, "team-a", "team-b"),
+				standingsMatch("defended", 2, models.MatchStatusCompleted, "team-a", "team-b"),
+				standingsMatch("abandoned", 3, models.MatchStatusCompleted, "team-a", "team-b"),
+				standingsMatch("live", 4, models.MatchStatusLive, "team-a", "team-b"),
+			},
+			innings: map[string][]*models.Innings{
+				"chased":   {standingsInnings(1, models.TeamTypeA, 150, 7, 120), standingsInnings(2, models.TeamTypeB, 151, 4, 110)},
+				"defended": {standingsInnings(1, models.TeamTypeB, 175, 5, 120), standingsInnings(2, models.TeamTypeA, 120, 10, 100)},
+				// Completed without a second innings, so left out
+				"abandoned": {standingsInnings(1, models.TeamTypeA, 210, 2, 120)},
+			},
+			expectedRecords: &models.VenueRecords{
+				VenueID:             "venue-1",
+				VenueName:           "Riverside Park",
+				MatchesPlayed:       2,
+				AverageFirstInnings: 162.5,
+				HighestTotal:        &models.VenueTotal{MatchID: "defended", Runs: 175, Wickets: 5},
+				HighestChase:        &models.VenueChase{MatchID: "chased", Target: 151, Runs: 151, Wickets: 4},
+				WinsBattingFirst:    1,
+				WinsChasing:         1,
+			},
+		},
+		{
+			name: "hides private organization matches",
+			matches: []*models.Match{
+				standingsMatch("chased", 1, models.MatchStatusCompleted, "team-a", "team-b"),
+				private,
+			},
+			innings: map[string][]*models.Innings{
+				"chased": {standingsInnings(1, models.TeamTypeA, 150, 7, 120), standingsInnings(2, models.TeamTypeB, 151, 4, 110)},
+			},
+			expectedRecords: &models.VenueRecords{
+				VenueID:             "venue-1",
+				VenueName:           "Riverside Park",
+				MatchesPlayed:       1,
+				AverageFirstInnings: 150,
+				HighestTotal:        &models.VenueTotal{MatchID: "chased", Runs: 151, Wickets: 4},
+				HighestChase:        &models.VenueChase{MatchID: "chased", Target: 151, Runs: 151, Wickets: 4},
+				WinsChasing:         1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockVenueRepo := new(MockVenueRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockScorecardRepo := new(MockScorecardRepository)
+			mockOrgRepo := new(MockOrganizationRepository)
+
+			// Setup expectations
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2}, nil)
+			mockMatchRepo.On("GetByVenueID", mock.Anything, "venue-1").Return(tt.matches, nil)
+			mockOrgRepo.On("GetPublic", mock.Anything).Return([]*models.Organization{}, nil)
+			for matchID, innings := range tt.innings {
+				mockScorecardRepo.On("GetInningsByMatchID", mock.Anything, matchID).Return(innings, nil)
+			}
+
+			// Create service
+			service := services.NewVenueService(mockVenueRepo, mockMatchRepo, mockScorecardRepo)
+			service.SetOrganizationService(services.NewOrganizationService(mockOrgRepo))
+
+			// Test
+			records, err := service.GetVenueRecords(context.Background(), "venue-1")
+
+			// Assertions
+			require.NoError(t, err)
+			records.UpdatedAt = time.Time{}
+			assert.Equal(t, tt.expectedRecords, records)
+
+			// Verify all expectations were met; live and hidden matches are not read
+			mockScorecardRepo.AssertExpectations(t)
+			mockScorecardRepo.AssertNotCalled(t, "GetInningsByMatchID", mock.Anything, "live")
+			mockScorecardRepo.AssertNotCalled(t, "GetInningsByMatchID", mock.Anything, "private")
+		})
+	}
+}
+
+func TestVenueService_GetVenueMatches(t *testing.T) {
+	private := bookedMatch("private", 0, 2, models.MatchStatusLive, "team-c", "team-d")
+	private.OrganizationID = "org-private"
+
+	tests := []struct {
+		name            string
+		matches         []*models.Match
+		expectedMatches []string
+	}{
+		{
+			name: "lists matches in date order",
+			matches: []*models.Match{
+				bookedMatch("evening", 8*time.Hour, 1, models.MatchStatusLive, "team-a", "team-b"),
+				bookedMatch("morning", 0, 1, models.MatchStatusCompleted, "team-a", "team-b"),
+			},
+			expectedMatches: []string{"morning", "evening"},
+		},
+		{
+			name: "hides private organization matches",
+			matches: []*models.Match{
+				bookedMatch("open", 0, 1, models.MatchStatusLive, "team-a", "team-b"),
+				private,
+			},
+			expectedMatches: []string{"open"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockVenueRepo := new(MockVenueRepository)
+			mockMatchRepo := new(MockMatchRepository)
+			mockOrgRepo := new(MockOrganizationRepository)
+
+			// Setup expectations
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2}, nil)
+			mockMatchRepo.On("GetByVenueID", mock.Anything, "venue-1").Return(tt.matches, nil)
+			mockOrgRepo.On("GetPublic", mock.Anything).Return([]*models.Organization{}, nil)
+
+			// Create service
+			service := services.NewVenueService(mockVenueRepo, mockMatchRepo, new(MockScorecardRepository))
+			service.SetOrganizationService(services.NewOrganizationService(mockOrgRepo))
+
+			// Test
+			matches, err := service.GetVenueMatches(context.Background(), "venue-1")
+
+			// Assertions
+			require.NoError(t, err)
+			matchIDs := []string{}
+			for _, match := range matches {
+				matchIDs = append(matchIDs, match.ID)
+			}
+			assert.Equal(t, tt.expectedMatches, matchIDs)
+		})
+	}
+}
+
+func TestVenueService_UpdateVenue(t *testing.T) {
+	tests := []struct {
+		name            string
+		pitches         int
+		expectedPitches int
+		expectedError   string
+	}{
+		{
+			name:          "keeps booked pitches",
+			pitches:       1,
+			expectedError: "booked on pitch 2",
+		},
+		{
+			name:            "adds pitches",
+			pitches:         3,
+			expectedPitches: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockVenueRepo := new(MockVenueRepository)
+			mockMatchRepo := new(MockMatchRepository)
+
+			// Setup expectations
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2, CreatedBy: "test-user-123"}, nil)
+			mockMatchRepo.On("GetByVenueID", mock.Anything, "venue-1").Return([]*models.Match{
+				bookedMatch("match-1", 0, 2, models.MatchStatusLive, "team-a", "team-b"),
+			}, nil)
+			if tt.expectedError == "" {
+				mockVenueRepo.On("Update", mock.Anything, "venue-1", mock.Anything).Return(nil)
+			}
+
+			// Create service
+			service := services.NewVenueService(mockVenueRepo, mockMatchRepo, new(MockScorecardRepository))
+
+			// Test
+			pitches := tt.pitches
+			venue, err := service.UpdateVenue(userContext(), "venue-1", &models.UpdateVenueRequest{Pitches: &pitches})
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, venue)
+				mockVenueRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPitches, venue.Pitches)
+
+			// Verify all expectations were met
+			mockVenueRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVenueService_DeleteVenue(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        models.MatchStatus
+		expectedError string
+	}{
+		{
+			name:          "keeps venues with live matches booked",
+			status:        models.MatchStatusLive,
+			expectedError: "live matches booked",
+		},
+		{
+			name:   "deletes venues with finished matches",
+			status: models.MatchStatusCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockVenueRepo := new(MockVenueRepository)
+			mockMatchRepo := new(MockMatchRepository)
+
+			// Setup expectations
+			mockVenueRepo.On("GetByID", mock.Anything, "venue-1").Return(&models.Venue{ID: "venue-1", Name: "Riverside Park", Pitches: 2, CreatedBy: "test-user-123"}, nil)
+			mockMatchRepo.On("GetByVenueID", mock.Anything, "venue-1").Return([]*models.Match{
+				bookedMatch("match-1", 0, 2, tt.status, "team-a", "team-b"),
+			}, nil)
+			if tt.expectedError == "" {
+				mockVenueRepo.On("Delete", mock.Anything, "venue-1").Return(nil)
+			}
+
+			// Create service
+			service := services.NewVenueService(mockVenueRepo, mockMatchRepo, new(MockScorecardRepository))
+
+			// Test
+			err := service.DeleteVenue(userContext(), "venue-1")
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockVenueRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+
+			// Verify all expectations were met
+			mockVenueRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVenueService_CreateVenue(t *testing.T) {
+	latitude := 51.5
+
+	tests := []struct {
+		name          string
+		req           *models.CreateVenueRequest
+		expectedName  string
+		expectedError string
+	}{
+		{
+			name:         "trims the name and defaults to one pitch",
+			req:          &models.CreateVenueRequest{Name: " North Field "},
+			expectedName: "North Field",
+		},
+		{
+			name:          "latitude without longitude",
+			req:           &models.CreateVenueRequest{Name: "North Field", Latitude: &latitude},
+			expectedError: "latitude and longitude must be provided together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockVenueRepo := new(MockVenueRepository)
+
+			// Setup expectations
+			if tt.expectedError == "" {
+				mockVenueRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			// Create service
+			service := services.NewVenueService(mockVenueRepo, new(MockMatchRepository), new(MockScorecardRepository))
+
+			// Test
+			venue, err := service.CreateVenue(userContext(), tt.req)
+
+			// Assertions
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, venue)
+				mockVenueRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, venue.Name)
+			assert.Equal(t, 1, venue.Pitches)
+			assert.Equal(t, "test-user-123", venue.CreatedBy)
+
+			// Verify all expectations were met
+			mockVenueRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	supabasego "github.com/supabase-community/supabase-go"
+
+	"spark-park-cricket-backend/internal/models"
+	"spark-park-cricket-backend/internal/repository/supabase"
+	"spark-park-cricket-backend/internal/services"
+)
+
+// fakeWebhookRepository keeps webhooks and deliveries in memory, copying
+// them in and out like a database would
+type fakeWebhookRepository struct {
+	mutex      sync.Mutex
+	webhooks   map[string]models.Webhook
+	deliveries map[string]models.WebhookDelivery
+	nextID     int
+}
+
+func newFakeWebhookRepository() *fakeWebhookRepository {
+	return &fakeWebhookRepository{
+		webhooks:   make(map[string]models.Webhook),
+		deliveries: make(map[string]models.WebhookDelivery),
+	}
+}
+
+func (r *fakeWebhookRepository) id(prefix string) string {
+	r.nextID++
+	return fmt.Sprintf("%s-%d", prefix, r.nextID)
+}
+
+func (r *fakeWebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	webhook.ID = r.id("webhook")
+	r.webhooks[webhook.ID] = *webhook
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, errors.New("webhook not found")
+	}
+	return &webhook, nil
+}
+
+func (r *fakeWebhookRepository) GetBySeriesID(ctx context.Context, seriesID string) ([]*models.Webhook, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	webhooks := []*models.Webhook{}
+	for _, webhook := range r.webhooks {
+		if webhook.SeriesID == seriesID {
+			webhook := webhook
+			webhooks = append(webhooks, &webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+func (r *fakeWebhookRepository) Update(ctx context.Context, id string, webhook *models.Webhook) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.webhooks[id] = *webhook
+	return nil
+}
+
+func (r *fakeWebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *fakeWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delivery.ID = r.id("delivery")
+	r.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, errors.New("delivery not found")
+	}
+	return &delivery, nil
+}
+
+func (r *fakeWebhookRepository) UpdateDelivery(ctx context.Context, id string, delivery *models.WebhookDelivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.deliveries[id] = *delivery
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetDeliveries(ctx context.Context, webhookID string, status models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	deliveries := []*models.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID && (status == "" || delivery.Status == status) {
+			delivery := delivery
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *fakeWebhookRepository) GetUnfinishedDeliveries(ctx context.Context, limit int) ([]*models.WebhookDelivery, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	deliveries := []*models.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending || delivery.Status == models.WebhookDeliveryRetrying {
+			delivery := delivery
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *fakeWebhookRepository) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	stored, ok := r.deliveries[delivery.ID]
+	if !ok || stored.Status != delivery.Status {
+		return false, nil
+	}
+	if (stored.NextAttemptAt == nil) != (delivery.NextAttemptAt == nil) ||
+		(stored.NextAttemptAt != nil && !stored.NextAttemptAt.Equal(*delivery.NextAttemptAt)) {
+		return false, nil
+	}
+	if stored.ClaimedUntil != nil && stored.ClaimedUntil.After(time.Now()) {
+		return false, nil
+	}
+	stored.ClaimedUntil = &until
+	r.deliveries[delivery.ID] = stored
+	*delivery = stored
+	return true, nil
+}
+
+// newWebhookService returns a running webhook service for series-1, created
+// by test-user-123, whose match-1 is being scored
+func newWebhookService(t *testing.T, allowPrivate bool) (*services.WebhookService, *fakeWebhookRepository) {
+	seriesRepo := new(MockSeriesRepository)
+	seriesRepo.On("GetByID", mock.Anything, "series-1").Return(&models.Series{ID: "series-1", CreatedBy: "test-user-123"}, nil)
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, "match-1").Return(&models.Match{ID: "match-1", SeriesID: "series-1"}, nil)
+
+	repo := newFakeWebhookRepository()
+	webhooks := services.NewWebhookService(repo, seriesRepo, matchRepo)
+	webhooks.SetAllowPrivateTargets(allowPrivate)
+	webhooks.SetRetryPolicy(services.WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: 20 * time.Millisecond})
+	go webhooks.Run()
+	return webhooks, repo
+}
+
+// webhookReceiver records the requests posted to it and answers with status
+type webhookReceiver struct {
+	status   atomic.Int32
+	mutex    sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) (*webhookReceiver, *httptest.Server) {
+	receiver := &webhookReceiver{}
+	receiver.status.Store(int32(status))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mutex.Lock()
+		receiver.requests = append(receiver.requests, r)
+		receiver.bodies = append(receiver.bodies, body)
+		receiver.mutex.Unlock()
+		w.WriteHeader(int(receiver.status.Load()))
+	}))
+	t.Cleanup(server.Close)
+	return receiver, server
+}
+
+func (r *webhookReceiver) received() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.requests)
+}
+
+func TestWebhookService_DeliversSignedEventsOfTheTypesAsked(t *testing.T) {
+	webhooks, _ := newWebhookService(t, true)
+	receiver, server := newWebhookReceiver(t, http.StatusNoContent)
+
+	// Only the series creator registers webhooks, for known event types
+	_, err := webhooks.CreateWebhook(asUser("someone-else"), "series-1", &models.CreateWebhookRequest{URL: server.URL})
+	assert.Error(t, err)
+	_, err = webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{URL: server.URL, EventTypes: []string{"ball_event"}})
+	assert.Error(t, err)
+	_, err = webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{URL: "ftp://example.com"})
+	assert.Error(t, err)
+
+	wickets, err := webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{
+		URL:        server.URL + "/wickets",
+		EventTypes: []string{"wicket_fallen", "match_completed"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, wickets.Secret)
+	everything, err := webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{URL: server.URL + "/all"})
+	require.NoError(t, err)
+
+	listed, err := webhooks.ListWebhooks(userContext(), "series-1")
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Empty(t, listed[0].Secret)
+
+	webhooks.Dispatch("match-1", "scorecard_delta", map[string]int{"version": 7})
+	webhooks.Dispatch("match-1", "wicket_fallen", map[string]int{"version": 7})
+	webhooks.Dispatch("match-1", "viewer_count", map[string]int{"viewers": 3}) // Not a webhook event
+	require.Eventually(t, func() bool { return receiver.received() == 3 }, 2*time.Second, 10*time.Millisecond)
+
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	paths := map[string][]string{}
+	for i, req := range receiver.requests {
+		event := req.Header.Get("X-Webhook-Event")
+		paths[req.URL.Path] = append(paths[req.URL.Path], event)
+
+		secret := everything.Secret
+		if req.URL.Path == "/wickets" {
+			secret = wickets.Secret
+		}
+		body := receiver.bodies[i]
+		assert.Equal(t, "sha256="+services.SignWebhookPayload(secret, req.Header.Get("X-Webhook-Timestamp"), body), req.Header.Get("X-Webhook-Signature"))
+		assert.NotEmpty(t, req.Header.Get("X-Webhook-Delivery"))
+
+		var payload struct {
+			ID       string          `json:"id"`
+			Type     string          `json:"type"`
+			SeriesID string          `json:"series_id"`
+			MatchID  string          `json:"match_id"`
+			Data     json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, event, payload.Type)
+		assert.Equal(t, "series-1", payload.SeriesID)
+		assert.Equal(t, "match-1", payload.MatchID)
+		assert.JSONEq(t, `{"version": 7}`, string(payload.Data))
+	}
+	assert.Equal(t, []string{"wicket_fallen"}, paths["/wickets"])
+	assert.ElementsMatch(t, []string{"scorecard_delta", "wicket_fallen"}, paths["/all"])
+}
+
+func TestWebhookService_RetriesWithBackoffThenDeadLettersAndRedelivers(t *testing.T) {
+	webhooks, _ := newWebhookService(t, true)
+	receiver, server := newWebhookReceiver(t, http.StatusInternalServerError)
+
+	webhook, err := webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{URL: server.URL})
+	require.NoError(t, err)
+	webhooks.Dispatch("match-1", "match_completed", map[string]string{"result": "target reached"})
+
+	var dead []*models.WebhookDelivery
+	require.Eventually(t, func() bool {
+		dead, err = webhooks.ListDeliveries(userContext(), "series-1", webhook.ID, models.WebhookDeliveryDead)
+		return err == nil && len(dead) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, dead[0].ResponseStatus)
+	assert.Contains(t, dead[0].LastError, "500")
+	assert.Nil(t, dead[0].NextAttemptAt)
+	assert.Equal(t, 3, receiver.received())
+
+	// Once the endpoint is fixed the dead letter can be sent again
+	receiver.status.Store(http.StatusOK)
+	_, err = webhooks.Redeliver(asUser("someone-else"), "series-1", webhook.ID, dead[0].ID)
+	assert.Error(t, err)
+	delivery, err := webhooks.Redeliver(userContext(), "series-1", webhook.ID, dead[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliverySucceeded, delivery.Status)
+	assert.NotNil(t, delivery.DeliveredAt)
+	assert.Equal(t, 4, receiver.received())
+
+	dead, err = webhooks.ListDeliveries(userContext(), "series-1", webhook.ID, models.WebhookDeliveryDead)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
+
+func TestWebhookService_RefusesPrivateTargetsByDefault(t *testing.T) {
+	webhooks, _ := newWebhookService(t, false)
+	receiver, server := newWebhookReceiver(t, http.StatusOK)
+
+	webhook, err := webhooks.CreateWebhook(userContext(), "series-1", &models.CreateWebhookRequest{URL: server.URL})
+	require.NoError(t, err)
+	webhooks.Dispatch("match-1", "wicket_fallen", map[string]int{"version": 1})
+
+	require.Eventually(t, func() bool {
+		deliveries, err := webhooks.ListDeliveries(userContext(), "series-1", webhook.ID, "")
+		return err == nil && len(deliveries) == 1 && deliveries[0].Attempts > 0
+	}, 2*time.Second, 10*time.Millisecond)
+	deliveries, err := webhooks.ListDeliveries(userContext(), "series-1", webhook.ID, "")
+	require.NoError(t, err)
+	assert.Contains(t, deliveries[0].LastError, "not a public address")
+	assert.Zero(t, receiver.received())
+}
+
+func TestWebhookService_InstancesResumeEachDeliveryOnce(t *testing.T) {
+	repo := newFakeWebhookRepository()
+	receiver, server := newWebhookReceiver(t, http.StatusOK)
+	webhook := &models.Webhook{SeriesID: "series-1", URL: server.URL, Active: true}
+	require.NoError(t, repo.Create(context.Background(), webhook))
+
+	due := time.Now().Add(50 * time.Millisecond)
+	claimedElsewhere := time.Now().Add(time.Minute)
+	for _, delivery := range []*models.WebhookDelivery{
+		{WebhookID: webhook.ID, Status: models.WebhookDeliveryPending},
+		{WebhookID: webhook.ID, Status: models.WebhookDeliveryRetrying, Attempts: 1, NextAttemptAt: &due},
+		// Another live instance is attempting this one
+		{WebhookID: webhook.ID, Status: models.WebhookDeliveryPending, ClaimedUntil: &claimedElsewhere},
+	} {
+		delivery.SeriesID = "series-1"
+		delivery.Payload = json.RawMessage(`{}`)
+		require.NoError(t, repo.CreateDelivery(context.Background(), delivery))
+	}
+
+	// Two instances start against the same database
+	for i := 0; i < 2; i++ {
+		instance := services.NewWebhookService(repo, new(MockSeriesRepository), new(MockMatchRepository))
+		instance.SetAllowPrivateTargets(true)
+		go instance.Run()
+	}
+
+	require.Eventually(t, func() bool {
+		succeeded, err := repo.GetDeliveries(context.Background(), webhook.ID, models.WebhookDeliverySucceeded, 10)
+		return err == nil && len(succeeded) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 2, receiver.received(), "each unclaimed delivery is posted by one instance only")
+
+	pending, err := repo.GetDeliveries(context.Background(), webhook.ID, models.WebhookDeliveryPending, 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
+
+func TestWebhookRepository_ClaimIsConditionalUpdate(t *testing.T) {
+	var query url.Values
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client, err := supabasego.NewClient(server.URL, "test-key", nil)
+	require.NoError(t, err)
+	repo := supabase.NewWebhookRepository(client)
+
+	due := time.Date(2025, 5, 31, 12, 0, 0, 0, time.UTC)
+	delivery := &models.WebhookDelivery{ID: "delivery-1", Status: models.WebhookDeliveryRetrying, NextAttemptAt: &due}
+	claimed, err := repo.ClaimDelivery(context.Background(), delivery, due.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed, "no row back means another instance won")
+
+	assert.Equal(t, "eq.delivery-1", query.Get("id"))
+	assert.Equal(t, "eq.retrying", query.Get("status"))
+	assert.Equal(t, "eq.2025-05-31T12:00:00Z", query.Get("next_attempt_at"))
+	assert.True(t, strings.HasPrefix(query.Get("or"), "(claimed_until.is.null,claimed_until.lt."), query.Get("or"))
+	assert.Contains(t, body, "claimed_until")
+}
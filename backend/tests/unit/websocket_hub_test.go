@@ -0,0 +1,588 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spark-park-cricket-backend/pkg/websocket"
+)
+
+// hubFrame is an outbound frame as a client decodes it
+type hubFrame struct {
+	Type     string          `json:"type"`
+	ID       string          `json:"id"`
+	Seq      int64           `json:"seq"`
+	RoomID   string          `json:"room_id"`
+	ClientID string          `json:"client_id"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// dialHub starts a hub behind a test server and connects a client that
+// joins roomID, returning the connection once the hub has greeted it
+func dialHub(t *testing.T, hub *websocket.Hub, roomID string) *gorillaws.Conn {
+	return dialHubQuery(t, hub, roomID, "")
+}
+
+// dialHubQuery is dialHub with a query string on the connection URL
+func dialHubQuery(t *testing.T, hub *websocket.Hub, roomID, query string) *gorillaws.Conn {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.ServeWS(w, r, roomID, "client-1")
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := gorillaws.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+query, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	connected := readFrame(t, conn)
+	require.Equal(t, websocket.TypeConnected, connected.Type)
+	return conn
+}
+
+func newRunningHub(limits websocket.Limits) *websocket.Hub {
+	hub := websocket.NewHub()
+	hub.SetLimits(limits)
+	go hub.Run()
+	return hub
+}
+
+func readFrame(t *testing.T, conn *gorillaws.Conn) hubFrame {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var frame hubFrame
+	require.NoError(t, conn.ReadJSON(&frame))
+	return frame
+}
+
+func sendFrame(t *testing.T, conn *gorillaws.Conn, msg websocket.ClientMessage) hubFrame {
+	t.Helper()
+	require.NoError(t, conn.WriteJSON(msg))
+	return readFrame(t, conn)
+}
+
+func errorCode(t *testing.T, frame hubFrame) string {
+	t.Helper()
+	require.Equal(t, websocket.TypeError, frame.Type)
+	var data websocket.ErrorData
+	require.NoError(t, json.Unmarshal(frame.Data, &data))
+	return data.Code
+}
+
+func TestHub_SubscribesToSeveralRooms(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{})
+	conn := dialHub(t, hub, "")
+
+	ack := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1"})
+	assert.Equal(t, websocket.TypeAck, ack.Type)
+	assert.Equal(t, "1", ack.ID)
+	ack = sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "2", SeriesID: "series-1"})
+	var data websocket.AckData
+	require.NoError(t, json.Unmarshal(ack.Data, &data))
+	assert.Equal(t, []string{"match-1", "series:series-1"}, data.Subscriptions)
+	assert.Equal(t, 1, hub.GetRoomClients("match-1"))
+
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "score_update", RoomID: "match-1"})
+	assert.Equal(t, "match-1", readFrame(t, conn).RoomID)
+	hub.BroadcastToRoom(websocket.SeriesRoomID("series-1"), websocket.Message{Type: "standings_update", RoomID: "series:series-1"})
+	assert.Equal(t, "standings_update", readFrame(t, conn).Type)
+
+	ack = sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeUnsubscribe, ID: "3", MatchID: "match-1"})
+	assert.Equal(t, websocket.TypeAck, ack.Type)
+	assert.Equal(t, 0, hub.GetRoomClients("match-1"))
+	assert.Equal(t, websocket.ErrorNotSubscribed, errorCode(t, sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeUnsubscribe, ID: "4", MatchID: "match-1"})))
+}
+
+func TestHub_AnswersPingsAndRejectsBadMessages(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{MaxSubscriptions: 1})
+	conn := dialHub(t, hub, "match-1")
+
+	pong := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypePing, ID: "p1"})
+	assert.Equal(t, websocket.TypePong, pong.Type)
+	assert.Equal(t, "p1", pong.ID)
+
+	assert.Equal(t, websocket.ErrorUnknownType, errorCode(t, sendFrame(t, conn, websocket.ClientMessage{Type: "shout"})))
+	assert.Equal(t, websocket.ErrorInvalidRoom, errorCode(t, sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, MatchID: "m", SeriesID: "s"})))
+	assert.Equal(t, websocket.ErrorSubscriptionLimit, errorCode(t, sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, MatchID: "match-2"})),
+		"the room from the URL counts towards the limit")
+
+	require.NoError(t, conn.WriteMessage(gorillaws.TextMessage, []byte("not json")))
+	assert.Equal(t, websocket.ErrorInvalidMessage, errorCode(t, readFrame(t, conn)))
+}
+
+func TestHub_EnforcesMessageLimits(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{MaxMessagesPerMinute: 2, MaxMessageSize: 256})
+	conn := dialHub(t, hub, "")
+
+	sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypePing})
+	sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypePing})
+	assert.Equal(t, websocket.ErrorRateLimited, errorCode(t, sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypePing})))
+
+	require.NoError(t, conn.WriteMessage(gorillaws.TextMessage, []byte(strings.Repeat("x", 512))))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, gorillaws.CloseMessageTooBig), "oversized frames close the connection: %v", err)
+}
+
+func TestHub_NumbersAndReplaysRoomMessages(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{})
+	live := dialHub(t, hub, "match-1")
+
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	hub.BroadcastToRoom("match-1", map[string]interface{}{"type": "scorecard_update", "data": "over 1"})
+	hub.BroadcastToRoom("match-2", websocket.Message{Type: "ball_event", RoomID: "match-2"})
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	for _, want := range []int64{1, 2, 3} {
+		assert.Equal(t, want, readFrame(t, live).Seq, "each room is numbered on its own")
+	}
+
+	// A phone that saw seq 1 reconnects and catches up in order
+	rejoined := dialHubQuery(t, hub, "match-1", "?last_seq=1")
+	update := readFrame(t, rejoined)
+	assert.Equal(t, "scorecard_update", update.Type)
+	assert.Equal(t, int64(2), update.Seq)
+	assert.Equal(t, int64(3), readFrame(t, rejoined).Seq)
+
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	assert.Equal(t, int64(4), readFrame(t, rejoined).Seq, "live messages follow the replay")
+
+	// A dashboard resubscribes over its connection and gets the ack first
+	dashboard := dialHub(t, hub, "")
+	lastSeq := int64(2)
+	ack := sendFrame(t, dashboard, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1", LastSeq: &lastSeq})
+	var data websocket.AckData
+	require.NoError(t, json.Unmarshal(ack.Data, &data))
+	assert.Equal(t, int64(4), data.Seq)
+	assert.Equal(t, int64(3), readFrame(t, dashboard).Seq)
+	assert.Equal(t, int64(4), readFrame(t, dashboard).Seq)
+}
+
+func TestHub_SendsSnapshotWhenGapIsTooOld(t *testing.T) {
+	hub := websocket.NewHub()
+	hub.SetReplayStore(websocket.NewMemoryReplayStore(2, time.Minute))
+	hub.SetSnapshotProvider(func(ctx context.Context, roomID string) (interface{}, error) {
+		return map[string]string{"room": roomID, "score": "42/1"}, nil
+	})
+	go hub.Run()
+	for i := 0; i < 4; i++ {
+		hub.BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	}
+
+	conn := dialHub(t, hub, "")
+	lastSeq := int64(1)
+	ack := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1", LastSeq: &lastSeq})
+	assert.Equal(t, websocket.TypeAck, ack.Type)
+
+	snapshot := readFrame(t, conn)
+	assert.Equal(t, websocket.TypeSnapshot, snapshot.Type)
+	assert.Equal(t, int64(4), snapshot.Seq, "the client carries on from the snapshot's seq")
+	assert.JSONEq(t, `{"room": "match-1", "score": "42/1"}`, string(snapshot.Data))
+
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	assert.Equal(t, int64(5), readFrame(t, conn).Seq)
+}
+
+func TestHub_SendsSnapshotOnSubscribeAndResync(t *testing.T) {
+	hub := websocket.NewHub()
+	hub.SetSnapshotProvider(func(ctx context.Context, roomID string) (interface{}, error) {
+		return map[string]interface{}{"version": 7}, nil
+	})
+	go hub.Run()
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "scorecard_delta", RoomID: "match-1"})
+
+	conn := dialHub(t, hub, "")
+	ack := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1"})
+	assert.Equal(t, websocket.TypeAck, ack.Type)
+	snapshot := readFrame(t, conn)
+	assert.Equal(t, websocket.TypeSnapshot, snapshot.Type)
+	assert.Equal(t, int64(1), snapshot.Seq)
+
+	// A client whose cached version no longer lines up asks again
+	resync := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeResync, ID: "2", MatchID: "match-1"})
+	assert.Equal(t, websocket.TypeSnapshot, resync.Type)
+	assert.Equal(t, "2", resync.ID)
+	assert.JSONEq(t, `{"version": 7}`, string(resync.Data))
+
+	notFollowed := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeResync, ID: "3", MatchID: "match-2"})
+	assert.Equal(t, websocket.ErrorNotSubscribed, errorCode(t, notFollowed))
+}
+
+// blockingSnapshots returns a snapshot provider that waits for release, and
+// a channel that reports each call to it
+func blockingSnapshots(release <-chan struct{}) (websocket.SnapshotFunc, <-chan struct{}) {
+	called := make(chan struct{}, 4)
+	return func(ctx context.Context, roomID string) (interface{}, error) {
+		called <- struct{}{}
+		<-release
+		return map[string]interface{}{"version": 7}, nil
+	}, called
+}
+
+// broadcastsWithin reports whether a broadcast to roomID returns in time
+func broadcastsWithin(hub *websocket.Hub, roomID string, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		hub.BroadcastToRoom(roomID, websocket.Message{Type: "scorecard_delta", RoomID: roomID})
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func TestHub_BuildsSubscribeSnapshotsWithoutBlockingBroadcasts(t *testing.T) {
+	release := make(chan struct{})
+	snapshot, called := blockingSnapshots(release)
+	hub := websocket.NewHub()
+	hub.SetSnapshotProvider(snapshot)
+	go hub.Run()
+	hub.BroadcastToRoom("match-1", websocket.Message{Type: "scorecard_delta", RoomID: "match-1"})
+
+	conn := dialHub(t, hub, "")
+	require.NoError(t, conn.WriteJSON(websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1"}))
+	<-called
+	assert.True(t, broadcastsWithin(hub, "match-1", time.Second), "scoring goes on while the snapshot is built")
+	close(release)
+
+	var data websocket.AckData
+	require.NoError(t, json.Unmarshal(readFrame(t, conn).Data, &data))
+	assert.Equal(t, int64(2), data.Seq)
+	snap := readFrame(t, conn)
+	assert.Equal(t, websocket.TypeSnapshot, snap.Type)
+	assert.Equal(t, int64(1), snap.Seq)
+	// The ball scored meanwhile follows the snapshot
+	assert.Equal(t, int64(2), readFrame(t, conn).Seq)
+}
+
+func TestHub_BuildsResyncSnapshotsWithoutBlockingBroadcasts(t *testing.T) {
+	release := make(chan struct{})
+	snapshot, called := blockingSnapshots(release)
+	hub := websocket.NewHub()
+	hub.SetSnapshotProvider(snapshot)
+	go hub.Run()
+
+	conn := dialHub(t, hub, "")
+	lastSeq := int64(0)
+	ack := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1", LastSeq: &lastSeq})
+	require.Equal(t, websocket.TypeAck, ack.Type)
+	require.NoError(t, conn.WriteJSON(websocket.ClientMessage{Type: websocket.TypeResync, ID: "2", MatchID: "match-1"}))
+	<-called
+	assert.True(t, broadcastsWithin(hub, "match-1", time.Second), "scoring goes on while the snapshot is built")
+	close(release)
+
+	assert.Equal(t, int64(1), readFrame(t, conn).Seq, "live messages still arrive")
+	resync := readFrame(t, conn)
+	assert.Equal(t, websocket.TypeSnapshot, resync.Type)
+	assert.Equal(t, "2", resync.ID)
+	assert.Equal(t, int64(0), resync.Seq)
+	// The ball scored meanwhile is sent again after the snapshot
+	assert.Equal(t, int64(1), readFrame(t, conn).Seq)
+}
+
+// fakeBus links the backplanes of hubs in the same process
+type fakeBus struct {
+	mutex     sync.Mutex
+	listeners map[string]func(websocket.Envelope)
+	stats     map[string]websocket.InstanceStats
+}
+
+// fakeBackplane is one instance's connection to a fakeBus
+type fakeBackplane struct {
+	id  string
+	bus *fakeBus
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{listeners: make(map[string]func(websocket.Envelope)), stats: make(map[string]websocket.InstanceStats)}
+}
+
+func (b *fakeBackplane) InstanceID() string { return b.id }
+
+func (b *fakeBackplane) Publish(envelope websocket.Envelope) error {
+	b.bus.mutex.Lock()
+	var others []func(websocket.Envelope)
+	for id, deliver := range b.bus.listeners {
+		if id != b.id {
+			others = append(others, deliver)
+		}
+	}
+	b.bus.mutex.Unlock()
+
+	envelope.InstanceID = b.id
+	for _, deliver := range others {
+		deliver(envelope)
+	}
+	return nil
+}
+
+func (b *fakeBackplane) Listen(deliver func(websocket.Envelope)) error {
+	b.bus.mutex.Lock()
+	b.bus.listeners[b.id] = deliver
+	b.bus.mutex.Unlock()
+	select {}
+}
+
+func (b *fakeBackplane) ReportStats(stats websocket.InstanceStats) error {
+	b.bus.mutex.Lock()
+	defer b.bus.mutex.Unlock()
+
+	stats.InstanceID = b.id
+	b.bus.stats[b.id] = stats
+	return nil
+}
+
+func (b *fakeBackplane) Stats() ([]websocket.InstanceStats, error) {
+	b.bus.mutex.Lock()
+	defer b.bus.mutex.Unlock()
+
+	stats := []websocket.InstanceStats{}
+	for _, instance := range b.bus.stats {
+		stats = append(stats, instance)
+	}
+	return stats, nil
+}
+
+func TestHub_FansOutAcrossInstances(t *testing.T) {
+	bus := newFakeBus()
+	replay := websocket.NewMemoryReplayStore(10, time.Minute)
+	hubs := make([]*websocket.Hub, 2)
+	for i, id := range []string{"instance-a", "instance-b"} {
+		hubs[i] = websocket.NewHub()
+		hubs[i].SetReplayStore(replay)
+		hubs[i].SetBackplane(&fakeBackplane{id: id, bus: bus})
+		go hubs[i].Run()
+	}
+	require.Eventually(t, func() bool {
+		bus.mutex.Lock()
+		defer bus.mutex.Unlock()
+		return len(bus.listeners) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	onA := dialHub(t, hubs[0], "match-1")
+	onB := dialHub(t, hubs[1], "match-1")
+	dialHub(t, hubs[1], "match-2")
+
+	hubs[1].BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	hubs[0].BroadcastToRoom("match-1", websocket.Message{Type: "ball_event", RoomID: "match-1"})
+	for _, conn := range []*gorillaws.Conn{onA, onB} {
+		assert.Equal(t, int64(1), readFrame(t, conn).Seq)
+		assert.Equal(t, int64(2), readFrame(t, conn).Seq)
+	}
+
+	require.NoError(t, (&fakeBackplane{id: "instance-b", bus: bus}).ReportStats(hubs[1].LocalStats()))
+	stats := hubs[0].ClusterStats()
+	assert.Equal(t, 2, stats.Instances)
+	assert.Equal(t, 3, stats.TotalClients)
+	assert.Equal(t, map[string]int{"match-1": 2, "match-2": 1}, stats.Rooms)
+}
+
+func TestHub_PingsConnectionsAndDropsUnresponsiveOnes(t *testing.T) {
+	hub := websocket.NewHub()
+	hub.SetKeepalive(websocket.Keepalive{PingInterval: 50 * time.Millisecond, PongTimeout: 200 * time.Millisecond})
+	go hub.Run()
+
+	// A spectator that keeps reading answers the hub's pings
+	live := dialHub(t, hub, "match-1")
+	pings := make(chan struct{}, 100)
+	live.SetPingHandler(func(data string) error {
+		pings <- struct{}{}
+		return live.WriteControl(gorillaws.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	require.NoError(t, live.SetReadDeadline(time.Time{}))
+	go func() {
+		for {
+			if _, _, err := live.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// One that stopped reading never does
+	dialHub(t, hub, "match-1")
+
+	assert.Eventually(t, func() bool {
+		return hub.LocalStats().Metrics.PongTimeouts == 1 && hub.GetRoomClients("match-1") == 1
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// The live spectator outlasts several pong timeouts without sending anything
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, 1, hub.GetRoomClients("match-1"))
+	assert.GreaterOrEqual(t, len(pings), 5)
+}
+
+func TestHub_EvictsSlowClientsWithCloseCode(t *testing.T) {
+	hub := newRunningHub(websocket.Limits{})
+	slow := dialHub(t, hub, "match-1")
+
+	// Broadcast until the unread socket and then the send buffer fill up
+	payload := strings.Repeat("x", 64*1024)
+	for i := 0; i < 2000 && hub.LocalStats().Metrics.SlowClientEvictions == 0; i++ {
+		hub.BroadcastToRoom("match-1", websocket.Message{Type: "scorecard_delta", RoomID: "match-1", Data: payload})
+	}
+
+	metrics := hub.LocalStats().Metrics
+	require.Equal(t, int64(1), metrics.SlowClientEvictions)
+	assert.GreaterOrEqual(t, metrics.MessagesDropped, int64(1))
+	assert.Equal(t, int64(0), metrics.QueuedBytes)
+	assert.Equal(t, 0, hub.GetRoomClients("match-1"))
+
+	// Once it reads again it is told why it was dropped, without the rest of its buffer
+	require.NoError(t, slow.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var err error
+	for err == nil {
+		_, _, err = slow.ReadMessage()
+	}
+	var closeErr *gorillaws.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, websocket.CloseSlowConsumer, closeErr.Code)
+	assert.Equal(t, "slow consumer", closeErr.Text)
+}
+
+func TestHub_CapsConnectionsPerAddressAndRoom(t *testing.T) {
+	hub := websocket.NewHub()
+	hub.SetCapacity(websocket.Capacity{MaxConnectionsPerIP: 2, MaxConnectionsPerRoom: 1})
+	go hub.Run()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.ServeWS(w, r, r.URL.Query().Get("room"), "client-1")
+	}))
+	t.Cleanup(server.Close)
+	dial := func(query string) (*gorillaws.Conn, int) {
+		conn, resp, err := gorillaws.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+query, nil)
+		if err != nil {
+			require.NotNil(t, resp)
+			return nil, resp.StatusCode
+		}
+		t.Cleanup(func() { conn.Close() })
+		require.Equal(t, websocket.TypeConnected, readFrame(t, conn).Type)
+		return conn, http.StatusSwitchingProtocols
+	}
+
+	first, _ := dial("?room=match-1")
+	_, status := dial("?room=match-1")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	// A multiplexed connection is refused the full room over the protocol
+	second, _ := dial("")
+	require.NotNil(t, second)
+	reply := sendFrame(t, second, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "match-1"})
+	assert.Equal(t, websocket.ErrorRoomFull, errorCode(t, reply))
+
+	// Both connections from this address are in use
+	_, status = dial("")
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, int64(3), hub.LocalStats().Metrics.Rejections)
+
+	// The room has space once its client leaves
+	first.Close()
+	assert.Eventually(t, func() bool { return hub.GetRoomClients("match-1") == 0 }, 2*time.Second, 20*time.Millisecond)
+	reply = sendFrame(t, second, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "2", MatchID: "match-1"})
+	assert.Equal(t, websocket.TypeAck, reply.Type)
+}
+
+func TestHub_EnforcesRoomAccessAndOrigins(t *testing.T) {
+	hub := websocket.NewHub()
+	hub.SetAllowedOrigins([]string{"https://scores.example.com"})
+	hub.SetAccessPolicy(func(ctx context.Context, roomID string) error {
+		userID, _ := ctx.Value("user_id").(string)
+		switch {
+		case roomID == "public":
+			return nil
+		case userID == "":
+			return websocket.ErrUnauthorized
+		case userID != "guest-1":
+			return websocket.ErrForbidden
+		}
+		return nil
+	})
+	go hub.Run()
+
+	// The test server stands in for the connection auth middleware
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), "user_id", r.URL.Query().Get("user"))
+		hub.ServeWS(w, r.WithContext(ctx), r.URL.Query().Get("room"), "client-1")
+	}))
+	t.Cleanup(server.Close)
+	dial := func(query string, header http.Header) (*gorillaws.Conn, int) {
+		conn, resp, err := gorillaws.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+query, header)
+		if err != nil {
+			require.NotNil(t, resp)
+			return nil, resp.StatusCode
+		}
+		t.Cleanup(func() { conn.Close() })
+		require.Equal(t, websocket.TypeConnected, readFrame(t, conn).Type)
+		return conn, http.StatusSwitchingProtocols
+	}
+
+	_, status := dial("?room=private", nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	_, status = dial("?room=private&user=member-1", nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	_, status = dial("?room=private&user=guest-1", nil)
+	assert.Equal(t, http.StatusSwitchingProtocols, status)
+
+	// Pages on other sites cannot connect
+	_, status = dial("?room=public", http.Header{"Origin": {"https://evil.example.com"}})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Subscriptions are checked as the connecting user
+	conn, _ := dial("?user=member-1", http.Header{"Origin": {"https://scores.example.com"}})
+	require.NotNil(t, conn)
+	reply := sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "1", MatchID: "private"})
+	assert.Equal(t, websocket.ErrorForbidden, errorCode(t, reply))
+	reply = sendFrame(t, conn, websocket.ClientMessage{Type: websocket.TypeSubscribe, ID: "2", MatchID: "public"})
+	assert.Equal(t, websocket.TypeAck, reply.Type)
+}
+
+// readViewerCount reads frames until a viewer_count one and returns its count
+func readViewerCount(t *testing.T, conn *gorillaws.Conn) int {
+	t.Helper()
+	for {
+		frame := readFrame(t, conn)
+		if frame.Type != websocket.TypeViewerCount {
+			continue
+		}
+		assert.Zero(t, frame.Seq)
+		var data websocket.ViewerCountData
+		require.NoError(t, json.Unmarshal(frame.Data, &data))
+		return data.Viewers
+	}
+}
+
+func TestHub_SendsDebouncedViewerCounts(t *testing.T) {
+	samples := make(chan map[string]int, 100)
+	hub := websocket.NewHub()
+	hub.SetPresence(100*time.Millisecond, func(at time.Time, viewers map[string]int) {
+		samples <- viewers
+	})
+	go hub.Run()
+
+	first := dialHub(t, hub, "match-1")
+	second := dialHub(t, hub, "match-1")
+
+	// Both viewers end up being told there are two of them
+	for viewers := readViewerCount(t, first); viewers != 2; viewers = readViewerCount(t, first) {
+	}
+	for viewers := readViewerCount(t, second); viewers != 2; viewers = readViewerCount(t, second) {
+	}
+
+	second.Close()
+	assert.Equal(t, 1, readViewerCount(t, first))
+
+	// The listener sees every room's count
+	require.Eventually(t, func() bool {
+		return (<-samples)["match-1"] == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}